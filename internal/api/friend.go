@@ -17,16 +17,18 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
 	"github.com/openimsdk/protocol/relation"
 	"github.com/openimsdk/tools/a2r"
 )
 
 type FriendApi struct {
-	Client relation.FriendClient
+	Client    relation.FriendClient
+	ExtClient relationext.RelationExtClient
 }
 
-func NewFriendApi(client relation.FriendClient) FriendApi {
-	return FriendApi{client}
+func NewFriendApi(client relation.FriendClient, extClient relationext.RelationExtClient) FriendApi {
+	return FriendApi{Client: client, ExtClient: extClient}
 }
 
 func (o *FriendApi) ApplyToAddFriend(c *gin.Context) {
@@ -102,7 +104,7 @@ func (o *FriendApi) UpdateFriends(c *gin.Context) {
 }
 
 func (o *FriendApi) GetIncrementalFriends(c *gin.Context) {
-	a2r.Call(c, relation.FriendClient.GetIncrementalFriends, o.Client)
+	a2r.Call(c, relationext.RelationExtClient.GetIncrementalFriendsWithCategories, o.ExtClient)
 }
 
 // GetIncrementalBlacks is temporarily unused.
@@ -118,3 +120,47 @@ func (o *FriendApi) GetFullFriendUserIDs(c *gin.Context) {
 func (o *FriendApi) GetSelfUnhandledApplyCount(c *gin.Context) {
 	a2r.Call(c, relation.FriendClient.GetSelfUnhandledApplyCount, o.Client)
 }
+
+func (o *FriendApi) CreateFriendCategory(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.CreateFriendCategory, o.ExtClient)
+}
+
+func (o *FriendApi) UpdateFriendCategory(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.UpdateFriendCategory, o.ExtClient)
+}
+
+func (o *FriendApi) SortFriendCategories(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.SortFriendCategories, o.ExtClient)
+}
+
+func (o *FriendApi) DeleteFriendCategories(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.DeleteFriendCategories, o.ExtClient)
+}
+
+func (o *FriendApi) GetFriendCategories(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.GetFriendCategories, o.ExtClient)
+}
+
+func (o *FriendApi) SetFriendsCategories(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.SetFriendsCategories, o.ExtClient)
+}
+
+func (o *FriendApi) UpdateFriendCategoryMembers(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.UpdateFriendCategoryMembers, o.ExtClient)
+}
+
+func (o *FriendApi) GetFriendSuggestions(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.GetFriendSuggestions, o.ExtClient)
+}
+
+func (o *FriendApi) SetBlackScope(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.SetBlackScope, o.ExtClient)
+}
+
+func (o *FriendApi) GetBlackScopes(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.GetBlackScopes, o.ExtClient)
+}
+
+func (o *FriendApi) GetFriendEvents(c *gin.Context) {
+	a2r.Call(c, relationext.RelationExtClient.GetFriendEvents, o.ExtClient)
+}
@@ -16,16 +16,18 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
 	"github.com/openimsdk/protocol/group"
 	"github.com/openimsdk/tools/a2r"
 )
 
 type GroupApi struct {
-	Client group.GroupClient
+	Client    group.GroupClient
+	ExtClient groupext.GroupExtClient
 }
 
-func NewGroupApi(client group.GroupClient) GroupApi {
-	return GroupApi{client}
+func NewGroupApi(client group.GroupClient, extClient groupext.GroupExtClient) GroupApi {
+	return GroupApi{Client: client, ExtClient: extClient}
 }
 
 func (o *GroupApi) CreateGroup(c *gin.Context) {
@@ -57,7 +59,7 @@ func (o *GroupApi) TransferGroupOwner(c *gin.Context) {
 }
 
 func (o *GroupApi) GetRecvGroupApplicationList(c *gin.Context) {
-	a2r.Call(c, group.GroupClient.GetGroupApplicationList, o.Client)
+	a2r.Call(c, groupext.GroupExtClient.GetGroupApplications, o.ExtClient)
 }
 
 func (o *GroupApi) GetUserReqGroupApplicationList(c *gin.Context) {
@@ -169,3 +171,139 @@ func (o *GroupApi) GetFullJoinGroupIDs(c *gin.Context) {
 func (o *GroupApi) GetGroupApplicationUnhandledCount(c *gin.Context) {
 	a2r.Call(c, group.GroupClient.GetGroupApplicationUnhandledCount, o.Client)
 }
+
+func (o *GroupApi) GetGroupSettings(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupSettings, o.ExtClient)
+}
+
+func (o *GroupApi) SetGroupMentionPolicy(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SetGroupMentionPolicy, o.ExtClient)
+}
+
+func (o *GroupApi) SetGroupSlowMode(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SetGroupSlowMode, o.ExtClient)
+}
+
+func (o *GroupApi) SetGroupMuteSchedule(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SetGroupMuteSchedule, o.ExtClient)
+}
+
+func (o *GroupApi) CreateGroupInviteLink(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.CreateGroupInviteLink, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupInviteLinks(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupInviteLinks, o.ExtClient)
+}
+
+func (o *GroupApi) RevokeGroupInviteLinks(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.RevokeGroupInviteLinks, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupInviteLinkInfo(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupInviteLinkInfo, o.ExtClient)
+}
+
+func (o *GroupApi) RedeemGroupInviteLink(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.RedeemGroupInviteLink, o.ExtClient)
+}
+
+func (o *GroupApi) CreateGroupRole(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.CreateGroupRole, o.ExtClient)
+}
+
+func (o *GroupApi) UpdateGroupRole(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.UpdateGroupRole, o.ExtClient)
+}
+
+func (o *GroupApi) DeleteGroupRoles(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.DeleteGroupRoles, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupRoles(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupRoles, o.ExtClient)
+}
+
+func (o *GroupApi) SetGroupMemberRole(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SetGroupMemberRole, o.ExtClient)
+}
+
+func (o *GroupApi) CreateGroupAnnouncement(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.CreateGroupAnnouncement, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupAnnouncements(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupAnnouncements, o.ExtClient)
+}
+
+func (o *GroupApi) SetGroupAnnouncementPinned(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SetGroupAnnouncementPinned, o.ExtClient)
+}
+
+func (o *GroupApi) DeleteGroupAnnouncements(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.DeleteGroupAnnouncements, o.ExtClient)
+}
+
+func (o *GroupApi) AckGroupAnnouncement(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.AckGroupAnnouncement, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupAnnouncementAcks(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupAnnouncementAcks, o.ExtClient)
+}
+
+func (o *GroupApi) RemindGroupAnnouncement(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.RemindGroupAnnouncement, o.ExtClient)
+}
+
+func (o *GroupApi) SucceedGroupOwners(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SucceedGroupOwners, o.ExtClient)
+}
+
+func (o *GroupApi) CreateGroupChannel(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.CreateGroupChannel, o.ExtClient)
+}
+
+func (o *GroupApi) UpdateGroupChannel(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.UpdateGroupChannel, o.ExtClient)
+}
+
+func (o *GroupApi) DeleteGroupChannels(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.DeleteGroupChannels, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupChannels(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupChannels, o.ExtClient)
+}
+
+func (o *GroupApi) GetIncrementalGroupChannels(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetIncrementalGroupChannels, o.ExtClient)
+}
+
+func (o *GroupApi) ImportGroups(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.ImportGroups, o.ExtClient)
+}
+
+func (o *GroupApi) SearchGroupMembers(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SearchGroupMembers, o.ExtClient)
+}
+
+func (o *GroupApi) ArchiveGroup(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.ArchiveGroup, o.ExtClient)
+}
+
+func (o *GroupApi) UnarchiveGroup(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.UnarchiveGroup, o.ExtClient)
+}
+
+func (o *GroupApi) GetJoinedGroups(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetJoinedGroups, o.ExtClient)
+}
+
+func (o *GroupApi) SetGroupJoinApprovalPolicy(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.SetGroupJoinApprovalPolicy, o.ExtClient)
+}
+
+func (o *GroupApi) GetGroupApplicationDecisions(c *gin.Context) {
+	a2r.Call(c, groupext.GroupExtClient.GetGroupApplicationDecisions, o.ExtClient)
+}
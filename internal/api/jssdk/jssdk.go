@@ -68,7 +68,7 @@ func (x *JSSdk) fillConversations(ctx context.Context, conversations []*jssdk.Co
 		groupMap  map[string]*sdkws.GroupInfo
 	)
 	if len(userIDs) > 0 {
-		users, err := x.userClient.GetUsersInfo(ctx, userIDs)
+		users, err := x.userClient.GetVisibleUsersInfo(ctx, userIDs)
 		if err != nil {
 			return err
 		}
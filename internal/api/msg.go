@@ -21,6 +21,8 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/apistruct"
 	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 	"github.com/openimsdk/protocol/constant"
 	"github.com/openimsdk/protocol/msg"
@@ -38,13 +40,14 @@ import (
 
 type MessageApi struct {
 	Client        msg.MsgClient
+	extClient     msgext.MsgExtClient
 	userClient    *rpcli.UserClient
 	imAdminUserID []string
 	validate      *validator.Validate
 }
 
-func NewMessageApi(client msg.MsgClient, userClient *rpcli.UserClient, imAdminUserID []string) MessageApi {
-	return MessageApi{Client: client, userClient: userClient, imAdminUserID: imAdminUserID, validate: validator.New()}
+func NewMessageApi(client msg.MsgClient, extClient msgext.MsgExtClient, userClient *rpcli.UserClient, imAdminUserID []string) MessageApi {
+	return MessageApi{Client: client, extClient: extClient, userClient: userClient, imAdminUserID: imAdminUserID, validate: validator.New()}
 }
 
 func (*MessageApi) SetOptions(options map[string]bool, value bool) {
@@ -155,6 +158,9 @@ func (m *MessageApi) DeleteMsgPhysical(c *gin.Context) {
 
 func (m *MessageApi) getSendMsgReq(c *gin.Context, req apistruct.SendMsg) (sendMsgReq *msg.SendMsgReq, err error) {
 	var data any
+	if req.TemplateID != "" {
+		applyMsgTemplate(&req)
+	}
 	log.ZDebug(c, "getSendMsgReq", "req", req.Content)
 	switch req.ContentType {
 	case constant.Text:
@@ -171,6 +177,8 @@ func (m *MessageApi) getSendMsgReq(c *gin.Context, req apistruct.SendMsg) (sendM
 		data = apistruct.AtElem{}
 	case constant.Custom:
 		data = apistruct.CustomElem{}
+	case msgprocessor.TemplateMsg:
+		data = msgprocessor.TemplateElem{}
 	case constant.OANotification:
 		data = apistruct.OANotificationElem{}
 		req.SessionType = constant.NotificationChatType
@@ -248,10 +256,13 @@ func (m *MessageApi) SendMessage(c *gin.Context) {
 
 func (m *MessageApi) SendBusinessNotification(c *gin.Context) {
 	req := struct {
-		Key        string `json:"key"`
-		Data       string `json:"data"`
-		SendUserID string `json:"sendUserID" binding:"required"`
-		RecvUserID string `json:"recvUserID" binding:"required"`
+		Key            string            `json:"key"`
+		Data           string            `json:"data"`
+		SendUserID     string            `json:"sendUserID" binding:"required"`
+		RecvUserID     string            `json:"recvUserID" binding:"required"`
+		TemplateID     string            `json:"templateID"`
+		TemplateParams map[string]string `json:"templateParams"`
+		TemplateLocale string            `json:"templateLocale"`
 	}{}
 	if err := c.BindJSON(&req); err != nil {
 		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
@@ -262,15 +273,21 @@ func (m *MessageApi) SendBusinessNotification(c *gin.Context) {
 		apiresp.GinError(c, errs.ErrNoPermission.WrapMsg("only app manager can send message"))
 		return
 	}
+	// The template is rendered by the msg service into data, and an empty key defaults to the template ID.
+	var template *msgprocessor.TemplateElem
+	if req.TemplateID != "" {
+		template = &msgprocessor.TemplateElem{TemplateID: req.TemplateID, Locale: req.TemplateLocale, Params: req.TemplateParams}
+	}
 	sendMsgReq := msg.SendMsgReq{
 		MsgData: &sdkws.MsgData{
 			SendID: req.SendUserID,
 			RecvID: req.RecvUserID,
 			Content: []byte(jsonutil.StructToJsonString(&sdkws.NotificationElem{
 				Detail: jsonutil.StructToJsonString(&struct {
-					Key  string `json:"key"`
-					Data string `json:"data"`
-				}{Key: req.Key, Data: req.Data}),
+					Key      string                     `json:"key"`
+					Data     string                     `json:"data"`
+					Template *msgprocessor.TemplateElem `json:"template,omitempty"`
+				}{Key: req.Key, Data: req.Data, Template: template}),
 			})),
 			MsgFrom:     constant.SysMsgType,
 			ContentType: constant.BusinessNotification,
@@ -0,0 +1,68 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/openimsdk/open-im-server/v3/pkg/apistruct"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/a2r"
+)
+
+func (m *MessageApi) CreateMsgTemplate(c *gin.Context) {
+	a2r.Call(c, msgext.MsgExtClient.CreateMsgTemplate, m.extClient)
+}
+
+func (m *MessageApi) UpdateMsgTemplate(c *gin.Context) {
+	a2r.Call(c, msgext.MsgExtClient.UpdateMsgTemplate, m.extClient)
+}
+
+func (m *MessageApi) DeleteMsgTemplates(c *gin.Context) {
+	a2r.Call(c, msgext.MsgExtClient.DeleteMsgTemplates, m.extClient)
+}
+
+func (m *MessageApi) GetMsgTemplate(c *gin.Context) {
+	a2r.Call(c, msgext.MsgExtClient.GetMsgTemplate, m.extClient)
+}
+
+func (m *MessageApi) SearchMsgTemplates(c *gin.Context) {
+	a2r.Call(c, msgext.MsgExtClient.SearchMsgTemplates, m.extClient)
+}
+
+func (m *MessageApi) RenderMsgTemplate(c *gin.Context) {
+	a2r.Call(c, msgext.MsgExtClient.RenderMsgTemplate, m.extClient)
+}
+
+// applyMsgTemplate converts the template fields of the request into a template reference,
+// which is rendered by the msg service when the message is sent.
+// For OANotification the reference is carried in the template field of the notification content.
+func applyMsgTemplate(req *apistruct.SendMsg) {
+	elem := map[string]any{
+		"templateID": req.TemplateID,
+		"locale":     req.TemplateLocale,
+		"params":     req.TemplateParams,
+	}
+	if req.ContentType == constant.OANotification {
+		if req.Content == nil {
+			req.Content = make(map[string]any)
+		}
+		req.Content["template"] = elem
+		return
+	}
+	req.ContentType = msgprocessor.TemplateMsg
+	req.Content = elem
+}
@@ -5,6 +5,11 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 	pbAuth "github.com/openimsdk/protocol/auth"
 	"github.com/openimsdk/protocol/conversation"
@@ -96,8 +101,8 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		r.Use(gzip.Gzip(gzip.BestSpeed))
 	}
 	r.Use(prommetricsGin(), gin.RecoveryWithWriter(gin.DefaultErrorWriter, mw.GinPanicErr), mw.CorsHandler(), mw.GinParseOperationID(), GinParseToken(rpcli.NewAuthClient(authConn)))
-	u := NewUserApi(user.NewUserClient(userConn), client, config.Share.RpcRegisterName)
-	m := NewMessageApi(msg.NewMsgClient(msgConn), rpcli.NewUserClient(userConn), config.Share.IMAdminUserID)
+	u := NewUserApi(user.NewUserClient(userConn), userext.NewUserExtClient(userConn), client, config.Share.RpcRegisterName)
+	m := NewMessageApi(msg.NewMsgClient(msgConn), msgext.NewMsgExtClient(msgConn), rpcli.NewUserClient(userConn), config.Share.IMAdminUserID)
 	userRouterGroup := r.Group("/user")
 	{
 		userRouterGroup.POST("/user_register", u.UserRegister)
@@ -113,6 +118,18 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		userRouterGroup.POST("/subscribe_users_status", u.SubscriberStatus)
 		userRouterGroup.POST("/get_users_status", u.GetUserStatus)
 		userRouterGroup.POST("/get_subscribe_users_status", u.GetSubscribeUsersStatus)
+		userRouterGroup.POST("/get_contact_import_config", u.GetContactImportConfig)
+		userRouterGroup.POST("/match_contacts", u.MatchContacts)
+		userRouterGroup.POST("/erase_user", u.EraseUser)
+		userRouterGroup.POST("/resume_user_erasure", u.ResumeUserErasure)
+		userRouterGroup.POST("/get_user_erasures", u.GetUserErasures)
+		userRouterGroup.POST("/ban_user", u.BanUser)
+		userRouterGroup.POST("/unban_user", u.UnbanUser)
+		userRouterGroup.POST("/search_banned_users", u.SearchBannedUsers)
+		userRouterGroup.POST("/set_profile_field", u.SetUserProfileField)
+		userRouterGroup.POST("/delete_profile_fields", u.DeleteUserProfileFields)
+		userRouterGroup.POST("/get_profile_fields", u.GetUserProfileFields)
+		userRouterGroup.POST("/search_users_by_profile", u.SearchUsersByProfile)
 
 		userRouterGroup.POST("/process_user_command_add", u.ProcessUserCommandAdd)
 		userRouterGroup.POST("/process_user_command_delete", u.ProcessUserCommandDelete)
@@ -126,7 +143,7 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 	}
 	// friend routing group
 	{
-		f := NewFriendApi(relation.NewFriendClient(friendConn))
+		f := NewFriendApi(relation.NewFriendClient(friendConn), relationext.NewRelationExtClient(friendConn))
 		friendRouterGroup := r.Group("/friend")
 		friendRouterGroup.POST("/delete_friend", f.DeleteFriend)
 		friendRouterGroup.POST("/get_friend_apply_list", f.GetFriendApplyList)
@@ -142,6 +159,8 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		friendRouterGroup.POST("/get_specified_blacks", f.GetSpecifiedBlacks)
 		friendRouterGroup.POST("/remove_black", f.RemoveBlack)
 		friendRouterGroup.POST("/get_incremental_blacks", f.GetIncrementalBlacks)
+		friendRouterGroup.POST("/set_black_scope", f.SetBlackScope)
+		friendRouterGroup.POST("/get_black_scopes", f.GetBlackScopes)
 		friendRouterGroup.POST("/import_friend", f.ImportFriends)
 		friendRouterGroup.POST("/is_friend", f.IsFriend)
 		friendRouterGroup.POST("/get_friend_id", f.GetFriendIDs)
@@ -150,9 +169,18 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		friendRouterGroup.POST("/get_incremental_friends", f.GetIncrementalFriends)
 		friendRouterGroup.POST("/get_full_friend_user_ids", f.GetFullFriendUserIDs)
 		friendRouterGroup.POST("/get_self_unhandled_apply_count", f.GetSelfUnhandledApplyCount)
+		friendRouterGroup.POST("/create_friend_category", f.CreateFriendCategory)
+		friendRouterGroup.POST("/update_friend_category", f.UpdateFriendCategory)
+		friendRouterGroup.POST("/sort_friend_categories", f.SortFriendCategories)
+		friendRouterGroup.POST("/delete_friend_categories", f.DeleteFriendCategories)
+		friendRouterGroup.POST("/get_friend_categories", f.GetFriendCategories)
+		friendRouterGroup.POST("/set_friends_categories", f.SetFriendsCategories)
+		friendRouterGroup.POST("/update_friend_category_members", f.UpdateFriendCategoryMembers)
+		friendRouterGroup.POST("/get_friend_suggestions", f.GetFriendSuggestions)
+		friendRouterGroup.POST("/get_friend_events", f.GetFriendEvents)
 	}
 
-	g := NewGroupApi(group.NewGroupClient(groupConn))
+	g := NewGroupApi(group.NewGroupClient(groupConn), groupext.NewGroupExtClient(groupConn))
 	{
 		groupRouterGroup := r.Group("/group")
 		groupRouterGroup.POST("/create_group", g.CreateGroup)
@@ -187,6 +215,40 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		groupRouterGroup.POST("/get_full_group_member_user_ids", g.GetFullGroupMemberUserIDs)
 		groupRouterGroup.POST("/get_full_join_group_ids", g.GetFullJoinGroupIDs)
 		groupRouterGroup.POST("/get_group_application_unhandled_count", g.GetGroupApplicationUnhandledCount)
+		groupRouterGroup.POST("/get_group_settings", g.GetGroupSettings)
+		groupRouterGroup.POST("/set_group_mention_policy", g.SetGroupMentionPolicy)
+		groupRouterGroup.POST("/set_group_slow_mode", g.SetGroupSlowMode)
+		groupRouterGroup.POST("/set_group_mute_schedule", g.SetGroupMuteSchedule)
+		groupRouterGroup.POST("/create_group_invite_link", g.CreateGroupInviteLink)
+		groupRouterGroup.POST("/get_group_invite_links", g.GetGroupInviteLinks)
+		groupRouterGroup.POST("/revoke_group_invite_links", g.RevokeGroupInviteLinks)
+		groupRouterGroup.POST("/get_group_invite_link_info", g.GetGroupInviteLinkInfo)
+		groupRouterGroup.POST("/redeem_group_invite_link", g.RedeemGroupInviteLink)
+		groupRouterGroup.POST("/create_group_role", g.CreateGroupRole)
+		groupRouterGroup.POST("/update_group_role", g.UpdateGroupRole)
+		groupRouterGroup.POST("/delete_group_roles", g.DeleteGroupRoles)
+		groupRouterGroup.POST("/get_group_roles", g.GetGroupRoles)
+		groupRouterGroup.POST("/set_group_member_role", g.SetGroupMemberRole)
+		groupRouterGroup.POST("/create_group_announcement", g.CreateGroupAnnouncement)
+		groupRouterGroup.POST("/get_group_announcements", g.GetGroupAnnouncements)
+		groupRouterGroup.POST("/set_group_announcement_pinned", g.SetGroupAnnouncementPinned)
+		groupRouterGroup.POST("/delete_group_announcements", g.DeleteGroupAnnouncements)
+		groupRouterGroup.POST("/ack_group_announcement", g.AckGroupAnnouncement)
+		groupRouterGroup.POST("/get_group_announcement_acks", g.GetGroupAnnouncementAcks)
+		groupRouterGroup.POST("/remind_group_announcement", g.RemindGroupAnnouncement)
+		groupRouterGroup.POST("/succeed_group_owners", g.SucceedGroupOwners)
+		groupRouterGroup.POST("/create_group_channel", g.CreateGroupChannel)
+		groupRouterGroup.POST("/update_group_channel", g.UpdateGroupChannel)
+		groupRouterGroup.POST("/delete_group_channels", g.DeleteGroupChannels)
+		groupRouterGroup.POST("/get_group_channels", g.GetGroupChannels)
+		groupRouterGroup.POST("/get_incremental_group_channels", g.GetIncrementalGroupChannels)
+		groupRouterGroup.POST("/import_groups", g.ImportGroups)
+		groupRouterGroup.POST("/search_group_members", g.SearchGroupMembers)
+		groupRouterGroup.POST("/archive_group", g.ArchiveGroup)
+		groupRouterGroup.POST("/unarchive_group", g.UnarchiveGroup)
+		groupRouterGroup.POST("/get_joined_groups", g.GetJoinedGroups)
+		groupRouterGroup.POST("/set_group_join_approval_policy", g.SetGroupJoinApprovalPolicy)
+		groupRouterGroup.POST("/get_group_application_decisions", g.GetGroupApplicationDecisions)
 	}
 	// certificate
 	{
@@ -200,7 +262,7 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 	}
 	// Third service
 	{
-		t := NewThirdApi(third.NewThirdClient(thirdConn), config.API.Prometheus.GrafanaURL)
+		t := NewThirdApi(third.NewThirdClient(thirdConn), thirdext.NewThirdExtClient(thirdConn), config.API.Prometheus.GrafanaURL)
 		thirdGroup := r.Group("/third")
 		thirdGroup.GET("/prometheus", t.GetPrometheus)
 		thirdGroup.POST("/fcm_update_token", t.FcmUpdateToken)
@@ -222,6 +284,13 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		objectGroup.POST("/initiate_form_data", t.InitiateFormData)
 		objectGroup.POST("/complete_form_data", t.CompleteFormData)
 		objectGroup.GET("/*name", t.ObjectRedirect)
+
+		applicationGroup := r.Group("/application")
+		applicationGroup.POST("/add_version", t.AddApplicationVersion)
+		applicationGroup.POST("/update_version", t.UpdateApplicationVersion)
+		applicationGroup.POST("/delete_versions", t.DeleteApplicationVersions)
+		applicationGroup.POST("/search_versions", t.SearchApplicationVersions)
+		applicationGroup.POST("/latest_version", t.GetLatestApplicationVersion)
 	}
 	// Message
 	{
@@ -246,6 +315,13 @@ func newGinRouter(ctx context.Context, client discovery.SvcDiscoveryRegistry, co
 		msgGroup.POST("/batch_send_msg", m.BatchSendMsg)
 		msgGroup.POST("/check_msg_is_send_success", m.CheckMsgIsSendSuccess)
 		msgGroup.POST("/get_server_time", m.GetServerTime)
+
+		msgGroup.POST("/create_msg_template", m.CreateMsgTemplate)
+		msgGroup.POST("/update_msg_template", m.UpdateMsgTemplate)
+		msgGroup.POST("/delete_msg_templates", m.DeleteMsgTemplates)
+		msgGroup.POST("/get_msg_template", m.GetMsgTemplate)
+		msgGroup.POST("/search_msg_templates", m.SearchMsgTemplates)
+		msgGroup.POST("/render_msg_template", m.RenderMsgTemplate)
 	}
 	// Conversation
 	{
@@ -332,4 +408,5 @@ func GinParseToken(authClient *rpcli.AuthClient) gin.HandlerFunc {
 var Whitelist = []string{
 	"/auth/get_admin_token",
 	"/auth/parse_token",
+	"/application/latest_version",
 }
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
 	"github.com/openimsdk/protocol/third"
 	"github.com/openimsdk/tools/a2r"
 	"github.com/openimsdk/tools/errs"
@@ -33,10 +34,11 @@ import (
 type ThirdApi struct {
 	GrafanaUrl string
 	Client     third.ThirdClient
+	ExtClient  thirdext.ThirdExtClient
 }
 
-func NewThirdApi(client third.ThirdClient, grafanaUrl string) ThirdApi {
-	return ThirdApi{Client: client, GrafanaUrl: grafanaUrl}
+func NewThirdApi(client third.ThirdClient, extClient thirdext.ThirdExtClient, grafanaUrl string) ThirdApi {
+	return ThirdApi{Client: client, ExtClient: extClient, GrafanaUrl: grafanaUrl}
 }
 
 func (o *ThirdApi) FcmUpdateToken(c *gin.Context) {
@@ -47,6 +49,31 @@ func (o *ThirdApi) SetAppBadge(c *gin.Context) {
 	a2r.Call(c, third.ThirdClient.SetAppBadge, o.Client)
 }
 
+// AddApplicationVersion Add an app version for a platform.
+func (o *ThirdApi) AddApplicationVersion(c *gin.Context) {
+	a2r.Call(c, thirdext.ThirdExtClient.AddApplicationVersion, o.ExtClient)
+}
+
+// UpdateApplicationVersion Update fields of an app version.
+func (o *ThirdApi) UpdateApplicationVersion(c *gin.Context) {
+	a2r.Call(c, thirdext.ThirdExtClient.UpdateApplicationVersion, o.ExtClient)
+}
+
+// DeleteApplicationVersions Delete app versions.
+func (o *ThirdApi) DeleteApplicationVersions(c *gin.Context) {
+	a2r.Call(c, thirdext.ThirdExtClient.DeleteApplicationVersions, o.ExtClient)
+}
+
+// SearchApplicationVersions Search app versions.
+func (o *ThirdApi) SearchApplicationVersions(c *gin.Context) {
+	a2r.Call(c, thirdext.ThirdExtClient.SearchApplicationVersions, o.ExtClient)
+}
+
+// GetLatestApplicationVersion Get the released version of a platform, no token required.
+func (o *ThirdApi) GetLatestApplicationVersion(c *gin.Context) {
+	a2r.Call(c, thirdext.ThirdExtClient.GetLatestApplicationVersion, o.ExtClient)
+}
+
 // #################### s3 ####################
 
 func setURLPrefixOption[A, B, C any](_ func(client C, ctx context.Context, req *A, options ...grpc.CallOption) (*B, error), fn func(*A) error) *a2r.Option[A, B] {
@@ -17,6 +17,7 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
 	"github.com/openimsdk/protocol/constant"
 	"github.com/openimsdk/protocol/msggateway"
 	"github.com/openimsdk/protocol/user"
@@ -28,13 +29,14 @@ import (
 )
 
 type UserApi struct {
-	Client user.UserClient
-	discov discovery.SvcDiscoveryRegistry
-	config config.RpcRegisterName
+	Client    user.UserClient
+	ExtClient userext.UserExtClient
+	discov    discovery.SvcDiscoveryRegistry
+	config    config.RpcRegisterName
 }
 
-func NewUserApi(client user.UserClient, discov discovery.SvcDiscoveryRegistry, config config.RpcRegisterName) UserApi {
-	return UserApi{Client: client, discov: discov, config: config}
+func NewUserApi(client user.UserClient, extClient userext.UserExtClient, discov discovery.SvcDiscoveryRegistry, config config.RpcRegisterName) UserApi {
+	return UserApi{Client: client, ExtClient: extClient, discov: discov, config: config}
 }
 
 func (u *UserApi) UserRegister(c *gin.Context) {
@@ -242,3 +244,63 @@ func (u *UserApi) UpdateNotificationAccountInfo(c *gin.Context) {
 func (u *UserApi) SearchNotificationAccount(c *gin.Context) {
 	a2r.Call(c, user.UserClient.SearchNotificationAccount, u.Client)
 }
+
+// GetContactImportConfig Get the salt used to hash contact identifiers.
+func (u *UserApi) GetContactImportConfig(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.GetContactImportConfig, u.ExtClient)
+}
+
+// MatchContacts Match hashed contact identifiers against registered users.
+func (u *UserApi) MatchContacts(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.MatchContacts, u.ExtClient)
+}
+
+// EraseUser Start a background job that erases the user's account and data.
+func (u *UserApi) EraseUser(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.EraseUser, u.ExtClient)
+}
+
+// ResumeUserErasure Resume a failed or interrupted user erasure job.
+func (u *UserApi) ResumeUserErasure(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.ResumeUserErasure, u.ExtClient)
+}
+
+// GetUserErasures Get user erasure jobs and their step status.
+func (u *UserApi) GetUserErasures(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.GetUserErasures, u.ExtClient)
+}
+
+// BanUser Ban a user from logging in and sending messages.
+func (u *UserApi) BanUser(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.BanUser, u.ExtClient)
+}
+
+// UnbanUser Lift a user's ban.
+func (u *UserApi) UnbanUser(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.UnbanUser, u.ExtClient)
+}
+
+// SearchBannedUsers Search users that are currently banned.
+func (u *UserApi) SearchBannedUsers(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.SearchBannedUsers, u.ExtClient)
+}
+
+// SetUserProfileField Add or replace a custom profile field definition.
+func (u *UserApi) SetUserProfileField(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.SetUserProfileField, u.ExtClient)
+}
+
+// DeleteUserProfileFields Delete custom profile field definitions.
+func (u *UserApi) DeleteUserProfileFields(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.DeleteUserProfileFields, u.ExtClient)
+}
+
+// GetUserProfileFields Get all custom profile field definitions.
+func (u *UserApi) GetUserProfileFields(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.GetUserProfileFields, u.ExtClient)
+}
+
+// SearchUsersByProfile Search users by searchable custom profile fields.
+func (u *UserApi) SearchUsersByProfile(c *gin.Context) {
+	a2r.Call(c, userext.UserExtClient.SearchUsersByProfile, u.ExtClient)
+}
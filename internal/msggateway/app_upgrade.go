@@ -0,0 +1,44 @@
+package msggateway
+
+import (
+	"encoding/json"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/log"
+)
+
+// pushAppForceUpgrade 连接建立后检查客户端应用版本，需要强制更新时推送最新版本信息
+// 设计思路：
+// 1. 版本按平台名称（如IOS、Android）匹配，与后台配置应用版本时使用的平台一致
+// 2. 检查失败只记录日志，不影响连接
+// 3. 客户端对象会被连接池复用，推送前确认仍是同一个连接
+//
+// 参数：
+//   - ctx: 建立连接时的上下文
+//   - client: 新建立的客户端连接
+func (ws *WsServer) pushAppForceUpgrade(ctx *UserConnContext, client *Client) {
+	platform := constant.PlatformIDToName(client.PlatformID)
+	resp, err := ws.thirdExtClient.GetLatestApplicationVersion(ctx, &thirdext.GetLatestApplicationVersionReq{
+		Platform: platform,
+		Version:  ctx.GetAppVersion(),
+	})
+	if err != nil {
+		log.ZWarn(ctx, "get latest application version failed", err, "platform", platform, "appVersion", ctx.GetAppVersion())
+		return
+	}
+	if !resp.Force {
+		return
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		log.ZError(ctx, "marshal application version failed", err)
+		return
+	}
+	if client.ctx != ctx {
+		return
+	}
+	if err := client.PushAppForceUpgrade(data); err != nil {
+		log.ZWarn(ctx, "push app force upgrade failed", err, "userID", client.UserID, "platformID", client.PlatformID)
+	}
+}
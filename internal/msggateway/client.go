@@ -469,6 +469,17 @@ func (c *Client) PushUserOnlineStatus(data []byte) error {
 	return c.writeBinaryMsg(resp)
 }
 
+// PushAppForceUpgrade 推送应用强制更新通知
+// data为JSON编码的最新版本信息
+func (c *Client) PushAppForceUpgrade(data []byte) error {
+	resp := Resp{
+		ReqIdentifier: WsAppForceUpgrade,
+		OperationID:   c.ctx.GetOperationID(),
+		Data:          data,
+	}
+	return c.writeBinaryMsg(resp)
+}
+
 // writeBinaryMsg 写入二进制消息
 // 设计思路：
 // 1. 并发写入保护，使用互斥锁
@@ -85,6 +85,11 @@ const (
 	// SDKType SDK类型参数名
 	// 标识客户端使用的SDK类型，影响编码方式
 	SDKType = "sdkType"
+
+	// AppVersion 应用版本号参数名
+	// 可选，客户端携带时网关在连接建立后检查是否需要强制更新
+	// 示例: ws://host/ws?appVersion=1.2.0
+	AppVersion = "appVersion"
 )
 
 // SDK类型常量定义
@@ -166,6 +171,10 @@ const (
 	// 客户端订阅指定用户的在线状态变更通知
 	WsSubUserOnlineStatus = 2005
 
+	// WsAppForceUpgrade 应用强制更新通知
+	// 连接建立时客户端版本需要强制更新，推送最新版本信息
+	WsAppForceUpgrade = 2006
+
 	// === 错误类型 (3xxx) ===
 
 	// WSDataError 数据错误
@@ -242,6 +242,12 @@ func (c *UserConnContext) GetOperationID() string {
 	return c.Req.URL.Query().Get(OperationID)
 }
 
+// GetAppVersion 获取客户端应用版本号
+// 从URL查询参数中提取，未携带时返回空字符串
+func (c *UserConnContext) GetAppVersion() string {
+	return c.Req.URL.Query().Get(AppVersion)
+}
+
 // SetOperationID 设置操作ID
 // 用于动态修改操作追踪标识符
 //
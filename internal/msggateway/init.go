@@ -26,11 +26,12 @@ import (
 )
 
 type Config struct {
-	MsgGateway     config.MsgGateway
-	Share          config.Share
-	RedisConfig    config.Redis
-	WebhooksConfig config.Webhooks
-	Discovery      config.Discovery
+	MsgGateway       config.MsgGateway
+	Share            config.Share
+	RedisConfig      config.Redis
+	WebhooksConfig   config.Webhooks
+	LocalCacheConfig config.LocalCache
+	Discovery        config.Discovery
 }
 
 // Start run ws server.
@@ -56,6 +57,7 @@ func Start(ctx context.Context, index int, conf *Config) error {
 
 	hubServer := NewServer(longServer, conf, func(srv *Server) error {
 		var err error
+		longServer.friendLocalCache = rpccache.NewFriendLocalCache(longServer.relationClient, &conf.LocalCacheConfig, rdb)
 		longServer.online, err = rpccache.NewOnlineCache(srv.userClient, nil, rdb, false, longServer.subscriberUserOnlineStatusChanges)
 		return err
 	})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/protocol/sdkws"
 	"github.com/openimsdk/tools/log"
 	"github.com/openimsdk/tools/utils/datautil"
@@ -63,10 +64,17 @@ func (ws *WsServer) SubUserOnlineStatus(ctx context.Context, client *Client, dat
 		resp.Subscribers = make([]*sdkws.SubUserOnlineStatusElem, 0, len(sub.SubscribeUserID))
 
 		// 为每个新订阅的用户获取当前在线状态
+		// 对订阅者隐藏在线状态的用户始终返回离线，订阅关系仍然保留，解除隐藏后即可收到状态变更
 		for _, userID := range sub.SubscribeUserID {
-			platformIDs, err := ws.online.GetUserOnlinePlatform(ctx, userID)
-			if err != nil {
-				return nil, err
+			var (
+				platformIDs []int32
+				err         error
+			)
+			if !ws.presenceHidden(ctx, userID, client.UserID) {
+				platformIDs, err = ws.online.GetUserOnlinePlatform(ctx, userID)
+				if err != nil {
+					return nil, err
+				}
 			}
 			resp.Subscribers = append(resp.Subscribers, &sdkws.SubUserOnlineStatusElem{
 				UserID:            userID,
@@ -77,6 +85,17 @@ func (ws *WsServer) SubUserOnlineStatus(ctx context.Context, client *Client, dat
 	return proto.Marshal(&resp)
 }
 
+// presenceHidden 判断ownerUserID是否对viewerUserID隐藏在线状态
+// 黑名单范围通过本地缓存查询，查询失败时按隐藏处理，只影响这一对用户，避免泄露在线状态
+func (ws *WsServer) presenceHidden(ctx context.Context, ownerUserID, viewerUserID string) bool {
+	scope, err := ws.friendLocalCache.GetBlackScope(ctx, ownerUserID, viewerUserID)
+	if err != nil {
+		log.ZWarn(ctx, "get black scope failed, hide online status", err, "ownerUserID", ownerUserID, "viewerUserID", viewerUserID)
+		return true
+	}
+	return scope&model.BlackScopePresence != 0
+}
+
 // newSubscription 创建新的订阅管理器
 func newSubscription() *Subscription {
 	return &Subscription{
@@ -271,10 +290,28 @@ func (ws *WsServer) pushUserIDOnlineStatus(ctx context.Context, userID string, p
 		log.ZError(ctx, "pushUserIDOnlineStatus json.Marshal", err)
 		return
 	}
+	// 被隐藏在线状态的订阅者始终收到离线状态
+	offlineStatus, err := proto.Marshal(&sdkws.SubUserOnlineStatusTips{
+		Subscribers: []*sdkws.SubUserOnlineStatusElem{{UserID: userID}},
+	})
+	if err != nil {
+		log.ZError(ctx, "pushUserIDOnlineStatus json.Marshal", err)
+		return
+	}
 
-	// 向每个订阅客户端推送状态变更通知
+	// 向每个订阅客户端推送状态变更通知，同一订阅者的多个连接只查询一次
+	hidden := make(map[string]bool)
 	for _, client := range clients {
-		if err := client.PushUserOnlineStatus(onlineStatus); err != nil {
+		h, ok := hidden[client.UserID]
+		if !ok {
+			h = ws.presenceHidden(ctx, userID, client.UserID)
+			hidden[client.UserID] = h
+		}
+		status := onlineStatus
+		if h {
+			status = offlineStatus
+		}
+		if err := client.PushUserOnlineStatus(status); err != nil {
 			log.ZError(ctx, "UserSubscribeOnlineStatusNotification push failed", err,
 				"userID", client.UserID,
 				"platformID", client.PlatformID,
@@ -51,6 +51,7 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/webhook"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpccache"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
 	pbAuth "github.com/openimsdk/protocol/auth"
 	"github.com/openimsdk/protocol/constant"
 	"github.com/openimsdk/protocol/msggateway"
@@ -120,6 +121,7 @@ type WsServer struct {
 	kickHandlerChan   chan *kickHandler              // 踢下线处理通道
 	clients           UserMap                        // 用户连接映射管理器
 	online            *rpccache.OnlineCache          // 在线状态缓存
+	friendLocalCache  *rpccache.FriendLocalCache     // 黑名单本地缓存，用于过滤对订阅者隐藏的在线状态
 	subscription      *Subscription                  // 订阅管理器
 	clientPool        sync.Pool                      // 客户端对象池
 	onlineUserNum     atomic.Int64                   // 在线用户数量（原子操作）
@@ -134,9 +136,11 @@ type WsServer struct {
 	MessageHandler // 消息处理器
 
 	// RPC客户端
-	webhookClient *webhook.Client   // Webhook客户端
-	userClient    *rpcli.UserClient // 用户服务客户端
-	authClient    *rpcli.AuthClient // 认证服务客户端
+	webhookClient  *webhook.Client         // Webhook客户端
+	userClient     *rpcli.UserClient       // 用户服务客户端
+	authClient     *rpcli.AuthClient       // 认证服务客户端
+	relationClient *rpcli.RelationClient   // 关系服务客户端
+	thirdExtClient thirdext.ThirdExtClient // 第三方扩展服务客户端，用于连接时检查应用版本
 }
 
 // kickHandler 踢下线处理器结构体
@@ -189,9 +193,23 @@ func (ws *WsServer) SetDiscoveryRegistry(ctx context.Context, disCov discovery.S
 		return err
 	}
 
+	// 获取关系服务连接
+	friendConn, err := disCov.GetConn(ctx, config.Share.RpcRegisterName.Friend)
+	if err != nil {
+		return err
+	}
+
+	// 获取第三方服务连接
+	thirdConn, err := disCov.GetConn(ctx, config.Share.RpcRegisterName.Third)
+	if err != nil {
+		return err
+	}
+
 	// 初始化RPC客户端
 	ws.userClient = rpcli.NewUserClient(userConn)
 	ws.authClient = rpcli.NewAuthClient(authConn)
+	ws.relationClient = rpcli.NewRelationClient(friendConn)
+	ws.thirdExtClient = thirdext.NewThirdExtClient(thirdConn)
 
 	// 初始化消息处理器，集成多个RPC服务
 	ws.MessageHandler = NewGrpcHandler(
@@ -830,4 +848,9 @@ func (ws *WsServer) wsHandler(w http.ResponseWriter, r *http.Request) {
 	// 注册客户端并启动消息处理循环
 	ws.registerChan <- client
 	go client.readMessage()
+
+	// 客户端携带应用版本号时，检查是否需要强制更新
+	if connContext.GetAppVersion() != "" {
+		go ws.pushAppForceUpgrade(connContext, client)
+	}
 }
@@ -58,7 +58,7 @@ import (
 type MsgTransfer struct {
 	// historyCH Redis消息处理器
 	// 功能：
-	// 1. 消费Kafka ToRedisTopic主题的消息
+	// 1. 消费Kafka ToRedisTopic主题的消息（配置了高优先级通道时同时消费ToRedisPriorityTopic）
 	// 2. 批量处理消息并分类（存储/非存储，通知/普通消息）
 	// 3. 使用Redis INCRBY原子操作分配唯一序号（核心去重机制）
 	// 4. 将消息写入Redis缓存（key: msg:conversationID:seq，TTL: 24小时）
@@ -194,6 +194,12 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 	if err != nil {
 		return err
 	}
+	if m.historyCH.priorityConsumerGroup != nil {
+		go m.historyCH.priorityConsumerGroup.RegisterHandleAndConsumer(m.ctx, m.historyCH.PriorityHandler())
+		if err := m.historyCH.priorityMessageBatches.Start(); err != nil {
+			return err
+		}
+	}
 
 	client, err := kdisc.NewDiscoveryRegister(&cfg.Discovery, &cfg.Share, nil)
 	if err != nil {
@@ -270,6 +276,7 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 		// graceful close kafka client.
 		m.cancel()
 		m.historyCH.redisMessageBatches.Close()
+		m.historyCH.closePriorityLane()
 		m.historyCH.Close()
 		m.historyCH.historyConsumerGroup.Close()
 		m.historyMongoCH.historyConsumerGroup.Close()
@@ -277,6 +284,7 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 	case <-netDone:
 		m.cancel()
 		m.historyCH.redisMessageBatches.Close()
+		m.historyCH.closePriorityLane()
 		m.historyCH.Close()
 		m.historyCH.historyConsumerGroup.Close()
 		m.historyMongoCH.historyConsumerGroup.Close()
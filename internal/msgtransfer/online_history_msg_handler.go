@@ -88,6 +88,14 @@ type OnlineHistoryRedisConsumerHandler struct {
 	// - 50个并发工作协程
 	redisMessageBatches *batcher.Batcher[sarama.ConsumerMessage]
 
+	// priorityConsumerGroup 高优先级通道的Kafka消费者组，消费ToRedisPriorityTopic主题
+	// 信令和系统通知使用独立的主题、消费者组和批处理器，不受大批量聊天消息的积压影响
+	// 未配置高优先级通道时为nil
+	priorityConsumerGroup *kafka.MConsumerGroup
+
+	// priorityMessageBatches 高优先级通道的批处理器，未配置高优先级通道时为nil
+	priorityMessageBatches *batcher.Batcher[sarama.ConsumerMessage]
+
 	// msgTransferDatabase 数据库操作接口，提供Redis和MongoDB的统一访问
 	msgTransferDatabase controller.MsgTransferDatabase
 
@@ -154,7 +162,24 @@ func NewOnlineHistoryRedisConsumerHandler(ctx context.Context, client discovery.
 	och.conversationClient = rpcli.NewConversationClient(conversationConn)
 	och.wg.Add(1) // 为异步已读序号处理协程添加计数
 
-	// 5. 创建和配置批处理器
+	// 5. 创建批处理器
+	och.redisMessageBatches = och.newRedisMessageBatcher()
+	och.historyConsumerGroup = historyConsumerGroup
+
+	// 6. 配置了高优先级通道时，创建独立的消费者组和批处理器
+	if kafkaConf.EnableRedisPriorityLane() {
+		och.priorityConsumerGroup, err = kafka.NewMConsumerGroup(kafkaConf.Build(), kafkaConf.ToRedisPriorityGroupID, []string{kafkaConf.ToRedisPriorityTopic}, false)
+		if err != nil {
+			return nil, err
+		}
+		och.priorityMessageBatches = och.newRedisMessageBatcher()
+	}
+
+	return &och, nil
+}
+
+// newRedisMessageBatcher 创建并配置批处理器
+func (och *OnlineHistoryRedisConsumerHandler) newRedisMessageBatcher() *batcher.Batcher[sarama.ConsumerMessage] {
 	b := batcher.New[sarama.ConsumerMessage](
 		batcher.WithSize(size),                 // 批量大小：500条消息
 		batcher.WithWorker(worker),             // 工作协程数：50个
@@ -164,24 +189,20 @@ func NewOnlineHistoryRedisConsumerHandler(ctx context.Context, client discovery.
 		batcher.WithBuffer(subChanBuffer),      // 子通道缓冲区：50
 	)
 
-	// 6. 设置分片函数 - 根据会话ID分片，确保同一会话的消息有序处理
+	// 设置分片函数 - 根据会话ID分片，确保同一会话的消息有序处理
 	b.Sharding = func(key string) int {
 		hashCode := stringutil.GetHashCode(key)
-		return int(hashCode) % och.redisMessageBatches.Worker()
+		return int(hashCode) % b.Worker()
 	}
 
-	// 7. 设置键提取函数 - 从Kafka消息中提取会话ID作为分片键
+	// 设置键提取函数 - 从Kafka消息中提取会话ID作为分片键
 	b.Key = func(consumerMessage *sarama.ConsumerMessage) string {
 		return string(consumerMessage.Key)
 	}
 
-	// 8. 设置批处理逻辑 - 指向do()方法
+	// 设置批处理逻辑 - 指向do()方法
 	b.Do = och.do
-
-	och.redisMessageBatches = b
-	och.historyConsumerGroup = historyConsumerGroup
-
-	return &och, nil
+	return b
 }
 
 // do 批处理消息的核心处理方法
@@ -203,7 +224,11 @@ func (och *OnlineHistoryRedisConsumerHandler) do(ctx context.Context, channelID
 
 	// 2. 解析Kafka消息，提取消息数据和上下文
 	ctxMessages := och.parseConsumerMessages(ctx, val.Val())
+	if len(ctxMessages) == 0 {
+		return
+	}
 	ctx = withAggregationCtx(ctx, ctxMessages)
+	observeLaneMetrics(ctxMessages)
 	log.ZInfo(ctx, "msg arrived channel", "channel id", channelID, "msgList length", len(ctxMessages), "key", val.Key())
 
 	// 3. 处理已读回执消息，更新用户已读序号
@@ -511,7 +536,9 @@ func (och *OnlineHistoryRedisConsumerHandler) handleMsg(ctx context.Context, key
 				log.ZDebug(ctx, "group chat first create conversation", "conversationID",
 					conversationID)
 
-				userIDs, err := och.groupClient.GetGroupMemberUserIDs(ctx, msg.GroupID)
+				// 频道会话为父群的全体成员创建
+				groupID, _ := msgprocessor.ParseGroupChannelID(msg.GroupID)
+				userIDs, err := och.groupClient.GetGroupMemberUserIDs(ctx, groupID)
 				if err != nil {
 					log.ZWarn(ctx, "get group member ids error", err, "conversationID",
 						conversationID)
@@ -641,6 +668,15 @@ func (och *OnlineHistoryRedisConsumerHandler) HandleUserHasReadSeqMessages(ctx c
 	log.ZInfo(ctx, "Channel closed, exiting handleUserHasReadSeqMessages")
 }
 
+// closePriorityLane 关闭高优先级通道的批处理器和消费者组
+func (och *OnlineHistoryRedisConsumerHandler) closePriorityLane() {
+	if och.priorityConsumerGroup == nil {
+		return
+	}
+	och.priorityMessageBatches.Close()
+	och.priorityConsumerGroup.Close()
+}
+
 // Close 优雅关闭Redis消息处理器
 // 确保所有异步处理协程安全退出
 func (och *OnlineHistoryRedisConsumerHandler) Close() {
@@ -671,6 +707,18 @@ func (och *OnlineHistoryRedisConsumerHandler) toPushTopic(ctx context.Context, k
 	}
 }
 
+// observeLaneMetrics 按优先级通道统计消费数量和从发送到消费的延迟
+func observeLaneMetrics(msgs []*ContextMsg) {
+	now := time.Now().UnixMilli()
+	for _, msg := range msgs {
+		lane := msgprocessor.GetPriorityLane(msg.message)
+		prommetrics.MsgTransferLaneConsumedCounter.WithLabelValues(lane).Inc()
+		if msg.message.SendTime > 0 && now > msg.message.SendTime {
+			prommetrics.MsgTransferLaneLatency.WithLabelValues(lane).Observe(float64(now-msg.message.SendTime) / 1000)
+		}
+	}
+}
+
 // withAggregationCtx 创建聚合上下文
 // 将批处理中多个消息的操作ID聚合为一个，便于追踪批处理操作
 //
@@ -743,11 +791,40 @@ func (och *OnlineHistoryRedisConsumerHandler) Cleanup(_ sarama.ConsumerGroupSess
 //   - error: 消费过程中的错误
 func (och *OnlineHistoryRedisConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 	claim sarama.ConsumerGroupClaim) error {
+	return och.consumeClaim(session, claim, och.redisMessageBatches)
+}
+
+// PriorityHandler 返回高优先级通道的消费处理器，与普通通道共用处理逻辑，使用独立的批处理器
+func (och *OnlineHistoryRedisConsumerHandler) PriorityHandler() sarama.ConsumerGroupHandler {
+	return &priorityRedisConsumerHandler{och: och}
+}
+
+// priorityRedisConsumerHandler 高优先级通道的消费处理器
+type priorityRedisConsumerHandler struct {
+	och *OnlineHistoryRedisConsumerHandler
+}
+
+func (p *priorityRedisConsumerHandler) Setup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (p *priorityRedisConsumerHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (p *priorityRedisConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
+	claim sarama.ConsumerGroupClaim) error {
+	return p.och.consumeClaim(session, claim, p.och.priorityMessageBatches)
+}
+
+// consumeClaim 将分区消息投递给指定通道的批处理器
+func (och *OnlineHistoryRedisConsumerHandler) consumeClaim(session sarama.ConsumerGroupSession,
+	claim sarama.ConsumerGroupClaim, batches *batcher.Batcher[sarama.ConsumerMessage]) error {
 	log.ZDebug(context.Background(), "online new session msg come", "highWaterMarkOffset",
 		claim.HighWaterMarkOffset(), "topic", claim.Topic(), "partition", claim.Partition())
 
 	// 设置批处理完成回调，用于提交Kafka偏移量
-	och.redisMessageBatches.OnComplete = func(lastMessage *sarama.ConsumerMessage, totalCount int) {
+	batches.OnComplete = func(lastMessage *sarama.ConsumerMessage, totalCount int) {
 		// 标记最后一条消息为已处理
 		session.MarkMessage(lastMessage, "")
 		// 提交偏移量，确保消息不会重复消费
@@ -770,7 +847,7 @@ func (och *OnlineHistoryRedisConsumerHandler) ConsumeClaim(session sarama.Consum
 
 			// 将消息投递给批处理器
 			// 批处理器会根据消息键（会话ID）进行分片，确保同一会话的消息有序处理
-			err := och.redisMessageBatches.Put(context.Background(), msg)
+			err := batches.Put(context.Background(), msg)
 			if err != nil {
 				log.ZWarn(context.Background(), "put msg to batcher error", err, "msg", msg)
 			}
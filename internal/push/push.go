@@ -127,6 +127,10 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	// 启动消息推送消费者协程
 	// 监听来自msg_transfer的消息，进行在线和离线推送
 	go consumer.pushConsumerGroup.RegisterHandleAndConsumer(ctx, consumer)
+	if consumer.priorityConsumerGroup != nil {
+		// 高优先级通道与普通通道共用处理逻辑，使用独立的消费者组
+		go consumer.priorityConsumerGroup.RegisterHandleAndConsumer(ctx, consumer)
+	}
 
 	// 启动离线推送消费者协程
 	// 专门处理离线推送队列中的消息
@@ -39,6 +39,7 @@ import (
 // 负责消费来自Kafka的推送消息，执行在线推送和离线推送
 type ConsumerHandler struct {
 	pushConsumerGroup      *kafka.MConsumerGroup            // Kafka消费者组，用于接收推送消息
+	priorityConsumerGroup  *kafka.MConsumerGroup            // 高优先级通道的Kafka消费者组，未配置时为nil
 	offlinePusher          offlinepush.OfflinePusher        // 离线推送器接口
 	onlinePusher           OnlinePusher                     // 在线推送器接口
 	pushDatabase           controller.PushDatabase          // 推送数据库控制器
@@ -75,6 +76,14 @@ func NewConsumerHandler(ctx context.Context, config *Config, database controller
 	if err != nil {
 		return nil, err
 	}
+	// 信令和系统通知使用独立的推送主题和消费者组，不受大批量聊天消息的积压影响
+	if config.KafkaConfig.EnablePushPriorityLane() {
+		consumerHandler.priorityConsumerGroup, err = kafka.NewMConsumerGroup(config.KafkaConfig.Build(), config.KafkaConfig.ToPushPriorityGroupID,
+			[]string{config.KafkaConfig.ToPushPriorityTopic}, true)
+		if err != nil {
+			return nil, err
+		}
+	}
 	userConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.User)
 	if err != nil {
 		return nil, err
@@ -122,6 +131,12 @@ func (c *ConsumerHandler) handleMs2PsChat(ctx context.Context, msg []byte) {
 		return
 	}
 
+	lane := msgprocessor.GetPriorityLane(msgFromMQ.MsgData)
+	prommetrics.MsgPushLaneConsumedCounter.WithLabelValues(lane).Inc()
+	if nowMilli := time.Now().UnixMilli(); msgFromMQ.MsgData.SendTime > 0 && nowMilli > msgFromMQ.MsgData.SendTime {
+		prommetrics.MsgPushLaneLatency.WithLabelValues(lane).Observe(float64(nowMilli-msgFromMQ.MsgData.SendTime) / 1000)
+	}
+
 	sec := msgFromMQ.MsgData.SendTime / 1000
 	nowSec := timeutil.GetCurrentTimestampBySecond()
 
@@ -352,7 +367,9 @@ func (c *ConsumerHandler) asyncOfflinePush(ctx context.Context, needOfflinePushU
 
 func (c *ConsumerHandler) groupMessagesHandler(ctx context.Context, groupID string, pushToUserIDs *[]string, msg *sdkws.MsgData) (err error) {
 	if len(*pushToUserIDs) == 0 {
-		*pushToUserIDs, err = c.groupLocalCache.GetGroupMemberIDs(ctx, groupID)
+		// 频道消息推送给父群的全体成员
+		parentGroupID, _ := msgprocessor.ParseGroupChannelID(groupID)
+		*pushToUserIDs, err = c.groupLocalCache.GetGroupMemberIDs(ctx, parentGroupID)
 		if err != nil {
 			return err
 		}
@@ -414,10 +431,30 @@ func (c *ConsumerHandler) offlinePushMsg(ctx context.Context, msg *sdkws.MsgData
 
 func (c *ConsumerHandler) filterGroupMessageOfflinePush(ctx context.Context, groupID string, msg *sdkws.MsgData,
 	offlinePushUserIDs []string) (userIDs []string, err error) {
-	needOfflinePushUserIDs, err := c.conversationClient.GetConversationOfflinePushUserIDs(ctx, conversationutil.GenGroupConversationID(groupID), offlinePushUserIDs)
+	conversationID := conversationutil.GenGroupConversationID(groupID)
+	needOfflinePushUserIDs, err := c.conversationClient.GetConversationOfflinePushUserIDs(ctx, conversationID, offlinePushUserIDs)
 	if err != nil {
 		return nil, err
 	}
+	// 上面按recv_msg_opt != ReceiveMessage过滤，设置了"仅被@时通知"的用户未被@时不做离线推送，
+	// 被@到（含@所有人）时重新加入离线推送
+	if msg.ContentType != constant.AtText || len(needOfflinePushUserIDs) == len(offlinePushUserIDs) {
+		return needOfflinePushUserIDs, nil
+	}
+	needSet := datautil.SliceSet(needOfflinePushUserIDs)
+	for _, userID := range offlinePushUserIDs {
+		if _, ok := needSet[userID]; ok || !msgprocessor.IsMentioned(msg, userID) {
+			continue
+		}
+		opt, err := c.conversationLocalCache.GetSingleConversationRecvMsgOpt(ctx, userID, conversationID)
+		if err != nil {
+			log.ZWarn(ctx, "GetSingleConversationRecvMsgOpt failed", err, "userID", userID, "conversationID", conversationID)
+			continue
+		}
+		if opt == msgprocessor.ReceiveMentionOnlyMessage {
+			needOfflinePushUserIDs = append(needOfflinePushUserIDs, userID)
+		}
+	}
 	return needOfflinePushUserIDs, nil
 }
 
@@ -467,7 +504,23 @@ func (c *ConsumerHandler) getOfflinePushInfos(msg *sdkws.MsgData) (title, conten
 }
 
 func (c *ConsumerHandler) DeleteMemberAndSetConversationSeq(ctx context.Context, groupID string, userIDs []string) error {
-	conversationID := msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, groupID)
+	if err := c.setConversationMaxSeq(ctx, msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, groupID), userIDs); err != nil {
+		return err
+	}
+	// 频道会话同样截止到成员离开时的消息
+	channels, err := c.groupLocalCache.GetGroupChannels(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		if err := c.setConversationMaxSeq(ctx, channel.ConversationID, userIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ConsumerHandler) setConversationMaxSeq(ctx context.Context, conversationID string, userIDs []string) error {
 	maxSeq, err := c.msgClient.GetConversationMaxSeq(ctx, conversationID)
 	if err != nil {
 		return err
@@ -280,6 +280,15 @@ func (s *authServer) GetUserToken(ctx context.Context, req *pbauth.GetUserTokenR
 		return nil, errs.ErrArgs.WrapMsg("app account can`t get token")
 	}
 
+	// 封禁中的用户不能获取令牌
+	ban, err := s.userClient.GetActiveUserBan(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if ban != nil {
+		return nil, servererrs.ErrUserBanned.WrapMsg("user is banned", "userID", req.UserID, "reason", ban.Reason, "expireTime", ban.ExpireTime)
+	}
+
 	// 生成用户Token
 	token, err := s.authDatabase.CreateToken(ctx, req.UserID, int(req.PlatformID))
 	if err != nil {
@@ -0,0 +1,46 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversation
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// DeleteGroupChannelConversations 删除群组频道时删除全部成员的频道会话，供群组服务内部调用
+// 会话ID由群组ID和频道ID生成，只能删除频道会话，不会影响群组主会话
+func (c *conversationServer) DeleteGroupChannelConversations(ctx context.Context, req *conversationext.DeleteGroupChannelConversationsReq) (*conversationext.DeleteGroupChannelConversationsResp, error) {
+	if req.GroupID == "" {
+		return nil, errs.ErrArgs.WrapMsg("groupID is empty")
+	}
+	conversationIDs := make([]string, 0, len(req.ChannelIDs))
+	for _, channelID := range datautil.Distinct(req.ChannelIDs) {
+		if channelID == "" {
+			return nil, errs.ErrArgs.WrapMsg("channelID is empty")
+		}
+		channelGroupID := msgprocessor.GetGroupChannelID(req.GroupID, channelID)
+		conversationIDs = append(conversationIDs, msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, channelGroupID))
+	}
+	count, err := c.conversationDatabase.DeleteConversations(ctx, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &conversationext.DeleteGroupChannelConversationsResp{DeletedCount: count}, nil
+}
@@ -41,6 +41,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
@@ -229,7 +230,9 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 
 	// 7. 注册会话服务到gRPC服务器
 	// 创建会话服务实例并注册，使其能够处理客户端请求
-	pbconversation.RegisterConversationServer(server, &conversationServer{
+	cs := &conversationServer{
+		config: config,
+
 		// 初始化通知发送器
 		conversationNotificationSender: NewConversationNotificationSender(&config.NotificationConfig, msgClient),
 
@@ -245,7 +248,9 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		userClient:  rpcli.NewUserClient(userConn),   // 用户服务客户端
 		groupClient: rpcli.NewGroupClient(groupConn), // 群组服务客户端
 		msgClient:   msgClient,                       // 消息服务客户端
-	})
+	}
+	pbconversation.RegisterConversationServer(server, cs)
+	conversationext.RegisterConversationExtServer(server, cs)
 
 	return nil
 }
@@ -340,12 +345,23 @@ func (c *conversationServer) GetSortedConversationList(ctx context.Context, req
 		return nil, err
 	}
 
+	// 设置了"仅被@时通知"且当前没有@提醒的会话不计入未读总数
+	mentionOnlyQuiet := make(map[string]struct{})
+	for _, v := range conversations {
+		if v.RecvMsgOpt == msgprocessor.ReceiveMentionOnlyMessage && v.GroupAtType == constant.AtNormal {
+			mentionOnlyQuiet[v.ConversationID] = struct{}{}
+		}
+	}
+
 	// 计算未读数：未读数 = 最大序列号 - 已读序列号
 	var unreadTotal int64
 	conversation_unreadCount := make(map[string]int64)
 	for conversationID, maxSeq := range maxSeqs {
 		unreadCount := maxSeq - hasReadSeqs[conversationID]
 		conversation_unreadCount[conversationID] = unreadCount
+		if _, ok := mentionOnlyQuiet[conversationID]; ok {
+			continue
+		}
 		unreadTotal += unreadCount
 	}
 
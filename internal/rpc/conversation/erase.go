@@ -0,0 +1,38 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversation
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
+	"github.com/openimsdk/tools/errs"
+)
+
+// EraseUserConversations 删除注销用户的全部会话，仅系统管理员可调用
+func (c *conversationServer) EraseUserConversations(ctx context.Context, req *conversationext.EraseUserConversationsReq) (*conversationext.EraseUserConversationsResp, error) {
+	if err := authverify.CheckAdmin(ctx, c.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	count, err := c.conversationDatabase.DeleteUserConversations(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &conversationext.EraseUserConversationsResp{DeletedCount: count}, nil
+}
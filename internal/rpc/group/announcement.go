@@ -0,0 +1,346 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	pbconversation "github.com/openimsdk/protocol/conversation"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/protocol/wrapperspb"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// groupAnnouncementRemindKey 公告确认提醒的业务通知key，客户端据此展示提醒
+const groupAnnouncementRemindKey = "groupAnnouncementRemind"
+
+// groupAnnouncementRemindBatchSize 每个异步任务发送的提醒数量，避免大群提醒占满内存队列
+const groupAnnouncementRemindBatchSize = 100
+
+func groupAnnouncementDB2Ext(announcement *model.GroupAnnouncement, acked bool) *groupext.GroupAnnouncement {
+	return &groupext.GroupAnnouncement{
+		AnnouncementID: announcement.AnnouncementID,
+		GroupID:        announcement.GroupID,
+		Content:        announcement.Content,
+		CreatorUserID:  announcement.CreatorUserID,
+		Pinned:         announcement.Pinned,
+		RequireAck:     announcement.RequireAck,
+		Acked:          acked,
+		Ex:             announcement.Ex,
+		CreateTime:     announcement.CreateTime.UnixMilli(),
+	}
+}
+
+// unackedUserIDs 返回尚未确认公告的成员，保持memberUserIDs的顺序
+func unackedUserIDs(memberUserIDs []string, acks []*model.GroupAnnouncementAck) []string {
+	acked := datautil.SliceSet(datautil.Slice(acks, func(a *model.GroupAnnouncementAck) string { return a.UserID }))
+	return datautil.Filter(memberUserIDs, func(userID string) (string, bool) {
+		_, ok := acked[userID]
+		return userID, !ok
+	})
+}
+
+// checkGroupMemberOrAppManager 校验操作者是群成员或系统管理员
+func (g *groupServer) checkGroupMemberOrAppManager(ctx context.Context, groupID string) error {
+	if authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
+		return nil
+	}
+	_, err := g.db.TakeGroupMember(ctx, groupID, mcontext.GetOpUserID(ctx))
+	return err
+}
+
+// newGroupAnnouncement 生成公告记录并写入历史
+func (g *groupServer) newGroupAnnouncement(ctx context.Context, groupID string, content string, pinned bool, requireAck bool, ex string) (*model.GroupAnnouncement, error) {
+	announcementID, err := genRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	announcement := &model.GroupAnnouncement{
+		AnnouncementID: announcementID,
+		GroupID:        groupID,
+		Content:        content,
+		CreatorUserID:  mcontext.GetOpUserID(ctx),
+		Pinned:         pinned,
+		RequireAck:     requireAck,
+		Ex:             ex,
+		CreateTime:     time.Now(),
+	}
+	if err := g.announcementDB.Create(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// saveGroupAnnouncementHistory 通过SetGroupInfo修改群公告时同时写入公告历史，失败只记录日志不影响群信息修改
+func (g *groupServer) saveGroupAnnouncementHistory(ctx context.Context, groupID string, content string) {
+	if _, err := g.newGroupAnnouncement(ctx, groupID, content, false, false, ""); err != nil {
+		log.ZWarn(ctx, "save group announcement history failed", err, "groupID", groupID)
+	}
+}
+
+// publishGroupNotification 将公告内容同步到Group.Notification，兼容只读取群信息中公告的客户端
+// content为空表示清空群公告
+func (g *groupServer) publishGroupNotification(ctx context.Context, groupID string, content string) error {
+	data := map[string]any{
+		"notification":             content,
+		"notification_update_time": time.Now(),
+		"notification_user_id":     mcontext.GetOpUserID(ctx),
+	}
+	if err := g.db.UpdateGroup(ctx, groupID, data); err != nil {
+		return err
+	}
+	sendMessage := content != ""
+	if sendMessage {
+		userIDs, err := g.db.FindGroupMemberUserID(ctx, groupID)
+		if err != nil {
+			log.ZWarn(ctx, "FindGroupMemberUserID failed", err, "groupID", groupID)
+		} else {
+			conversation := &pbconversation.ConversationReq{
+				ConversationID:   msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, groupID),
+				ConversationType: constant.ReadGroupChatType,
+				GroupID:          groupID,
+				GroupAtType:      &wrapperspb.Int32Value{Value: constant.GroupNotification},
+			}
+			if err := g.conversationClient.SetConversations(ctx, userIDs, conversation); err != nil {
+				log.ZWarn(ctx, "SetConversations", err, "UserIDs", userIDs, "conversation", conversation)
+			}
+		}
+	}
+	groupInfo, err := g.notification.getGroupInfo(ctx, groupID)
+	if err != nil {
+		log.ZError(ctx, "publishGroupNotification getGroupInfo failed", err, "groupID", groupID)
+		return nil
+	}
+	g.notification.GroupInfoSetAnnouncementNotification(ctx, &sdkws.GroupInfoSetAnnouncementTips{Group: groupInfo}, &sendMessage)
+	return nil
+}
+
+// CreateGroupAnnouncement 发布群公告，需要修改群资料权限，发布时置顶还需要置顶权限
+// 新公告同时成为群信息中的当前公告
+func (g *groupServer) CreateGroupAnnouncement(ctx context.Context, req *groupext.CreateGroupAnnouncementReq) (*groupext.CreateGroupAnnouncementResp, error) {
+	perm := model.GroupPermEditInfo
+	if req.Pinned {
+		perm |= model.GroupPermPinMessage
+	}
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, perm); err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	announcement, err := g.newGroupAnnouncement(ctx, req.GroupID, req.Content, req.Pinned, req.RequireAck, req.Ex)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.publishGroupNotification(ctx, req.GroupID, req.Content); err != nil {
+		return nil, err
+	}
+	return &groupext.CreateGroupAnnouncementResp{Announcement: groupAnnouncementDB2Ext(announcement, false)}, nil
+}
+
+// GetGroupAnnouncements 分页获取群公告历史，置顶公告在前，群成员均可查看
+func (g *groupServer) GetGroupAnnouncements(ctx context.Context, req *groupext.GetGroupAnnouncementsReq) (*groupext.GetGroupAnnouncementsResp, error) {
+	if err := g.checkGroupMemberOrAppManager(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	total, announcements, err := g.announcementDB.FindPage(ctx, req.GroupID, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	ackIDs := datautil.Filter(announcements, func(a *model.GroupAnnouncement) (string, bool) {
+		return a.AnnouncementID, a.RequireAck
+	})
+	ackedIDs, err := g.announcementDB.FindUserAckedIDs(ctx, mcontext.GetOpUserID(ctx), ackIDs)
+	if err != nil {
+		return nil, err
+	}
+	acked := datautil.SliceSet(ackedIDs)
+	resp := &groupext.GetGroupAnnouncementsResp{Total: total, Announcements: make([]*groupext.GroupAnnouncement, 0, len(announcements))}
+	for _, announcement := range announcements {
+		_, ok := acked[announcement.AnnouncementID]
+		resp.Announcements = append(resp.Announcements, groupAnnouncementDB2Ext(announcement, ok))
+	}
+	return resp, nil
+}
+
+// SetGroupAnnouncementPinned 置顶或取消置顶群公告，需要置顶权限
+func (g *groupServer) SetGroupAnnouncementPinned(ctx context.Context, req *groupext.SetGroupAnnouncementPinnedReq) (*groupext.SetGroupAnnouncementPinnedResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermPinMessage); err != nil {
+		return nil, err
+	}
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	if err := g.announcementDB.SetPinned(ctx, req.GroupID, req.AnnouncementID, req.Pinned); err != nil {
+		return nil, err
+	}
+	return &groupext.SetGroupAnnouncementPinnedResp{}, nil
+}
+
+// DeleteGroupAnnouncements 删除群公告及其确认记录，需要修改群资料权限
+// 删除后群信息中的当前公告回退为剩余最新的一条，全部删除时清空
+func (g *groupServer) DeleteGroupAnnouncements(ctx context.Context, req *groupext.DeleteGroupAnnouncementsReq) (*groupext.DeleteGroupAnnouncementsResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermEditInfo); err != nil {
+		return nil, err
+	}
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	if err := g.announcementDB.Delete(ctx, req.GroupID, datautil.Distinct(req.AnnouncementIDs)); err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	var content string
+	latest, err := g.announcementDB.TakeLatest(ctx, req.GroupID)
+	if err == nil {
+		content = latest.Content
+	} else if !g.IsNotFound(err) {
+		return nil, err
+	}
+	if content != group.Notification {
+		if err := g.publishGroupNotification(ctx, req.GroupID, content); err != nil {
+			return nil, err
+		}
+	}
+	return &groupext.DeleteGroupAnnouncementsResp{}, nil
+}
+
+// AckGroupAnnouncement 当前用户确认已读需要确认的群公告
+func (g *groupServer) AckGroupAnnouncement(ctx context.Context, req *groupext.AckGroupAnnouncementReq) (*groupext.AckGroupAnnouncementResp, error) {
+	opUserID := mcontext.GetOpUserID(ctx)
+	if _, err := g.db.TakeGroupMember(ctx, req.GroupID, opUserID); err != nil {
+		return nil, err
+	}
+	announcement, err := g.announcementDB.Take(ctx, req.GroupID, req.AnnouncementID)
+	if err != nil {
+		return nil, err
+	}
+	if !announcement.RequireAck {
+		return nil, errs.ErrArgs.WrapMsg("announcement does not require acknowledgement", "announcementID", req.AnnouncementID)
+	}
+	ack := &model.GroupAnnouncementAck{
+		AnnouncementID: req.AnnouncementID,
+		GroupID:        req.GroupID,
+		UserID:         opUserID,
+		AckTime:        time.Now(),
+	}
+	if err := g.announcementDB.Ack(ctx, ack); err != nil {
+		return nil, err
+	}
+	return &groupext.AckGroupAnnouncementResp{}, nil
+}
+
+// takeAckGroupAnnouncement 获取需要确认的公告及当前群成员中尚未确认的成员，需要修改群资料权限
+func (g *groupServer) takeAckGroupAnnouncement(ctx context.Context, groupID string, announcementID string) (*model.GroupAnnouncement, []*model.GroupAnnouncementAck, []string, error) {
+	if _, err := g.CheckGroupPermission(ctx, groupID, model.GroupPermEditInfo); err != nil {
+		return nil, nil, nil, err
+	}
+	announcement, err := g.announcementDB.Take(ctx, groupID, announcementID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !announcement.RequireAck {
+		return nil, nil, nil, errs.ErrArgs.WrapMsg("announcement does not require acknowledgement", "announcementID", announcementID)
+	}
+	acks, err := g.announcementDB.FindAcks(ctx, announcementID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	memberUserIDs, err := g.db.FindGroupMemberUserID(ctx, groupID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return announcement, acks, unackedUserIDs(memberUserIDs, acks), nil
+}
+
+// GetGroupAnnouncementAcks 查看公告的确认情况，需要修改群资料权限
+func (g *groupServer) GetGroupAnnouncementAcks(ctx context.Context, req *groupext.GetGroupAnnouncementAcksReq) (*groupext.GetGroupAnnouncementAcksResp, error) {
+	_, acks, unacked, err := g.takeAckGroupAnnouncement(ctx, req.GroupID, req.AnnouncementID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupAnnouncementAcksResp{
+		Acks: datautil.Slice(acks, func(a *model.GroupAnnouncementAck) *groupext.GroupAnnouncementAck {
+			return &groupext.GroupAnnouncementAck{UserID: a.UserID, AckTime: a.AckTime.UnixMilli()}
+		}),
+		UnackedUserIDs: unacked,
+	}, nil
+}
+
+// RemindGroupAnnouncement 向尚未确认公告的成员（操作者本人除外）发送业务通知提醒，需要修改群资料权限
+// 通知key为groupAnnouncementRemind，data中携带groupID、announcementID和公告内容
+// 提醒按批放入内存队列异步发送，返回已加入发送队列的成员
+func (g *groupServer) RemindGroupAnnouncement(ctx context.Context, req *groupext.RemindGroupAnnouncementReq) (*groupext.RemindGroupAnnouncementResp, error) {
+	announcement, _, unacked, err := g.takeAckGroupAnnouncement(ctx, req.GroupID, req.AnnouncementID)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	content := newBusinessNotificationContent(groupAnnouncementRemindKey, &struct {
+		GroupID        string `json:"groupID"`
+		AnnouncementID string `json:"announcementID"`
+		Content        string `json:"content"`
+	}{GroupID: announcement.GroupID, AnnouncementID: announcement.AnnouncementID, Content: announcement.Content})
+	opUserID := mcontext.GetOpUserID(ctx)
+	userIDs := datautil.Filter(unacked, func(userID string) (string, bool) { return userID, userID != opUserID })
+	reminded := make([]string, 0, len(userIDs))
+	sendCtx := context.WithoutCancel(ctx)
+	for _, batch := range splitUserIDs(userIDs, groupAnnouncementRemindBatchSize) {
+		err := g.queue.Push(func() {
+			for _, userID := range batch {
+				if err := g.sendBusinessNotification(sendCtx, opUserID, userID, content); err != nil {
+					log.ZWarn(sendCtx, "send group announcement remind failed", err, "userID", userID)
+				}
+			}
+		})
+		if err != nil {
+			log.ZWarn(ctx, "push group announcement remind failed", err, "groupID", req.GroupID, "count", len(batch))
+			continue
+		}
+		reminded = append(reminded, batch...)
+	}
+	return &groupext.RemindGroupAnnouncementResp{RemindedUserIDs: reminded}, nil
+}
+
+// splitUserIDs 将用户ID按size分批，保持原有顺序
+func splitUserIDs(userIDs []string, size int) [][]string {
+	batches := make([][]string, 0, (len(userIDs)+size-1)/size)
+	for start := 0; start < len(userIDs); start += size {
+		batches = append(batches, userIDs[start:min(start+size, len(userIDs))])
+	}
+	return batches
+}
@@ -0,0 +1,32 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnackedUserIDs(t *testing.T) {
+	acks := []*model.GroupAnnouncementAck{{UserID: "u2"}, {UserID: "u4"}}
+	assert.Equal(t, []string{"u1", "u3"}, unackedUserIDs([]string{"u1", "u2", "u3"}, acks))
+	assert.Equal(t, []string{"u1"}, unackedUserIDs([]string{"u1"}, nil))
+	assert.Empty(t, unackedUserIDs([]string{"u2"}, acks))
+}
+
+func TestGroupAnnouncementDB2Ext(t *testing.T) {
+	now := time.Now()
+	announcement := &model.GroupAnnouncement{AnnouncementID: "a1", GroupID: "g1", Content: "hello", Pinned: true, RequireAck: true, CreateTime: now}
+	ext := groupAnnouncementDB2Ext(announcement, true)
+	assert.Equal(t, "a1", ext.AnnouncementID)
+	assert.True(t, ext.Pinned)
+	assert.True(t, ext.Acked)
+	assert.Equal(t, now.UnixMilli(), ext.CreateTime)
+}
+
+func TestSplitUserIDs(t *testing.T) {
+	assert.Empty(t, splitUserIDs(nil, 2))
+	assert.Equal(t, [][]string{{"u1", "u2"}, {"u3"}}, splitUserIDs([]string{"u1", "u2", "u3"}, 2))
+	assert.Equal(t, [][]string{{"u1", "u2"}}, splitUserIDs([]string{"u1", "u2"}, 2))
+}
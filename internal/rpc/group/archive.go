@@ -0,0 +1,121 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+// checkGroupNotArchived 已归档的群组冻结成员和资料，所有修改操作都需要先通过该检查
+func checkGroupNotArchived(group *model.Group) error {
+	if group.Archived {
+		return servererrs.ErrGroupArchived.WrapMsg("group archived", "groupID", group.GroupID)
+	}
+	return nil
+}
+
+// checkGroupIDNotArchived 获取群组并检查是否已归档，用于本身不需要读取群组信息的修改操作
+func (g *groupServer) checkGroupIDNotArchived(ctx context.Context, groupID string) error {
+	group, err := g.db.TakeGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	return checkGroupNotArchived(group)
+}
+
+// checkGroupArchiveManager 归档和取消归档只允许群主或系统管理员操作
+func (g *groupServer) checkGroupArchiveManager(ctx context.Context, groupID string) (*model.Group, error) {
+	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
+		opMember, err := g.db.TakeGroupMember(ctx, groupID, mcontext.GetOpUserID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if opMember.RoleLevel != constant.GroupOwner {
+			return nil, errs.ErrNoPermission.WrapMsg("only group owner can archive group")
+		}
+	}
+	group, err := g.db.TakeGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	return group, nil
+}
+
+// ArchiveGroup 归档群组
+// 归档后保留成员和历史消息，历史消息仍可拉取和搜索，但拒绝发送消息和成员、资料变动，且默认不出现在已加入群组列表中
+func (g *groupServer) ArchiveGroup(ctx context.Context, req *groupext.ArchiveGroupReq) (*groupext.ArchiveGroupResp, error) {
+	group, err := g.checkGroupArchiveManager(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Archived {
+		return &groupext.ArchiveGroupResp{}, nil
+	}
+	data := map[string]any{
+		"archived":        true,
+		"archive_time":    time.Now(),
+		"archive_user_id": mcontext.GetOpUserID(ctx),
+	}
+	if err := g.db.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.ArchiveGroupResp{}, nil
+}
+
+// UnarchiveGroup 取消归档，群组恢复为归档前的状态
+func (g *groupServer) UnarchiveGroup(ctx context.Context, req *groupext.UnarchiveGroupReq) (*groupext.UnarchiveGroupResp, error) {
+	group, err := g.checkGroupArchiveManager(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if !group.Archived {
+		return &groupext.UnarchiveGroupResp{}, nil
+	}
+	data := map[string]any{
+		"archived":        false,
+		"archive_time":    time.Time{},
+		"archive_user_id": "",
+	}
+	if err := g.db.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.UnarchiveGroupResp{}, nil
+}
+
+// GetJoinedGroups 分页获取用户加入的群组，includeArchived为true时包含已归档的群组
+func (g *groupServer) GetJoinedGroups(ctx context.Context, req *groupext.GetJoinedGroupsReq) (*groupext.GetJoinedGroupsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.FromUserID, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	resp, err := g.getJoinedGroupList(ctx, req.FromUserID, req.IncludeArchived, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetJoinedGroupsResp{Total: resp.Total, Groups: resp.Groups}, nil
+}
@@ -0,0 +1,27 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGroupNotArchived(t *testing.T) {
+	assert.NoError(t, checkGroupNotArchived(&model.Group{GroupID: "g1"}))
+
+	err := checkGroupNotArchived(&model.Group{GroupID: "g1", Archived: true})
+	assert.True(t, servererrs.ErrGroupArchived.Is(err))
+}
+
+func TestGroupSettingsArchived(t *testing.T) {
+	settings := groupSettingsDB2Ext(&model.Group{GroupID: "g1"})
+	assert.False(t, settings.Archived)
+	assert.Zero(t, settings.ArchiveTime)
+
+	settings = groupSettingsDB2Ext(&model.Group{GroupID: "g1", Archived: true, ArchiveTime: time.UnixMilli(1000)})
+	assert.True(t, settings.Archived)
+	assert.Equal(t, int64(1000), settings.ArchiveTime)
+}
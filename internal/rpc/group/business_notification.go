@@ -0,0 +1,58 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/msg"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/utils/idutil"
+	"github.com/openimsdk/tools/utils/jsonutil"
+	"github.com/openimsdk/tools/utils/timeutil"
+)
+
+// newBusinessNotificationContent 生成业务通知的消息内容，data序列化为JSON字符串
+func newBusinessNotificationContent(key string, data any) []byte {
+	detail := jsonutil.StructToJsonString(&struct {
+		Key  string `json:"key"`
+		Data string `json:"data"`
+	}{Key: key, Data: jsonutil.StructToJsonString(data)})
+	return []byte(jsonutil.StructToJsonString(&sdkws.NotificationElem{Detail: detail}))
+}
+
+// sendBusinessNotification 以单聊业务通知的形式发送给recvID，不计入未读数
+func (g *groupServer) sendBusinessNotification(ctx context.Context, sendID, recvID string, content []byte) error {
+	_, err := g.msgClient.SendMsg(ctx, &msg.SendMsgReq{
+		MsgData: &sdkws.MsgData{
+			SendID:      sendID,
+			RecvID:      recvID,
+			Content:     content,
+			MsgFrom:     constant.SysMsgType,
+			ContentType: constant.BusinessNotification,
+			SessionType: constant.SingleChatType,
+			CreateTime:  timeutil.GetCurrentTimestampByMill(),
+			ClientMsgID: idutil.GetMsgIDByMD5(recvID),
+			Options: config.GetOptionsByNotification(config.NotificationConfig{
+				IsSendMsg:        false,
+				ReliabilityLevel: 1,
+				UnreadCount:      false,
+			}, nil),
+		},
+	})
+	return err
+}
@@ -0,0 +1,256 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/internal/rpc/incrversion"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// maxGroupChannelIDLen 自定义频道ID的最大长度
+const maxGroupChannelIDLen = 64
+
+func groupChannelDB2Ext(channel *model.GroupChannel) *groupext.GroupChannel {
+	channelGroupID := msgprocessor.GetGroupChannelID(channel.GroupID, channel.ChannelID)
+	return &groupext.GroupChannel{
+		GroupID:           channel.GroupID,
+		ChannelID:         channel.ChannelID,
+		ChannelGroupID:    channelGroupID,
+		ConversationID:    msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, channelGroupID),
+		Name:              channel.Name,
+		Description:       channel.Description,
+		FaceURL:           channel.FaceURL,
+		Order:             channel.Order,
+		ReadOnly:          channel.ReadOnly,
+		DeniedPermissions: channel.DeniedPermissions,
+		CreatorUserID:     channel.CreatorUserID,
+		Ex:                channel.Ex,
+		CreateTime:        channel.CreateTime.UnixMilli(),
+	}
+}
+
+// groupConversationGroupIDs 返回群组主会话及各频道会话使用的群组ID，主会话在前
+func groupConversationGroupIDs(groupID string, channels []*model.GroupChannel) []string {
+	groupIDs := make([]string, 0, len(channels)+1)
+	groupIDs = append(groupIDs, groupID)
+	for _, channel := range channels {
+		groupIDs = append(groupIDs, msgprocessor.GetGroupChannelID(groupID, channel.ChannelID))
+	}
+	return groupIDs
+}
+
+// checkGroupChannelID 校验自定义频道ID，频道ID不能包含频道分隔符
+func checkGroupChannelID(channelID string) error {
+	if len(channelID) > maxGroupChannelIDLen || strings.Contains(channelID, msgprocessor.GroupChannelSeparator) {
+		return errs.ErrArgs.WrapMsg("invalid channelID", "channelID", channelID)
+	}
+	return nil
+}
+
+// checkGroupChannelManager 校验操作者拥有管理频道权限且群组未解散，返回群组信息
+func (g *groupServer) checkGroupChannelManager(ctx context.Context, groupID string) (*model.Group, error) {
+	if _, err := g.CheckGroupPermission(ctx, groupID, model.GroupPermManageChannel); err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// CreateGroupChannel 创建群组频道，需要管理频道权限
+// 频道共享父群的成员列表，频道会话在第一条消息发送时为全体成员创建
+func (g *groupServer) CreateGroupChannel(ctx context.Context, req *groupext.CreateGroupChannelReq) (*groupext.CreateGroupChannelResp, error) {
+	if err := checkGroupRolePermissions(req.DeniedPermissions); err != nil {
+		return nil, err
+	}
+	if err := checkGroupChannelID(req.ChannelID); err != nil {
+		return nil, err
+	}
+	if _, err := g.checkGroupChannelManager(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	channels, err := g.db.FindGroupChannels(ctx, req.GroupID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) >= model.MaxGroupChannels {
+		return nil, errs.ErrArgs.WrapMsg("too many group channels", "max", model.MaxGroupChannels)
+	}
+	channelID := req.ChannelID
+	if channelID == "" {
+		if channelID, err = genRandomToken(8); err != nil {
+			return nil, err
+		}
+	} else if datautil.Contain(channelID, datautil.Slice(channels, func(c *model.GroupChannel) string { return c.ChannelID })...) {
+		return nil, errs.ErrDuplicateKey.WrapMsg("group channel already exists", "channelID", channelID)
+	}
+	channel := &model.GroupChannel{
+		GroupID:           req.GroupID,
+		ChannelID:         channelID,
+		Name:              req.Name,
+		Description:       req.Description,
+		FaceURL:           req.FaceURL,
+		Order:             req.Order,
+		ReadOnly:          req.ReadOnly,
+		DeniedPermissions: req.DeniedPermissions,
+		CreatorUserID:     mcontext.GetOpUserID(ctx),
+		Ex:                req.Ex,
+		CreateTime:        time.Now(),
+	}
+	if err := g.db.CreateGroupChannel(ctx, channel); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.CreateGroupChannelResp{Channel: groupChannelDB2Ext(channel)}, nil
+}
+
+// UpdateGroupChannel 修改群组频道，需要管理频道权限
+func (g *groupServer) UpdateGroupChannel(ctx context.Context, req *groupext.UpdateGroupChannelReq) (*groupext.UpdateGroupChannelResp, error) {
+	if req.DeniedPermissions != nil {
+		if err := checkGroupRolePermissions(*req.DeniedPermissions); err != nil {
+			return nil, err
+		}
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, errs.ErrArgs.WrapMsg("name is empty")
+	}
+	if _, err := g.checkGroupChannelManager(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	if _, err := g.db.TakeGroupChannel(ctx, req.GroupID, req.ChannelID); err != nil {
+		return nil, err
+	}
+	data := make(map[string]any)
+	if req.Name != nil {
+		data["name"] = *req.Name
+	}
+	if req.Description != nil {
+		data["description"] = *req.Description
+	}
+	if req.FaceURL != nil {
+		data["face_url"] = *req.FaceURL
+	}
+	if req.Order != nil {
+		data["order"] = *req.Order
+	}
+	if req.ReadOnly != nil {
+		data["read_only"] = *req.ReadOnly
+	}
+	if req.DeniedPermissions != nil {
+		data["denied_permissions"] = *req.DeniedPermissions
+	}
+	if req.Ex != nil {
+		data["ex"] = *req.Ex
+	}
+	if len(data) == 0 {
+		return &groupext.UpdateGroupChannelResp{}, nil
+	}
+	if err := g.db.UpdateGroupChannel(ctx, req.GroupID, req.ChannelID, data); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.UpdateGroupChannelResp{}, nil
+}
+
+// DeleteGroupChannels 删除群组频道，需要管理频道权限，频道会话中的历史消息保留
+func (g *groupServer) DeleteGroupChannels(ctx context.Context, req *groupext.DeleteGroupChannelsReq) (*groupext.DeleteGroupChannelsResp, error) {
+	if _, err := g.checkGroupChannelManager(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	channels, err := g.db.FindGroupChannels(ctx, req.GroupID, datautil.Distinct(req.ChannelIDs))
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return &groupext.DeleteGroupChannelsResp{}, nil
+	}
+	channelIDs := datautil.Slice(channels, func(c *model.GroupChannel) string { return c.ChannelID })
+	if err := g.db.DeleteGroupChannels(ctx, req.GroupID, channelIDs); err != nil {
+		return nil, err
+	}
+	// 频道删除后成员的频道会话不再有效，一并删除
+	if _, err := g.conversationExtClient.DeleteGroupChannelConversations(ctx, &conversationext.DeleteGroupChannelConversationsReq{GroupID: req.GroupID, ChannelIDs: channelIDs}); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.DeleteGroupChannelsResp{}, nil
+}
+
+// GetGroupChannels 获取群组频道，请求者需为群成员或系统管理员
+// msg、push等服务通过GroupLocalCache读取时不带操作者（见rpcli.GroupClient.GetGroupChannels），成员退出后仍可读取频道列表
+func (g *groupServer) GetGroupChannels(ctx context.Context, req *groupext.GetGroupChannelsReq) (*groupext.GetGroupChannelsResp, error) {
+	if mcontext.GetOpUserID(ctx) != "" {
+		if err := g.checkGroupMemberOrAppManager(ctx, req.GroupID); err != nil {
+			return nil, err
+		}
+	}
+	channels, err := g.db.FindGroupChannels(ctx, req.GroupID, req.ChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupChannelsResp{Channels: datautil.Slice(channels, groupChannelDB2Ext)}, nil
+}
+
+// GetIncrementalGroupChannels 增量同步群组频道列表，版本按群组维度记录
+func (g *groupServer) GetIncrementalGroupChannels(ctx context.Context, req *groupext.GetIncrementalGroupChannelsReq) (*groupext.GetIncrementalGroupChannelsResp, error) {
+	if err := g.checkGroupMemberOrAppManager(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	opt := incrversion.Option[*groupext.GroupChannel, groupext.GetIncrementalGroupChannelsResp]{
+		Ctx:           ctx,
+		VersionKey:    req.GroupID,
+		VersionID:     req.VersionID,
+		VersionNumber: req.Version,
+		Version:       g.db.FindGroupChannelIncrVersion,
+		Find: func(ctx context.Context, ids []string) ([]*groupext.GroupChannel, error) {
+			channels, err := g.db.FindGroupChannels(ctx, req.GroupID, ids)
+			if err != nil {
+				return nil, err
+			}
+			return datautil.Slice(channels, groupChannelDB2Ext), nil
+		},
+		Resp: func(version *model.VersionLog, deleteIds []string, insertList, updateList []*groupext.GroupChannel, full bool) *groupext.GetIncrementalGroupChannelsResp {
+			return &groupext.GetIncrementalGroupChannelsResp{
+				VersionID: version.ID.Hex(),
+				Version:   uint64(version.Version),
+				Full:      full,
+				Delete:    deleteIds,
+				Insert:    insertList,
+				Update:    updateList,
+			}
+		},
+	}
+	return opt.Build()
+}
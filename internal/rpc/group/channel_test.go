@@ -0,0 +1,101 @@
+package group
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupChannelID(t *testing.T) {
+	channelGroupID := msgprocessor.GetGroupChannelID("g1", "c1")
+	assert.Equal(t, "g1#c1", channelGroupID)
+
+	groupID, channelID := msgprocessor.ParseGroupChannelID(channelGroupID)
+	assert.Equal(t, "g1", groupID)
+	assert.Equal(t, "c1", channelID)
+
+	groupID, channelID = msgprocessor.ParseGroupChannelID("g1")
+	assert.Equal(t, "g1", groupID)
+	assert.Equal(t, "", channelID)
+}
+
+func TestCheckGroupChannelID(t *testing.T) {
+	assert.NoError(t, checkGroupChannelID("general"))
+	assert.Error(t, checkGroupChannelID("a#b"))
+	assert.Error(t, checkGroupChannelID(strings.Repeat("a", maxGroupChannelIDLen+1)))
+}
+
+func TestGroupChannelPermissions(t *testing.T) {
+	perms := model.GroupPermAtAll | model.GroupPermRevokeMessage
+	denied := model.GroupPermAtAll
+
+	assert.Equal(t, perms, model.GroupChannelPermissions(perms, constant.GroupOwner, denied))
+	assert.Equal(t, perms, model.GroupChannelPermissions(perms, constant.GroupAdmin, denied))
+	assert.Equal(t, model.GroupPermRevokeMessage, model.GroupChannelPermissions(perms, constant.GroupOrdinaryUsers, denied))
+}
+
+func TestCanSendGroupChannelMessage(t *testing.T) {
+	assert.True(t, model.CanSendGroupChannelMessage(false, constant.GroupOrdinaryUsers, 0))
+	assert.True(t, model.CanSendGroupChannelMessage(true, constant.GroupOwner, 0))
+	assert.True(t, model.CanSendGroupChannelMessage(true, constant.GroupAdmin, 0))
+	assert.True(t, model.CanSendGroupChannelMessage(true, constant.GroupOrdinaryUsers, model.GroupPermManageChannel))
+	assert.False(t, model.CanSendGroupChannelMessage(true, constant.GroupOrdinaryUsers, model.GroupPermAtAll))
+}
+
+func TestGroupConversationGroupIDs(t *testing.T) {
+	assert.Equal(t, []string{"g1"}, groupConversationGroupIDs("g1", nil))
+	channels := []*model.GroupChannel{{GroupID: "g1", ChannelID: "c1"}, {GroupID: "g1", ChannelID: "c2"}}
+	assert.Equal(t, []string{"g1", "g1#c1", "g1#c2"}, groupConversationGroupIDs("g1", channels))
+}
+
+// memberCheckGroupDB 校验成员身份的读接口测试使用，只有members中的用户是群成员
+type memberCheckGroupDB struct {
+	controller.GroupDatabase
+	members  map[string]bool
+	channels []*model.GroupChannel
+}
+
+func (d *memberCheckGroupDB) TakeGroupMember(ctx context.Context, groupID string, userID string) (*model.GroupMember, error) {
+	if !d.members[userID] {
+		return nil, errs.ErrRecordNotFound.WrapMsg("group member not found")
+	}
+	return &model.GroupMember{GroupID: groupID, UserID: userID}, nil
+}
+
+func (d *memberCheckGroupDB) TakeGroup(ctx context.Context, groupID string) (*model.Group, error) {
+	return &model.Group{GroupID: groupID, AtAllPolicy: 1}, nil
+}
+
+func (d *memberCheckGroupDB) FindGroupChannels(ctx context.Context, groupID string, channelIDs []string) ([]*model.GroupChannel, error) {
+	return d.channels, nil
+}
+
+func newMemberCheckGroupServer(db controller.GroupDatabase) *groupServer {
+	g := &groupServer{db: db, config: &Config{}}
+	g.config.Share.IMAdminUserID = []string{"admin"}
+	return g
+}
+
+func TestGetGroupChannelsMemberCheck(t *testing.T) {
+	db := &memberCheckGroupDB{members: map[string]bool{"u1": true}, channels: []*model.GroupChannel{{GroupID: "g1", ChannelID: "c1"}}}
+	g := newMemberCheckGroupServer(db)
+	req := &groupext.GetGroupChannelsReq{GroupID: "g1"}
+
+	for _, opUserID := range []string{"u1", "admin", ""} {
+		resp, err := g.GetGroupChannels(mcontext.WithOpUserIDContext(context.Background(), opUserID), req)
+		require.NoError(t, err, opUserID)
+		assert.Len(t, resp.Channels, 1)
+	}
+	_, err := g.GetGroupChannels(mcontext.WithOpUserIDContext(context.Background(), "u2"), req)
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+)
+
+// EraseUserGroups 将注销的用户移出其加入的全部群组
+// 仅系统管理员可调用，由用户服务的注销任务执行；可重复调用，已退出的群组不会重复处理
+func (g *groupServer) EraseUserGroups(ctx context.Context, req *groupext.EraseUserGroupsReq) (*groupext.EraseUserGroupsResp, error) {
+	if err := authverify.CheckAdmin(ctx, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	groupIDs, err := g.db.FindJoinGroupID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := g.db.FindGroup(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.EraseUserGroupsResp{}
+	for _, group := range groups {
+		member, err := g.db.TakeGroupMember(ctx, group.GroupID, req.UserID)
+		if err != nil {
+			if g.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		// 已解散的群组只删除成员记录
+		if group.Status == constant.GroupStatusDismissed {
+			if err := g.db.DeleteGroupMember(ctx, group.GroupID, []string{req.UserID}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := g.PopulateGroupMember(ctx, member); err != nil {
+			log.ZWarn(ctx, "populate erased group member failed", err, "groupID", group.GroupID, "userID", req.UserID)
+		}
+		if member.RoleLevel == constant.GroupOwner {
+			newOwner, err := g.succeedGroupOwner(ctx, group, req.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if newOwner == nil {
+				resp.DismissedCount++
+				continue
+			}
+			resp.TransferredCount++
+		} else {
+			if err := g.db.DeleteGroupMember(ctx, group.GroupID, []string{req.UserID}); err != nil {
+				return nil, err
+			}
+			resp.QuitCount++
+		}
+		g.notification.MemberQuitNotification(ctx, g.groupMemberDB2PB(member, 0))
+		if err := g.deleteMemberAndSetConversationSeq(ctx, group.GroupID, []string{req.UserID}); err != nil {
+			log.ZWarn(ctx, "set erased member conversation seq failed", err, "groupID", group.GroupID, "userID", req.UserID)
+		}
+	}
+	return resp, nil
+}
+
+// EraseUserGroupRequests 删除注销用户提交的入群申请，并匿名化其作为处理人、邀请人和审批人的记录
+// 仅系统管理员可调用，由用户服务的注销任务在退出群组之后执行
+func (g *groupServer) EraseUserGroupRequests(ctx context.Context, req *groupext.EraseUserGroupRequestsReq) (*groupext.EraseUserGroupRequestsResp, error) {
+	if err := authverify.CheckAdmin(ctx, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	deleted, anonymized, err := g.db.EraseUserGroupRequests(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.EraseUserGroupRequestsResp{DeletedCount: deleted, AnonymizedCount: anonymized}, nil
+}
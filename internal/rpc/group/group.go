@@ -72,10 +72,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/common"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/webhook"
@@ -94,11 +97,13 @@ import (
 	"github.com/openimsdk/protocol/sdkws"
 	"github.com/openimsdk/protocol/wrapperspb"
 	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/db/pagination"
 	"github.com/openimsdk/tools/db/redisutil"
 	"github.com/openimsdk/tools/discovery"
 	"github.com/openimsdk/tools/errs"
 	"github.com/openimsdk/tools/log"
 	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/mq/memamq"
 	"github.com/openimsdk/tools/mw/specialerror"
 	"github.com/openimsdk/tools/utils/datautil"
 	"github.com/openimsdk/tools/utils/encrypt"
@@ -116,14 +121,18 @@ import (
 // - 统一接口：通过gRPC提供标准化的服务接口
 // - 事件驱动：基于通知机制的异步处理
 type groupServer struct {
-	pbgroup.UnimplementedGroupServer                           // gRPC服务基础实现
-	db                               controller.GroupDatabase  // 群组数据库操作层，提供CRUD和复杂查询
-	notification                     *NotificationSender       // 通知发送器，处理群组相关的所有通知
-	config                           *Config                   // 服务配置，包含各种配置参数
-	webhookClient                    *webhook.Client           // Webhook客户端，用于业务扩展回调
-	userClient                       *rpcli.UserClient         // 用户服务客户端，获取用户信息
-	msgClient                        *rpcli.MsgClient          // 消息服务客户端，发送系统消息
-	conversationClient               *rpcli.ConversationClient // 会话服务客户端，管理群组会话
+	pbgroup.UnimplementedGroupServer                                       // gRPC服务基础实现
+	db                               controller.GroupDatabase              // 群组数据库操作层，提供CRUD和复杂查询
+	notification                     *NotificationSender                   // 通知发送器，处理群组相关的所有通知
+	config                           *Config                               // 服务配置，包含各种配置参数
+	webhookClient                    *webhook.Client                       // Webhook客户端，用于业务扩展回调
+	userClient                       *rpcli.UserClient                     // 用户服务客户端，获取用户信息
+	msgClient                        *rpcli.MsgClient                      // 消息服务客户端，发送系统消息
+	conversationClient               *rpcli.ConversationClient             // 会话服务客户端，管理群组会话
+	conversationExtClient            conversationext.ConversationExtClient // 会话扩展服务客户端，删除频道会话
+	inviteLinkDB                     database.GroupInviteLink              // 群组邀请链接数据库操作
+	announcementDB                   database.GroupAnnouncement            // 群公告历史及确认记录数据库操作
+	queue                            *memamq.MemoryQueue                   // 内存队列，异步发送批量业务通知
 }
 
 // Config 群组服务配置结构体
@@ -204,6 +213,24 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 
+	// 创建群组邀请链接的数据访问对象
+	inviteLinkDB, err := mgo.NewGroupInviteLinkMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
+	// 创建群公告的数据访问对象
+	announcementDB, err := mgo.NewGroupAnnouncementMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
+	// 创建群组频道的数据访问对象
+	groupChannelDB, err := mgo.NewGroupChannelMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
 	// 第4步：建立外部服务连接
 	// 建立用户服务连接，用于获取用户信息和权限验证
 	userConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.User)
@@ -226,16 +253,20 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	// 第5步：组装群组服务实例
 	// 创建群组服务器实例，注入所有依赖
 	gs := groupServer{
-		config:             config,                                              // 服务配置
-		webhookClient:      webhook.NewWebhookClient(config.WebhooksConfig.URL), // Webhook客户端
-		userClient:         rpcli.NewUserClient(userConn),                       // 用户服务客户端
-		msgClient:          rpcli.NewMsgClient(msgConn),                         // 消息服务客户端
-		conversationClient: rpcli.NewConversationClient(conversationConn),       // 会话服务客户端
+		config:                config,                                                     // 服务配置
+		webhookClient:         webhook.NewWebhookClient(config.WebhooksConfig.URL),        // Webhook客户端
+		userClient:            rpcli.NewUserClient(userConn),                              // 用户服务客户端
+		msgClient:             rpcli.NewMsgClient(msgConn),                                // 消息服务客户端
+		conversationClient:    rpcli.NewConversationClient(conversationConn),              // 会话服务客户端
+		conversationExtClient: conversationext.NewConversationExtClient(conversationConn), // 会话扩展服务客户端
+		inviteLinkDB:          inviteLinkDB,                                               // 邀请链接数据访问
+		announcementDB:        announcementDB,                                             // 群公告数据访问
+		queue:                 memamq.NewMemoryQueue(16, 1024*16),                         // 内存队列：16个worker，16K任务缓冲
 	}
 
 	// 第6步：初始化数据库控制器
 	// 创建统一的数据库控制器，集成缓存、事务、版本控制等功能
-	gs.db = controller.NewGroupDatabase(rdb, &config.LocalCacheConfig, groupDB, groupMemberDB, groupRequestDB, mgocli.GetTx(), grouphash.NewGroupHashFromGroupServer(&gs))
+	gs.db = controller.NewGroupDatabase(rdb, &config.LocalCacheConfig, groupDB, groupMemberDB, groupRequestDB, groupChannelDB, mgocli.GetTx(), grouphash.NewGroupHashFromGroupServer(&gs))
 
 	// 第7步：初始化通知服务
 	// 创建通知发送器，用于处理群组相关的所有通知
@@ -248,6 +279,7 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	// 第9步：注册gRPC服务
 	// 将群组服务注册到gRPC服务器，开始接收客户端请求
 	pbgroup.RegisterGroupServer(server, &gs)
+	groupext.RegisterGroupExtServer(server, &gs)
 
 	// 第10步：启动完成
 	// 服务启动成功，所有组件就绪
@@ -307,35 +339,44 @@ func (g *groupServer) NotificationUserInfoUpdate(ctx context.Context, req *pbgro
 	return &pbgroup.NotificationUserInfoUpdateResp{}, nil
 }
 
-// CheckGroupAdmin 检查用户是否为群组管理员
+// CheckGroupPermission 检查操作者在群组内是否拥有指定权限
 //
-// 这是一个核心的权限验证方法，用于验证操作者是否有权限执行管理员级别的操作。
-// 权限层级：系统管理员 > 群主 > 群管理员 > 普通成员
+// 这是群组服务统一的权限验证入口，取代按RoleLevel分散判断的写法。
+// 权限层级：系统管理员 > 群主 > 群管理员 > 普通成员，自定义角色在RoleLevel对应的内置权限上叠加。
 //
 // 权限验证逻辑：
-// 1. 系统管理员：拥有所有群组的最高权限，可执行任何操作
-// 2. 群主：拥有该群组的完全控制权
-// 3. 群管理员：拥有该群组的部分管理权限
-// 4. 普通成员：只有基础的群组功能权限
-//
-// 安全设计：
-// - 多层权限验证：系统级 -> 群组级 -> 角色级
-// - 操作者身份确认：通过上下文获取真实操作者ID
-// - 权限等级校验：确保操作者具备足够的权限等级
-func (g *groupServer) CheckGroupAdmin(ctx context.Context, groupID string) error {
-	// 检查是否为系统管理员，系统管理员拥有所有权限
-	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
-		// 非系统管理员，需要检查群组内的角色权限
-		// 获取操作者在该群组中的成员信息
-		groupMember, err := g.db.TakeGroupMember(ctx, groupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return err
-		}
+// 1. 系统管理员：拥有所有群组的全部权限，返回的成员信息为nil
+// 2. 群成员：有效权限（见model.GroupMemberPermissions）需包含perm中的全部权限位
+//
+// 返回操作者的群成员信息，供调用方继续校验对目标成员的管理关系
+func (g *groupServer) CheckGroupPermission(ctx context.Context, groupID string, perm int64) (*model.GroupMember, error) {
+	// 系统管理员拥有所有权限
+	if authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
+		return nil, nil
+	}
+	groupMember, err := g.db.TakeGroupMember(ctx, groupID, mcontext.GetOpUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if model.GroupMemberPermissions(group, groupMember)&perm != perm {
+		return nil, errs.ErrNoPermission.WrapMsg("no group permission", "groupID", groupID, "permission", perm)
+	}
+	return groupMember, nil
+}
 
-		// 验证角色权限：只有群主和群管理员可以执行管理操作
-		if !(groupMember.RoleLevel == constant.GroupOwner || groupMember.RoleLevel == constant.GroupAdmin) {
-			return errs.ErrNoPermission.WrapMsg("no group owner or admin")
-		}
+// CheckManageGroupMember 检查操作者拥有指定权限，并且可以对目标成员执行管理操作
+// 群主可以管理除自己外的所有成员，管理员和拥有自定义角色的成员只能管理普通成员
+func (g *groupServer) CheckManageGroupMember(ctx context.Context, groupID string, perm int64, target *model.GroupMember) error {
+	opMember, err := g.CheckGroupPermission(ctx, groupID, perm)
+	if err != nil {
+		return err
+	}
+	if opMember != nil && !model.CanManageGroupMember(opMember.RoleLevel, target.RoleLevel) {
+		return errs.ErrNoPermission.WrapMsg("can not manage this group member", "groupID", groupID, "userID", target.UserID)
 	}
 	return nil
 }
@@ -375,6 +416,10 @@ func (g *groupServer) IsNotFound(err error) bool {
 func (g *groupServer) GenGroupID(ctx context.Context, groupID *string) error {
 	// 如果指定了群组ID，验证其可用性
 	if *groupID != "" {
+		// 频道消息以"群组ID#频道ID"作为群组ID，群组ID本身不能包含分隔符
+		if strings.Contains(*groupID, msgprocessor.GroupChannelSeparator) {
+			return errs.ErrArgs.WrapMsg("groupID must not contain " + msgprocessor.GroupChannelSeparator)
+		}
 		_, err := g.db.TakeGroup(ctx, *groupID)
 		if err == nil {
 			// ID已存在，返回错误
@@ -609,8 +654,14 @@ func (g *groupServer) GetJoinedGroupList(ctx context.Context, req *pbgroup.GetJo
 		return nil, err
 	}
 
+	// 默认不返回已归档的群组
+	return g.getJoinedGroupList(ctx, req.FromUserID, false, req.Pagination)
+}
+
+// getJoinedGroupList 分页获取用户加入的群组，includeArchived为false时排除已归档的群组
+func (g *groupServer) getJoinedGroupList(ctx context.Context, fromUserID string, includeArchived bool, pagination pagination.Pagination) (*pbgroup.GetJoinedGroupListResp, error) {
 	// 分页查询用户加入的群组成员记录
-	total, members, err := g.db.PageGetJoinGroup(ctx, req.FromUserID, req.Pagination)
+	total, members, err := g.db.PageGetJoinGroup(ctx, fromUserID, includeArchived, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -715,6 +766,9 @@ func (g *groupServer) InviteUserToGroup(ctx context.Context, req *pbgroup.Invite
 	if group.Status == constant.GroupStatusDismissed {
 		return nil, servererrs.ErrDismissedAlready.WrapMsg("group dismissed checking group status found it dismissed")
 	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
 
 	// 验证所有被邀请用户都存在
 	userMap, err := g.userClient.GetUsersInfoMap(ctx, req.InvitedUserIDs)
@@ -755,8 +809,8 @@ func (g *groupServer) InviteUserToGroup(ctx context.Context, req *pbgroup.Invite
 	if group.NeedVerification == constant.AllNeedVerification {
 		if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
 			// 非系统管理员且群组需要验证，检查是否为群主或管理员
-			if !(groupMember.RoleLevel == constant.GroupOwner || groupMember.RoleLevel == constant.GroupAdmin) {
-				// 普通成员邀请，创建加群申请记录
+			if model.GroupMemberPermissions(group, groupMember)&model.GroupPermApproveJoin == 0 {
+				// 没有审批权限的成员邀请，创建加群申请记录
 				var requests []*model.GroupRequest
 				for _, userID := range req.InvitedUserIDs {
 					requests = append(requests, &model.GroupRequest{
@@ -992,6 +1046,9 @@ func (g *groupServer) KickGroupMember(ctx context.Context, req *pbgroup.KickGrou
 	if err != nil {
 		return nil, err
 	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
 
 	// 验证被踢用户列表不能为空
 	if len(req.KickedUserIDs) == 0 {
@@ -1056,21 +1113,13 @@ func (g *groupServer) KickGroupMember(ctx context.Context, req *pbgroup.KickGrou
 				return nil, errs.ErrNoPermission.WrapMsg("opUserID no in group")
 			}
 
-			// 根据操作者角色验证权限
-			switch opMember.RoleLevel {
-			case constant.GroupOwner:
-				// 群主可以踢出任何成员（除自己外，已在前面验证）
-			case constant.GroupAdmin:
-				// 管理员不能踢出群主和其他管理员
-				if member.RoleLevel == constant.GroupOwner || member.RoleLevel == constant.GroupAdmin {
-					return nil, errs.ErrNoPermission.WrapMsg("group admins cannot remove the group owner and other admins")
-				}
-			case constant.GroupOrdinaryUsers:
-				// 普通成员无踢出权限
+			// 需要拥有移除成员权限，群主可以踢出任何成员（除自己外，已在前面验证），
+			// 管理员和拥有自定义角色的成员只能踢出普通成员
+			if model.GroupMemberPermissions(group, opMember)&model.GroupPermKickMember == 0 {
 				return nil, errs.ErrNoPermission.WrapMsg("opUserID no permission")
-			default:
-				// 未知角色等级
-				return nil, errs.ErrNoPermission.WrapMsg("opUserID roleLevel unknown")
+			}
+			if !model.CanManageGroupMember(opMember.RoleLevel, member.RoleLevel) {
+				return nil, errs.ErrNoPermission.WrapMsg("group admins cannot remove the group owner and other admins")
 			}
 		}
 	}
@@ -1258,6 +1307,15 @@ func (g *groupServer) getGroupMembersInfo(ctx context.Context, groupID string, u
 // - 申请总数
 // - 申请详细信息列表（包含申请者、群组、处理状态等）
 func (g *groupServer) GetGroupApplicationList(ctx context.Context, req *pbgroup.GetGroupApplicationListReq) (*pbgroup.GetGroupApplicationListResp, error) {
+	resp, _, _, err := g.getGroupApplicationList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// getGroupApplicationList 分页查询入群申请，同时返回申请记录和涉及的群组，供扩展接口附加审批记录
+func (g *groupServer) getGroupApplicationList(ctx context.Context, req *pbgroup.GetGroupApplicationListReq) (*pbgroup.GetGroupApplicationListResp, []*model.GroupRequest, map[string]*model.Group, error) {
 	var (
 		groupIDs []string
 		err      error
@@ -1265,14 +1323,14 @@ func (g *groupServer) GetGroupApplicationList(ctx context.Context, req *pbgroup.
 	if len(req.GroupIDs) == 0 {
 		groupIDs, err = g.db.FindUserManagedGroupID(ctx, req.FromUserID)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 	} else {
 		req.GroupIDs = datautil.Distinct(req.GroupIDs)
 		if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
 			for _, groupID := range req.GroupIDs {
-				if err := g.CheckGroupAdmin(ctx, groupID); err != nil {
-					return nil, err
+				if _, err := g.CheckGroupPermission(ctx, groupID, model.GroupPermApproveJoin); err != nil {
+					return nil, nil, nil, err
 				}
 			}
 		}
@@ -1280,18 +1338,18 @@ func (g *groupServer) GetGroupApplicationList(ctx context.Context, req *pbgroup.
 	}
 	resp := &pbgroup.GetGroupApplicationListResp{}
 	if len(groupIDs) == 0 {
-		return resp, nil
+		return resp, nil, nil, nil
 	}
 	handleResults := datautil.Slice(req.HandleResults, func(e int32) int {
 		return int(e)
 	})
 	total, groupRequests, err := g.db.PageGroupRequest(ctx, groupIDs, handleResults, req.Pagination)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	resp.Total = uint32(total)
 	if len(groupRequests) == 0 {
-		return resp, nil
+		return resp, nil, nil, nil
 	}
 	var userIDs []string
 
@@ -1301,28 +1359,28 @@ func (g *groupServer) GetGroupApplicationList(ctx context.Context, req *pbgroup.
 	userIDs = datautil.Distinct(userIDs)
 	userMap, err := g.userClient.GetUsersInfoMap(ctx, userIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	groups, err := g.db.FindGroup(ctx, datautil.Distinct(groupIDs))
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	groupMap := datautil.SliceToMap(groups, func(e *model.Group) string {
 		return e.GroupID
 	})
 	if ids := datautil.Single(datautil.Keys(groupMap), groupIDs); len(ids) > 0 {
-		return nil, servererrs.ErrGroupIDNotFound.WrapMsg(strings.Join(ids, ","))
+		return nil, nil, nil, servererrs.ErrGroupIDNotFound.WrapMsg(strings.Join(ids, ","))
 	}
 	groupMemberNumMap, err := g.db.MapGroupMemberNum(ctx, groupIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	owners, err := g.db.FindGroupsOwner(ctx, groupIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	if err := g.PopulateGroupMember(ctx, owners...); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	ownerMap := datautil.SliceToMap(owners, func(e *model.GroupMember) string {
 		return e.GroupID
@@ -1334,7 +1392,7 @@ func (g *groupServer) GetGroupApplicationList(ctx context.Context, req *pbgroup.
 		}
 		return convert.Db2PbGroupRequest(e, userMap[e.UserID], convert.Db2PbGroupInfo(groupMap[e.GroupID], ownerUserID, groupMemberNumMap[e.GroupID]))
 	})
-	return resp, nil
+	return resp, groupRequests, groupMap, nil
 }
 
 // GetGroupsInfo 获取多个群组的详细信息
@@ -1520,19 +1578,23 @@ func (g *groupServer) GroupApplicationResponse(ctx context.Context, req *pbgroup
 	if !datautil.Contain(req.HandleResult, constant.GroupResponseAgree, constant.GroupResponseRefuse) {
 		return nil, errs.ErrArgs.WrapMsg("HandleResult unknown")
 	}
-	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
-		groupMember, err := g.db.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		if !(groupMember.RoleLevel == constant.GroupOwner || groupMember.RoleLevel == constant.GroupAdmin) {
-			return nil, errs.ErrNoPermission.WrapMsg("no group owner or admin")
-		}
+	// 拒绝必须填写理由，理由记入审批记录
+	if req.HandleResult == constant.GroupResponseRefuse && strings.TrimSpace(req.HandledMsg) == "" {
+		return nil, errs.ErrArgs.WrapMsg("handledMsg is required when refusing")
+	}
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermApproveJoin); err != nil {
+		return nil, err
 	}
 	group, err := g.db.TakeGroup(ctx, req.GroupID)
 	if err != nil {
 		return nil, err
 	}
+	// 已归档的群组成员冻结，只能拒绝申请
+	if req.HandleResult == constant.GroupResponseAgree {
+		if err := checkGroupNotArchived(group); err != nil {
+			return nil, err
+		}
+	}
 	groupRequest, err := g.db.TakeGroupRequest(ctx, req.GroupID, req.FromUserID)
 	if err != nil {
 		return nil, err
@@ -1549,6 +1611,41 @@ func (g *groupServer) GroupApplicationResponse(ctx context.Context, req *pbgroup
 	if err := g.userClient.CheckUser(ctx, []string{req.FromUserID}); err != nil {
 		return nil, err
 	}
+	decision := &model.GroupRequestDecision{
+		UserID: mcontext.GetOpUserID(ctx),
+		Result: req.HandleResult,
+		Reason: req.HandledMsg,
+		Time:   time.Now(),
+	}
+	// 多人审批模式下同意先计票，未达到通过人数时申请保持待处理，并通知其他审批人最新进度
+	if req.HandleResult == constant.GroupResponseAgree && !inGroup && group.JoinApprovalMode == model.GroupJoinApprovalQuorum {
+		requiredApprovals, err := g.requiredJoinApprovals(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := g.db.AddGroupRequestDecision(ctx, req.GroupID, req.FromUserID, decision)
+		if err != nil {
+			if !g.IsNotFound(err) {
+				return nil, err
+			}
+			// 申请已被处理，或该审批人已经同意过：重复同意不重复计票，但人数已满足时（如管理员减少后）继续完成审批
+			if updated, err = g.db.TakeGroupRequest(ctx, req.GroupID, req.FromUserID); err != nil {
+				return nil, err
+			}
+			if updated.HandleResult != 0 {
+				return nil, servererrs.ErrGroupRequestHandled.WrapMsg("group request already processed")
+			}
+			if decision = updated.Decision(decision.UserID); decision == nil {
+				return nil, servererrs.ErrGroupRequestHandled.WrapMsg("group request decision conflict")
+			}
+			if updated.Approvals() < requiredApprovals {
+				return &pbgroup.GroupApplicationResponseResp{}, nil
+			}
+		} else if updated.Approvals() < requiredApprovals {
+			g.groupApplicationProgressNotification(ctx, updated, requiredApprovals)
+			return &pbgroup.GroupApplicationResponseResp{}, nil
+		}
+	}
 	var member *model.GroupMember
 	if (!inGroup) && req.HandleResult == constant.GroupResponseAgree {
 		member = &model.GroupMember{
@@ -1569,7 +1666,10 @@ func (g *groupServer) GroupApplicationResponse(ctx context.Context, req *pbgroup
 		}
 	}
 	log.ZDebug(ctx, "GroupApplicationResponse", "inGroup", inGroup, "HandleResult", req.HandleResult, "member", member)
-	if err := g.db.HandlerGroupRequest(ctx, req.GroupID, req.FromUserID, req.HandledMsg, req.HandleResult, member); err != nil {
+	if err := g.db.HandlerGroupRequest(ctx, req.GroupID, req.FromUserID, req.HandleResult, decision, member); err != nil {
+		if g.IsNotFound(err) {
+			return nil, servererrs.ErrGroupRequestHandled.WrapMsg("group request already processed")
+		}
 		return nil, err
 	}
 	switch req.HandleResult {
@@ -1634,6 +1734,9 @@ func (g *groupServer) JoinGroup(ctx context.Context, req *pbgroup.JoinGroupReq)
 	if group.Status == constant.GroupStatusDismissed {
 		return nil, servererrs.ErrDismissedAlready.Wrap()
 	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
 
 	// 构建申请加群前的回调请求参数
 	reqCall := &callbackstruct.CallbackJoinGroupReq{
@@ -1726,7 +1829,7 @@ func (g *groupServer) JoinGroup(ctx context.Context, req *pbgroup.JoinGroupReq)
 // 1. 用户身份确认：确定退出的用户ID（可以是自己或管理员代操作）
 // 2. 权限验证：检查操作权限和退出权限
 // 3. 成员信息获取：获取要退出用户的群组成员信息
-// 4. 特殊角色检查：群主不能退出群组
+// 4. 特殊角色检查：群主退出时按配置执行群主继任，未启用时群主不能退出
 // 5. 数据库操作：删除群组成员记录
 // 6. 会话处理：设置用户的会话序列号
 // 7. 通知发送：向群组成员发送退出通知
@@ -1735,7 +1838,7 @@ func (g *groupServer) JoinGroup(ctx context.Context, req *pbgroup.JoinGroupReq)
 // 权限规则：
 // - 用户可以退出自己加入的群组
 // - 系统管理员可以代替任何用户退出群组
-// - 群主不能退出群组（需要先转让群主身份）
+// - 未启用群主继任时群主不能退出群组（需要先转让群主身份）
 //
 // 特殊处理：
 // - 退出后用户不能看到退出后的群组消息
@@ -1757,9 +1860,12 @@ func (g *groupServer) QuitGroup(ctx context.Context, req *pbgroup.QuitGroupReq)
 	if err != nil {
 		return nil, err
 	}
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
 
-	// 检查群主不能退出群组
-	if member.RoleLevel == constant.GroupOwner {
+	// 未启用群主继任时群主不能退出群组，需要先转让群主
+	if member.RoleLevel == constant.GroupOwner && !g.config.RpcConfig.OwnerSuccession.Enable {
 		return nil, errs.ErrNoPermission.WrapMsg("group owner can't quit")
 	}
 
@@ -1768,14 +1874,34 @@ func (g *groupServer) QuitGroup(ctx context.Context, req *pbgroup.QuitGroupReq)
 		return nil, err
 	}
 
-	// 从群组中删除该成员
-	err = g.db.DeleteGroupMember(ctx, req.GroupID, []string{req.UserID})
-	if err != nil {
-		return nil, err
+	var dismissed bool
+	if member.RoleLevel == constant.GroupOwner {
+		// 群主退出：选出新群主并移出原群主，没有可继任的成员时解散群组
+		group, err := g.db.TakeGroup(ctx, req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if group.Status == constant.GroupStatusDismissed {
+			return nil, servererrs.ErrDismissedAlready.Wrap()
+		}
+		newOwner, err := g.succeedGroupOwner(ctx, group, member.UserID)
+		if err != nil {
+			return nil, err
+		}
+		// 没有继任者时群组已解散，解散通知已发送，不再发送成员退出通知
+		dismissed = newOwner == nil
+	} else {
+		// 从群组中删除该成员
+		err = g.db.DeleteGroupMember(ctx, req.GroupID, []string{req.UserID})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 发送成员退出通知
-	g.notification.MemberQuitNotification(ctx, g.groupMemberDB2PB(member, 0))
+	if !dismissed {
+		g.notification.MemberQuitNotification(ctx, g.groupMemberDB2PB(member, 0))
+	}
 
 	// 设置用户的会话序列号，确保退出后不能看到后续消息
 	if err := g.deleteMemberAndSetConversationSeq(ctx, req.GroupID, []string{req.UserID}); err != nil {
@@ -1811,18 +1937,28 @@ func (g *groupServer) QuitGroup(ctx context.Context, req *pbgroup.QuitGroupReq)
 // 返回：
 // - error: 操作错误，nil表示成功
 func (g *groupServer) deleteMemberAndSetConversationSeq(ctx context.Context, groupID string, userIDs []string) error {
-	// 构建群组会话ID
-	conversationID := msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, groupID)
-
-	// 获取当前群组会话的最大序列号
-	maxSeq, err := g.msgClient.GetConversationMaxSeq(ctx, conversationID)
+	// 群组主会话和各频道会话都需要限制，频道消息的可见范围与群组相同
+	channels, err := g.db.FindGroupChannels(ctx, groupID, nil)
 	if err != nil {
 		return err
 	}
+	for _, id := range groupConversationGroupIDs(groupID, channels) {
+		// 构建群组或频道的会话ID
+		conversationID := msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, id)
 
-	// 为指定用户设置会话序列号为当前最大值
-	// 这样用户重新加入时不会看到离开期间的历史消息
-	return g.conversationClient.SetConversationMaxSeq(ctx, conversationID, userIDs, maxSeq)
+		// 获取当前会话的最大序列号
+		maxSeq, err := g.msgClient.GetConversationMaxSeq(ctx, conversationID)
+		if err != nil {
+			return err
+		}
+
+		// 为指定用户设置会话序列号为当前最大值
+		// 这样用户重新加入时不会看到离开期间的历史消息
+		if err := g.conversationClient.SetConversationMaxSeq(ctx, conversationID, userIDs, maxSeq); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetGroupInfo 设置群组信息
@@ -1860,16 +1996,11 @@ func (g *groupServer) deleteMemberAndSetConversationSeq(ctx context.Context, gro
 // - 只更新变更的字段
 // - 批量处理多个字段的更新
 func (g *groupServer) SetGroupInfo(ctx context.Context, req *pbgroup.SetGroupInfoReq) (*pbgroup.SetGroupInfoResp, error) {
-	var opMember *model.GroupMember
-	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
-		var err error
-		opMember, err = g.db.TakeGroupMember(ctx, req.GroupInfoForSet.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		if !(opMember.RoleLevel == constant.GroupOwner || opMember.RoleLevel == constant.GroupAdmin) {
-			return nil, errs.ErrNoPermission.WrapMsg("no group owner or admin")
-		}
+	opMember, err := g.CheckGroupPermission(ctx, req.GroupInfoForSet.GroupID, model.GroupPermEditInfo)
+	if err != nil {
+		return nil, err
+	}
+	if opMember != nil {
 		if err := g.PopulateGroupMember(ctx, opMember); err != nil {
 			return nil, err
 		}
@@ -1886,6 +2017,9 @@ func (g *groupServer) SetGroupInfo(ctx context.Context, req *pbgroup.SetGroupInf
 	if group.Status == constant.GroupStatusDismissed {
 		return nil, servererrs.ErrDismissedAlready.Wrap()
 	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
 
 	count, err := g.db.FindGroupMemberNum(ctx, group.GroupID)
 	if err != nil {
@@ -1936,6 +2070,7 @@ func (g *groupServer) SetGroupInfo(ctx context.Context, req *pbgroup.SetGroupInf
 				log.ZWarn(ctx, "SetConversations", err, "UserIDs", resp.UserIDs, "conversation", conversation)
 			}
 		}()
+		g.saveGroupAnnouncementHistory(ctx, req.GroupInfoForSet.GroupID, req.GroupInfoForSet.Notification)
 		notficationFlag := true
 		g.notification.GroupInfoSetAnnouncementNotification(ctx, &sdkws.GroupInfoSetAnnouncementTips{Group: tips.Group, OpUser: tips.OpUser}, &notficationFlag)
 	}
@@ -1981,20 +2116,12 @@ func (g *groupServer) SetGroupInfo(ctx context.Context, req *pbgroup.SetGroupInf
 // - 管理员：可以修改所有群组信息
 // - 系统管理员：可以修改任何群组信息
 func (g *groupServer) SetGroupInfoEx(ctx context.Context, req *pbgroup.SetGroupInfoExReq) (*pbgroup.SetGroupInfoExResp, error) {
-	var opMember *model.GroupMember
-
-	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
-		var err error
-
-		opMember, err = g.db.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-
-		if !(opMember.RoleLevel == constant.GroupOwner || opMember.RoleLevel == constant.GroupAdmin) {
-			return nil, errs.ErrNoPermission.WrapMsg("no group owner or admin")
-		}
+	opMember, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermEditInfo)
+	if err != nil {
+		return nil, err
+	}
 
+	if opMember != nil {
 		if err := g.PopulateGroupMember(ctx, opMember); err != nil {
 			return nil, err
 		}
@@ -2011,6 +2138,9 @@ func (g *groupServer) SetGroupInfoEx(ctx context.Context, req *pbgroup.SetGroupI
 	if group.Status == constant.GroupStatusDismissed {
 		return nil, servererrs.ErrDismissedAlready.Wrap()
 	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
 
 	count, err := g.db.FindGroupMemberNum(ctx, group.GroupID)
 	if err != nil {
@@ -2073,6 +2203,7 @@ func (g *groupServer) SetGroupInfoEx(ctx context.Context, req *pbgroup.SetGroupI
 				log.ZWarn(ctx, "SetConversations", err, "UserIDs", resp.UserIDs, "conversation", conversation)
 			}
 
+			g.saveGroupAnnouncementHistory(ctx, req.GroupID, req.Notification.Value)
 			g.notification.GroupInfoSetAnnouncementNotification(ctx, &sdkws.GroupInfoSetAnnouncementTips{Group: tips.Group, OpUser: tips.OpUser}, &notificationFlag)
 		} else {
 			notificationFlag = false
@@ -2136,6 +2267,9 @@ func (g *groupServer) TransferGroupOwner(ctx context.Context, req *pbgroup.Trans
 	if group.Status == constant.GroupStatusDismissed {
 		return nil, servererrs.ErrDismissedAlready.Wrap()
 	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
 
 	if req.OldOwnerUserID == req.NewOwnerUserID {
 		return nil, errs.ErrArgs.WrapMsg("OldOwnerUserID == NewOwnerUserID")
@@ -2445,6 +2579,9 @@ func (g *groupServer) DismissGroup(ctx context.Context, req *pbgroup.DismissGrou
 // - 禁言状态会同步到所有客户端
 // - 禁言到期后自动解除
 func (g *groupServer) MuteGroupMember(ctx context.Context, req *pbgroup.MuteGroupMemberReq) (*pbgroup.MuteGroupMemberResp, error) {
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
 	member, err := g.db.TakeGroupMember(ctx, req.GroupID, req.UserID)
 	if err != nil {
 		return nil, err
@@ -2452,23 +2589,8 @@ func (g *groupServer) MuteGroupMember(ctx context.Context, req *pbgroup.MuteGrou
 	if err := g.PopulateGroupMember(ctx, member); err != nil {
 		return nil, err
 	}
-	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
-		opMember, err := g.db.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-		switch member.RoleLevel {
-		case constant.GroupOwner:
-			return nil, errs.ErrNoPermission.WrapMsg("set group owner mute")
-		case constant.GroupAdmin:
-			if opMember.RoleLevel != constant.GroupOwner {
-				return nil, errs.ErrNoPermission.WrapMsg("set group admin mute")
-			}
-		case constant.GroupOrdinaryUsers:
-			if !(opMember.RoleLevel == constant.GroupAdmin || opMember.RoleLevel == constant.GroupOwner) {
-				return nil, errs.ErrNoPermission.WrapMsg("set group ordinary users mute")
-			}
-		}
+	if err := g.CheckManageGroupMember(ctx, req.GroupID, model.GroupPermMuteMember, member); err != nil {
+		return nil, err
 	}
 	data := UpdateGroupMemberMutedTimeMap(time.Now().Add(time.Second * time.Duration(req.MutedSeconds)))
 	if err := g.db.UpdateGroupMember(ctx, member.GroupID, member.UserID, data); err != nil {
@@ -2509,6 +2631,9 @@ func (g *groupServer) MuteGroupMember(ctx context.Context, req *pbgroup.MuteGrou
 // - 禁言状态同步到所有客户端
 // - 操作记录用于审计
 func (g *groupServer) CancelMuteGroupMember(ctx context.Context, req *pbgroup.CancelMuteGroupMemberReq) (*pbgroup.CancelMuteGroupMemberResp, error) {
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
 	member, err := g.db.TakeGroupMember(ctx, req.GroupID, req.UserID)
 	if err != nil {
 		return nil, err
@@ -2518,24 +2643,8 @@ func (g *groupServer) CancelMuteGroupMember(ctx context.Context, req *pbgroup.Ca
 		return nil, err
 	}
 
-	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
-		opMember, err := g.db.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx))
-		if err != nil {
-			return nil, err
-		}
-
-		switch member.RoleLevel {
-		case constant.GroupOwner:
-			return nil, errs.ErrNoPermission.WrapMsg("Can not set group owner unmute")
-		case constant.GroupAdmin:
-			if opMember.RoleLevel != constant.GroupOwner {
-				return nil, errs.ErrNoPermission.WrapMsg("Can not set group admin unmute")
-			}
-		case constant.GroupOrdinaryUsers:
-			if !(opMember.RoleLevel == constant.GroupAdmin || opMember.RoleLevel == constant.GroupOwner) {
-				return nil, errs.ErrNoPermission.WrapMsg("Can not set group ordinary users unmute")
-			}
-		}
+	if err := g.CheckManageGroupMember(ctx, req.GroupID, model.GroupPermMuteMember, member); err != nil {
+		return nil, err
 	}
 
 	data := UpdateGroupMemberMutedTimeMap(time.Unix(0, 0))
@@ -2578,7 +2687,10 @@ func (g *groupServer) CancelMuteGroupMember(ctx context.Context, req *pbgroup.Ca
 // - 重要公告发布
 // - 临时管理需要
 func (g *groupServer) MuteGroup(ctx context.Context, req *pbgroup.MuteGroupReq) (*pbgroup.MuteGroupResp, error) {
-	if err := g.CheckGroupAdmin(ctx, req.GroupID); err != nil {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermMuteMember); err != nil {
+		return nil, err
+	}
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
 		return nil, err
 	}
 	if err := g.db.UpdateGroup(ctx, req.GroupID, UpdateGroupStatusMap(constant.GroupStatusMuted)); err != nil {
@@ -2622,7 +2734,10 @@ func (g *groupServer) MuteGroup(ctx context.Context, req *pbgroup.MuteGroupReq)
 // - 重要通知发布完毕
 // - 群组秩序恢复正常
 func (g *groupServer) CancelMuteGroup(ctx context.Context, req *pbgroup.CancelMuteGroupReq) (*pbgroup.CancelMuteGroupResp, error) {
-	if err := g.CheckGroupAdmin(ctx, req.GroupID); err != nil {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermMuteMember); err != nil {
+		return nil, err
+	}
+	if err := g.checkGroupIDNotArchived(ctx, req.GroupID); err != nil {
 		return nil, err
 	}
 	if err := g.db.UpdateGroup(ctx, req.GroupID, UpdateGroupStatusMap(constant.GroupOk)); err != nil {
@@ -2695,6 +2810,9 @@ func (g *groupServer) SetGroupMemberInfo(ctx context.Context, req *pbgroup.SetGr
 		groupMembers[member.GroupID] = append(groupMembers[member.GroupID], req.Members[i])
 	}
 	for groupID, members := range groupMembers {
+		if err := g.checkGroupIDNotArchived(ctx, groupID); err != nil {
+			return nil, err
+		}
 		temp := make(map[string]struct{})
 		userIDs := make([]string, 0, len(members)+1)
 		for _, member := range members {
@@ -0,0 +1,311 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// maxImportGroups 单次导入的最大群组数
+const maxImportGroups = 100
+
+// importGroupRolesExt2DB 校验并转换导入的自定义角色，roles为nil时返回nil表示保留已有角色
+func importGroupRolesExt2DB(roles []*groupext.GroupRole) ([]*model.GroupRole, error) {
+	if roles == nil {
+		return nil, nil
+	}
+	if len(roles) > model.MaxGroupRoles {
+		return nil, errs.ErrArgs.WrapMsg("too many group roles", "max", model.MaxGroupRoles)
+	}
+	res := make([]*model.GroupRole, 0, len(roles))
+	roleIDs := make(map[string]struct{}, len(roles))
+	for _, role := range roles {
+		if role.RoleID == "" || role.Name == "" {
+			return nil, errs.ErrArgs.WrapMsg("roleID and name are required")
+		}
+		if _, ok := roleIDs[role.RoleID]; ok {
+			return nil, errs.ErrArgs.WrapMsg("group role repeated", "roleID", role.RoleID)
+		}
+		roleIDs[role.RoleID] = struct{}{}
+		if err := checkGroupRolePermissions(role.Permissions); err != nil {
+			return nil, err
+		}
+		res = append(res, &model.GroupRole{RoleID: role.RoleID, Name: role.Name, Permissions: role.Permissions})
+	}
+	return res, nil
+}
+
+// checkImportGroup 校验导入的群组和成员，返回群主ID，roles为导入后群组的全部自定义角色
+func checkImportGroup(group *groupext.ImportGroup, roles []*model.GroupRole) (string, error) {
+	if group.ExternalID == "" {
+		return "", errs.ErrArgs.WrapMsg("externalID is empty")
+	}
+	if group.GroupName == "" {
+		return "", errs.ErrArgs.WrapMsg("groupName is empty")
+	}
+	if len(group.Members) == 0 {
+		return "", errs.ErrArgs.WrapMsg("no group members")
+	}
+	roleIDs := datautil.SliceSet(datautil.Slice(roles, func(role *model.GroupRole) string { return role.RoleID }))
+	userIDs := make(map[string]struct{}, len(group.Members))
+	var ownerUserID string
+	for _, member := range group.Members {
+		if member.UserID == "" {
+			return "", errs.ErrArgs.WrapMsg("userID is empty")
+		}
+		if _, ok := userIDs[member.UserID]; ok {
+			return "", errs.ErrArgs.WrapMsg("group member repeated", "userID", member.UserID)
+		}
+		userIDs[member.UserID] = struct{}{}
+		switch member.RoleLevel {
+		case constant.GroupOwner:
+			if ownerUserID != "" {
+				return "", errs.ErrArgs.WrapMsg("group owner repeated", "userID", member.UserID)
+			}
+			ownerUserID = member.UserID
+		case 0, constant.GroupAdmin, constant.GroupOrdinaryUsers:
+		default:
+			return "", errs.ErrArgs.WrapMsg("invalid roleLevel", "userID", member.UserID, "roleLevel", member.RoleLevel)
+		}
+		if member.RoleID != "" {
+			if _, ok := roleIDs[member.RoleID]; !ok {
+				return "", errs.ErrRecordNotFound.WrapMsg("group role not found", "roleID", member.RoleID)
+			}
+		}
+	}
+	if ownerUserID == "" {
+		return "", errs.ErrArgs.WrapMsg("no group owner")
+	}
+	return ownerUserID, nil
+}
+
+// importGroupMemberExt2DB 转换导入的群成员，old为已存在的成员记录
+func importGroupMemberExt2DB(groupID string, member *groupext.ImportGroupMember, old *model.GroupMember, opUserID string, now time.Time) *model.GroupMember {
+	dbMember := &model.GroupMember{
+		GroupID:        groupID,
+		UserID:         member.UserID,
+		Nickname:       member.Nickname,
+		FaceURL:        member.FaceURL,
+		RoleLevel:      member.RoleLevel,
+		JoinTime:       now,
+		JoinSource:     constant.JoinByAdmin,
+		InviterUserID:  member.InviterUserID,
+		OperatorUserID: opUserID,
+		MuteEndTime:    time.UnixMilli(member.MuteEndTime),
+		RoleID:         member.RoleID,
+		Ex:             member.Ex,
+	}
+	if dbMember.RoleLevel == 0 {
+		dbMember.RoleLevel = constant.GroupOrdinaryUsers
+	}
+	if old != nil {
+		dbMember.JoinTime = old.JoinTime
+		dbMember.JoinSource = old.JoinSource
+	}
+	if member.JoinTime > 0 {
+		dbMember.JoinTime = time.UnixMilli(member.JoinTime)
+	}
+	return dbMember
+}
+
+// ImportGroups 批量导入群组（仅系统管理员）
+// 以externalID为幂等键：未导入过的群组新建，已导入的群组更新资料并新增或覆盖导入的成员，不在本次导入中的成员保持不变。
+// 每个群组在独立的事务中写入，单个群组失败不影响其他群组，结果按请求顺序逐条返回；导入过程不发送任何通知和回调
+func (g *groupServer) ImportGroups(ctx context.Context, req *groupext.ImportGroupsReq) (*groupext.ImportGroupsResp, error) {
+	if err := authverify.CheckAdmin(ctx, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if len(req.Groups) == 0 || len(req.Groups) > maxImportGroups {
+		return nil, errs.ErrArgs.WrapMsg("invalid number of groups", "max", maxImportGroups)
+	}
+	var (
+		externalIDs []string
+		userIDs     []string
+	)
+	for _, group := range req.Groups {
+		if group.ExternalID != "" {
+			externalIDs = append(externalIDs, group.ExternalID)
+		}
+		for _, member := range group.Members {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	existGroups, err := g.db.FindGroupsByExternalIDs(ctx, datautil.Distinct(externalIDs))
+	if err != nil {
+		return nil, err
+	}
+	existGroupMap := datautil.SliceToMap(existGroups, func(group *model.Group) string { return group.ExternalID })
+	userMap, err := g.userClient.GetUsersInfoMap(ctx, datautil.Distinct(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.ImportGroupsResp{Results: make([]*groupext.ImportGroupResult, 0, len(req.Groups))}
+	imported := make(map[string]struct{}, len(req.Groups))
+	for i, group := range req.Groups {
+		result := &groupext.ImportGroupResult{Index: i, ExternalID: group.ExternalID}
+		if _, ok := imported[group.ExternalID]; ok && group.ExternalID != "" {
+			err = errs.ErrArgs.WrapMsg("externalID repeated", "externalID", group.ExternalID)
+		} else {
+			imported[group.ExternalID] = struct{}{}
+			result.GroupID, result.Created, err = g.importGroup(ctx, group, existGroupMap[group.ExternalID], userMap)
+		}
+		if err != nil {
+			log.ZWarn(ctx, "import group failed", err, "index", i, "externalID", group.ExternalID)
+			codeErr := errs.ErrInternalServer
+			errors.As(err, &codeErr)
+			result.ErrCode = codeErr.Code()
+			result.ErrMsg = err.Error()
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// importGroup 导入单个群组，exist为以相同externalID导入过的群组
+func (g *groupServer) importGroup(ctx context.Context, req *groupext.ImportGroup, exist *model.Group, userMap map[string]*sdkws.UserInfo) (groupID string, created bool, err error) {
+	roles, err := importGroupRolesExt2DB(req.Roles)
+	if err != nil {
+		return "", false, err
+	}
+	allRoles := roles
+	if roles == nil && exist != nil {
+		allRoles = exist.Roles
+	}
+	ownerUserID, err := checkImportGroup(req, allRoles)
+	if err != nil {
+		return "", false, err
+	}
+	for _, member := range req.Members {
+		if _, ok := userMap[member.UserID]; !ok {
+			return "", false, servererrs.ErrUserIDNotFound.WrapMsg("user not found", "userID", member.UserID)
+		}
+	}
+	now := time.Now()
+	status := int32(constant.GroupOk)
+	if req.Muted {
+		status = constant.GroupStatusMuted
+	}
+	var (
+		group       *model.Group
+		groupUpdate map[string]any
+		oldMembers  map[string]*model.GroupMember
+	)
+	if exist == nil {
+		group = &model.Group{
+			GroupID:           req.GroupID,
+			GroupName:         req.GroupName,
+			Notification:      req.Notification,
+			Introduction:      req.Introduction,
+			FaceURL:           req.FaceURL,
+			CreateTime:        now,
+			Ex:                req.Ex,
+			Status:            status,
+			CreatorUserID:     ownerUserID,
+			GroupType:         constant.WorkingGroup,
+			NeedVerification:  req.NeedVerification,
+			LookMemberInfo:    req.LookMemberInfo,
+			ApplyMemberFriend: req.ApplyMemberFriend,
+			Roles:             roles,
+			ExternalID:        req.ExternalID,
+		}
+		if req.CreateTime > 0 {
+			group.CreateTime = time.UnixMilli(req.CreateTime)
+		}
+		if req.Notification != "" {
+			group.NotificationUpdateTime = group.CreateTime
+			group.NotificationUserID = ownerUserID
+		}
+		if err := g.GenGroupID(ctx, &group.GroupID); err != nil {
+			return "", false, err
+		}
+	} else {
+		group = exist
+		if group.Status == constant.GroupStatusDismissed {
+			return group.GroupID, false, servererrs.ErrDismissedAlready.Wrap()
+		}
+		if err := checkGroupNotArchived(group); err != nil {
+			return group.GroupID, false, err
+		}
+		owner, err := g.db.TakeGroupOwner(ctx, group.GroupID)
+		if err != nil {
+			return group.GroupID, false, err
+		}
+		if owner.UserID != ownerUserID {
+			return group.GroupID, false, errs.ErrArgs.WrapMsg("group owner can not be changed by import", "ownerUserID", owner.UserID)
+		}
+		if !req.Muted && group.Status != constant.GroupStatusMuted {
+			status = group.Status
+		}
+		groupUpdate = map[string]any{
+			"group_name":          req.GroupName,
+			"face_url":            req.FaceURL,
+			"introduction":        req.Introduction,
+			"ex":                  req.Ex,
+			"need_verification":   req.NeedVerification,
+			"look_member_info":    req.LookMemberInfo,
+			"apply_member_friend": req.ApplyMemberFriend,
+			"status":              status,
+		}
+		if req.Notification != group.Notification {
+			groupUpdate["notification"] = req.Notification
+			groupUpdate["notification_update_time"] = now
+			groupUpdate["notification_user_id"] = ownerUserID
+		}
+		if roles != nil {
+			groupUpdate["roles"] = roles
+		}
+		members, err := g.db.FindGroupMembers(ctx, group.GroupID, datautil.Slice(req.Members, func(member *groupext.ImportGroupMember) string { return member.UserID }))
+		if err != nil {
+			return group.GroupID, false, err
+		}
+		oldMembers = datautil.SliceToMap(members, func(member *model.GroupMember) string { return member.UserID })
+	}
+	opUserID := mcontext.GetOpUserID(ctx)
+	var insertMembers, replaceMembers []*model.GroupMember
+	for _, member := range req.Members {
+		old := oldMembers[member.UserID]
+		dbMember := importGroupMemberExt2DB(group.GroupID, member, old, opUserID, now)
+		if old == nil {
+			insertMembers = append(insertMembers, dbMember)
+		} else {
+			replaceMembers = append(replaceMembers, dbMember)
+		}
+	}
+	if err := g.db.ImportGroup(ctx, group, groupUpdate, insertMembers, replaceMembers); err != nil {
+		return group.GroupID, false, err
+	}
+	// 已有群组新增的成员与正常入群一样创建会话，并按EnableHistoryForNewMembers限制可见的历史消息
+	// 新建的群组没有历史消息，成员会话在群组收到第一条消息时创建
+	if exist != nil && len(insertMembers) > 0 {
+		userIDs := datautil.Slice(insertMembers, func(member *model.GroupMember) string { return member.UserID })
+		if err := g.notification.initMemberConversations(ctx, group.GroupID, userIDs); err != nil {
+			return group.GroupID, false, err
+		}
+	}
+	return group.GroupID, exist == nil, nil
+}
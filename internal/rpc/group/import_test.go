@@ -0,0 +1,91 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportGroupRolesExt2DB(t *testing.T) {
+	roles, err := importGroupRolesExt2DB(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, roles)
+
+	roles, err = importGroupRolesExt2DB([]*groupext.GroupRole{{RoleID: "mod", Name: "mod", Permissions: model.GroupPermKickMember}})
+	assert.NoError(t, err)
+	assert.Len(t, roles, 1)
+
+	_, err = importGroupRolesExt2DB([]*groupext.GroupRole{{RoleID: "mod", Name: "a"}, {RoleID: "mod", Name: "b"}})
+	assert.Error(t, err)
+	_, err = importGroupRolesExt2DB([]*groupext.GroupRole{{RoleID: "mod", Name: "mod", Permissions: -1}})
+	assert.Error(t, err)
+	_, err = importGroupRolesExt2DB([]*groupext.GroupRole{{Name: "mod"}})
+	assert.Error(t, err)
+}
+
+func TestCheckImportGroup(t *testing.T) {
+	roles := []*model.GroupRole{{RoleID: "mod"}}
+	newGroup := func(members ...*groupext.ImportGroupMember) *groupext.ImportGroup {
+		return &groupext.ImportGroup{ExternalID: "e1", GroupName: "g", Members: members}
+	}
+
+	ownerUserID, err := checkImportGroup(newGroup(
+		&groupext.ImportGroupMember{UserID: "u1", RoleLevel: constant.GroupOwner},
+		&groupext.ImportGroupMember{UserID: "u2"},
+		&groupext.ImportGroupMember{UserID: "u3", RoleLevel: constant.GroupAdmin, RoleID: "mod"},
+	), roles)
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", ownerUserID)
+
+	// 缺少群主
+	_, err = checkImportGroup(newGroup(&groupext.ImportGroupMember{UserID: "u1"}), roles)
+	assert.Error(t, err)
+	// 多个群主
+	_, err = checkImportGroup(newGroup(
+		&groupext.ImportGroupMember{UserID: "u1", RoleLevel: constant.GroupOwner},
+		&groupext.ImportGroupMember{UserID: "u2", RoleLevel: constant.GroupOwner},
+	), roles)
+	assert.Error(t, err)
+	// 成员重复
+	_, err = checkImportGroup(newGroup(
+		&groupext.ImportGroupMember{UserID: "u1", RoleLevel: constant.GroupOwner},
+		&groupext.ImportGroupMember{UserID: "u1"},
+	), roles)
+	assert.Error(t, err)
+	// 未定义的自定义角色
+	_, err = checkImportGroup(newGroup(
+		&groupext.ImportGroupMember{UserID: "u1", RoleLevel: constant.GroupOwner, RoleID: "unknown"},
+	), roles)
+	assert.Error(t, err)
+	// 非法角色等级
+	_, err = checkImportGroup(newGroup(
+		&groupext.ImportGroupMember{UserID: "u1", RoleLevel: constant.GroupOwner},
+		&groupext.ImportGroupMember{UserID: "u2", RoleLevel: 1},
+	), roles)
+	assert.Error(t, err)
+	// 缺少externalID
+	_, err = checkImportGroup(&groupext.ImportGroup{GroupName: "g"}, roles)
+	assert.Error(t, err)
+}
+
+func TestImportGroupMemberExt2DB(t *testing.T) {
+	now := time.UnixMilli(3000)
+
+	member := importGroupMemberExt2DB("g1", &groupext.ImportGroupMember{UserID: "u1"}, nil, "admin", now)
+	assert.Equal(t, int32(constant.GroupOrdinaryUsers), member.RoleLevel)
+	assert.Equal(t, now, member.JoinTime)
+	assert.Equal(t, int32(constant.JoinByAdmin), member.JoinSource)
+
+	old := &model.GroupMember{JoinTime: time.UnixMilli(1000), JoinSource: constant.JoinByInvitation}
+	member = importGroupMemberExt2DB("g1", &groupext.ImportGroupMember{UserID: "u1", MuteEndTime: 5000}, old, "admin", now)
+	assert.Equal(t, old.JoinTime, member.JoinTime)
+	assert.Equal(t, int32(constant.JoinByInvitation), member.JoinSource)
+	assert.Equal(t, int64(5000), member.MuteEndTime.UnixMilli())
+
+	member = importGroupMemberExt2DB("g1", &groupext.ImportGroupMember{UserID: "u1", JoinTime: 2000}, old, "admin", now)
+	assert.Equal(t, int64(2000), member.JoinTime.UnixMilli())
+}
@@ -0,0 +1,310 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/callbackstruct"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	pbgroup "github.com/openimsdk/protocol/group"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// genRandomToken 生成n字节的随机令牌，URL安全编码后可直接拼接到链接或二维码中
+func genRandomToken(n int) (string, error) {
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		return "", errs.WrapMsg(err, "generate random token failed")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func groupInviteLinkDB2Ext(link *model.GroupInviteLink) *groupext.GroupInviteLink {
+	var expireTime int64
+	if !link.ExpireTime.IsZero() {
+		expireTime = link.ExpireTime.UnixMilli()
+	}
+	return &groupext.GroupInviteLink{
+		Token:            link.Token,
+		GroupID:          link.GroupID,
+		CreatorUserID:    link.CreatorUserID,
+		ExpireTime:       expireTime,
+		MaxUses:          link.MaxUses,
+		UsedCount:        link.UsedCount,
+		SkipVerification: link.SkipVerification,
+		Revoked:          link.Revoked,
+		Ex:               link.Ex,
+		CreateTime:       link.CreateTime.UnixMilli(),
+	}
+}
+
+// inviteLinkNeedVerification 兑换邀请链接是否需要管理员审批
+// 未设置跳过验证时与成员邀请一致：仅在群组要求所有加群都验证时需要审批
+func inviteLinkNeedVerification(link *model.GroupInviteLink, group *model.Group) bool {
+	return !link.SkipVerification && group.NeedVerification == constant.AllNeedVerification
+}
+
+// takeAvailableInviteLink 获取可兑换的邀请链接，已撤销、已过期或已用完时返回错误
+func (g *groupServer) takeAvailableInviteLink(ctx context.Context, token string) (*model.GroupInviteLink, error) {
+	link, err := g.inviteLinkDB.Take(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if link.Revoked || link.Expired(time.Now()) {
+		return nil, servererrs.ErrGroupInviteLinkInvalid.WrapMsg("invite link revoked or expired", "token", token)
+	}
+	if link.UsedUp() {
+		return nil, servererrs.ErrGroupInviteLinkUsedUp.WrapMsg("invite link used up", "token", token, "maxUses", link.MaxUses)
+	}
+	return link, nil
+}
+
+// CreateGroupInviteLink 创建群组邀请链接，需要审批加群权限
+func (g *groupServer) CreateGroupInviteLink(ctx context.Context, req *groupext.CreateGroupInviteLinkReq) (*groupext.CreateGroupInviteLinkResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermApproveJoin); err != nil {
+		return nil, err
+	}
+	if req.MaxUses < 0 {
+		return nil, errs.ErrArgs.WrapMsg("maxUses must not be negative", "maxUses", req.MaxUses)
+	}
+	now := time.Now()
+	var expireTime time.Time
+	if req.ExpireTime > 0 {
+		expireTime = time.UnixMilli(req.ExpireTime)
+		if !expireTime.After(now) {
+			return nil, errs.ErrArgs.WrapMsg("expireTime must be in the future", "expireTime", req.ExpireTime)
+		}
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	token, err := genRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	link := &model.GroupInviteLink{
+		Token:            token,
+		GroupID:          req.GroupID,
+		CreatorUserID:    mcontext.GetOpUserID(ctx),
+		ExpireTime:       expireTime,
+		MaxUses:          req.MaxUses,
+		SkipVerification: req.SkipVerification,
+		Ex:               req.Ex,
+		CreateTime:       now,
+	}
+	if err := g.inviteLinkDB.Create(ctx, link); err != nil {
+		return nil, err
+	}
+	return &groupext.CreateGroupInviteLinkResp{Link: groupInviteLinkDB2Ext(link)}, nil
+}
+
+// GetGroupInviteLinks 获取群组的所有邀请链接（含已撤销和已失效的），需要审批加群权限
+func (g *groupServer) GetGroupInviteLinks(ctx context.Context, req *groupext.GetGroupInviteLinksReq) (*groupext.GetGroupInviteLinksResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermApproveJoin); err != nil {
+		return nil, err
+	}
+	links, err := g.inviteLinkDB.FindByGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupInviteLinksResp{Links: datautil.Slice(links, groupInviteLinkDB2Ext)}, nil
+}
+
+// RevokeGroupInviteLinks 撤销群组邀请链接，撤销后不可再兑换，已提交的加群申请不受影响
+func (g *groupServer) RevokeGroupInviteLinks(ctx context.Context, req *groupext.RevokeGroupInviteLinksReq) (*groupext.RevokeGroupInviteLinksResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermApproveJoin); err != nil {
+		return nil, err
+	}
+	if err := g.inviteLinkDB.Revoke(ctx, req.GroupID, datautil.Distinct(req.Tokens)); err != nil {
+		return nil, err
+	}
+	return &groupext.RevokeGroupInviteLinksResp{}, nil
+}
+
+// GetGroupInviteLinkInfo 兑换前预览邀请链接对应的群组，持有有效令牌即可查看
+func (g *groupServer) GetGroupInviteLinkInfo(ctx context.Context, req *groupext.GetGroupInviteLinkInfoReq) (*groupext.GetGroupInviteLinkInfoResp, error) {
+	link, err := g.takeAvailableInviteLink(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, link.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	num, err := g.db.FindGroupMemberNum(ctx, link.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupInviteLinkInfoResp{
+		GroupID:          group.GroupID,
+		GroupName:        group.GroupName,
+		FaceURL:          group.FaceURL,
+		MemberCount:      num,
+		CreatorUserID:    link.CreatorUserID,
+		ExpireTime:       groupInviteLinkDB2Ext(link).ExpireTime,
+		NeedVerification: inviteLinkNeedVerification(link, group),
+	}, nil
+}
+
+// RedeemGroupInviteLink 兑换群组邀请链接
+//
+// 业务逻辑：
+// 1. 校验链接可用、群组未解散、当前用户不在群内
+// 2. 原子地占用一次使用次数，后续步骤失败时归还
+// 3. 无需审批时直接入群，加入方式和邀请人记录在群成员上
+// 4. 需要审批时创建加群申请，加入方式和邀请人记录在申请上，审批同意后带入群成员
+//
+// 同一邀请人的加群申请仍在等待处理时重复兑换不再占用次数
+func (g *groupServer) RedeemGroupInviteLink(ctx context.Context, req *groupext.RedeemGroupInviteLinkReq) (*groupext.RedeemGroupInviteLinkResp, error) {
+	joinSource := req.JoinSource
+	switch joinSource {
+	case 0:
+		joinSource = constant.JoinByInvitation
+	case constant.JoinByInvitation, constant.JoinByQRCode:
+	default:
+		return nil, errs.ErrArgs.WrapMsg("invalid joinSource", "joinSource", req.JoinSource)
+	}
+	link, err := g.takeAvailableInviteLink(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, link.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	userID := mcontext.GetOpUserID(ctx)
+	if _, err := g.db.TakeGroupMember(ctx, group.GroupID, userID); err == nil {
+		return nil, errs.ErrArgs.WrapMsg("already in group", "groupID", group.GroupID, "userID", userID)
+	} else if !g.IsNotFound(err) {
+		return nil, err
+	}
+	resp := &groupext.RedeemGroupInviteLinkResp{GroupID: group.GroupID}
+
+	needVerification := inviteLinkNeedVerification(link, group)
+	if needVerification {
+		request, err := g.db.TakeGroupRequest(ctx, group.GroupID, userID)
+		if err == nil && request.HandleResult == 0 && request.InviterUserID == link.CreatorUserID {
+			return resp, nil
+		} else if err != nil && !g.IsNotFound(err) {
+			return nil, err
+		}
+		reqCall := &callbackstruct.CallbackJoinGroupReq{
+			GroupID:    group.GroupID,
+			GroupType:  string(group.GroupType),
+			ApplyID:    userID,
+			ReqMessage: req.ReqMessage,
+			Ex:         req.Ex,
+		}
+		if err := g.webhookBeforeApplyJoinGroup(ctx, &g.config.WebhooksConfig.BeforeApplyJoinGroup, reqCall); err != nil && err != servererrs.ErrCallbackContinue {
+			return nil, err
+		}
+	}
+
+	claimed, err := g.inviteLinkDB.IncrUsedCount(ctx, link.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		// 并发兑换导致次数耗尽，或在此期间被撤销
+		return nil, servererrs.ErrGroupInviteLinkUsedUp.WrapMsg("invite link used up", "token", link.Token)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err := g.inviteLinkDB.DecrUsedCount(ctx, link.Token); err != nil {
+			log.ZError(ctx, "RedeemGroupInviteLink release use failed", err, "token", link.Token)
+		}
+	}()
+
+	if needVerification {
+		groupRequest := &model.GroupRequest{
+			UserID:        userID,
+			GroupID:       group.GroupID,
+			ReqMsg:        req.ReqMessage,
+			ReqTime:       time.Now(),
+			HandledTime:   time.Unix(0, 0),
+			JoinSource:    joinSource,
+			InviterUserID: link.CreatorUserID,
+			Ex:            req.Ex,
+		}
+		if err = g.db.CreateGroupRequest(ctx, []*model.GroupRequest{groupRequest}); err != nil {
+			return nil, err
+		}
+		g.notification.JoinGroupApplicationNotification(ctx, &pbgroup.JoinGroupReq{
+			GroupID:       groupRequest.GroupID,
+			ReqMessage:    groupRequest.ReqMsg,
+			JoinSource:    groupRequest.JoinSource,
+			InviterUserID: groupRequest.InviterUserID,
+			Ex:            groupRequest.Ex,
+		}, groupRequest)
+		return resp, nil
+	}
+
+	groupMember := &model.GroupMember{
+		GroupID:        group.GroupID,
+		UserID:         userID,
+		RoleLevel:      constant.GroupOrdinaryUsers,
+		OperatorUserID: userID,
+		InviterUserID:  link.CreatorUserID,
+		JoinSource:     joinSource,
+		JoinTime:       time.Now(),
+		MuteEndTime:    time.UnixMilli(0),
+	}
+	if err = g.webhookBeforeMembersJoinGroup(ctx, &g.config.WebhooksConfig.BeforeMemberJoinGroup, []*model.GroupMember{groupMember}, group.GroupID, group.Ex); err != nil && err != servererrs.ErrCallbackContinue {
+		return nil, err
+	}
+	if err = g.db.CreateGroup(ctx, nil, []*model.GroupMember{groupMember}); err != nil {
+		return nil, err
+	}
+	if err := g.notification.MemberEnterNotification(ctx, group.GroupID, userID); err != nil {
+		log.ZError(ctx, "RedeemGroupInviteLink MemberEnterNotification failed", err, "groupID", group.GroupID, "userID", userID)
+	}
+	g.webhookAfterJoinGroup(ctx, &g.config.WebhooksConfig.AfterJoinGroup, &pbgroup.JoinGroupReq{
+		GroupID:       group.GroupID,
+		ReqMessage:    req.ReqMessage,
+		JoinSource:    joinSource,
+		InviterUserID: link.CreatorUserID,
+		Ex:            req.Ex,
+	})
+	resp.Joined = true
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupInviteLinkState(t *testing.T) {
+	now := time.Now()
+	link := &model.GroupInviteLink{}
+	assert.False(t, link.Expired(now))
+	assert.False(t, link.UsedUp())
+
+	link.ExpireTime = now
+	assert.True(t, link.Expired(now))
+	assert.False(t, link.Expired(now.Add(-time.Second)))
+
+	link.MaxUses, link.UsedCount = 2, 1
+	assert.False(t, link.UsedUp())
+	link.UsedCount = 2
+	assert.True(t, link.UsedUp())
+}
+
+func TestInviteLinkNeedVerification(t *testing.T) {
+	cases := []struct {
+		skip             bool
+		needVerification int32
+		want             bool
+	}{
+		{false, constant.AllNeedVerification, true},
+		{true, constant.AllNeedVerification, false},
+		{false, constant.ApplyNeedVerificationInviteDirectly, false},
+		{false, constant.Directly, false},
+	}
+	for _, c := range cases {
+		link := &model.GroupInviteLink{SkipVerification: c.skip}
+		group := &model.Group{NeedVerification: c.needVerification}
+		assert.Equal(t, c.want, inviteLinkNeedVerification(link, group), "skip=%v needVerification=%d", c.skip, c.needVerification)
+	}
+}
+
+func TestGenRandomToken(t *testing.T) {
+	a, err := genRandomToken(16)
+	assert.NoError(t, err)
+	b, err := genRandomToken(16)
+	assert.NoError(t, err)
+	assert.Len(t, a, 22)
+	assert.NotEqual(t, a, b)
+}
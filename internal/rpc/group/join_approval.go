@@ -0,0 +1,283 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	pbgroup "github.com/openimsdk/protocol/group"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+const (
+	// groupApplicationProgressKey 多人审批进度的业务通知key，客户端据此刷新待处理申请的审批进度
+	groupApplicationProgressKey = "groupApplicationApprovalProgress"
+	// joinRequestExpireBatchSize 过期处理时每批查询的群组数和申请数
+	joinRequestExpireBatchSize = 100
+	// joinRequestExpiredMsg 过期自动拒绝时写入的处理消息
+	joinRequestExpiredMsg = "expired"
+	// maxGroupApplicationDecisionsQuery 单次查询审批记录的最大申请数
+	maxGroupApplicationDecisionsQuery = 100
+)
+
+// groupApplicationAuditDB2Ext 将入群申请转换为审批进度结构
+func groupApplicationAuditDB2Ext(request *model.GroupRequest, requiredApprovals int) *groupext.GroupApplicationAudit {
+	return &groupext.GroupApplicationAudit{
+		GroupID:           request.GroupID,
+		UserID:            request.UserID,
+		HandleResult:      request.HandleResult,
+		ReqTime:           request.ReqTime.UnixMilli(),
+		RequiredApprovals: int32(requiredApprovals),
+		Approvals:         int32(request.Approvals()),
+		Decisions:         groupApplicationDecisionsDB2Ext(request.Decisions),
+		History: datautil.Slice(request.History, func(e *model.GroupRequestHistory) *groupext.GroupApplicationHistory {
+			return &groupext.GroupApplicationHistory{
+				HandleResult: e.HandleResult,
+				ReqMsg:       e.ReqMsg,
+				HandledMsg:   e.HandledMsg,
+				ReqTime:      e.ReqTime.UnixMilli(),
+				HandleUserID: e.HandleUserID,
+				HandledTime:  e.HandledTime.UnixMilli(),
+				Decisions:    groupApplicationDecisionsDB2Ext(e.Decisions),
+			}
+		}),
+	}
+}
+
+func groupApplicationDecisionsDB2Ext(decisions []*model.GroupRequestDecision) []*groupext.GroupApplicationDecision {
+	return datautil.Slice(decisions, func(e *model.GroupRequestDecision) *groupext.GroupApplicationDecision {
+		return &groupext.GroupApplicationDecision{
+			UserID: e.UserID,
+			Result: e.Result,
+			Reason: e.Reason,
+			Time:   e.Time.UnixMilli(),
+		}
+	})
+}
+
+// requiredJoinApprovals 按群组审批策略和当前群主、管理员人数计算申请通过所需的同意人数
+func (g *groupServer) requiredJoinApprovals(ctx context.Context, group *model.Group) (int, error) {
+	if group.JoinApprovalMode != model.GroupJoinApprovalQuorum {
+		return group.RequiredJoinApprovals(0), nil
+	}
+	approvers, err := g.db.FindGroupMemberRoleLevels(ctx, group.GroupID, []int32{constant.GroupOwner, constant.GroupAdmin})
+	if err != nil {
+		return 0, err
+	}
+	return group.RequiredJoinApprovals(len(approvers)), nil
+}
+
+// SetGroupJoinApprovalPolicy 设置入群审批策略
+// 包括审批模式、多人审批所需的同意人数和待处理申请的有效期，需要修改群资料权限
+// 策略变更只影响后续的审批，已累计的同意记录仍然有效
+func (g *groupServer) SetGroupJoinApprovalPolicy(ctx context.Context, req *groupext.SetGroupJoinApprovalPolicyReq) (*groupext.SetGroupJoinApprovalPolicyResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermEditInfo); err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any)
+	if req.JoinApprovalMode != nil {
+		switch *req.JoinApprovalMode {
+		case model.GroupJoinApprovalAny, model.GroupJoinApprovalQuorum:
+		default:
+			return nil, errs.ErrArgs.WrapMsg("invalid joinApprovalMode", "joinApprovalMode", *req.JoinApprovalMode)
+		}
+		data["join_approval_mode"] = *req.JoinApprovalMode
+	}
+	if req.JoinApprovalCount != nil {
+		if *req.JoinApprovalCount < 1 {
+			return nil, errs.ErrArgs.WrapMsg("joinApprovalCount must be positive", "joinApprovalCount", *req.JoinApprovalCount)
+		}
+		data["join_approval_count"] = *req.JoinApprovalCount
+	}
+	if req.JoinRequestExpireSeconds != nil {
+		if *req.JoinRequestExpireSeconds < 0 || *req.JoinRequestExpireSeconds > model.MaxJoinRequestExpireSeconds {
+			return nil, errs.ErrArgs.WrapMsg("invalid joinRequestExpireSeconds", "joinRequestExpireSeconds", *req.JoinRequestExpireSeconds, "max", model.MaxJoinRequestExpireSeconds)
+		}
+		data["join_request_expire_seconds"] = *req.JoinRequestExpireSeconds
+	}
+	if len(data) == 0 {
+		return &groupext.SetGroupJoinApprovalPolicyResp{}, nil
+	}
+	if err := g.db.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.SetGroupJoinApprovalPolicyResp{}, nil
+}
+
+// groupApplicationProgressNotification 多人审批尚未达到通过人数时，通知群主和管理员最新的审批进度
+// 申请仍为待处理状态，客户端据此更新待处理申请的审批进度展示
+func (g *groupServer) groupApplicationProgressNotification(ctx context.Context, request *model.GroupRequest, requiredApprovals int) {
+	userIDs, err := g.notification.getGroupOwnerAndAdminUserID(ctx, request.GroupID)
+	if err != nil {
+		log.ZError(ctx, "groupApplicationProgressNotification getGroupOwnerAndAdminUserID failed", err, "groupID", request.GroupID)
+		return
+	}
+	content := newBusinessNotificationContent(groupApplicationProgressKey, groupApplicationAuditDB2Ext(request, requiredApprovals))
+	opUserID := mcontext.GetOpUserID(ctx)
+	for _, userID := range userIDs {
+		if err := g.sendBusinessNotification(ctx, opUserID, userID, content); err != nil {
+			log.ZWarn(ctx, "send group application progress failed", err, "groupID", request.GroupID, "userID", userID)
+		}
+	}
+}
+
+// GetGroupApplicationDecisions 获取入群申请的审批进度和审批记录
+// 需要审批入群申请权限，用于审计每位审批人的决定以及过期处理
+func (g *groupServer) GetGroupApplicationDecisions(ctx context.Context, req *groupext.GetGroupApplicationDecisionsReq) (*groupext.GetGroupApplicationDecisionsResp, error) {
+	if len(req.FromUserIDs) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("fromUserIDs is empty")
+	}
+	if len(req.FromUserIDs) > maxGroupApplicationDecisionsQuery {
+		return nil, errs.ErrArgs.WrapMsg("too many fromUserIDs", "max", maxGroupApplicationDecisionsQuery)
+	}
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermApproveJoin); err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	requiredApprovals, err := g.requiredJoinApprovals(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := g.db.FindGroupRequests(ctx, req.GroupID, datautil.Distinct(req.FromUserIDs))
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupApplicationDecisionsResp{
+		Applications: datautil.Slice(requests, func(e *model.GroupRequest) *groupext.GroupApplicationAudit {
+			return groupApplicationAuditDB2Ext(e, requiredApprovals)
+		}),
+	}, nil
+}
+
+// GetGroupApplications 分页获取收到的入群申请，与GetGroupApplicationList权限和筛选条件相同
+// 每条申请附带审批进度和审批记录，审批人据此查看其他审批人的决定
+func (g *groupServer) GetGroupApplications(ctx context.Context, req *groupext.GetGroupApplicationsReq) (*groupext.GetGroupApplicationsResp, error) {
+	pbResp, requests, groupMap, err := g.getGroupApplicationList(ctx, &pbgroup.GetGroupApplicationListReq{
+		Pagination:    req.Pagination,
+		FromUserID:    req.FromUserID,
+		GroupIDs:      req.GroupIDs,
+		HandleResults: req.HandleResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+	requiredApprovals := make(map[string]int, len(groupMap))
+	for groupID, group := range groupMap {
+		required, err := g.requiredJoinApprovals(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		requiredApprovals[groupID] = required
+	}
+	resp := &groupext.GetGroupApplicationsResp{Total: pbResp.Total, GroupRequests: make([]*groupext.GroupApplication, 0, len(requests))}
+	for i, request := range requests {
+		audit := groupApplicationAuditDB2Ext(request, requiredApprovals[request.GroupID])
+		resp.GroupRequests = append(resp.GroupRequests, &groupext.GroupApplication{
+			GroupRequest:      pbResp.GroupRequests[i],
+			RequiredApprovals: audit.RequiredApprovals,
+			Approvals:         audit.Approvals,
+			Decisions:         audit.Decisions,
+		})
+	}
+	return resp, nil
+}
+
+// expireGroupRequest 将过期的申请自动拒绝并通知申请人和管理员
+// 申请已被并发处理时忽略
+func (g *groupServer) expireGroupRequest(ctx context.Context, request *model.GroupRequest, now time.Time) (bool, error) {
+	decision := &model.GroupRequestDecision{
+		Result: model.GroupRequestDecisionExpire,
+		Reason: joinRequestExpiredMsg,
+		Time:   now,
+	}
+	if err := g.db.HandlerGroupRequest(ctx, request.GroupID, request.UserID, constant.GroupResponseRefuse, decision, nil); err != nil {
+		if g.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	g.notification.GroupApplicationRejectedNotification(ctx, &pbgroup.GroupApplicationResponseReq{
+		GroupID:      request.GroupID,
+		FromUserID:   request.UserID,
+		HandledMsg:   joinRequestExpiredMsg,
+		HandleResult: constant.GroupResponseRefuse,
+	})
+	return true, nil
+}
+
+// ExpireGroupApplications 自动拒绝超过群组有效期的待处理入群申请
+// 仅系统管理员可调用，由定时任务周期执行
+func (g *groupServer) ExpireGroupApplications(ctx context.Context, req *groupext.ExpireGroupApplicationsReq) (*groupext.ExpireGroupApplicationsResp, error) {
+	if err := authverify.CheckAdmin(ctx, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	resp := &groupext.ExpireGroupApplicationsResp{}
+	now := time.Now()
+	var afterGroupID string
+	for {
+		groups, err := g.db.FindJoinRequestExpireGroups(ctx, afterGroupID, joinRequestExpireBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			before := now.Add(-time.Duration(group.JoinRequestExpireSeconds) * time.Second)
+			requests, err := g.db.FindExpiredGroupRequests(ctx, group.GroupID, before, joinRequestExpireBatchSize)
+			if err != nil {
+				log.ZWarn(ctx, "find expired group requests failed", err, "groupID", group.GroupID)
+				continue
+			}
+			// 每个群组每次最多处理一批，剩余的在下次执行时处理
+			for _, request := range requests {
+				expired, err := g.expireGroupRequest(ctx, request, now)
+				if err != nil {
+					log.ZWarn(ctx, "expire group request failed", err, "groupID", request.GroupID, "userID", request.UserID)
+					continue
+				}
+				if expired {
+					resp.ExpiredCount++
+				}
+			}
+		}
+		if len(groups) < joinRequestExpireBatchSize {
+			break
+		}
+		afterGroupID = groups[len(groups)-1].GroupID
+	}
+	return resp, nil
+}
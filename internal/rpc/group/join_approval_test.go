@@ -0,0 +1,96 @@
+package group
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredJoinApprovals(t *testing.T) {
+	group := &model.Group{GroupID: "g1", JoinApprovalCount: 3}
+	assert.Equal(t, 1, group.RequiredJoinApprovals(5)) // 任一审批人模式忽略人数设置
+
+	group.JoinApprovalMode = model.GroupJoinApprovalQuorum
+	assert.Equal(t, 3, group.RequiredJoinApprovals(5))
+	assert.Equal(t, 2, group.RequiredJoinApprovals(2)) // 审批人减少后不超过当前人数
+	assert.Equal(t, 1, group.RequiredJoinApprovals(0))
+
+	group.JoinApprovalCount = 0
+	assert.Equal(t, 1, group.RequiredJoinApprovals(5))
+}
+
+func TestGroupApplicationAudit(t *testing.T) {
+	request := &model.GroupRequest{
+		GroupID: "g1",
+		UserID:  "u1",
+		ReqTime: time.UnixMilli(1000),
+		Decisions: []*model.GroupRequestDecision{
+			{UserID: "a1", Result: model.GroupRequestDecisionApprove, Time: time.UnixMilli(2000)},
+			{UserID: "a2", Result: model.GroupRequestDecisionApprove, Time: time.UnixMilli(3000)},
+		},
+	}
+	assert.Equal(t, 2, request.Approvals())
+	assert.NotNil(t, request.Decision("a1"))
+	assert.Nil(t, request.Decision("a3"))
+
+	audit := groupApplicationAuditDB2Ext(request, 3)
+	assert.Equal(t, int32(3), audit.RequiredApprovals)
+	assert.Equal(t, int32(2), audit.Approvals)
+	assert.Equal(t, int64(1000), audit.ReqTime)
+	assert.Len(t, audit.Decisions, 2)
+	assert.Equal(t, int64(3000), audit.Decisions[1].Time)
+}
+
+func TestGroupApplicationJSON(t *testing.T) {
+	application := &groupext.GroupApplication{
+		GroupRequest:      &sdkws.GroupRequest{ReqMsg: "hello", HandleResult: 0},
+		RequiredApprovals: 2,
+		Approvals:         1,
+		Decisions:         []*groupext.GroupApplicationDecision{{UserID: "admin", Result: model.GroupRequestDecisionApprove}},
+	}
+	data, err := json.Marshal(application)
+	assert.NoError(t, err)
+	var values map[string]any
+	assert.NoError(t, json.Unmarshal(data, &values))
+	// 申请字段与原接口保持相同的结构，审批记录作为附加字段
+	assert.Equal(t, "hello", values["reqMsg"])
+	assert.Equal(t, float64(2), values["requiredApprovals"])
+	assert.Len(t, values["decisions"], 1)
+
+	var decoded groupext.GroupApplication
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "hello", decoded.ReqMsg)
+	assert.Equal(t, "admin", decoded.Decisions[0].UserID)
+}
+
+func TestGroupRequestArchive(t *testing.T) {
+	old := &model.GroupRequest{
+		GroupID:      "g1",
+		UserID:       "u1",
+		HandleResult: -1,
+		ReqMsg:       "first",
+		Decisions:    []*model.GroupRequestDecision{{UserID: "a1", Result: model.GroupRequestDecisionReject}},
+	}
+	request := &model.GroupRequest{GroupID: "g1", UserID: "u1", ReqMsg: "second"}
+	old.ArchiveTo(request)
+	assert.Len(t, request.History, 1)
+	assert.Equal(t, "first", request.History[0].ReqMsg)
+	assert.Equal(t, "a1", request.History[0].Decisions[0].UserID)
+	assert.Empty(t, request.Decisions)
+
+	audit := groupApplicationAuditDB2Ext(request, 1)
+	assert.Len(t, audit.History, 1)
+	assert.Equal(t, int32(-1), audit.History[0].HandleResult)
+
+	for i := 0; i < model.MaxGroupRequestHistory+5; i++ {
+		next := &model.GroupRequest{GroupID: "g1", UserID: "u1"}
+		request.ArchiveTo(next)
+		request = next
+	}
+	assert.Len(t, request.History, model.MaxGroupRequestHistory)
+}
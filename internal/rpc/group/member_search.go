@@ -0,0 +1,155 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/convert"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+const (
+	defaultGroupMemberSearchCount = 100
+	maxGroupMemberSearchCount     = 500
+)
+
+// groupMemberSortFields 接口排序字段到数据库字段的映射
+var groupMemberSortFields = map[string]string{
+	"joinTime":    model.GroupMemberSortJoinTime,
+	"roleLevel":   model.GroupMemberSortRoleLevel,
+	"muteEndTime": model.GroupMemberSortMuteEndTime,
+	"nickname":    model.GroupMemberSortNickname,
+}
+
+// groupMemberCursor 群成员搜索的翻页游标，记录上一页最后一个成员的排序字段值和用户ID
+type groupMemberCursor struct {
+	Field  string `json:"f"`
+	Int    int64  `json:"i,omitempty"`
+	Str    string `json:"s,omitempty"`
+	UserID string `json:"u"`
+}
+
+func encodeGroupMemberCursor(sortField string, member *model.GroupMember) string {
+	cursor := groupMemberCursor{Field: sortField, UserID: member.UserID}
+	switch sortField {
+	case model.GroupMemberSortJoinTime:
+		cursor.Int = member.JoinTime.UnixMilli()
+	case model.GroupMemberSortRoleLevel:
+		cursor.Int = int64(member.RoleLevel)
+	case model.GroupMemberSortMuteEndTime:
+		cursor.Int = member.MuteEndTime.UnixMilli()
+	case model.GroupMemberSortNickname:
+		cursor.Str = member.Nickname
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeGroupMemberCursor 解析翻页游标，返回排序字段值和用户ID，游标必须由相同排序字段的搜索生成
+func decodeGroupMemberCursor(sortField string, value string) (any, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, "", errs.ErrArgs.WrapMsg("invalid cursor")
+	}
+	var cursor groupMemberCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.UserID == "" || cursor.Field != sortField {
+		return nil, "", errs.ErrArgs.WrapMsg("invalid cursor")
+	}
+	switch sortField {
+	case model.GroupMemberSortJoinTime, model.GroupMemberSortMuteEndTime:
+		return time.UnixMilli(cursor.Int), cursor.UserID, nil
+	case model.GroupMemberSortRoleLevel:
+		return int32(cursor.Int), cursor.UserID, nil
+	default:
+		return cursor.Str, cursor.UserID, nil
+	}
+}
+
+// newGroupMemberSearch 将搜索请求转换为数据库查询条件
+func newGroupMemberSearch(req *groupext.SearchGroupMembersReq, now time.Time) (*model.GroupMemberSearch, error) {
+	search := &model.GroupMemberSearch{
+		GroupID:        req.GroupID,
+		Keyword:        req.Keyword,
+		RoleLevels:     req.RoleLevels,
+		RoleIDs:        req.RoleIDs,
+		InviterUserIDs: req.InviterUserIDs,
+		JoinSources:    req.JoinSources,
+		Muted:          req.Muted,
+		MuteTime:       now,
+		Ex:             req.Ex,
+		SortField:      model.GroupMemberSortJoinTime,
+		SortDesc:       req.SortDesc,
+		Limit:          defaultGroupMemberSearchCount,
+	}
+	if req.SortField != "" {
+		field, ok := groupMemberSortFields[req.SortField]
+		if !ok {
+			return nil, errs.ErrArgs.WrapMsg("invalid sortField", "sortField", req.SortField)
+		}
+		search.SortField = field
+	}
+	if req.Count < 0 || req.Count > maxGroupMemberSearchCount {
+		return nil, errs.ErrArgs.WrapMsg("invalid count", "max", maxGroupMemberSearchCount)
+	}
+	if req.Count > 0 {
+		search.Limit = int64(req.Count)
+	}
+	if req.JoinTimeBegin > 0 {
+		search.JoinTimeBegin = time.UnixMilli(req.JoinTimeBegin)
+	}
+	if req.JoinTimeEnd > 0 {
+		search.JoinTimeEnd = time.UnixMilli(req.JoinTimeEnd)
+	}
+	if req.Cursor != "" {
+		var err error
+		search.AfterValue, search.AfterUserID, err = decodeGroupMemberCursor(search.SortField, req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return search, nil
+}
+
+// SearchGroupMembers 按入群时间、禁言状态、邀请者、入群方式、Ex等条件在服务端过滤和排序群成员
+// 与GetGroupMemberList相同，只有群组成员或系统管理员可以查询
+func (g *groupServer) SearchGroupMembers(ctx context.Context, req *groupext.SearchGroupMembersReq) (*groupext.SearchGroupMembersResp, error) {
+	search, err := newGroupMemberSearch(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkAdminOrInGroup(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+	total, members, err := g.db.FilterGroupMembers(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	resp := &groupext.SearchGroupMembersResp{Total: total}
+	if len(members) == int(search.Limit) {
+		resp.NextCursor = encodeGroupMemberCursor(search.SortField, members[len(members)-1])
+	}
+	if err := g.PopulateGroupMember(ctx, members...); err != nil {
+		return nil, err
+	}
+	resp.Members = datautil.Batch(convert.Db2PbGroupMember, members)
+	return resp, nil
+}
@@ -0,0 +1,61 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupMemberCursor(t *testing.T) {
+	member := &model.GroupMember{
+		UserID:      "u1",
+		Nickname:    "alice",
+		RoleLevel:   60,
+		JoinTime:    time.UnixMilli(1000),
+		MuteEndTime: time.UnixMilli(2000),
+	}
+
+	value, userID, err := decodeGroupMemberCursor(model.GroupMemberSortJoinTime, encodeGroupMemberCursor(model.GroupMemberSortJoinTime, member))
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", userID)
+	assert.Equal(t, time.UnixMilli(1000), value)
+
+	value, _, err = decodeGroupMemberCursor(model.GroupMemberSortRoleLevel, encodeGroupMemberCursor(model.GroupMemberSortRoleLevel, member))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(60), value)
+
+	value, _, err = decodeGroupMemberCursor(model.GroupMemberSortNickname, encodeGroupMemberCursor(model.GroupMemberSortNickname, member))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", value)
+
+	// 游标与排序字段不一致
+	_, _, err = decodeGroupMemberCursor(model.GroupMemberSortNickname, encodeGroupMemberCursor(model.GroupMemberSortJoinTime, member))
+	assert.Error(t, err)
+	_, _, err = decodeGroupMemberCursor(model.GroupMemberSortJoinTime, "not a cursor")
+	assert.Error(t, err)
+}
+
+func TestNewGroupMemberSearch(t *testing.T) {
+	now := time.UnixMilli(5000)
+
+	search, err := newGroupMemberSearch(&groupext.SearchGroupMembersReq{GroupID: "g1", JoinTimeBegin: 1000}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, model.GroupMemberSortJoinTime, search.SortField)
+	assert.Equal(t, int64(defaultGroupMemberSearchCount), search.Limit)
+	assert.Equal(t, time.UnixMilli(1000), search.JoinTimeBegin)
+	assert.True(t, search.JoinTimeEnd.IsZero())
+	assert.Equal(t, now, search.MuteTime)
+
+	search, err = newGroupMemberSearch(&groupext.SearchGroupMembersReq{GroupID: "g1", SortField: "muteEndTime", Count: 10}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, model.GroupMemberSortMuteEndTime, search.SortField)
+	assert.Equal(t, int64(10), search.Limit)
+
+	_, err = newGroupMemberSearch(&groupext.SearchGroupMembersReq{GroupID: "g1", SortField: "unknown"}, now)
+	assert.Error(t, err)
+	_, err = newGroupMemberSearch(&groupext.SearchGroupMembersReq{GroupID: "g1", Count: maxGroupMemberSearchCount + 1}, now)
+	assert.Error(t, err)
+}
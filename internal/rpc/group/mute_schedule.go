@@ -0,0 +1,215 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+)
+
+// muteScheduleBatchSize 定时任务每批检查的群组数量
+const muteScheduleBatchSize = 500
+
+// groupMuteScheduleDB2Ext 将定时禁言计划转换为扩展RPC结构
+func groupMuteScheduleDB2Ext(schedule *model.GroupMuteSchedule) *groupext.GroupMuteSchedule {
+	if schedule == nil {
+		return nil
+	}
+	res := &groupext.GroupMuteSchedule{
+		Timezone: schedule.Timezone,
+		Windows:  make([]*groupext.GroupMuteWindow, 0, len(schedule.Windows)),
+	}
+	if !schedule.MuteUntil.IsZero() {
+		res.MuteUntil = schedule.MuteUntil.UnixMilli()
+	}
+	for _, w := range schedule.Windows {
+		res.Windows = append(res.Windows, &groupext.GroupMuteWindow{
+			StartMinute: w.StartMinute,
+			EndMinute:   w.EndMinute,
+			Weekdays:    w.Weekdays,
+		})
+	}
+	return res
+}
+
+// newGroupMuteSchedule 校验请求并生成定时禁言计划，没有任何禁言规则时返回nil
+func newGroupMuteSchedule(req *groupext.SetGroupMuteScheduleReq) (*model.GroupMuteSchedule, error) {
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return nil, errs.ErrArgs.WrapMsg("invalid timezone", "timezone", req.Timezone)
+		}
+	}
+	if len(req.Windows) > model.MaxGroupMuteWindows {
+		return nil, errs.ErrArgs.WrapMsg("too many mute windows", "count", len(req.Windows), "max", model.MaxGroupMuteWindows)
+	}
+	if req.MuteUntil < 0 {
+		return nil, errs.ErrArgs.WrapMsg("muteUntil must not be negative", "muteUntil", req.MuteUntil)
+	}
+	schedule := &model.GroupMuteSchedule{
+		Timezone: req.Timezone,
+		Windows:  make([]*model.GroupMuteWindow, 0, len(req.Windows)),
+	}
+	if req.MuteUntil > 0 {
+		schedule.MuteUntil = time.UnixMilli(req.MuteUntil)
+	}
+	for _, w := range req.Windows {
+		if w == nil {
+			return nil, errs.ErrArgs.WrapMsg("mute window is nil")
+		}
+		if w.StartMinute < 0 || w.StartMinute >= model.MinutesPerDay || w.EndMinute < 0 || w.EndMinute >= model.MinutesPerDay {
+			return nil, errs.ErrArgs.WrapMsg("mute window minute out of range", "startMinute", w.StartMinute, "endMinute", w.EndMinute)
+		}
+		if w.StartMinute == w.EndMinute {
+			return nil, errs.ErrArgs.WrapMsg("mute window must not be empty", "startMinute", w.StartMinute)
+		}
+		for _, d := range w.Weekdays {
+			if d < 0 || d > 6 {
+				return nil, errs.ErrArgs.WrapMsg("invalid weekday", "weekday", d)
+			}
+		}
+		schedule.Windows = append(schedule.Windows, &model.GroupMuteWindow{
+			StartMinute: w.StartMinute,
+			EndMinute:   w.EndMinute,
+			Weekdays:    w.Weekdays,
+		})
+	}
+	if schedule.Empty() {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
+// groupMuteScheduleNotification 发送定时禁言状态切换通知
+// 群组已被手动全员禁言时，定时计划的切换不改变实际禁言状态，不发送通知
+func (g *groupServer) groupMuteScheduleNotification(ctx context.Context, group *model.Group, muted bool) {
+	if group.Status == constant.GroupStatusMuted {
+		return
+	}
+	if muted {
+		g.notification.GroupMutedNotification(ctx, group.GroupID)
+	} else {
+		g.notification.GroupCancelMutedNotification(ctx, group.GroupID)
+	}
+}
+
+// SetGroupMuteSchedule 设置群组定时禁言计划
+// 整体替换原有计划，需要禁言成员权限；设置后若禁言状态立即发生变化则同时发送禁言/解禁通知
+func (g *groupServer) SetGroupMuteSchedule(ctx context.Context, req *groupext.SetGroupMuteScheduleReq) (*groupext.SetGroupMuteScheduleResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermMuteMember); err != nil {
+		return nil, err
+	}
+	schedule, err := newGroupMuteSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	muted := schedule.Muted(time.Now())
+	data := map[string]any{
+		"mute_schedule":        schedule,
+		"mute_schedule_active": muted,
+	}
+	if err := g.db.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	if muted != group.MuteScheduleActive {
+		g.groupMuteScheduleNotification(ctx, group, muted)
+	} else {
+		g.groupSettingsChangedNotification(ctx, req.GroupID)
+	}
+	return &groupext.SetGroupMuteScheduleResp{Muted: muted}, nil
+}
+
+// processGroupMuteSchedule 检查单个群组的定时禁言状态，状态切换时更新记录并发送通知
+// 一次性禁言到期后清除其结束时间，计划中不再有任何规则时整体清除
+func (g *groupServer) processGroupMuteSchedule(ctx context.Context, group *model.Group, now time.Time) (changed bool, muted bool, err error) {
+	schedule := group.MuteSchedule
+	muted = schedule.Muted(now)
+	data := make(map[string]any)
+	if muted != group.MuteScheduleActive {
+		data["mute_schedule_active"] = muted
+	}
+	if schedule != nil && !schedule.MuteUntil.IsZero() && !now.Before(schedule.MuteUntil) {
+		if len(schedule.Windows) == 0 {
+			data["mute_schedule"] = nil
+		} else {
+			data["mute_schedule.mute_until"] = time.Time{}
+		}
+	}
+	if len(data) == 0 {
+		return false, muted, nil
+	}
+	if err := g.db.UpdateGroup(ctx, group.GroupID, data); err != nil {
+		return false, muted, err
+	}
+	if muted == group.MuteScheduleActive {
+		return false, muted, nil
+	}
+	g.groupMuteScheduleNotification(ctx, group, muted)
+	return true, muted, nil
+}
+
+// ProcessGroupMuteSchedules 检查所有设置了定时禁言计划的群组，在禁言/解禁切换时发送通知
+// 仅系统管理员可调用，由定时任务周期执行；消息校验直接按计划计算禁言状态，不依赖本方法的执行时机
+func (g *groupServer) ProcessGroupMuteSchedules(ctx context.Context, req *groupext.ProcessGroupMuteSchedulesReq) (*groupext.ProcessGroupMuteSchedulesResp, error) {
+	if err := authverify.CheckAdmin(ctx, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	resp := &groupext.ProcessGroupMuteSchedulesResp{}
+	now := time.Now()
+	var afterGroupID string
+	for {
+		groups, err := g.db.FindMuteScheduledGroups(ctx, afterGroupID, muteScheduleBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			changed, muted, err := g.processGroupMuteSchedule(ctx, group, now)
+			if err != nil {
+				log.ZWarn(ctx, "process group mute schedule failed", err, "groupID", group.GroupID)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			if muted {
+				resp.MutedGroupIDs = append(resp.MutedGroupIDs, group.GroupID)
+			} else {
+				resp.UnmutedGroupIDs = append(resp.UnmutedGroupIDs, group.GroupID)
+			}
+		}
+		if len(groups) < muteScheduleBatchSize {
+			break
+		}
+		afterGroupID = groups[len(groups)-1].GroupID
+	}
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupMuteScheduleMuted(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+	// 2024-01-01为周一
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, loc)
+	}
+	schedule := &model.GroupMuteSchedule{
+		Timezone: "Asia/Shanghai",
+		Windows: []*model.GroupMuteWindow{
+			{StartMinute: 23 * 60, EndMinute: 7 * 60, Weekdays: []int32{1}},
+			{StartMinute: 12 * 60, EndMinute: 13 * 60},
+		},
+	}
+	assert.True(t, schedule.Muted(at(1, 23, 30)))
+	assert.True(t, schedule.Muted(at(2, 6, 59)))   // 周一开始的跨零点时段延续到周二
+	assert.False(t, schedule.Muted(at(2, 7, 0)))   // 结束时间不包含
+	assert.False(t, schedule.Muted(at(2, 23, 30))) // 周二不在生效星期内
+	assert.False(t, schedule.Muted(at(1, 6, 0)))   // 周日开始的时段不生效
+	assert.True(t, schedule.Muted(at(3, 12, 0)))
+	assert.False(t, schedule.Muted(at(3, 13, 0)))
+	// 同一时刻在UTC下不在时段内
+	schedule.Timezone = ""
+	assert.False(t, schedule.Muted(at(3, 12, 30)))
+
+	now := at(3, 9, 0)
+	oneOff := &model.GroupMuteSchedule{MuteUntil: now.Add(time.Hour)}
+	assert.True(t, oneOff.Muted(now))
+	assert.False(t, oneOff.Muted(now.Add(time.Hour)))
+
+	var empty *model.GroupMuteSchedule
+	assert.True(t, empty.Empty())
+	assert.False(t, empty.Muted(now))
+}
+
+func TestNewGroupMuteSchedule(t *testing.T) {
+	schedule, err := newGroupMuteSchedule(&groupext.SetGroupMuteScheduleReq{})
+	assert.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	schedule, err = newGroupMuteSchedule(&groupext.SetGroupMuteScheduleReq{
+		Timezone: "Asia/Shanghai",
+		Windows:  []*groupext.GroupMuteWindow{{StartMinute: 1380, EndMinute: 420}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, schedule.Windows, 1)
+
+	invalid := []*groupext.SetGroupMuteScheduleReq{
+		{Timezone: "Mars/Base"},
+		{MuteUntil: -1},
+		{Windows: []*groupext.GroupMuteWindow{{StartMinute: 60, EndMinute: 60}}},
+		{Windows: []*groupext.GroupMuteWindow{{StartMinute: 0, EndMinute: model.MinutesPerDay}}},
+		{Windows: []*groupext.GroupMuteWindow{{StartMinute: 0, EndMinute: 60, Weekdays: []int32{7}}}},
+		{Windows: make([]*groupext.GroupMuteWindow, model.MaxGroupMuteWindows+1)},
+	}
+	for _, req := range invalid {
+		_, err := newGroupMuteSchedule(req)
+		assert.Error(t, err)
+	}
+}
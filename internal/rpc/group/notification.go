@@ -1514,6 +1514,31 @@ func (g *NotificationSender) GroupApplicationAgreeMemberEnterNotification(ctx co
 	return g.groupApplicationAgreeMemberEnterNotification(ctx, groupID, SendMessage, invitedOpUserID, entrantUserID...)
 }
 
+// initMemberConversations 为新成员创建群组及其所有频道的会话
+// 未开启新成员查看历史消息时，将新成员在各会话中的起始seq设置为当前最大seq之后
+func (g *NotificationSender) initMemberConversations(ctx context.Context, groupID string, userIDs []string) error {
+	channels, err := g.db.FindGroupChannels(ctx, groupID, nil)
+	if err != nil {
+		return err
+	}
+	for _, id := range groupConversationGroupIDs(groupID, channels) {
+		if !g.config.RpcConfig.EnableHistoryForNewMembers {
+			conversationID := msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, id)
+			maxSeq, err := g.msgClient.GetConversationMaxSeq(ctx, conversationID)
+			if err != nil {
+				return err
+			}
+			if err := g.msgClient.SetUserConversationsMinSeq(ctx, conversationID, userIDs, maxSeq+1); err != nil {
+				return err
+			}
+		}
+		if err := g.conversationClient.CreateGroupChatConversations(ctx, id, userIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // groupApplicationAgreeMemberEnterNotification 群组申请同意成员进入通知（私有实现）
 //
 // 当群组申请被同意后，新成员正式加入群组时的完整通知处理逻辑。
@@ -1567,17 +1592,7 @@ func (g *NotificationSender) groupApplicationAgreeMemberEnterNotification(ctx co
 		}
 	}()
 
-	if !g.config.RpcConfig.EnableHistoryForNewMembers {
-		conversationID := msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, groupID)
-		maxSeq, err := g.msgClient.GetConversationMaxSeq(ctx, conversationID)
-		if err != nil {
-			return err
-		}
-		if err := g.msgClient.SetUserConversationsMinSeq(ctx, conversationID, entrantUserID, maxSeq+1); err != nil {
-			return err
-		}
-	}
-	if err := g.conversationClient.CreateGroupChatConversations(ctx, groupID, entrantUserID); err != nil {
+	if err := g.initMemberConversations(ctx, groupID, entrantUserID); err != nil {
 		return err
 	}
 
@@ -1619,17 +1634,7 @@ func (g *NotificationSender) MemberEnterNotification(ctx context.Context, groupI
 		}
 	}()
 
-	if !g.config.RpcConfig.EnableHistoryForNewMembers {
-		conversationID := msgprocessor.GetConversationIDBySessionType(constant.ReadGroupChatType, groupID)
-		maxSeq, err := g.msgClient.GetConversationMaxSeq(ctx, conversationID)
-		if err != nil {
-			return err
-		}
-		if err := g.msgClient.SetUserConversationsMinSeq(ctx, conversationID, []string{entrantUserID}, maxSeq+1); err != nil {
-			return err
-		}
-	}
-	if err := g.conversationClient.CreateGroupChatConversations(ctx, groupID, []string{entrantUserID}); err != nil {
+	if err := g.initMemberConversations(ctx, groupID, []string{entrantUserID}); err != nil {
 		return err
 	}
 	var group *sdkws.GroupInfo
@@ -0,0 +1,239 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// checkGroupRoleManager 自定义角色只能由群主或系统管理员管理，返回群组信息
+func (g *groupServer) checkGroupRoleManager(ctx context.Context, groupID string) (*model.Group, error) {
+	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
+		opMember, err := g.db.TakeGroupMember(ctx, groupID, mcontext.GetOpUserID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if opMember.RoleLevel != constant.GroupOwner {
+			return nil, errs.ErrNoPermission.WrapMsg("only group owner can manage group roles")
+		}
+	}
+	group, err := g.db.TakeGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func checkGroupRolePermissions(permissions int64) error {
+	if permissions < 0 || permissions&^model.GroupPermAll != 0 {
+		return errs.ErrArgs.WrapMsg("invalid permissions", "permissions", permissions, "all", model.GroupPermAll)
+	}
+	return nil
+}
+
+func groupRoleDB2Ext(role *model.GroupRole, userIDs []string) *groupext.GroupRole {
+	return &groupext.GroupRole{
+		RoleID:      role.RoleID,
+		Name:        role.Name,
+		Permissions: role.Permissions,
+		UserIDs:     userIDs,
+	}
+}
+
+// updateGroupRoles 保存群组的自定义角色，群组缓存随之失效，msg等服务通过本地缓存读取到新的权限
+func (g *groupServer) updateGroupRoles(ctx context.Context, groupID string, roles []*model.GroupRole) error {
+	if err := g.db.UpdateGroup(ctx, groupID, map[string]any{"roles": roles}); err != nil {
+		return err
+	}
+	g.groupSettingsChangedNotification(ctx, groupID)
+	return nil
+}
+
+// CreateGroupRole 创建群组自定义角色
+func (g *groupServer) CreateGroupRole(ctx context.Context, req *groupext.CreateGroupRoleReq) (*groupext.CreateGroupRoleResp, error) {
+	if err := checkGroupRolePermissions(req.Permissions); err != nil {
+		return nil, err
+	}
+	group, err := g.checkGroupRoleManager(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(group.Roles) >= model.MaxGroupRoles {
+		return nil, errs.ErrArgs.WrapMsg("too many group roles", "max", model.MaxGroupRoles)
+	}
+	roleID, err := genRandomToken(8)
+	if err != nil {
+		return nil, err
+	}
+	role := &model.GroupRole{RoleID: roleID, Name: req.Name, Permissions: req.Permissions}
+	if err := g.updateGroupRoles(ctx, req.GroupID, append(group.Roles, role)); err != nil {
+		return nil, err
+	}
+	return &groupext.CreateGroupRoleResp{Role: groupRoleDB2Ext(role, nil)}, nil
+}
+
+// UpdateGroupRole 修改群组自定义角色的名称或权限，拥有该角色的成员立即按新权限生效
+func (g *groupServer) UpdateGroupRole(ctx context.Context, req *groupext.UpdateGroupRoleReq) (*groupext.UpdateGroupRoleResp, error) {
+	if req.Permissions != nil {
+		if err := checkGroupRolePermissions(*req.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, errs.ErrArgs.WrapMsg("name is empty")
+	}
+	group, err := g.checkGroupRoleManager(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	index := datautil.IndexOf(req.RoleID, datautil.Slice(group.Roles, func(r *model.GroupRole) string { return r.RoleID })...)
+	if index < 0 {
+		return nil, errs.ErrRecordNotFound.WrapMsg("group role not found", "roleID", req.RoleID)
+	}
+	role := *group.Roles[index]
+	datautil.NotNilReplace(&role.Name, req.Name)
+	datautil.NotNilReplace(&role.Permissions, req.Permissions)
+	group.Roles[index] = &role
+	if err := g.updateGroupRoles(ctx, req.GroupID, group.Roles); err != nil {
+		return nil, err
+	}
+	return &groupext.UpdateGroupRoleResp{}, nil
+}
+
+// DeleteGroupRoles 删除群组自定义角色，拥有这些角色的成员回到RoleLevel对应的内置权限
+func (g *groupServer) DeleteGroupRoles(ctx context.Context, req *groupext.DeleteGroupRolesReq) (*groupext.DeleteGroupRolesResp, error) {
+	group, err := g.checkGroupRoleManager(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	roles := datautil.Filter(group.Roles, func(r *model.GroupRole) (*model.GroupRole, bool) {
+		return r, !datautil.Contain(r.RoleID, req.RoleIDs...)
+	})
+	if len(roles) == len(group.Roles) {
+		return &groupext.DeleteGroupRolesResp{}, nil
+	}
+	// 先删除角色定义使权限立即失效，再清理成员上的角色引用
+	if err := g.updateGroupRoles(ctx, req.GroupID, roles); err != nil {
+		return nil, err
+	}
+	members, err := g.db.FindGroupRoleMembers(ctx, req.GroupID, req.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if err := g.db.UpdateGroupMember(ctx, req.GroupID, member.UserID, map[string]any{"role_id": ""}); err != nil {
+			return nil, err
+		}
+	}
+	return &groupext.DeleteGroupRolesResp{}, nil
+}
+
+// GetGroupRoles 获取群组的自定义角色及拥有各角色的成员，群成员均可查看
+func (g *groupServer) GetGroupRoles(ctx context.Context, req *groupext.GetGroupRolesReq) (*groupext.GetGroupRolesResp, error) {
+	if !authverify.IsAppManagerUid(ctx, g.config.Share.IMAdminUserID) {
+		if _, err := g.db.TakeGroupMember(ctx, req.GroupID, mcontext.GetOpUserID(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := datautil.Slice(group.Roles, func(r *model.GroupRole) string { return r.RoleID })
+	members, err := g.db.FindGroupRoleMembers(ctx, req.GroupID, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	roleUserIDs := make(map[string][]string)
+	for _, member := range members {
+		roleUserIDs[member.RoleID] = append(roleUserIDs[member.RoleID], member.UserID)
+	}
+	resp := &groupext.GetGroupRolesResp{Roles: make([]*groupext.GroupRole, 0, len(group.Roles))}
+	for _, role := range group.Roles {
+		resp.Roles = append(resp.Roles, groupRoleDB2Ext(role, roleUserIDs[role.RoleID]))
+	}
+	return resp, nil
+}
+
+// SetGroupMemberRole 为成员设置或移除自定义角色，群主不需要自定义角色
+func (g *groupServer) SetGroupMemberRole(ctx context.Context, req *groupext.SetGroupMemberRoleReq) (*groupext.SetGroupMemberRoleResp, error) {
+	if datautil.Duplicate(req.UserIDs) {
+		return nil, errs.ErrArgs.WrapMsg("duplicate userIDs")
+	}
+	group, err := g.checkGroupRoleManager(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if req.RoleID != "" && !datautil.Contain(req.RoleID, datautil.Slice(group.Roles, func(r *model.GroupRole) string { return r.RoleID })...) {
+		return nil, errs.ErrRecordNotFound.WrapMsg("group role not found", "roleID", req.RoleID)
+	}
+	members, err := g.db.FindGroupMembers(ctx, req.GroupID, req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) != len(req.UserIDs) {
+		return nil, servererrs.ErrNotInGroupYet.WrapMsg("some users are not in the group")
+	}
+	for _, member := range members {
+		if member.RoleLevel == constant.GroupOwner {
+			return nil, errs.ErrArgs.WrapMsg("can not set role for group owner", "userID", member.UserID)
+		}
+	}
+	for _, member := range members {
+		if member.RoleID == req.RoleID {
+			continue
+		}
+		if err := g.db.UpdateGroupMember(ctx, req.GroupID, member.UserID, map[string]any{"role_id": req.RoleID}); err != nil {
+			return nil, err
+		}
+		g.notification.GroupMemberInfoSetNotification(ctx, req.GroupID, member.UserID)
+	}
+	return &groupext.SetGroupMemberRoleResp{}, nil
+}
+
+// GetGroupMemberPermissions 获取成员在群内的有效权限
+// 供msg等服务通过GroupLocalCache读取，不做操作者校验
+func (g *groupServer) GetGroupMemberPermissions(ctx context.Context, req *groupext.GetGroupMemberPermissionsReq) (*groupext.GetGroupMemberPermissionsResp, error) {
+	member, err := g.db.TakeGroupMember(ctx, req.GroupID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupMemberPermissionsResp{
+		GroupID:     member.GroupID,
+		UserID:      member.UserID,
+		RoleLevel:   member.RoleLevel,
+		RoleID:      member.RoleID,
+		Permissions: model.GroupMemberPermissions(group, member),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupMemberPermissions(t *testing.T) {
+	group := &model.Group{
+		AtAllPolicy: model.AtAllPolicyAdmin,
+		Roles: []*model.GroupRole{
+			{RoleID: "mod", Permissions: model.GroupPermKickMember | model.GroupPermRevokeMessage},
+		},
+	}
+	owner := &model.GroupMember{RoleLevel: constant.GroupOwner}
+	admin := &model.GroupMember{RoleLevel: constant.GroupAdmin}
+	member := &model.GroupMember{RoleLevel: constant.GroupOrdinaryUsers}
+	mod := &model.GroupMember{RoleLevel: constant.GroupOrdinaryUsers, RoleID: "mod"}
+	deleted := &model.GroupMember{RoleLevel: constant.GroupOrdinaryUsers, RoleID: "deleted"}
+
+	assert.Equal(t, model.GroupPermAll, model.GroupMemberPermissions(group, owner))
+	assert.Equal(t, model.GroupPermAll, model.GroupMemberPermissions(group, admin))
+	assert.Equal(t, int64(0), model.GroupMemberPermissions(group, member))
+	assert.Equal(t, model.GroupPermKickMember|model.GroupPermRevokeMessage, model.GroupMemberPermissions(group, mod))
+	assert.Equal(t, int64(0), model.GroupMemberPermissions(group, deleted))
+
+	group.AtAllPolicy = model.AtAllPolicyOwner
+	assert.Equal(t, model.GroupPermAll&^model.GroupPermAtAll, model.GroupMemberPermissions(group, admin))
+	group.AtAllPolicy = model.AtAllPolicyEveryone
+	assert.Equal(t, model.GroupPermAtAll, model.GroupMemberPermissions(group, member))
+}
+
+func TestCanManageGroupMember(t *testing.T) {
+	assert.True(t, model.CanManageGroupMember(constant.GroupOwner, constant.GroupAdmin))
+	assert.False(t, model.CanManageGroupMember(constant.GroupOwner, constant.GroupOwner))
+	assert.True(t, model.CanManageGroupMember(constant.GroupAdmin, constant.GroupOrdinaryUsers))
+	assert.False(t, model.CanManageGroupMember(constant.GroupAdmin, constant.GroupAdmin))
+	assert.True(t, model.CanManageGroupMember(constant.GroupOrdinaryUsers, constant.GroupOrdinaryUsers))
+	assert.False(t, model.CanManageGroupMember(constant.GroupOrdinaryUsers, constant.GroupOwner))
+}
+
+func TestCheckGroupRolePermissions(t *testing.T) {
+	assert.NoError(t, checkGroupRolePermissions(0))
+	assert.NoError(t, checkGroupRolePermissions(model.GroupPermAll))
+	assert.Error(t, checkGroupRolePermissions(-1))
+	assert.Error(t, checkGroupRolePermissions(model.GroupPermAll+1))
+}
@@ -0,0 +1,145 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+// groupSettingsDB2Ext 将群组模型中的扩展设置转换为扩展RPC结构
+func groupSettingsDB2Ext(group *model.Group) *groupext.GroupSettings {
+	settings := &groupext.GroupSettings{
+		GroupID:         group.GroupID,
+		AtAllPolicy:     group.AtAllPolicy,
+		MaxAtUserCount:  group.MaxAtUserCount,
+		SlowModeSeconds: group.SlowModeSeconds,
+		MuteSchedule:    groupMuteScheduleDB2Ext(group.MuteSchedule),
+		Archived:        group.Archived,
+		// 入群审批策略
+		JoinApprovalMode:         group.JoinApprovalMode,
+		JoinApprovalCount:        group.JoinApprovalCount,
+		JoinRequestExpireSeconds: group.JoinRequestExpireSeconds,
+	}
+	if group.Archived {
+		settings.ArchiveTime = group.ArchiveTime.UnixMilli()
+	}
+	return settings
+}
+
+// GetGroupSettings 获取群组扩展设置
+// 客户端调用时需要是群成员或系统管理员；msg、push等服务通过GroupLocalCache读取时不携带操作用户，跳过校验
+func (g *groupServer) GetGroupSettings(ctx context.Context, req *groupext.GetGroupSettingsReq) (*groupext.GetGroupSettingsResp, error) {
+	if mcontext.GetOpUserID(ctx) != "" {
+		if err := g.checkGroupMemberOrAppManager(ctx, req.GroupID); err != nil {
+			return nil, err
+		}
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetGroupSettingsResp{Settings: groupSettingsDB2Ext(group)}, nil
+}
+
+// SetGroupMentionPolicy 设置群组@提及策略
+// 包括允许@所有人的成员范围和单条消息最多@的成员数，需要修改群资料权限
+func (g *groupServer) SetGroupMentionPolicy(ctx context.Context, req *groupext.SetGroupMentionPolicyReq) (*groupext.SetGroupMentionPolicyResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermEditInfo); err != nil {
+		return nil, err
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any)
+	if req.AtAllPolicy != nil {
+		switch *req.AtAllPolicy {
+		case model.AtAllPolicyEveryone, model.AtAllPolicyAdmin, model.AtAllPolicyOwner:
+		default:
+			return nil, errs.ErrArgs.WrapMsg("invalid atAllPolicy", "atAllPolicy", *req.AtAllPolicy)
+		}
+		data["at_all_policy"] = *req.AtAllPolicy
+	}
+	if req.MaxAtUserCount != nil {
+		if *req.MaxAtUserCount < 0 {
+			return nil, errs.ErrArgs.WrapMsg("maxAtUserCount must not be negative", "maxAtUserCount", *req.MaxAtUserCount)
+		}
+		data["max_at_user_count"] = *req.MaxAtUserCount
+	}
+	if len(data) == 0 {
+		return &groupext.SetGroupMentionPolicyResp{}, nil
+	}
+	if err := g.db.UpdateGroup(ctx, req.GroupID, data); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.SetGroupMentionPolicyResp{}, nil
+}
+
+// SetGroupSlowMode 设置群组慢速模式
+// 开启后普通成员在间隔内只能发送一条消息，群主和管理员不受限制
+func (g *groupServer) SetGroupSlowMode(ctx context.Context, req *groupext.SetGroupSlowModeReq) (*groupext.SetGroupSlowModeResp, error) {
+	if _, err := g.CheckGroupPermission(ctx, req.GroupID, model.GroupPermEditInfo); err != nil {
+		return nil, err
+	}
+	if req.SlowModeSeconds < 0 || req.SlowModeSeconds > model.MaxSlowModeSeconds {
+		return nil, errs.ErrArgs.WrapMsg("invalid slowModeSeconds", "slowModeSeconds", req.SlowModeSeconds, "max", model.MaxSlowModeSeconds)
+	}
+	group, err := g.db.TakeGroup(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, servererrs.ErrDismissedAlready.Wrap()
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	if group.SlowModeSeconds == req.SlowModeSeconds {
+		return &groupext.SetGroupSlowModeResp{}, nil
+	}
+	if err := g.db.UpdateGroup(ctx, req.GroupID, map[string]any{"slow_mode_seconds": req.SlowModeSeconds}); err != nil {
+		return nil, err
+	}
+	g.groupSettingsChangedNotification(ctx, req.GroupID)
+	return &groupext.SetGroupSlowModeResp{}, nil
+}
+
+// groupSettingsChangedNotification 群组扩展设置变更后发送群信息变更通知
+// 扩展设置不在sdkws.GroupInfo中，客户端收到通知后通过get_group_settings重新拉取
+func (g *groupServer) groupSettingsChangedNotification(ctx context.Context, groupID string) {
+	groupInfo, err := g.notification.getGroupInfo(ctx, groupID)
+	if err != nil {
+		log.ZError(ctx, "groupSettingsChangedNotification getGroupInfo failed", err, "groupID", groupID)
+		return
+	}
+	g.notification.GroupInfoSetNotification(ctx, &sdkws.GroupInfoSetTips{Group: groupInfo})
+}
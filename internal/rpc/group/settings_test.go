@@ -0,0 +1,24 @@
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGroupSettingsMemberCheck(t *testing.T) {
+	g := newMemberCheckGroupServer(&memberCheckGroupDB{members: map[string]bool{"u1": true}})
+	req := &groupext.GetGroupSettingsReq{GroupID: "g1"}
+
+	for _, opUserID := range []string{"u1", "admin", ""} {
+		resp, err := g.GetGroupSettings(mcontext.WithOpUserIDContext(context.Background(), opUserID), req)
+		require.NoError(t, err, opUserID)
+		assert.Equal(t, int32(1), resp.Settings.AtAllPolicy)
+	}
+	_, err := g.GetGroupSettings(mcontext.WithOpUserIDContext(context.Background(), "u2"), req)
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+const (
+	// maxSharedGroupCountLimit 单次统计共同群组返回的最大人数
+	maxSharedGroupCountLimit = 500
+	// maxSharedGroupScanGroups 统计共同群组时最多扫描的群组数
+	maxSharedGroupScanGroups = 500
+	// maxSharedGroupMemberNum 成员数超过该值的群组不参与统计，大群成员之间关系较弱且聚合开销高
+	maxSharedGroupMemberNum = 2000
+)
+
+// GetSharedGroupCounts 统计与用户有共同群组的成员及共同群组数，按共同群组数降序返回
+// 由好友服务计算好友推荐时调用，只统计成员数不超过maxSharedGroupMemberNum的群组
+func (g *groupServer) GetSharedGroupCounts(ctx context.Context, req *groupext.GetSharedGroupCountsReq) (*groupext.GetSharedGroupCountsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.Limit <= 0 || req.Limit > maxSharedGroupCountLimit {
+		return nil, errs.ErrArgs.WrapMsg("invalid limit", "limit", req.Limit, "max", maxSharedGroupCountLimit)
+	}
+	groupIDs, err := g.db.FindJoinGroupID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	scanGroupIDs := make([]string, 0, min(len(groupIDs), maxSharedGroupScanGroups))
+	for _, groupID := range groupIDs {
+		if len(scanGroupIDs) >= maxSharedGroupScanGroups {
+			break
+		}
+		num, err := g.db.FindGroupMemberNum(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+		if num <= 1 || num > maxSharedGroupMemberNum {
+			continue
+		}
+		scanGroupIDs = append(scanGroupIDs, groupID)
+	}
+	counts, err := g.db.CountSharedGroups(ctx, scanGroupIDs, append([]string{req.UserID}, req.ExcludeUserIDs...), int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &groupext.GetSharedGroupCountsResp{
+		Users: datautil.Slice(counts, func(e *model.UserCount) *groupext.SharedGroupCount {
+			return &groupext.SharedGroupCount{UserID: e.UserID, Count: e.Count}
+		}),
+	}, nil
+}
@@ -0,0 +1,119 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/protocol/constant"
+	pbgroup "github.com/openimsdk/protocol/group"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// succeedGroupOwner 执行群主继任并发送群主转让或群组解散通知
+// oldOwnerUserID为空表示群组已没有群主记录；返回新群主，群组被解散时返回nil
+func (g *groupServer) succeedGroupOwner(ctx context.Context, group *model.Group, oldOwnerUserID string) (*model.GroupMember, error) {
+	newOwner, err := g.db.SucceedGroupOwner(ctx, group.GroupID, oldOwnerUserID, g.config.RpcConfig.OwnerSuccession.PromoteMember)
+	if err != nil {
+		return nil, err
+	}
+	if newOwner != nil {
+		g.notification.GroupOwnerTransferredNotification(ctx, &pbgroup.TransferGroupOwnerReq{
+			GroupID:        group.GroupID,
+			OldOwnerUserID: oldOwnerUserID,
+			NewOwnerUserID: newOwner.UserID,
+		})
+		return newOwner, nil
+	}
+	log.ZInfo(ctx, "no group owner successor, group dismissed", "groupID", group.GroupID, "oldOwnerUserID", oldOwnerUserID)
+	num, err := g.db.FindGroupMemberNum(ctx, group.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	group.Status = constant.GroupStatusDismissed
+	g.notification.GroupDismissedNotification(ctx, &sdkws.GroupDismissedTips{
+		Group:  g.groupDB2PB(group, "", num),
+		OpUser: &sdkws.GroupMemberFullInfo{},
+	}, nil)
+	return nil, nil
+}
+
+// succeedOrphanedGroupOwner 对单个群组执行群主继任
+// 仅处理群主账号已不存在或没有群主记录的群组，群主账号仍然存在时返回错误
+func (g *groupServer) succeedOrphanedGroupOwner(ctx context.Context, groupID string) (*groupext.GroupOwnerSuccession, error) {
+	group, err := g.db.TakeGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == constant.GroupStatusDismissed {
+		return nil, errs.ErrArgs.WrapMsg("group already dismissed", "groupID", groupID)
+	}
+	if err := checkGroupNotArchived(group); err != nil {
+		return nil, err
+	}
+	var oldOwnerUserID string
+	owner, err := g.db.TakeGroupOwner(ctx, groupID)
+	if err == nil {
+		users, err := g.userClient.GetUsersInfo(ctx, []string{owner.UserID})
+		if err != nil {
+			return nil, err
+		}
+		if len(users) > 0 {
+			return nil, errs.ErrArgs.WrapMsg("group owner account still exists", "groupID", groupID, "ownerUserID", owner.UserID)
+		}
+		oldOwnerUserID = owner.UserID
+	} else if !g.IsNotFound(err) {
+		return nil, err
+	}
+	newOwner, err := g.succeedGroupOwner(ctx, group, oldOwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if oldOwnerUserID != "" {
+		if err := g.deleteMemberAndSetConversationSeq(ctx, groupID, []string{oldOwnerUserID}); err != nil {
+			log.ZWarn(ctx, "set old owner conversation seq failed", err, "groupID", groupID, "userID", oldOwnerUserID)
+		}
+	}
+	res := &groupext.GroupOwnerSuccession{GroupID: groupID, OldOwnerUserID: oldOwnerUserID, Dismissed: newOwner == nil}
+	if newOwner != nil {
+		res.NewOwnerUserID = newOwner.UserID
+	}
+	return res, nil
+}
+
+// SucceedGroupOwners 为群主账号已删除的孤儿群组执行群主继任，仅系统管理员可调用
+// 各群组独立处理，单个群组失败不影响其他群组，失败原因记录在结果的errMsg中
+func (g *groupServer) SucceedGroupOwners(ctx context.Context, req *groupext.SucceedGroupOwnersReq) (*groupext.SucceedGroupOwnersResp, error) {
+	if err := authverify.CheckAdmin(ctx, g.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	groupIDs := datautil.Distinct(req.GroupIDs)
+	resp := &groupext.SucceedGroupOwnersResp{Results: make([]*groupext.GroupOwnerSuccession, 0, len(groupIDs))}
+	for _, groupID := range groupIDs {
+		res, err := g.succeedOrphanedGroupOwner(ctx, groupID)
+		if err != nil {
+			log.ZWarn(ctx, "succeed group owner failed", err, "groupID", groupID)
+			res = &groupext.GroupOwnerSuccession{GroupID: groupID, ErrMsg: err.Error()}
+		}
+		resp.Results = append(resp.Results, res)
+	}
+	return resp, nil
+}
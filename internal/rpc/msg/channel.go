@@ -0,0 +1,58 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/tools/errs"
+)
+
+// checkGroupChannel 校验频道消息：频道必须存在，只读频道仅允许群主、管理员和拥有管理频道权限的成员发言
+func (m *msgServer) checkGroupChannel(ctx context.Context, groupID, channelID, sendID string) error {
+	perms, err := m.groupMemberPermissions(ctx, groupID, channelID, sendID)
+	if err != nil {
+		return err
+	}
+	channel, err := m.GroupLocalCache.GetGroupChannel(ctx, groupID, channelID)
+	if err != nil {
+		return err
+	}
+	if !model.CanSendGroupChannelMessage(channel.ReadOnly, perms.RoleLevel, perms.Permissions) {
+		return servererrs.ErrGroupChannelReadOnly.WrapMsg("group channel is read-only", "groupID", groupID, "channelID", channelID)
+	}
+	return nil
+}
+
+// groupMemberPermissions 获取成员在群内的有效权限，channelID非空时在父群权限基础上应用频道的权限收回
+func (m *msgServer) groupMemberPermissions(ctx context.Context, groupID, channelID, userID string) (*groupext.GetGroupMemberPermissionsResp, error) {
+	perms, err := m.GroupLocalCache.GetGroupMemberPermissions(ctx, groupID, userID)
+	if err != nil || channelID == "" {
+		return perms, err
+	}
+	channel, err := m.GroupLocalCache.GetGroupChannel(ctx, groupID, channelID)
+	if err != nil {
+		if errs.ErrRecordNotFound.Is(err) {
+			return nil, servererrs.ErrGroupChannelNotFound.WrapMsg(err.Error())
+		}
+		return nil, err
+	}
+	res := *perms
+	res.Permissions = model.GroupChannelPermissions(perms.Permissions, perms.RoleLevel, channel.DeniedPermissions)
+	return &res, nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
+	pbmsg "github.com/openimsdk/protocol/msg"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+)
+
+const maxDeleteUserSentMsgsLimit = 1000
+
+// EraseUserSeqs 删除注销用户在各会话中的seq记录，仅系统管理员可调用
+func (m *msgServer) EraseUserSeqs(ctx context.Context, req *msgext.EraseUserSeqsReq) (*msgext.EraseUserSeqsResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	count, err := m.MsgDatabase.DeleteUserSeqs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &msgext.EraseUserSeqsResp{DeletedCount: count}, nil
+}
+
+// DeleteUserSentMsgs 物理删除用户发送的消息，仅系统管理员可调用
+// 被删除的消息内容置空，不会通知其他会话成员
+func (m *msgServer) DeleteUserSentMsgs(ctx context.Context, req *msgext.DeleteUserSentMsgsReq) (*msgext.DeleteUserSentMsgsResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	if req.Limit <= 0 || req.Limit > maxDeleteUserSentMsgsLimit {
+		return nil, errs.ErrArgs.WrapMsg("limit must be between 1 and 1000")
+	}
+	_, msgs, err := m.MsgDatabase.SearchMessage(ctx, &pbmsg.SearchMessageReq{
+		SendID:     req.UserID,
+		Pagination: &sdkws.RequestPagination{PageNumber: 1, ShowNumber: req.Limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+	conversationSeqs := make(map[string][]int64)
+	for _, msg := range msgs {
+		if msg.MsgData == nil {
+			continue
+		}
+		conversationID := msgprocessor.GetConversationIDByMsg(msg.MsgData)
+		conversationSeqs[conversationID] = append(conversationSeqs[conversationID], msg.MsgData.Seq)
+	}
+	var count int64
+	for conversationID, seqs := range conversationSeqs {
+		if err := m.MsgDatabase.DeleteMsgsPhysicalBySeqs(ctx, conversationID, seqs); err != nil {
+			return nil, err
+		}
+		count += int64(len(seqs))
+	}
+	return &msgext.DeleteUserSentMsgsResp{DeletedCount: count}, nil
+}
@@ -0,0 +1,69 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+)
+
+// checkGroupMention 校验群聊@消息是否符合群组的@提及策略
+// 1. 去除重复的@对象
+// 2. 检查发送者是否有@所有人权限（由群组AtAllPolicy和自定义角色共同决定，见model.GroupMemberPermissions，频道消息再应用频道的权限收回）
+// 3. 检查@的成员数是否超过上限（群组设置优先，未设置时使用全局配置）
+func (m *msgServer) checkGroupMention(ctx context.Context, msg *sdkws.MsgData) error {
+	if msg.ContentType != constant.AtText || len(msg.AtUserIDList) == 0 {
+		return nil
+	}
+	atAll := msgprocessor.IsAtAllMsg(msg)
+	atUserIDs := msgprocessor.GetAtUserIDs(msg)
+	if atAll {
+		msg.AtUserIDList = append([]string{constant.AtAllString}, atUserIDs...)
+	} else {
+		msg.AtUserIDList = atUserIDs
+	}
+
+	groupID, channelID := msgprocessor.ParseGroupChannelID(msg.GroupID)
+	settings, err := m.GroupLocalCache.GetGroupSettings(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if atAll {
+		perms, err := m.groupMemberPermissions(ctx, groupID, channelID, msg.SendID)
+		if err != nil {
+			return err
+		}
+		if perms.Permissions&model.GroupPermAtAll == 0 {
+			return servererrs.ErrAtAllNotAllowed.WrapMsg("sender is not allowed to @all in this group", "groupID", msg.GroupID, "atAllPolicy", settings.AtAllPolicy)
+		}
+	}
+	if limit := maxAtUserCount(settings.MaxAtUserCount, m.config.RpcConfig.MaxAtUserCount); limit > 0 && len(atUserIDs) > limit {
+		return servererrs.ErrTooManyAtUsers.WrapMsg("too many @ users in one message", "count", len(atUserIDs), "limit", limit)
+	}
+	return nil
+}
+
+// maxAtUserCount 计算单条消息允许@的最大成员数，群组设置为0时使用全局配置，返回0表示不限制
+func maxAtUserCount(groupLimit int32, defaultLimit int) int {
+	if groupLimit > 0 {
+		return int(groupLimit)
+	}
+	return defaultLimit
+}
@@ -0,0 +1,53 @@
+package msg
+
+import (
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanAtAll(t *testing.T) {
+	cases := []struct {
+		policy    int32
+		roleLevel int32
+		want      bool
+	}{
+		{model.AtAllPolicyEveryone, constant.GroupOrdinaryUsers, true},
+		{model.AtAllPolicyAdmin, constant.GroupOrdinaryUsers, false},
+		{model.AtAllPolicyAdmin, constant.GroupAdmin, true},
+		{model.AtAllPolicyAdmin, constant.GroupOwner, true},
+		{model.AtAllPolicyOwner, constant.GroupAdmin, false},
+		{model.AtAllPolicyOwner, constant.GroupOwner, true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, model.BuiltinGroupPermissions(c.roleLevel, c.policy)&model.GroupPermAtAll != 0, "policy=%d roleLevel=%d", c.policy, c.roleLevel)
+	}
+}
+
+func TestMaxAtUserCount(t *testing.T) {
+	assert.Equal(t, 100, maxAtUserCount(0, 100))
+	assert.Equal(t, 5, maxAtUserCount(5, 100))
+	assert.Equal(t, 0, maxAtUserCount(0, 0))
+}
+
+func TestGetAtUserIDs(t *testing.T) {
+	msg := &sdkws.MsgData{
+		ContentType:  constant.AtText,
+		AtUserIDList: []string{"u1", constant.AtAllString, "u2", "u1", constant.AtAllString},
+	}
+	assert.Equal(t, []string{"u1", "u2"}, msgprocessor.GetAtUserIDs(msg))
+	assert.True(t, msgprocessor.IsAtAllMsg(msg))
+	assert.True(t, msgprocessor.IsMentioned(msg, "u3"))
+
+	msg.AtUserIDList = []string{"u1"}
+	assert.False(t, msgprocessor.IsAtAllMsg(msg))
+	assert.True(t, msgprocessor.IsMentioned(msg, "u1"))
+	assert.False(t, msgprocessor.IsMentioned(msg, "u2"))
+
+	msg.ContentType = constant.Text
+	assert.False(t, msgprocessor.IsMentioned(msg, "u1"))
+}
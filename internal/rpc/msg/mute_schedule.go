@@ -0,0 +1,55 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+)
+
+// groupScheduleMuted 判断群组当前是否处于定时禁言中
+// 计划随群组扩展设置一起从GroupLocalCache读取，按当前时间实时计算，不依赖定时任务的通知时机
+func (m *msgServer) groupScheduleMuted(ctx context.Context, groupID string) (bool, error) {
+	settings, err := m.GroupLocalCache.GetGroupSettings(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+	return groupMuteScheduleExt2DB(settings.MuteSchedule).Muted(time.Now()), nil
+}
+
+// groupMuteScheduleExt2DB 将扩展RPC结构的定时禁言计划转换为模型，以复用模型中的计算逻辑
+func groupMuteScheduleExt2DB(schedule *groupext.GroupMuteSchedule) *model.GroupMuteSchedule {
+	if schedule == nil {
+		return nil
+	}
+	res := &model.GroupMuteSchedule{
+		Timezone: schedule.Timezone,
+		Windows:  make([]*model.GroupMuteWindow, 0, len(schedule.Windows)),
+	}
+	if schedule.MuteUntil > 0 {
+		res.MuteUntil = time.UnixMilli(schedule.MuteUntil)
+	}
+	for _, w := range schedule.Windows {
+		res.Windows = append(res.Windows, &model.GroupMuteWindow{
+			StartMinute: w.StartMinute,
+			EndMinute:   w.EndMinute,
+			Weekdays:    w.Weekdays,
+		})
+	}
+	return res
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
 	"github.com/openimsdk/protocol/constant"
 	"github.com/openimsdk/protocol/msg"
 	"github.com/openimsdk/protocol/sdkws"
@@ -119,30 +120,37 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 			// 群聊权限验证：根据群角色决定撤回权限
 
 			// 获取相关群成员信息（撤回请求者和消息发送者）
-			members, err := m.GroupLocalCache.GetGroupMemberInfoMap(ctx, msgs[0].GroupID,
+			// 频道消息按父群成员和频道内有效权限校验
+			groupID, channelID := msgprocessor.ParseGroupChannelID(msgs[0].GroupID)
+			// 已归档的群组历史消息只读，成员不能撤回
+			settings, err := m.GroupLocalCache.GetGroupSettings(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+			if settings.Archived {
+				return nil, servererrs.ErrGroupArchived.Wrap()
+			}
+			members, err := m.GroupLocalCache.GetGroupMemberInfoMap(ctx, groupID,
 				datautil.Distinct([]string{req.UserID, msgs[0].SendID}))
 			if err != nil {
 				return nil, err
 			}
 
-			// 如果撤回者不是消息发送者，需要检查管理权限
+			// 如果撤回者不是消息发送者，需要拥有撤回他人消息的权限
+			// 群主可以撤回任何人的消息，管理员和拥有自定义角色的成员只能撤回普通成员的消息
 			if req.UserID != msgs[0].SendID {
-				switch members[req.UserID].RoleLevel {
-				case constant.GroupOwner:
-					// 群主可以撤回任何人的消息
-
-				case constant.GroupAdmin:
-					// 群管理员可以撤回普通成员的消息，但不能撤回群主和其他管理员的消息
-					if sendMember, ok := members[msgs[0].SendID]; ok {
-						if sendMember.RoleLevel != constant.GroupOrdinaryUsers {
-							return nil, errs.ErrNoPermission.WrapMsg("no permission")
-						}
-					}
-
-				default:
-					// 普通成员只能撤回自己的消息
+				perms, err := m.groupMemberPermissions(ctx, groupID, channelID, req.UserID)
+				if err != nil {
+					return nil, err
+				}
+				if perms.Permissions&model.GroupPermRevokeMessage == 0 {
 					return nil, errs.ErrNoPermission.WrapMsg("no permission")
 				}
+				if sendMember, ok := members[msgs[0].SendID]; ok {
+					if !model.CanManageGroupMember(perms.RoleLevel, sendMember.RoleLevel) {
+						return nil, errs.ErrNoPermission.WrapMsg("no permission")
+					}
+				}
 			}
 
 			// 记录撤回者在群中的角色级别
@@ -35,26 +35,43 @@ import (
 // 这是整个消息系统的核心方法，负责处理所有类型的消息发送请求
 // 根据不同的会话类型（单聊、群聊、通知）分发到对应的处理流程
 func (m *msgServer) SendMsg(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
-	if req.MsgData != nil {
-		// 封装消息数据：生成服务器消息ID、设置发送时间、处理消息选项等
-		m.encapsulateMsgData(req.MsgData)
-
-		// 根据会话类型分发到不同的处理流程
-		switch req.MsgData.SessionType {
-		case constant.SingleChatType:
-			// 单聊消息处理：需要验证好友关系、黑名单等
-			return m.sendMsgSingleChat(ctx, req)
-		case constant.NotificationChatType:
-			// 通知消息处理：系统通知、业务通知等
-			return m.sendMsgNotification(ctx, req)
-		case constant.ReadGroupChatType:
-			// 群聊消息处理：需要验证群成员身份、禁言状态等
-			return m.sendMsgGroupChat(ctx, req)
-		default:
-			return nil, errs.ErrArgs.WrapMsg("unknown sessionType")
-		}
+	if req.MsgData == nil {
+		return nil, errs.ErrArgs.WrapMsg("msgData is nil")
+	}
+	// 渲染引用了消息模板的消息，之后按渲染后的消息类型处理
+	if err := m.renderTemplateMsg(ctx, req.MsgData); err != nil {
+		return nil, err
+	}
+	// 封装消息数据：生成服务器消息ID、设置发送时间、处理消息选项等
+	m.encapsulateMsgData(req.MsgData)
+
+	// 被封禁的用户不能发送消息
+	if err := m.checkSenderBan(ctx, req.MsgData); err != nil {
+		return nil, err
+	}
+
+	// 客户端超时重发：按(sendID, clientMsgID)去重，返回首次发送的结果
+	if m.needSendMsgDedup(req.MsgData) {
+		return m.sendMsgWithDedup(ctx, req, m.sendMsgBySessionType)
+	}
+	return m.sendMsgBySessionType(ctx, req)
+}
+
+// sendMsgBySessionType 根据会话类型分发到不同的处理流程
+func (m *msgServer) sendMsgBySessionType(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+	switch req.MsgData.SessionType {
+	case constant.SingleChatType:
+		// 单聊消息处理：需要验证好友关系、黑名单等
+		return m.sendMsgSingleChat(ctx, req)
+	case constant.NotificationChatType:
+		// 通知消息处理：系统通知、业务通知等
+		return m.sendMsgNotification(ctx, req)
+	case constant.ReadGroupChatType:
+		// 群聊消息处理：需要验证群成员身份、禁言状态等
+		return m.sendMsgGroupChat(ctx, req)
+	default:
+		return nil, errs.ErrArgs.WrapMsg("unknown sessionType")
 	}
-	return nil, errs.ErrArgs.WrapMsg("msgData is nil")
 }
 
 // sendMsgGroupChat 处理群聊消息发送
@@ -131,7 +148,9 @@ func (m *msgServer) setConversationAtInfo(nctx context.Context, msg *sdkws.MsgDa
 	}
 
 	// 获取群组所有成员ID列表
-	memberUserIDList, err := m.GroupLocalCache.GetGroupMemberIDs(ctx, msg.GroupID)
+	// 频道消息的成员即父群成员
+	groupID, _ := msgprocessor.ParseGroupChannelID(msg.GroupID)
+	memberUserIDList, err := m.GroupLocalCache.GetGroupMemberIDs(ctx, groupID)
 	if err != nil {
 		log.ZWarn(ctx, "GetGroupMemberIDs", err)
 		return
@@ -0,0 +1,135 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/protocol/constant"
+	pbmsg "github.com/openimsdk/protocol/msg"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// sendMsgDedupPollInterval 重发请求等待首次发送结果的轮询间隔
+	sendMsgDedupPollInterval = time.Millisecond * 50
+	// sendMsgDedupWaitTimeout 重发请求等待首次发送结果的最长时间，超时返回ErrMsgSendInProgress由客户端稍后重试
+	sendMsgDedupWaitTimeout = time.Second * 3
+)
+
+// needSendMsgDedup 判断消息是否需要发送去重
+// 只处理客户端发起的单聊和群聊消息，系统通知由服务端生成不存在超时重发
+func (m *msgServer) needSendMsgDedup(msg *sdkws.MsgData) bool {
+	if !m.config.RpcConfig.SendMsgDedup.Enable || m.config.RpcConfig.SendMsgDedup.Window <= 0 {
+		return false
+	}
+	if msg.SendID == "" || msg.ClientMsgID == "" {
+		return false
+	}
+	switch msg.SessionType {
+	case constant.SingleChatType, constant.ReadGroupChatType:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendMsgWithDedup 带幂等保护的消息发送
+//
+// 处理流程：
+// 1. 以(sendID, clientMsgID)在Redis中占位，记录本次生成的ServerMsgID和SendTime
+// 2. 占位成功：执行实际发送，成功后标记为已发送；失败则释放占位，允许客户端重试
+// 3. 占位失败：说明是重发请求，等待首次发送完成后返回首次的ServerMsgID/SendTime/Seq
+func (m *msgServer) sendMsgWithDedup(ctx context.Context, req *pbmsg.SendMsgReq, send func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error)) (*pbmsg.SendMsgResp, error) {
+	msgData := req.MsgData
+	record := &cache.SendMsgDedup{
+		ServerMsgID: msgData.ServerMsgID,
+		SendTime:    msgData.SendTime,
+		Status:      cache.SendMsgDedupPending,
+	}
+	exist, claimed, err := m.MsgDatabase.ClaimSendMsgDedup(ctx, msgData.SendID, msgData.ClientMsgID, record, m.config.RpcConfig.SendMsgDedup.WindowDuration())
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return m.replaySendMsg(ctx, req, exist, send)
+	}
+
+	// 标记消息已登记幂等记录，msgtransfer分配序列号后回写seq
+	if msgData.Options == nil {
+		msgData.Options = make(map[string]bool)
+	}
+	msgprocessor.WithOptions(msgData.Options, msgprocessor.WithSendMsgDedup())
+
+	resp, err := send(ctx, req)
+	if err != nil || resp == nil {
+		// 首次发送失败（或被接收方设置丢弃），释放占位，客户端重发时重新走发送流程
+		if delErr := m.MsgDatabase.DelSendMsgDedup(ctx, msgData.SendID, msgData.ClientMsgID); delErr != nil {
+			log.ZWarn(ctx, "DelSendMsgDedup failed", delErr, "sendID", msgData.SendID, "clientMsgID", msgData.ClientMsgID)
+		}
+		return resp, err
+	}
+	if err := m.MsgDatabase.SetSendMsgDedupSent(ctx, msgData.SendID, msgData.ClientMsgID); err != nil {
+		log.ZWarn(ctx, "SetSendMsgDedupSent failed", err, "sendID", msgData.SendID, "clientMsgID", msgData.ClientMsgID)
+	}
+	return resp, nil
+}
+
+// replaySendMsg 处理重发请求
+// 首次请求仍在发送中时轮询等待；首次请求失败释放了占位时，本次请求重新发送
+func (m *msgServer) replaySendMsg(ctx context.Context, req *pbmsg.SendMsgReq, exist *cache.SendMsgDedup, send func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error)) (*pbmsg.SendMsgResp, error) {
+	msgData := req.MsgData
+	deadline := time.Now().Add(sendMsgDedupWaitTimeout)
+	for exist.Status != cache.SendMsgDedupSent {
+		if time.Now().After(deadline) {
+			return nil, servererrs.ErrMsgSendInProgress.WrapMsg("message with the same clientMsgID is still being sent", "clientMsgID", msgData.ClientMsgID)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errs.Wrap(ctx.Err())
+		case <-time.After(sendMsgDedupPollInterval):
+		}
+		var err error
+		exist, err = m.MsgDatabase.GetSendMsgDedup(ctx, msgData.SendID, msgData.ClientMsgID)
+		if err != nil {
+			return nil, err
+		}
+		if exist == nil {
+			return m.sendMsgWithDedup(ctx, req, send)
+		}
+	}
+	log.ZInfo(ctx, "duplicate send msg replayed", "sendID", msgData.SendID, "clientMsgID", msgData.ClientMsgID, "serverMsgID", exist.ServerMsgID, "seq", exist.Seq)
+	resp := &pbmsg.SendMsgResp{
+		ServerMsgID: exist.ServerMsgID,
+		ClientMsgID: msgData.ClientMsgID,
+		SendTime:    exist.SendTime,
+	}
+	// SendMsgResp没有seq字段，序列号已分配时通过Modify返回带首次ServerMsgID/SendTime/Seq的消息
+	if exist.Seq > 0 {
+		modify := proto.Clone(msgData).(*sdkws.MsgData)
+		modify.ServerMsgID = exist.ServerMsgID
+		modify.SendTime = exist.SendTime
+		modify.Seq = exist.Seq
+		resp.Modify = modify
+	}
+	return resp, nil
+}
@@ -0,0 +1,208 @@
+package msg
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/protocol/constant"
+	pbmsg "github.com/openimsdk/protocol/msg"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dedupMsgDatabase 内存实现的发送幂等存储，语义与Redis Lua脚本一致
+type dedupMsgDatabase struct {
+	controller.CommonMsgDatabase
+	mu      sync.Mutex
+	records map[string]*cache.SendMsgDedup
+}
+
+func newDedupMsgDatabase() *dedupMsgDatabase {
+	return &dedupMsgDatabase{records: make(map[string]*cache.SendMsgDedup)}
+}
+
+func (d *dedupMsgDatabase) ClaimSendMsgDedup(_ context.Context, sendID string, clientMsgID string, record *cache.SendMsgDedup, _ time.Duration) (*cache.SendMsgDedup, bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if exist, ok := d.records[sendID+":"+clientMsgID]; ok {
+		v := *exist
+		return &v, false, nil
+	}
+	v := *record
+	d.records[sendID+":"+clientMsgID] = &v
+	return nil, true, nil
+}
+
+func (d *dedupMsgDatabase) GetSendMsgDedup(_ context.Context, sendID string, clientMsgID string) (*cache.SendMsgDedup, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	exist, ok := d.records[sendID+":"+clientMsgID]
+	if !ok {
+		return nil, nil
+	}
+	v := *exist
+	return &v, nil
+}
+
+func (d *dedupMsgDatabase) SetSendMsgDedupSent(_ context.Context, sendID string, clientMsgID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if exist, ok := d.records[sendID+":"+clientMsgID]; ok {
+		exist.Status = cache.SendMsgDedupSent
+	}
+	return nil
+}
+
+func (d *dedupMsgDatabase) DelSendMsgDedup(_ context.Context, sendID string, clientMsgID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.records, sendID+":"+clientMsgID)
+	return nil
+}
+
+func (d *dedupMsgDatabase) setSeq(sendID string, clientMsgID string, seq int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if exist, ok := d.records[sendID+":"+clientMsgID]; ok {
+		exist.Seq = seq
+	}
+}
+
+func newDedupMsgServer(db controller.CommonMsgDatabase) *msgServer {
+	conf := &Config{}
+	conf.RpcConfig.SendMsgDedup = config.SendMsgDedup{Enable: true, Window: 60}
+	return &msgServer{MsgDatabase: db, config: conf}
+}
+
+func newDedupSendReq(sendID string, clientMsgID string) *pbmsg.SendMsgReq {
+	msgData := &sdkws.MsgData{
+		SendID:      sendID,
+		RecvID:      "recv",
+		ClientMsgID: clientMsgID,
+		SessionType: constant.SingleChatType,
+		ContentType: constant.Text,
+	}
+	msgData.ServerMsgID = GetMsgID(sendID)
+	msgData.SendTime = time.Now().UnixMilli()
+	return &pbmsg.SendMsgReq{MsgData: msgData}
+}
+
+func TestSendMsgDedupConcurrentRetry(t *testing.T) {
+	db := newDedupMsgDatabase()
+	m := newDedupMsgServer(db)
+
+	var sendCount atomic.Int32
+	send := func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+		sendCount.Add(1)
+		assert.True(t, msgprocessor.Options(req.MsgData.Options).IsSendMsgDedup())
+		time.Sleep(time.Millisecond * 100)
+		return &pbmsg.SendMsgResp{ServerMsgID: req.MsgData.ServerMsgID, ClientMsgID: req.MsgData.ClientMsgID, SendTime: req.MsgData.SendTime}, nil
+	}
+
+	const retries = 20
+	var wg sync.WaitGroup
+	resps := make([]*pbmsg.SendMsgResp, retries)
+	errList := make([]error, retries)
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resps[i], errList[i] = m.sendMsgWithDedup(context.Background(), newDedupSendReq("user1", "client-msg-1"), send)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), sendCount.Load())
+	for i := 0; i < retries; i++ {
+		require.NoError(t, errList[i])
+		assert.Equal(t, resps[0].ServerMsgID, resps[i].ServerMsgID)
+		assert.Equal(t, resps[0].SendTime, resps[i].SendTime)
+		assert.Equal(t, "client-msg-1", resps[i].ClientMsgID)
+	}
+}
+
+func TestSendMsgDedupReplayWithSeq(t *testing.T) {
+	db := newDedupMsgDatabase()
+	m := newDedupMsgServer(db)
+	send := func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+		return &pbmsg.SendMsgResp{ServerMsgID: req.MsgData.ServerMsgID, ClientMsgID: req.MsgData.ClientMsgID, SendTime: req.MsgData.SendTime}, nil
+	}
+
+	first, err := m.sendMsgWithDedup(context.Background(), newDedupSendReq("user1", "client-msg-2"), send)
+	require.NoError(t, err)
+	assert.Nil(t, first.Modify)
+
+	// msgtransfer分配序列号后回写
+	db.setSeq("user1", "client-msg-2", 42)
+
+	replay, err := m.sendMsgWithDedup(context.Background(), newDedupSendReq("user1", "client-msg-2"), send)
+	require.NoError(t, err)
+	assert.Equal(t, first.ServerMsgID, replay.ServerMsgID)
+	assert.Equal(t, first.SendTime, replay.SendTime)
+	require.NotNil(t, replay.Modify)
+	assert.Equal(t, int64(42), replay.Modify.Seq)
+	assert.Equal(t, first.ServerMsgID, replay.Modify.ServerMsgID)
+}
+
+func TestSendMsgDedupReleaseOnFailure(t *testing.T) {
+	db := newDedupMsgDatabase()
+	m := newDedupMsgServer(db)
+
+	var sendCount atomic.Int32
+	sendErr := errs.New("mq unavailable")
+	send := func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+		if sendCount.Add(1) == 1 {
+			return nil, sendErr
+		}
+		return &pbmsg.SendMsgResp{ServerMsgID: req.MsgData.ServerMsgID, ClientMsgID: req.MsgData.ClientMsgID, SendTime: req.MsgData.SendTime}, nil
+	}
+
+	_, err := m.sendMsgWithDedup(context.Background(), newDedupSendReq("user1", "client-msg-3"), send)
+	assert.ErrorIs(t, err, sendErr)
+
+	retry := newDedupSendReq("user1", "client-msg-3")
+	resp, err := m.sendMsgWithDedup(context.Background(), retry, send)
+	require.NoError(t, err)
+	assert.Equal(t, retry.MsgData.ServerMsgID, resp.ServerMsgID)
+	assert.Equal(t, int32(2), sendCount.Load())
+}
+
+func TestSendMsgDedupWaitTimeout(t *testing.T) {
+	db := newDedupMsgDatabase()
+	m := newDedupMsgServer(db)
+
+	defer func(timeout time.Duration) { sendMsgDedupWaitTimeout = timeout }(sendMsgDedupWaitTimeout)
+	sendMsgDedupWaitTimeout = time.Millisecond * 100
+
+	req := newDedupSendReq("user1", "client-msg-4")
+	_, claimed, err := db.ClaimSendMsgDedup(context.Background(), "user1", "client-msg-4", &cache.SendMsgDedup{ServerMsgID: req.MsgData.ServerMsgID}, time.Minute)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	send := func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+		t.Fatal("duplicate request must not be sent")
+		return nil, nil
+	}
+	_, err = m.sendMsgWithDedup(context.Background(), newDedupSendReq("user1", "client-msg-4"), send)
+	assert.Error(t, err)
+}
+
+func TestNeedSendMsgDedup(t *testing.T) {
+	m := newDedupMsgServer(newDedupMsgDatabase())
+	assert.True(t, m.needSendMsgDedup(&sdkws.MsgData{SendID: "u", ClientMsgID: "c", SessionType: constant.SingleChatType}))
+	assert.True(t, m.needSendMsgDedup(&sdkws.MsgData{SendID: "u", ClientMsgID: "c", SessionType: constant.ReadGroupChatType}))
+	assert.False(t, m.needSendMsgDedup(&sdkws.MsgData{SendID: "u", ClientMsgID: "c", SessionType: constant.NotificationChatType}))
+	assert.False(t, m.needSendMsgDedup(&sdkws.MsgData{SendID: "u", SessionType: constant.SingleChatType}))
+
+	m.config.RpcConfig.SendMsgDedup.Enable = false
+	assert.False(t, m.needSendMsgDedup(&sdkws.MsgData{SendID: "u", ClientMsgID: "c", SessionType: constant.SingleChatType}))
+}
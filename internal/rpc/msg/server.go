@@ -22,6 +22,7 @@ import (
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/redis"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/webhook"
 	"github.com/openimsdk/protocol/sdkws"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/controller"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpccache"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
 	"github.com/openimsdk/protocol/constant"
 	"github.com/openimsdk/protocol/conversation"
 	"github.com/openimsdk/protocol/msg"
@@ -96,6 +98,9 @@ type msgServer struct {
 	// conversationClient 会话服务客户端
 	// 用于处理消息发送过程中的会话相关操作
 	conversationClient *rpcli.ConversationClient
+
+	// msgTemplateDB 消息模板存储
+	msgTemplateDB database.MsgTemplate
 }
 
 // addInterceptorHandler 添加消息拦截器
@@ -148,6 +153,12 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 
+	// 7.1 创建消息模板数据模型
+	msgTemplateDB, err := mgo.NewMsgTemplateMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
 	// 8. 获取其他服务的连接
 	userConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.User)
 	if err != nil {
@@ -182,6 +193,7 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		config:             config,
 		webhookClient:      webhook.NewWebhookClient(config.WebhooksConfig.URL),
 		conversationClient: conversationClient,
+		msgTemplateDB:      msgTemplateDB,
 	}
 
 	// 10. 初始化通知发送器
@@ -192,6 +204,7 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 
 	// 11. 注册gRPC服务
 	msg.RegisterMsgServer(server, s)
+	msgext.RegisterMsgExtServer(server, s)
 
 	return nil
 }
@@ -0,0 +1,56 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+)
+
+// checkGroupSlowMode 校验群组慢速模式
+// 群主和管理员不受限制；普通成员在间隔内重复发言时返回ErrGroupSlowMode，detail中携带剩余等待秒数
+func (m *msgServer) checkGroupSlowMode(ctx context.Context, msg *sdkws.MsgData, roleLevel int32) error {
+	if roleLevel == constant.GroupOwner || roleLevel == constant.GroupAdmin {
+		return nil
+	}
+	// 慢速模式设置来自父群，发言间隔按消息的GroupID计算，频道之间互不影响
+	groupID, _ := msgprocessor.ParseGroupChannelID(msg.GroupID)
+	settings, err := m.GroupLocalCache.GetGroupSettings(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if settings.SlowModeSeconds <= 0 {
+		return nil
+	}
+	remaining, err := m.MsgDatabase.ClaimGroupSlowMode(ctx, msg.GroupID, msg.SendID, time.Duration(settings.SlowModeSeconds)*time.Second)
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return servererrs.ErrGroupSlowMode.WithDetail("remainingSeconds=" + strconv.FormatInt(slowModeRemainingSeconds(remaining), 10)).Wrap()
+	}
+	return nil
+}
+
+// slowModeRemainingSeconds 剩余等待时间向上取整到秒，避免客户端按0秒重试
+func slowModeRemainingSeconds(remaining time.Duration) int64 {
+	return int64((remaining + time.Second - 1) / time.Second)
+}
@@ -256,8 +256,9 @@ func (m *msgServer) SearchMessage(ctx context.Context, req *msg.SearchMessageReq
 			// 单聊和通知类型：收集接收者ID
 			recvIDs = append(recvIDs, chatLog.MsgData.RecvID)
 		case constant.WriteGroupChatType, constant.ReadGroupChatType:
-			// 群聊类型：收集群组ID
-			groupIDs = append(groupIDs, chatLog.MsgData.GroupID)
+			// 群聊类型：收集群组ID，频道消息取父群ID
+			groupID, _ := msgprocessor.ParseGroupChannelID(chatLog.MsgData.GroupID)
+			groupIDs = append(groupIDs, groupID)
 		}
 	}
 
@@ -321,10 +322,11 @@ func (m *msgServer) SearchMessage(ctx context.Context, req *msg.SearchMessageReq
 			pbchatLog.RecvNickname = recvMap[chatLog.MsgData.RecvID]
 		case constant.ReadGroupChatType:
 			// 群聊：补全群组详细信息
-			groupInfo := groupMap[chatLog.MsgData.GroupID]
+			groupID, _ := msgprocessor.ParseGroupChannelID(chatLog.MsgData.GroupID)
+			groupInfo := groupMap[groupID]
 			pbchatLog.SenderFaceURL = groupInfo.FaceURL        // 群组头像
 			pbchatLog.GroupMemberCount = groupInfo.MemberCount // 实际成员数量
-			pbchatLog.RecvID = groupInfo.GroupID               // 接收方为群组ID
+			pbchatLog.RecvID = chatLog.MsgData.GroupID         // 接收方为群组ID（频道消息为频道群组ID）
 			pbchatLog.GroupName = groupInfo.GroupName          // 群组名称
 			pbchatLog.GroupOwner = groupInfo.OwnerUserID       // 群主ID
 			pbchatLog.GroupType = groupInfo.GroupType          // 群组类型
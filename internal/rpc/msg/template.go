@@ -0,0 +1,322 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+	"github.com/openimsdk/tools/utils/jsonutil"
+)
+
+func msgTemplateExt2DB(tpl *msgext.MsgTemplate) *model.MsgTemplate {
+	res := &model.MsgTemplate{
+		TemplateID:    tpl.TemplateID,
+		Name:          tpl.Name,
+		PayloadType:   tpl.PayloadType,
+		Params:        make([]*model.MsgTemplateParam, 0, len(tpl.Params)),
+		DefaultLocale: tpl.DefaultLocale,
+		Locales:       make(map[string]*model.MsgTemplateContent, len(tpl.Locales)),
+		Ex:            tpl.Ex,
+	}
+	for _, param := range tpl.Params {
+		if param == nil {
+			res.Params = append(res.Params, nil)
+			continue
+		}
+		res.Params = append(res.Params, &model.MsgTemplateParam{Name: param.Name, Type: param.Type, Required: param.Required})
+	}
+	for locale, content := range tpl.Locales {
+		res.Locales[locale] = msgTemplateContentExt2DB(content)
+	}
+	return res
+}
+
+func msgTemplateContentExt2DB(content *msgext.MsgTemplateContent) *model.MsgTemplateContent {
+	if content == nil {
+		return nil
+	}
+	return &model.MsgTemplateContent{
+		Title:    content.Title,
+		Text:     content.Text,
+		ImageURL: content.ImageURL,
+		URL:      content.URL,
+		Buttons: datautil.Slice(content.Buttons, func(b *msgext.MsgTemplateButton) *model.MsgTemplateButton {
+			if b == nil {
+				return nil
+			}
+			return &model.MsgTemplateButton{Text: b.Text, Action: b.Action, URL: b.URL}
+		}),
+	}
+}
+
+func msgTemplateDB2Ext(tpl *model.MsgTemplate) *msgext.MsgTemplate {
+	res := &msgext.MsgTemplate{
+		TemplateID:    tpl.TemplateID,
+		Name:          tpl.Name,
+		PayloadType:   tpl.PayloadType,
+		Params:        make([]*msgext.MsgTemplateParam, 0, len(tpl.Params)),
+		DefaultLocale: tpl.DefaultLocale,
+		Locales:       make(map[string]*msgext.MsgTemplateContent, len(tpl.Locales)),
+		Ex:            tpl.Ex,
+		CreateTime:    tpl.CreateTime.UnixMilli(),
+		UpdateTime:    tpl.UpdateTime.UnixMilli(),
+	}
+	for _, param := range tpl.Params {
+		res.Params = append(res.Params, &msgext.MsgTemplateParam{Name: param.Name, Type: param.Type, Required: param.Required})
+	}
+	for locale, content := range tpl.Locales {
+		res.Locales[locale] = msgTemplateContentDB2Ext(content)
+	}
+	return res
+}
+
+func msgTemplateContentDB2Ext(content *model.MsgTemplateContent) *msgext.MsgTemplateContent {
+	return &msgext.MsgTemplateContent{
+		Title:    content.Title,
+		Text:     content.Text,
+		ImageURL: content.ImageURL,
+		URL:      content.URL,
+		Buttons: datautil.Slice(content.Buttons, func(b *model.MsgTemplateButton) *msgext.MsgTemplateButton {
+			return &msgext.MsgTemplateButton{Text: b.Text, Action: b.Action, URL: b.URL}
+		}),
+	}
+}
+
+// CreateMsgTemplate 创建消息模板，仅系统管理员可用
+func (m *msgServer) CreateMsgTemplate(ctx context.Context, req *msgext.CreateMsgTemplateReq) (*msgext.CreateMsgTemplateResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	tpl := msgTemplateExt2DB(req.Template)
+	if err := validateMsgTemplate(tpl); err != nil {
+		return nil, err
+	}
+	if _, err := m.msgTemplateDB.Take(ctx, tpl.TemplateID); err == nil {
+		return nil, errs.ErrDuplicateKey.WrapMsg("template already exists", "templateID", tpl.TemplateID)
+	} else if !errs.ErrRecordNotFound.Is(err) {
+		return nil, err
+	}
+	tpl.CreateTime = time.Now()
+	tpl.UpdateTime = tpl.CreateTime
+	if err := m.msgTemplateDB.Create(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return &msgext.CreateMsgTemplateResp{}, nil
+}
+
+// UpdateMsgTemplate 整体替换消息模板的定义，仅系统管理员可用
+func (m *msgServer) UpdateMsgTemplate(ctx context.Context, req *msgext.UpdateMsgTemplateReq) (*msgext.UpdateMsgTemplateResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	tpl := msgTemplateExt2DB(req.Template)
+	if err := validateMsgTemplate(tpl); err != nil {
+		return nil, err
+	}
+	if _, err := m.msgTemplateDB.Take(ctx, tpl.TemplateID); err != nil {
+		return nil, err
+	}
+	data := map[string]any{
+		"name":           tpl.Name,
+		"payload_type":   tpl.PayloadType,
+		"params":         tpl.Params,
+		"default_locale": tpl.DefaultLocale,
+		"locales":        tpl.Locales,
+		"ex":             tpl.Ex,
+		"update_time":    time.Now(),
+	}
+	if err := m.msgTemplateDB.Update(ctx, tpl.TemplateID, data); err != nil {
+		return nil, err
+	}
+	return &msgext.UpdateMsgTemplateResp{}, nil
+}
+
+// DeleteMsgTemplates 删除消息模板，仅系统管理员可用
+func (m *msgServer) DeleteMsgTemplates(ctx context.Context, req *msgext.DeleteMsgTemplatesReq) (*msgext.DeleteMsgTemplatesResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if err := m.msgTemplateDB.Delete(ctx, req.TemplateIDs); err != nil {
+		return nil, err
+	}
+	return &msgext.DeleteMsgTemplatesResp{}, nil
+}
+
+// GetMsgTemplate 获取消息模板，仅系统管理员可用
+func (m *msgServer) GetMsgTemplate(ctx context.Context, req *msgext.GetMsgTemplateReq) (*msgext.GetMsgTemplateResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	tpl, err := m.msgTemplateDB.Take(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	return &msgext.GetMsgTemplateResp{Template: msgTemplateDB2Ext(tpl)}, nil
+}
+
+// SearchMsgTemplates 按模板ID或名称搜索消息模板，仅系统管理员可用
+func (m *msgServer) SearchMsgTemplates(ctx context.Context, req *msgext.SearchMsgTemplatesReq) (*msgext.SearchMsgTemplatesResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	total, templates, err := m.msgTemplateDB.Search(ctx, req.Keyword, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &msgext.SearchMsgTemplatesResp{Total: total, Templates: datautil.Slice(templates, msgTemplateDB2Ext)}, nil
+}
+
+// RenderMsgTemplate 使用参数渲染消息模板，供管理员预览，不发送消息
+func (m *msgServer) RenderMsgTemplate(ctx context.Context, req *msgext.RenderMsgTemplateReq) (*msgext.RenderMsgTemplateResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.TemplateID == "" {
+		return nil, errs.ErrArgs.WrapMsg("templateID is empty")
+	}
+	tpl, err := m.msgTemplateDB.Take(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	locale, content, err := renderMsgTemplate(tpl, req.Locale, req.Params)
+	if err != nil {
+		return nil, err
+	}
+	resp := &msgext.RenderMsgTemplateResp{
+		TemplateID:  tpl.TemplateID,
+		PayloadType: tpl.PayloadType,
+		Locale:      locale,
+		Text:        content.Text,
+	}
+	if tpl.PayloadType != model.MsgTemplatePayloadText {
+		resp.Card = msgTemplateContentDB2Ext(content)
+	}
+	return resp, nil
+}
+
+// renderTemplateMsg 发送前渲染引用了消息模板的消息
+// 1. TemplateMsg类型的消息：文本模板转为Text消息，卡片、按钮模板转为Custom消息
+// 2. 通知账号消息和业务通知的详情携带template时：渲染结果写入通知的text/ex或key/data
+func (m *msgServer) renderTemplateMsg(ctx context.Context, data *sdkws.MsgData) error {
+	switch data.ContentType {
+	case msgprocessor.TemplateMsg:
+		var elem msgprocessor.TemplateElem
+		if err := json.Unmarshal(data.Content, &elem); err != nil {
+			return errs.ErrArgs.WrapMsg("invalid template message content")
+		}
+		tpl, content, err := m.renderMsgTemplateElem(ctx, &elem)
+		if err != nil {
+			return err
+		}
+		if tpl.PayloadType == model.MsgTemplatePayloadText {
+			data.ContentType = constant.Text
+			data.Content = []byte(jsonutil.StructToJsonString(map[string]string{"content": content.Text}))
+			return nil
+		}
+		data.ContentType = constant.Custom
+		data.Content = []byte(jsonutil.StructToJsonString(map[string]string{
+			"data":        jsonutil.StructToJsonString(newMsgTemplateCardElem(tpl, content)),
+			"description": tpl.PayloadType,
+			"extension":   tpl.TemplateID,
+		}))
+		return nil
+	case constant.OANotification, constant.BusinessNotification:
+		var notification sdkws.NotificationElem
+		if err := json.Unmarshal(data.Content, &notification); err != nil {
+			return nil
+		}
+		var detail map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(notification.Detail), &detail); err != nil {
+			return nil
+		}
+		raw, ok := detail["template"]
+		if !ok {
+			return nil
+		}
+		var elem msgprocessor.TemplateElem
+		if err := json.Unmarshal(raw, &elem); err != nil {
+			return errs.ErrArgs.WrapMsg("invalid notification template")
+		}
+		tpl, content, err := m.renderMsgTemplateElem(ctx, &elem)
+		if err != nil {
+			return err
+		}
+		delete(detail, "template")
+		card := jsonutil.StructToJsonString(newMsgTemplateCardElem(tpl, content))
+		if data.ContentType == constant.OANotification {
+			detail["text"] = msgTemplateJSONString(content.Text)
+			if tpl.PayloadType != model.MsgTemplatePayloadText {
+				detail["ex"] = msgTemplateJSONString(card)
+			}
+		} else {
+			var key string
+			_ = json.Unmarshal(detail["key"], &key)
+			if key == "" {
+				detail["key"] = msgTemplateJSONString(tpl.TemplateID)
+			}
+			detail["data"] = msgTemplateJSONString(card)
+		}
+		notification.Detail = jsonutil.StructToJsonString(detail)
+		data.Content = []byte(jsonutil.StructToJsonString(&notification))
+	}
+	return nil
+}
+
+func (m *msgServer) renderMsgTemplateElem(ctx context.Context, elem *msgprocessor.TemplateElem) (*model.MsgTemplate, *model.MsgTemplateContent, error) {
+	if elem.TemplateID == "" {
+		return nil, nil, errs.ErrArgs.WrapMsg("templateID is empty")
+	}
+	tpl, err := m.msgTemplateDB.Take(ctx, elem.TemplateID)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, content, err := renderMsgTemplate(tpl, elem.Locale, elem.Params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tpl, content, nil
+}
+
+func newMsgTemplateCardElem(tpl *model.MsgTemplate, content *model.MsgTemplateContent) *msgprocessor.TemplateCardElem {
+	elem := &msgprocessor.TemplateCardElem{
+		TemplateID:  tpl.TemplateID,
+		PayloadType: tpl.PayloadType,
+		Text:        content.Text,
+	}
+	if tpl.PayloadType != model.MsgTemplatePayloadText {
+		elem.Title = content.Title
+		elem.ImageURL = content.ImageURL
+		elem.URL = content.URL
+		elem.Buttons = datautil.Slice(content.Buttons, func(b *model.MsgTemplateButton) *msgprocessor.TemplateButton {
+			return &msgprocessor.TemplateButton{Text: b.Text, Action: b.Action, URL: b.URL}
+		})
+	}
+	return elem
+}
+
+func msgTemplateJSONString(s string) json.RawMessage {
+	data, _ := json.Marshal(s)
+	return data
+}
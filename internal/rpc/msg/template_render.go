@@ -0,0 +1,204 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/errs"
+)
+
+const (
+	maxMsgTemplateButtons = 5
+	maxMsgTemplateParams  = 32
+)
+
+var (
+	// msgTemplateVarRegexp 匹配模板中的参数引用，形如{{name}}或{{ name }}
+	msgTemplateVarRegexp   = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	msgTemplateParamRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// validateMsgTemplate 校验模板定义
+// 1. 载荷类型、参数声明合法
+// 2. 默认语言存在，每个语言版本满足载荷类型的必填要求
+// 3. 内容中引用的参数都已声明
+func validateMsgTemplate(tpl *model.MsgTemplate) error {
+	if tpl.TemplateID == "" {
+		return errs.ErrArgs.WrapMsg("templateID is empty")
+	}
+	switch tpl.PayloadType {
+	case model.MsgTemplatePayloadText, model.MsgTemplatePayloadCard, model.MsgTemplatePayloadButton:
+	default:
+		return errs.ErrArgs.WrapMsg("invalid payloadType", "payloadType", tpl.PayloadType)
+	}
+	if len(tpl.Params) > maxMsgTemplateParams {
+		return errs.ErrArgs.WrapMsg("too many params", "max", maxMsgTemplateParams)
+	}
+	declared := make(map[string]struct{}, len(tpl.Params))
+	for _, param := range tpl.Params {
+		if param == nil || !msgTemplateParamRegexp.MatchString(param.Name) {
+			return errs.ErrArgs.WrapMsg("invalid param name")
+		}
+		if _, ok := declared[param.Name]; ok {
+			return errs.ErrArgs.WrapMsg("duplicate param", "name", param.Name)
+		}
+		switch param.Type {
+		case "":
+			param.Type = model.MsgTemplateParamString
+		case model.MsgTemplateParamString, model.MsgTemplateParamNumber, model.MsgTemplateParamURL:
+		default:
+			return errs.ErrArgs.WrapMsg("invalid param type", "name", param.Name, "type", param.Type)
+		}
+		declared[param.Name] = struct{}{}
+	}
+	if _, ok := tpl.Locales[tpl.DefaultLocale]; !ok {
+		return errs.ErrArgs.WrapMsg("defaultLocale has no content", "defaultLocale", tpl.DefaultLocale)
+	}
+	for locale, content := range tpl.Locales {
+		if content == nil {
+			return errs.ErrArgs.WrapMsg("empty locale content", "locale", locale)
+		}
+		for _, button := range content.Buttons {
+			if button == nil {
+				return errs.ErrArgs.WrapMsg("button is nil", "locale", locale)
+			}
+		}
+		if err := validateMsgTemplateContent(tpl.PayloadType, content); err != nil {
+			return errs.WrapMsg(err, "invalid locale content", "locale", locale)
+		}
+		for _, text := range msgTemplateContentFields(content) {
+			for _, match := range msgTemplateVarRegexp.FindAllStringSubmatch(*text, -1) {
+				if _, ok := declared[match[1]]; !ok {
+					return errs.ErrArgs.WrapMsg("undeclared param", "locale", locale, "name", match[1])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateMsgTemplateContent(payloadType string, content *model.MsgTemplateContent) error {
+	switch payloadType {
+	case model.MsgTemplatePayloadText:
+		if content.Text == "" {
+			return errs.ErrArgs.WrapMsg("text is required")
+		}
+	case model.MsgTemplatePayloadCard:
+		if content.Title == "" && content.Text == "" {
+			return errs.ErrArgs.WrapMsg("title or text is required")
+		}
+	case model.MsgTemplatePayloadButton:
+		if len(content.Buttons) == 0 || len(content.Buttons) > maxMsgTemplateButtons {
+			return errs.ErrArgs.WrapMsg("invalid button count", "count", len(content.Buttons), "max", maxMsgTemplateButtons)
+		}
+		for _, button := range content.Buttons {
+			if button.Text == "" {
+				return errs.ErrArgs.WrapMsg("button text is required")
+			}
+		}
+	}
+	return nil
+}
+
+// msgTemplateContentFields 返回内容中所有支持参数替换的字段
+func msgTemplateContentFields(content *model.MsgTemplateContent) []*string {
+	fields := []*string{&content.Title, &content.Text, &content.ImageURL, &content.URL}
+	for _, button := range content.Buttons {
+		if button == nil {
+			continue
+		}
+		fields = append(fields, &button.Text, &button.Action, &button.URL)
+	}
+	return fields
+}
+
+// selectMsgTemplateLocale 选择渲染使用的语言：完全匹配 > 语言前缀匹配（zh-CN匹配zh） > 默认语言
+func selectMsgTemplateLocale(tpl *model.MsgTemplate, locale string) string {
+	if _, ok := tpl.Locales[locale]; ok {
+		return locale
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		if _, ok := tpl.Locales[locale[:i]]; ok {
+			return locale[:i]
+		}
+	}
+	return tpl.DefaultLocale
+}
+
+// checkMsgTemplateParams 按参数声明校验调用方传入的参数
+func checkMsgTemplateParams(tpl *model.MsgTemplate, params map[string]string) error {
+	declared := make(map[string]*model.MsgTemplateParam, len(tpl.Params))
+	for _, param := range tpl.Params {
+		declared[param.Name] = param
+		value, ok := params[param.Name]
+		if !ok || value == "" {
+			if param.Required {
+				return errs.ErrArgs.WrapMsg("missing required param", "name", param.Name)
+			}
+			continue
+		}
+		switch param.Type {
+		case model.MsgTemplateParamNumber:
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return errs.ErrArgs.WrapMsg("param is not a number", "name", param.Name, "value", value)
+			}
+		case model.MsgTemplateParamURL:
+			u, err := url.Parse(value)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errs.ErrArgs.WrapMsg("param is not a valid url", "name", param.Name, "value", value)
+			}
+		}
+	}
+	for name := range params {
+		if _, ok := declared[name]; !ok {
+			return errs.ErrArgs.WrapMsg("unknown param", "name", name)
+		}
+	}
+	return nil
+}
+
+// renderMsgTemplate 校验参数并渲染指定语言的模板内容，返回实际使用的语言和渲染后的内容
+func renderMsgTemplate(tpl *model.MsgTemplate, locale string, params map[string]string) (string, *model.MsgTemplateContent, error) {
+	if err := checkMsgTemplateParams(tpl, params); err != nil {
+		return "", nil, err
+	}
+	locale = selectMsgTemplateLocale(tpl, locale)
+	src := tpl.Locales[locale]
+	content := &model.MsgTemplateContent{
+		Title:    src.Title,
+		Text:     src.Text,
+		ImageURL: src.ImageURL,
+		URL:      src.URL,
+		Buttons:  make([]*model.MsgTemplateButton, 0, len(src.Buttons)),
+	}
+	for _, button := range src.Buttons {
+		if button == nil {
+			continue
+		}
+		b := *button
+		content.Buttons = append(content.Buttons, &b)
+	}
+	for _, field := range msgTemplateContentFields(content) {
+		*field = msgTemplateVarRegexp.ReplaceAllStringFunc(*field, func(s string) string {
+			return params[msgTemplateVarRegexp.FindStringSubmatch(s)[1]]
+		})
+	}
+	return locale, content, nil
+}
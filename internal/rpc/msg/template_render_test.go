@@ -0,0 +1,146 @@
+package msg
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMsgTemplate() *model.MsgTemplate {
+	return &model.MsgTemplate{
+		TemplateID:  "order_shipped",
+		PayloadType: model.MsgTemplatePayloadButton,
+		Params: []*model.MsgTemplateParam{
+			{Name: "orderID", Required: true},
+			{Name: "amount", Type: model.MsgTemplateParamNumber},
+			{Name: "link", Type: model.MsgTemplateParamURL, Required: true},
+		},
+		DefaultLocale: "en",
+		Locales: map[string]*model.MsgTemplateContent{
+			"en": {
+				Title:   "Order {{orderID}} shipped",
+				Text:    "Total: {{ amount }}",
+				Buttons: []*model.MsgTemplateButton{{Text: "View", URL: "{{link}}"}},
+			},
+			"zh": {
+				Title:   "订单{{orderID}}已发货",
+				Buttons: []*model.MsgTemplateButton{{Text: "查看", URL: "{{link}}"}},
+			},
+		},
+	}
+}
+
+func TestValidateMsgTemplate(t *testing.T) {
+	tpl := newTestMsgTemplate()
+	assert.NoError(t, validateMsgTemplate(tpl))
+	assert.Equal(t, model.MsgTemplateParamString, tpl.Params[0].Type)
+
+	tpl = newTestMsgTemplate()
+	tpl.Locales["en"].Text = "{{unknown}}"
+	assert.Error(t, validateMsgTemplate(tpl))
+
+	tpl = newTestMsgTemplate()
+	tpl.DefaultLocale = "fr"
+	assert.Error(t, validateMsgTemplate(tpl))
+
+	tpl = newTestMsgTemplate()
+	tpl.Locales["zh"].Buttons = nil
+	assert.Error(t, validateMsgTemplate(tpl))
+
+	tpl = newTestMsgTemplate()
+	tpl.Params = append(tpl.Params, &model.MsgTemplateParam{Name: "orderID"})
+	assert.Error(t, validateMsgTemplate(tpl))
+
+	tpl = newTestMsgTemplate()
+	tpl.TemplateID = ""
+	assert.Error(t, validateMsgTemplate(tpl))
+
+	tpl = newTestMsgTemplate()
+	tpl.PayloadType = model.MsgTemplatePayloadCard
+	tpl.Locales["en"].Buttons = append(tpl.Locales["en"].Buttons, nil)
+	assert.Error(t, validateMsgTemplate(tpl))
+}
+
+func TestRenderMsgTemplate(t *testing.T) {
+	tpl := newTestMsgTemplate()
+	params := map[string]string{"orderID": "A1", "amount": "9.5", "link": "https://example.com/a1"}
+
+	locale, content, err := renderMsgTemplate(tpl, "zh-CN", params)
+	assert.NoError(t, err)
+	assert.Equal(t, "zh", locale)
+	assert.Equal(t, "订单A1已发货", content.Title)
+	assert.Equal(t, "https://example.com/a1", content.Buttons[0].URL)
+	assert.Equal(t, "{{link}}", tpl.Locales["zh"].Buttons[0].URL)
+
+	locale, content, err = renderMsgTemplate(tpl, "fr", params)
+	assert.NoError(t, err)
+	assert.Equal(t, "en", locale)
+	assert.Equal(t, "Total: 9.5", content.Text)
+
+	_, _, err = renderMsgTemplate(tpl, "en", map[string]string{"orderID": "A1"})
+	assert.Error(t, err)
+	_, _, err = renderMsgTemplate(tpl, "en", map[string]string{"orderID": "A1", "link": "ftp://x"})
+	assert.Error(t, err)
+	_, _, err = renderMsgTemplate(tpl, "en", map[string]string{"orderID": "A1", "link": "https://x", "amount": "abc"})
+	assert.Error(t, err)
+	_, _, err = renderMsgTemplate(tpl, "en", map[string]string{"orderID": "A1", "link": "https://x", "extra": "1"})
+	assert.Error(t, err)
+}
+
+type fakeMsgTemplateDB struct {
+	database.MsgTemplate
+	templates map[string]*model.MsgTemplate
+}
+
+func (f *fakeMsgTemplateDB) Take(ctx context.Context, templateID string) (*model.MsgTemplate, error) {
+	if tpl, ok := f.templates[templateID]; ok {
+		return tpl, nil
+	}
+	return nil, errs.ErrRecordNotFound.WrapMsg("template not found")
+}
+
+func TestRenderTemplateMsg(t *testing.T) {
+	tpl := newTestMsgTemplate()
+	m := &msgServer{msgTemplateDB: &fakeMsgTemplateDB{templates: map[string]*model.MsgTemplate{tpl.TemplateID: tpl}}}
+	ctx := context.Background()
+	elem := &msgprocessor.TemplateElem{TemplateID: tpl.TemplateID, Locale: "zh", Params: map[string]string{"orderID": "A1", "link": "https://example.com/a1"}}
+
+	data := &sdkws.MsgData{ContentType: msgprocessor.TemplateMsg, Content: []byte(jsonutil.StructToJsonString(elem))}
+	require.NoError(t, m.renderTemplateMsg(ctx, data))
+	assert.Equal(t, int32(constant.Custom), data.ContentType)
+	var custom struct {
+		Data      string `json:"data"`
+		Extension string `json:"extension"`
+	}
+	require.NoError(t, json.Unmarshal(data.Content, &custom))
+	assert.Equal(t, tpl.TemplateID, custom.Extension)
+	assert.Contains(t, custom.Data, "订单A1已发货")
+
+	detail := jsonutil.StructToJsonString(map[string]any{"template": elem})
+	data = &sdkws.MsgData{ContentType: constant.BusinessNotification, Content: []byte(jsonutil.StructToJsonString(&sdkws.NotificationElem{Detail: detail}))}
+	require.NoError(t, m.renderTemplateMsg(ctx, data))
+	var notification sdkws.NotificationElem
+	require.NoError(t, json.Unmarshal(data.Content, &notification))
+	var business map[string]string
+	require.NoError(t, json.Unmarshal([]byte(notification.Detail), &business))
+	assert.Equal(t, tpl.TemplateID, business["key"])
+	assert.Contains(t, business["data"], "https://example.com/a1")
+
+	data = &sdkws.MsgData{ContentType: msgprocessor.TemplateMsg, Content: []byte(`{"templateID":""}`)}
+	assert.Error(t, m.renderTemplateMsg(ctx, data))
+
+	content := []byte(`{"content":"hello"}`)
+	data = &sdkws.MsgData{ContentType: constant.Text, Content: content}
+	require.NoError(t, m.renderTemplateMsg(ctx, data))
+	assert.Equal(t, content, data.Content)
+}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
 	"github.com/openimsdk/tools/utils/datautil"
 	"github.com/openimsdk/tools/utils/encrypt"
 	"github.com/openimsdk/tools/utils/timeutil"
@@ -79,12 +81,12 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 			return err
 		}
 
-		// 4. 检查黑名单关系：如果被对方拉黑，则不能发送消息
-		black, err := m.FriendLocalCache.IsBlack(ctx, data.MsgData.SendID, data.MsgData.RecvID)
+		// 4. 检查黑名单关系：如果被对方拉黑且范围包含消息，则不能发送消息
+		scope, err := m.FriendLocalCache.GetBlackScope(ctx, data.MsgData.RecvID, data.MsgData.SendID)
 		if err != nil {
 			return err
 		}
-		if black {
+		if scope&model.BlackScopeMessage != 0 {
 			return servererrs.ErrBlockedByPeer.Wrap()
 		}
 
@@ -104,8 +106,11 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 	case constant.ReadGroupChatType:
 		// 群聊消息验证逻辑
 
+		// 频道消息的GroupID为"父群ID#频道ID"，群组相关校验均基于父群
+		groupID, channelID := msgprocessor.ParseGroupChannelID(data.MsgData.GroupID)
+
 		// 1. 获取群组信息
-		groupInfo, err := m.GroupLocalCache.GetGroupInfo(ctx, data.MsgData.GroupID)
+		groupInfo, err := m.GroupLocalCache.GetGroupInfo(ctx, groupID)
 		if err != nil {
 			return err
 		}
@@ -116,6 +121,18 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 			return servererrs.ErrDismissedAlready.Wrap()
 		}
 
+		// 已归档的群组只读，拒绝除系统通知外的所有消息，包括系统管理员发送的消息
+		if data.MsgData.ContentType > constant.NotificationEnd ||
+			data.MsgData.ContentType < constant.NotificationBegin {
+			settings, err := m.GroupLocalCache.GetGroupSettings(ctx, groupID)
+			if err != nil {
+				return err
+			}
+			if settings.Archived {
+				return servererrs.ErrGroupArchived.Wrap()
+			}
+		}
+
 		// 3. 超级群组跳过后续验证
 		if groupInfo.GroupType == constant.SuperGroup {
 			return nil
@@ -133,7 +150,7 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 		}
 
 		// 6. 检查用户是否为群成员
-		memberIDs, err := m.GroupLocalCache.GetGroupMemberIDMap(ctx, data.MsgData.GroupID)
+		memberIDs, err := m.GroupLocalCache.GetGroupMemberIDMap(ctx, groupID)
 		if err != nil {
 			return err
 		}
@@ -142,7 +159,7 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 		}
 
 		// 7. 获取群成员详细信息，检查权限和禁言状态
-		groupMemberInfo, err := m.GroupLocalCache.GetGroupMember(ctx, data.MsgData.GroupID, data.MsgData.SendID)
+		groupMemberInfo, err := m.GroupLocalCache.GetGroupMember(ctx, groupID, data.MsgData.SendID)
 		if err != nil {
 			if errs.ErrRecordNotFound.Is(err) {
 				return servererrs.ErrNotInGroupYet.WrapMsg(err.Error())
@@ -150,21 +167,49 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 			return err
 		}
 
-		// 8. 群主拥有最高权限，跳过禁言检查
+		// 频道消息：检查频道是否存在以及只读频道的发言权限
+		if channelID != "" {
+			if err := m.checkGroupChannel(ctx, groupID, channelID, data.MsgData.SendID); err != nil {
+				return err
+			}
+		}
+
+		// 8. 检查@提及策略：@所有人权限和@人数上限
+		if err := m.checkGroupMention(ctx, data.MsgData); err != nil {
+			return err
+		}
+
+		// 9. 群主拥有最高权限，跳过禁言检查
 		if groupMemberInfo.RoleLevel == constant.GroupOwner {
 			return nil
 		} else {
-			// 9. 检查个人禁言状态
+			// 10. 检查个人禁言状态
 			if groupMemberInfo.MuteEndTime >= time.Now().UnixMilli() {
 				return servererrs.ErrMutedInGroup.Wrap()
 			}
 
-			// 10. 检查群组禁言状态（管理员不受群组禁言影响）
-			if groupInfo.Status == constant.GroupStatusMuted && groupMemberInfo.RoleLevel != constant.GroupAdmin {
-				return servererrs.ErrMutedGroup.Wrap()
+			// 11. 检查群组禁言状态，包括手动全员禁言和定时禁言
+			// 拥有禁言权限的成员（管理员或自定义角色）不受群组禁言影响
+			muted := groupInfo.Status == constant.GroupStatusMuted
+			if !muted {
+				muted, err = m.groupScheduleMuted(ctx, groupID)
+				if err != nil {
+					return err
+				}
+			}
+			if muted {
+				perms, err := m.GroupLocalCache.GetGroupMemberPermissions(ctx, groupID, data.MsgData.SendID)
+				if err != nil {
+					return err
+				}
+				if perms.Permissions&model.GroupPermMuteMember == 0 {
+					return servererrs.ErrMutedGroup.Wrap()
+				}
 			}
 		}
-		return nil
+
+		// 12. 检查慢速模式（放在最后，避免被其他校验拒绝的消息占用发言间隔）
+		return m.checkGroupSlowMode(ctx, data.MsgData, groupMemberInfo.RoleLevel)
 
 	default:
 		// 其他类型的会话（如通知消息）不需要验证
@@ -172,6 +217,28 @@ func (m *msgServer) messageVerification(ctx context.Context, data *msg.SendMsgRe
 	}
 }
 
+// checkSenderBan 检查消息发送者是否处于封禁状态
+// 系统通知和管理员发送的消息不受封禁限制；封禁状态来自用户本地缓存，封禁/解封时随用户信息一同失效
+func (m *msgServer) checkSenderBan(ctx context.Context, data *sdkws.MsgData) error {
+	if data.SessionType == constant.NotificationChatType {
+		return nil
+	}
+	if data.ContentType <= constant.NotificationEnd && data.ContentType >= constant.NotificationBegin {
+		return nil
+	}
+	if datautil.Contain(data.SendID, m.config.Share.IMAdminUserID...) {
+		return nil
+	}
+	ban, err := m.UserLocalCache.GetUserBan(ctx, data.SendID)
+	if err != nil {
+		return err
+	}
+	if !ban.IsActive(time.Now()) {
+		return nil
+	}
+	return servererrs.ErrUserBanned.WrapMsg("sender is banned", "sendID", data.SendID, "reason", ban.Reason, "expireTime", ban.ExpireTime)
+}
+
 // encapsulateMsgData 封装消息数据
 // 为消息设置服务器端的必要信息，如消息ID、发送时间、消息选项等
 func (m *msgServer) encapsulateMsgData(msg *sdkws.MsgData) {
@@ -230,6 +297,9 @@ func (m *msgServer) encapsulateMsgData(msg *sdkws.MsgData) {
 		datautil.SetSwitchFromOptions(msg.Options, constant.IsUnreadCount, false)
 		datautil.SetSwitchFromOptions(msg.Options, constant.IsOfflinePush, false)
 	}
+
+	// 通知会话的消息走高优先级通道，避免被大批量聊天消息阻塞；通道按会话划分，会话内保持顺序
+	msgprocessor.SetMsgPriority(msg)
 }
 
 // GetMsgID 生成唯一的消息ID
@@ -279,7 +349,8 @@ func (m *msgServer) modifyMessageByUserMessageReceiveOpt(ctx context.Context, us
 			return true, nil
 		}
 		return false, nil
-	case constant.ReceiveNotNotifyMessage:
+	case constant.ReceiveNotNotifyMessage, msgprocessor.ReceiveMentionOnlyMessage:
+		// 单聊消息没有@，"仅被@时通知"等同于接收但不推送
 		if pb.MsgData.Options == nil {
 			pb.MsgData.Options = make(map[string]bool, 10)
 		}
@@ -93,8 +93,8 @@ func (s *friendServer) GetPaginationBlacks(ctx context.Context, req *relation.Ge
 	resp = &relation.GetPaginationBlacksResp{}
 
 	// 4. 转换黑名单数据并填充用户信息
-	// convert.BlackDB2Pb方法将数据库模型转换为API格式，同时通过GetUsersInfoMap获取用户基本信息
-	resp.Blacks, err = convert.BlackDB2Pb(ctx, blacks, s.userClient.GetUsersInfoMap)
+	// convert.BlackDB2Pb方法将数据库模型转换为API格式，同时获取按请求者可见范围过滤的用户信息
+	resp.Blacks, err = convert.BlackDB2Pb(ctx, blacks, s.userClient.GetVisibleUsersInfoMap)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +176,8 @@ func (s *friendServer) RemoveBlack(ctx context.Context, req *relation.RemoveBlac
 
 	// 2. 从数据库中删除黑名单记录
 	// 构造黑名单模型并调用Delete方法删除
-	if err := s.blackDatabase.Delete(ctx, []*model.Black{{OwnerUserID: req.OwnerUserID, BlockUserID: req.BlackUserID}}); err != nil {
+	events := friendEvents(ctx, model.FriendEventUnblock, model.FriendEventSourceRemoveBlack, req.OwnerUserID, req.BlackUserID)
+	if err := s.blackDatabase.Delete(ctx, []*model.Black{{OwnerUserID: req.OwnerUserID, BlockUserID: req.BlackUserID}}, events); err != nil {
 		return nil, err
 	}
 
@@ -244,11 +245,13 @@ func (s *friendServer) AddBlack(ctx context.Context, req *relation.AddBlackReq)
 		OperatorUserID: mcontext.GetOpUserID(ctx), // 操作者（通常是拥有者本人）
 		CreateTime:     time.Now(),                // 创建时间
 		Ex:             req.Ex,                    // 扩展字段
+		Scope:          model.DefaultBlackScope,   // 生效范围，可通过SetBlackScope修改
 	}
 
 	// 5. 将黑名单记录保存到数据库
 	// 使用Create方法持久化存储黑名单关系
-	if err := s.blackDatabase.Create(ctx, []*model.Black{&black}); err != nil {
+	events := friendEvents(ctx, model.FriendEventBlock, model.FriendEventSourceAddBlack, req.OwnerUserID, req.BlackUserID)
+	if err := s.blackDatabase.Create(ctx, []*model.Black{&black}, events); err != nil {
 		return nil, err
 	}
 
@@ -307,8 +310,8 @@ func (s *friendServer) GetSpecifiedBlacks(ctx context.Context, req *relation.Get
 	}
 
 	// 4. 批量获取用户基本信息
-	// 获取所有待检查用户的基本信息，用于后续组装响应数据
-	userMap, err := s.userClient.GetUsersInfoMap(ctx, req.UserIDList)
+	// 获取所有待检查用户的基本信息，用于后续组装响应数据，用户资料按请求者的可见范围过滤
+	userMap, err := s.userClient.GetVisibleUsersInfoMap(ctx, req.UserIDList)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,95 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// maxCheckBlackScopePairs 单次CheckBlackScopes最多查询的用户对数
+const maxCheckBlackScopePairs = 1000
+
+func blackScopeDB2Ext(black *model.Black) *relationext.BlackScope {
+	return &relationext.BlackScope{
+		OwnerUserID: black.OwnerUserID,
+		BlockUserID: black.BlockUserID,
+		Scope:       black.EffectiveScope(),
+	}
+}
+
+// checkBlackScope 判断blockUserID是否在ownerUserID的黑名单中且范围包含scope
+func (s *friendServer) checkBlackScope(ctx context.Context, ownerUserID, blockUserID string, scope int32) (bool, error) {
+	blacks, err := s.blackDatabase.FindBlacksIn(ctx, []string{ownerUserID}, []string{blockUserID})
+	if err != nil {
+		return false, err
+	}
+	for _, black := range blacks {
+		if black.EffectiveScope()&scope != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SetBlackScope 修改黑名单的生效范围
+// 范围至少包含一项，移出黑名单仍使用RemoveBlack
+func (s *friendServer) SetBlackScope(ctx context.Context, req *relationext.SetBlackScopeReq) (*relationext.SetBlackScopeResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.Scope <= 0 || req.Scope&^model.BlackScopeAll != 0 {
+		return nil, errs.ErrArgs.WrapMsg("invalid scope", "scope", req.Scope)
+	}
+	if err := s.blackDatabase.UpdateScope(ctx, req.OwnerUserID, req.BlackUserID, req.Scope); err != nil {
+		if mgo.IsNotFound(err) {
+			return nil, servererrs.ErrNotInBlackList.WrapMsg("user not in blacklist", "blackUserID", req.BlackUserID)
+		}
+		return nil, err
+	}
+	return &relationext.SetBlackScopeResp{}, nil
+}
+
+// GetBlackScopes 获取用户黑名单的生效范围
+func (s *friendServer) GetBlackScopes(ctx context.Context, req *relationext.GetBlackScopesReq) (*relationext.GetBlackScopesResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	blacks, err := s.blackDatabase.FindBlackInfos(ctx, req.OwnerUserID, req.BlackUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &relationext.GetBlackScopesResp{Scopes: datautil.Slice(blacks, blackScopeDB2Ext)}, nil
+}
+
+// CheckBlackScopes 批量查询用户之间的黑名单范围，供其他服务校验黑名单时调用
+// 与IsBlack一样不校验调用者身份，调用方需自行确保不会把结果返回给无关用户
+func (s *friendServer) CheckBlackScopes(ctx context.Context, req *relationext.CheckBlackScopesReq) (*relationext.CheckBlackScopesResp, error) {
+	if len(req.OwnerUserIDs)*len(req.BlockUserIDs) > maxCheckBlackScopePairs {
+		return nil, errs.ErrArgs.WrapMsg("too many user pairs", "max", maxCheckBlackScopePairs)
+	}
+	blacks, err := s.blackDatabase.FindBlacksIn(ctx, datautil.Distinct(req.OwnerUserIDs), datautil.Distinct(req.BlockUserIDs))
+	if err != nil {
+		return nil, err
+	}
+	return &relationext.CheckBlackScopesResp{Scopes: datautil.Slice(blacks, blackScopeDB2Ext)}, nil
+}
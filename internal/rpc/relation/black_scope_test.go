@@ -0,0 +1,24 @@
+package relation
+
+import (
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlackScopeDB2Ext(t *testing.T) {
+	// 引入范围前的记录没有scope字段，按默认范围只禁止发送消息
+	legacy := blackScopeDB2Ext(&model.Black{OwnerUserID: "u1", BlockUserID: "u2"})
+	assert.Equal(t, "u1", legacy.OwnerUserID)
+	assert.Equal(t, "u2", legacy.BlockUserID)
+	assert.Equal(t, model.BlackScopeMessage, legacy.Scope)
+
+	presence := blackScopeDB2Ext(&model.Black{Scope: model.BlackScopePresence})
+	assert.Equal(t, model.BlackScopePresence, presence.Scope)
+	assert.Zero(t, presence.Scope&model.BlackScopeMessage)
+
+	// 未定义的范围位被忽略
+	all := blackScopeDB2Ext(&model.Black{Scope: model.BlackScopeAll | 1<<10})
+	assert.Equal(t, model.BlackScopeAll, all.Scope)
+}
@@ -0,0 +1,399 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/openimsdk/open-im-server/v3/internal/rpc/incrversion"
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/convert"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// maxFriendCategoryIDLen 自定义分组ID的最大长度
+const maxFriendCategoryIDLen = 64
+
+func genFriendCategoryID() (string, error) {
+	data := make([]byte, 8)
+	if _, err := rand.Read(data); err != nil {
+		return "", errs.WrapMsg(err, "generate friend category id failed")
+	}
+	return hex.EncodeToString(data), nil
+}
+
+func friendCategoryDB2Ext(category *model.FriendCategory, friendUserIDs []string) *relationext.FriendCategory {
+	if friendUserIDs == nil {
+		friendUserIDs = []string{}
+	}
+	return &relationext.FriendCategory{
+		CategoryID:    category.CategoryID,
+		Name:          category.Name,
+		Order:         category.Order,
+		CreateTime:    category.CreateTime.UnixMilli(),
+		FriendUserIDs: friendUserIDs,
+	}
+}
+
+// checkFriendCategoryName 校验分组名称，返回去除首尾空白后的名称
+func checkFriendCategoryName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errs.ErrArgs.WrapMsg("name is empty")
+	}
+	if utf8.RuneCountInString(name) > model.MaxFriendCategoryNameLen {
+		return "", errs.ErrArgs.WrapMsg("name too long", "max", model.MaxFriendCategoryNameLen)
+	}
+	return name, nil
+}
+
+// checkFriendCategories 校验分组均属于该用户
+func (s *friendServer) checkFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	if datautil.Duplicate(categoryIDs) {
+		return errs.ErrArgs.WrapMsg("categoryIDs repeated")
+	}
+	categories, err := s.db.FindFriendCategories(ctx, ownerUserID, categoryIDs)
+	if err != nil {
+		return err
+	}
+	if len(categories) != len(categoryIDs) {
+		exists := datautil.Slice(categories, func(e *model.FriendCategory) string { return e.CategoryID })
+		return servererrs.ErrFriendCategoryNotFound.WrapMsg("friend category not found", "categoryIDs", datautil.Single(categoryIDs, exists))
+	}
+	return nil
+}
+
+// checkFriends 校验好友ID不为空、不重复且均为该用户的好友
+func (s *friendServer) checkFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) error {
+	if datautil.Duplicate(friendUserIDs) {
+		return errs.ErrArgs.WrapMsg("friendUserIDs repeated")
+	}
+	friends, err := s.db.FindFriendsWithError(ctx, ownerUserID, friendUserIDs)
+	if err != nil {
+		return err
+	}
+	if len(friends) != len(friendUserIDs) {
+		exists := datautil.Slice(friends, func(e *model.Friend) string { return e.FriendUserID })
+		return servererrs.ErrNotPeersFriend.WrapMsg("not friend", "friendUserIDs", datautil.Single(friendUserIDs, exists))
+	}
+	return nil
+}
+
+// CreateFriendCategory 创建好友分组
+func (s *friendServer) CreateFriendCategory(ctx context.Context, req *relationext.CreateFriendCategoryReq) (*relationext.CreateFriendCategoryResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	name, err := checkFriendCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.CategoryID) > maxFriendCategoryIDLen {
+		return nil, errs.ErrArgs.WrapMsg("invalid categoryID", "categoryID", req.CategoryID)
+	}
+	count, err := s.db.CountFriendCategories(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= model.MaxFriendCategories {
+		return nil, errs.ErrArgs.WrapMsg("too many friend categories", "max", model.MaxFriendCategories)
+	}
+	categoryID := req.CategoryID
+	if categoryID == "" {
+		if categoryID, err = genFriendCategoryID(); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.db.TakeFriendCategory(ctx, req.OwnerUserID, categoryID); err == nil {
+		return nil, errs.ErrDuplicateKey.WrapMsg("friend category already exists", "categoryID", categoryID)
+	} else if !mgo.IsNotFound(err) {
+		return nil, err
+	}
+	category := &model.FriendCategory{
+		OwnerUserID: req.OwnerUserID,
+		CategoryID:  categoryID,
+		Name:        name,
+		Order:       req.Order,
+		CreateTime:  time.Now(),
+	}
+	if err := s.db.CreateFriendCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendCategoriesChangedNotification(ctx, req.OwnerUserID, nil)
+	return &relationext.CreateFriendCategoryResp{Category: friendCategoryDB2Ext(category, nil)}, nil
+}
+
+// UpdateFriendCategory 重命名好友分组或调整排序
+func (s *friendServer) UpdateFriendCategory(ctx context.Context, req *relationext.UpdateFriendCategoryReq) (*relationext.UpdateFriendCategoryResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	data := make(map[string]any)
+	if req.Name != nil {
+		name, err := checkFriendCategoryName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		data["name"] = name
+	}
+	if req.Order != nil {
+		data["order"] = *req.Order
+	}
+	if err := s.checkFriendCategories(ctx, req.OwnerUserID, []string{req.CategoryID}); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return &relationext.UpdateFriendCategoryResp{}, nil
+	}
+	if err := s.db.UpdateFriendCategory(ctx, req.OwnerUserID, req.CategoryID, data); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendCategoriesChangedNotification(ctx, req.OwnerUserID, nil)
+	return &relationext.UpdateFriendCategoryResp{}, nil
+}
+
+// SortFriendCategories 按指定顺序重新排列全部好友分组
+func (s *friendServer) SortFriendCategories(ctx context.Context, req *relationext.SortFriendCategoriesReq) (*relationext.SortFriendCategoriesResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if datautil.Duplicate(req.CategoryIDs) {
+		return nil, errs.ErrArgs.WrapMsg("categoryIDs repeated")
+	}
+	categories, err := s.db.FindFriendCategories(ctx, req.OwnerUserID, nil)
+	if err != nil {
+		return nil, err
+	}
+	exists := datautil.Slice(categories, func(e *model.FriendCategory) string { return e.CategoryID })
+	if len(req.CategoryIDs) != len(exists) || len(datautil.Single(req.CategoryIDs, exists)) > 0 {
+		return nil, errs.ErrArgs.WrapMsg("categoryIDs must contain all friend categories")
+	}
+	if err := s.db.SortFriendCategories(ctx, req.OwnerUserID, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendCategoriesChangedNotification(ctx, req.OwnerUserID, nil)
+	return &relationext.SortFriendCategoriesResp{}, nil
+}
+
+// DeleteFriendCategories 删除好友分组，分组内的好友保留，仅移除归属
+func (s *friendServer) DeleteFriendCategories(ctx context.Context, req *relationext.DeleteFriendCategoriesReq) (*relationext.DeleteFriendCategoriesResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if len(req.CategoryIDs) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("categoryIDs is empty")
+	}
+	if err := s.checkFriendCategories(ctx, req.OwnerUserID, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+	friendUserIDs, err := s.db.DeleteFriendCategories(ctx, req.OwnerUserID, req.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendCategoriesChangedNotification(ctx, req.OwnerUserID, friendUserIDs)
+	return &relationext.DeleteFriendCategoriesResp{}, nil
+}
+
+// GetFriendCategories 获取全部好友分组及分组内的好友，同时返回当前好友版本
+func (s *friendServer) GetFriendCategories(ctx context.Context, req *relationext.GetFriendCategoriesReq) (*relationext.GetFriendCategoriesResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	vl, err := s.db.FindMaxFriendVersionCache(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.findFriendCategories(ctx, req.OwnerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &relationext.GetFriendCategoriesResp{
+		Categories: categories,
+		Version:    uint64(vl.Version),
+		VersionID:  vl.ID.Hex(),
+	}, nil
+}
+
+// findFriendCategories 获取用户的全部好友分组及分组内的好友
+func (s *friendServer) findFriendCategories(ctx context.Context, ownerUserID string) ([]*relationext.FriendCategory, error) {
+	categories, err := s.db.FindFriendCategories(ctx, ownerUserID, nil)
+	if err != nil {
+		return nil, err
+	}
+	friends, err := s.db.FindCategorizedFriends(ctx, ownerUserID, nil)
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string][]string)
+	for _, friend := range friends {
+		for _, categoryID := range friend.CategoryIDs {
+			members[categoryID] = append(members[categoryID], friend.FriendUserID)
+		}
+	}
+	return datautil.Slice(categories, func(e *model.FriendCategory) *relationext.FriendCategory {
+		return friendCategoryDB2Ext(e, members[e.CategoryID])
+	}), nil
+}
+
+// GetIncrementalFriendsWithCategories 增量同步好友列表，每个好友附带所属分组
+// 分组本身有变化或全量同步时一并返回用户的全部分组，客户端无需再调用get_friend_categories
+func (s *friendServer) GetIncrementalFriendsWithCategories(ctx context.Context, req *relationext.GetIncrementalFriendsWithCategoriesReq) (*relationext.GetIncrementalFriendsWithCategoriesResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	var (
+		sortVersion     uint64
+		categoryChanged bool
+	)
+	opt := incrversion.Option[*relationext.IncrementalFriend, relationext.GetIncrementalFriendsWithCategoriesResp]{
+		Ctx:             ctx,
+		VersionKey:      req.UserID,
+		VersionID:       req.VersionID,
+		VersionNumber:   req.Version,
+		Version:         s.findFriendIncrVersion(&sortVersion, &categoryChanged),
+		CacheMaxVersion: s.db.FindMaxFriendVersionCache,
+		Find: func(ctx context.Context, ids []string) ([]*relationext.IncrementalFriend, error) {
+			return s.getIncrementalFriends(ctx, req.UserID, ids)
+		},
+		Resp: func(version *model.VersionLog, deleteIds []string, insertList, updateList []*relationext.IncrementalFriend, full bool) *relationext.GetIncrementalFriendsWithCategoriesResp {
+			return &relationext.GetIncrementalFriendsWithCategoriesResp{
+				VersionID:       version.ID.Hex(),
+				Version:         uint64(version.Version),
+				Full:            full,
+				Delete:          deleteIds,
+				Insert:          insertList,
+				Update:          updateList,
+				SortVersion:     sortVersion,
+				CategoryChanged: categoryChanged || full,
+			}
+		},
+	}
+	resp, err := opt.Build()
+	if err != nil {
+		return nil, err
+	}
+	if resp.CategoryChanged {
+		resp.Categories, err = s.findFriendCategories(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// getIncrementalFriends 获取好友信息并附带好友所属的分组
+func (s *friendServer) getIncrementalFriends(ctx context.Context, ownerUserID string, friendUserIDs []string) ([]*relationext.IncrementalFriend, error) {
+	if len(friendUserIDs) == 0 {
+		return nil, nil
+	}
+	friends, err := s.db.FindFriendsWithError(ctx, ownerUserID, friendUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := convert.FriendsDB2Pb(ctx, friends, s.userClient.GetVisibleUsersInfoMap)
+	if err != nil {
+		return nil, err
+	}
+	return incrementalFriends(friends, infos), nil
+}
+
+// incrementalFriends 合并好友信息和好友所属的分组，infos与friends按相同顺序一一对应
+func incrementalFriends(friends []*model.Friend, infos []*sdkws.FriendInfo) []*relationext.IncrementalFriend {
+	res := make([]*relationext.IncrementalFriend, 0, len(infos))
+	for i, info := range infos {
+		categoryIDs := friends[i].CategoryIDs
+		if categoryIDs == nil {
+			categoryIDs = []string{}
+		}
+		res = append(res, &relationext.IncrementalFriend{FriendInfo: info, CategoryIDs: categoryIDs})
+	}
+	return res
+}
+
+// SetFriendsCategories 覆盖设置好友所属的分组
+func (s *friendServer) SetFriendsCategories(ctx context.Context, req *relationext.SetFriendsCategoriesReq) (*relationext.SetFriendsCategoriesResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if len(req.FriendUserIDs) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("friendUserIDs is empty")
+	}
+	if err := s.checkFriends(ctx, req.OwnerUserID, req.FriendUserIDs); err != nil {
+		return nil, err
+	}
+	if err := s.checkFriendCategories(ctx, req.OwnerUserID, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+	categoryIDs := req.CategoryIDs
+	if categoryIDs == nil {
+		categoryIDs = []string{}
+	}
+	if err := s.db.UpdateFriends(ctx, req.OwnerUserID, req.FriendUserIDs, map[string]any{"category_ids": categoryIDs}); err != nil {
+		return nil, err
+	}
+	s.notificationSender.FriendCategoriesChangedNotification(ctx, req.OwnerUserID, req.FriendUserIDs)
+	return &relationext.SetFriendsCategoriesResp{}, nil
+}
+
+// UpdateFriendCategoryMembers 向单个分组加入或移出好友
+func (s *friendServer) UpdateFriendCategoryMembers(ctx context.Context, req *relationext.UpdateFriendCategoryMembersReq) (*relationext.UpdateFriendCategoryMembersResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.OwnerUserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if len(req.AddFriendUserIDs) == 0 && len(req.RemoveFriendUserIDs) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("addFriendUserIDs and removeFriendUserIDs are empty")
+	}
+	for _, userID := range req.RemoveFriendUserIDs {
+		if datautil.Contain(userID, req.AddFriendUserIDs...) {
+			return nil, errs.ErrArgs.WrapMsg("friend both added and removed", "friendUserID", userID)
+		}
+	}
+	if err := s.checkFriendCategories(ctx, req.OwnerUserID, []string{req.CategoryID}); err != nil {
+		return nil, err
+	}
+	if len(req.AddFriendUserIDs) > 0 {
+		if err := s.checkFriends(ctx, req.OwnerUserID, req.AddFriendUserIDs); err != nil {
+			return nil, err
+		}
+		if err := s.db.AddFriendsToCategory(ctx, req.OwnerUserID, req.AddFriendUserIDs, req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.RemoveFriendUserIDs) > 0 {
+		if err := s.checkFriends(ctx, req.OwnerUserID, req.RemoveFriendUserIDs); err != nil {
+			return nil, err
+		}
+		if err := s.db.RemoveFriendsFromCategories(ctx, req.OwnerUserID, req.RemoveFriendUserIDs, []string{req.CategoryID}); err != nil {
+			return nil, err
+		}
+	}
+	friendUserIDs := make([]string, 0, len(req.AddFriendUserIDs)+len(req.RemoveFriendUserIDs))
+	friendUserIDs = append(append(friendUserIDs, req.AddFriendUserIDs...), req.RemoveFriendUserIDs...)
+	s.notificationSender.FriendCategoriesChangedNotification(ctx, req.OwnerUserID, friendUserIDs)
+	return &relationext.UpdateFriendCategoryMembersResp{}, nil
+}
@@ -0,0 +1,95 @@
+package relation
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFriendCategoryName(t *testing.T) {
+	name, err := checkFriendCategoryName("  同事 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "同事", name)
+
+	_, err = checkFriendCategoryName("   ")
+	assert.Error(t, err)
+
+	_, err = checkFriendCategoryName(strings.Repeat("分", model.MaxFriendCategoryNameLen))
+	assert.NoError(t, err)
+	_, err = checkFriendCategoryName(strings.Repeat("分", model.MaxFriendCategoryNameLen+1))
+	assert.Error(t, err)
+}
+
+func TestFriendCategoryDB2Ext(t *testing.T) {
+	category := &model.FriendCategory{OwnerUserID: "u1", CategoryID: "c1", Name: "家人", Order: 2, CreateTime: time.UnixMilli(1000)}
+	ext := friendCategoryDB2Ext(category, nil)
+	assert.Equal(t, "c1", ext.CategoryID)
+	assert.Equal(t, int32(2), ext.Order)
+	assert.Equal(t, int64(1000), ext.CreateTime)
+	assert.NotNil(t, ext.FriendUserIDs) // 空分组返回[]而不是null
+
+	ext = friendCategoryDB2Ext(category, []string{"u2"})
+	assert.Equal(t, []string{"u2"}, ext.FriendUserIDs)
+}
+
+func TestIncrementalFriends(t *testing.T) {
+	friends := []*model.Friend{
+		{FriendUserID: "u2", CategoryIDs: []string{"c1", "c2"}},
+		{FriendUserID: "u3"},
+	}
+	infos := []*sdkws.FriendInfo{
+		{FriendUser: &sdkws.UserInfo{UserID: "u2"}},
+		{FriendUser: &sdkws.UserInfo{UserID: "u3"}},
+	}
+	res := incrementalFriends(friends, infos)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "u2", res[0].FriendUser.UserID)
+	assert.Equal(t, []string{"c1", "c2"}, res[0].CategoryIDs)
+	assert.NotNil(t, res[1].CategoryIDs) // 未分组返回[]而不是null
+
+	data, err := json.Marshal(res[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"friendUser":`)
+	assert.Contains(t, string(data), `"categoryIDs":["c1","c2"]`)
+}
+
+type incrVersionFriendDB struct {
+	controller.FriendDatabase
+	logs []model.VersionLogElem
+}
+
+func (d *incrVersionFriendDB) FindFriendIncrVersion(ctx context.Context, ownerUserID string, version uint, limit int) (*model.VersionLog, error) {
+	logs := slices.Clone(d.logs)
+	return &model.VersionLog{Logs: logs, LogLen: len(logs)}, nil
+}
+
+func TestFindFriendIncrVersion(t *testing.T) {
+	s := &friendServer{db: &incrVersionFriendDB{logs: []model.VersionLogElem{
+		{EID: "u2", Version: 1},
+		{EID: model.VersionFriendCategoryChangeID, Version: 2},
+		{EID: model.VersionSortChangeID, Version: 3},
+	}}}
+	var (
+		sortVersion     uint64
+		categoryChanged bool
+	)
+	vl, err := s.findFriendIncrVersion(&sortVersion, &categoryChanged)(context.Background(), "u1", 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, vl.LogLen)
+	assert.Equal(t, "u2", vl.Logs[0].EID)
+	assert.Equal(t, uint64(3), sortVersion)
+	assert.True(t, categoryChanged)
+
+	sortVersion = 0
+	vl, err = s.findFriendIncrVersion(&sortVersion, nil)(context.Background(), "u1", 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, vl.LogLen)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/protocol/relation"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// eraseUserRelationsBatchSize 注销用户时每批查询的反向好友数
+const eraseUserRelationsBatchSize = 500
+
+// EraseUserRelations 删除用户双向的好友关系、黑名单、好友申请和好友分组
+// 仅系统管理员可调用，由用户服务的注销任务执行；删除不记录好友关系事件，避免在事件中留下注销用户的ID，
+// 把该用户加为好友的一方会收到好友删除通知以便同步
+func (s *friendServer) EraseUserRelations(ctx context.Context, req *relationext.EraseUserRelationsReq) (*relationext.EraseUserRelationsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	resp := &relationext.EraseUserRelationsResp{}
+
+	friendUserIDs, err := s.db.FindFriendUserIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(friendUserIDs) > 0 {
+		if err := s.db.Delete(ctx, req.UserID, friendUserIDs, nil); err != nil {
+			return nil, err
+		}
+		resp.DeletedFriends += int64(len(friendUserIDs))
+	}
+
+	// 每批删除后剩余记录前移，始终查询第一页
+	for {
+		_, friends, err := s.db.PageInWhoseFriends(ctx, req.UserID, &sdkws.RequestPagination{PageNumber: 1, ShowNumber: eraseUserRelationsBatchSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, friend := range friends {
+			if err := s.db.Delete(ctx, friend.OwnerUserID, []string{req.UserID}, nil); err != nil {
+				return nil, err
+			}
+			s.notificationSender.FriendDeletedNotification(ctx, &relation.DeleteFriendReq{OwnerUserID: friend.OwnerUserID, FriendUserID: req.UserID})
+			resp.DeletedFriends++
+		}
+		if len(friends) < eraseUserRelationsBatchSize {
+			break
+		}
+	}
+
+	blacks, err := s.blackDatabase.FindUserBlacks(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(blacks) > 0 {
+		if err := s.blackDatabase.Delete(ctx, blacks, nil); err != nil {
+			return nil, err
+		}
+		resp.DeletedBlacks = int64(len(blacks))
+	}
+
+	if err := s.db.DeleteUserFriendRequests(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.db.FindFriendCategories(ctx, req.UserID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) > 0 {
+		categoryIDs := datautil.Slice(categories, func(e *model.FriendCategory) string { return e.CategoryID })
+		if _, err := s.db.DeleteFriendCategories(ctx, req.UserID, categoryIDs); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// EraseUserFriendEvents 删除注销用户作为任一方或操作者的好友关系事件
+// 仅系统管理员可调用，由用户服务的注销任务在删除关系之后执行
+func (s *friendServer) EraseUserFriendEvents(ctx context.Context, req *relationext.EraseUserFriendEventsReq) (*relationext.EraseUserFriendEventsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	count, err := s.db.DeleteUserFriendEvents(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &relationext.EraseUserFriendEventsResp{DeletedCount: count}, nil
+}
@@ -0,0 +1,40 @@
+package relation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eraseFriendEventDB struct {
+	controller.FriendDatabase
+	deletedUserIDs []string
+}
+
+func (d *eraseFriendEventDB) DeleteUserFriendEvents(ctx context.Context, userID string) (int64, error) {
+	d.deletedUserIDs = append(d.deletedUserIDs, userID)
+	return 3, nil
+}
+
+func TestEraseUserFriendEvents(t *testing.T) {
+	db := &eraseFriendEventDB{}
+	s := &friendServer{db: db, config: &Config{}}
+	s.config.Share.IMAdminUserID = []string{"admin"}
+
+	_, err := s.EraseUserFriendEvents(mcontext.WithOpUserIDContext(context.Background(), "u2"), &relationext.EraseUserFriendEventsReq{UserID: "u1"})
+	assert.Error(t, err)
+
+	ctx := mcontext.WithOpUserIDContext(context.Background(), "admin")
+	_, err = s.EraseUserFriendEvents(ctx, &relationext.EraseUserFriendEventsReq{})
+	assert.Error(t, err)
+
+	resp, err := s.EraseUserFriendEvents(ctx, &relationext.EraseUserFriendEventsReq{UserID: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.DeletedCount)
+	assert.Equal(t, []string{"u1"}, db.deletedUserIDs)
+}
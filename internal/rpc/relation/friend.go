@@ -48,6 +48,7 @@ import (
 	"context"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/notification/common_user"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 
 	"github.com/openimsdk/tools/mq/memamq"
@@ -151,6 +152,10 @@ type friendServer struct {
 	// - 获取用户基本信息用于通知
 	// - 用户信息变更时的关联更新
 	userClient *rpcli.UserClient
+
+	// groupClient 群组服务客户端
+	// 用于计算好友推荐中的共同群组数
+	groupClient *rpcli.GroupClient
 }
 
 // Config 好友服务配置结构体
@@ -236,6 +241,18 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 
+	// 创建好友分组数据库DAO
+	friendCategoryMongoDB, err := mgo.NewFriendCategoryMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
+	// 创建好友关系事件日志DAO
+	friendEventMongoDB, err := mgo.NewFriendEventMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
 	// 6. 获取其他服务的连接
 	// 通过服务发现机制获取其他微服务的连接，实现服务间通信
 
@@ -251,6 +268,12 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 
+	// 获取群组服务连接，用于计算好友推荐的共同群组
+	groupConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Group)
+	if err != nil {
+		return err
+	}
+
 	// 7. 创建用户服务客户端
 	// 用于调用用户服务的接口，获取用户信息和验证用户有效性
 	userClient := rpcli.NewUserClient(userConn)
@@ -269,20 +292,24 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 
 	// 10. 注册好友服务到gRPC服务器
 	// 创建好友服务实例并注册，使其能够处理客户端请求
-	relation.RegisterFriendServer(server, &friendServer{
+	fs := &friendServer{
 		// 初始化好友数据库控制器
 		// 组合了MongoDB DAO、Redis缓存和事务管理器
 		db: controller.NewFriendDatabase(
-			friendMongoDB,        // 好友关系MongoDB DAO
-			friendRequestMongoDB, // 好友申请MongoDB DAO
+			friendMongoDB,         // 好友关系MongoDB DAO
+			friendRequestMongoDB,  // 好友申请MongoDB DAO
+			friendCategoryMongoDB, // 好友分组MongoDB DAO
+			friendEventMongoDB,    // 好友关系事件日志MongoDB DAO
 			redis.NewFriendCacheRedis(rdb, &config.LocalCacheConfig, friendMongoDB, redis.GetRocksCacheOptions()), // 好友关系Redis缓存
 			mgocli.GetTx(), // MongoDB事务管理器
 		),
 
 		// 初始化黑名单数据库控制器
 		blackDatabase: controller.NewBlackDatabase(
-			blackMongoDB, // 黑名单MongoDB DAO
+			blackMongoDB,       // 黑名单MongoDB DAO
+			friendEventMongoDB, // 好友关系事件日志MongoDB DAO
 			redis.NewBlackCacheRedis(rdb, &config.LocalCacheConfig, blackMongoDB, redis.GetRocksCacheOptions()), // 黑名单Redis缓存
+			mgocli.GetTx(), // MongoDB事务管理器
 		),
 
 		// 组装各种组件
@@ -292,7 +319,11 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		webhookClient:      webhook.NewWebhookClient(config.WebhooksConfig.URL), // Webhook客户端
 		queue:              memamq.NewMemoryQueue(16, 1024*1024),                // 内存队列：16个worker，1MB缓冲区
 		userClient:         userClient,                                          // 用户服务客户端
-	})
+		groupClient:        rpcli.NewGroupClient(groupConn),                     // 群组服务客户端
+	}
+	relation.RegisterFriendServer(server, fs)
+	// 好友分组等扩展接口注册在同一个gRPC Server上
+	relationext.RegisterRelationExtServer(server, fs)
 
 	return nil
 }
@@ -308,9 +339,10 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 // 3. Webhook前置回调：允许外部系统干预申请流程
 // 4. 用户有效性验证：确认申请双方用户都存在
 // 5. 关系状态检查：防止重复申请已是好友的用户
-// 6. 申请记录存储：保存申请信息到数据库
-// 7. 异步通知推送：通知目标用户有新的好友申请
-// 8. Webhook后置回调：通知外部系统申请已完成
+// 6. 申请频率检查：被拒绝后的冷却时间和每日申请上限（系统管理员不受限制）
+// 7. 申请记录存储：保存申请信息到数据库
+// 8. 异步通知推送：通知目标用户有新的好友申请
+// 9. Webhook后置回调：通知外部系统申请已完成
 //
 // 参数说明：
 // - req.FromUserID: 发起申请的用户ID
@@ -326,6 +358,7 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 // - 权限错误：用户无权限发起申请
 // - 参数错误：不能添加自己为好友
 // - 业务逻辑错误：用户已是好友关系
+// - 频率限制：被拒绝后冷却时间未到（1307）、超过每日申请上限（1308）
 // - 系统错误：数据库操作失败、网络错误等
 func (s *friendServer) ApplyToAddFriend(ctx context.Context, req *relation.ApplyToAddFriendReq) (resp *relation.ApplyToAddFriendResp, err error) {
 	resp = &relation.ApplyToAddFriendResp{}
@@ -368,17 +401,31 @@ func (s *friendServer) ApplyToAddFriend(ctx context.Context, req *relation.Apply
 		return nil, servererrs.ErrRelationshipAlready.WrapMsg("already friends has f")
 	}
 
-	// 6. 添加好友申请记录到数据库
+	// 6. 申请频率检查：被对方拒绝后的冷却时间和每日申请上限
+	if err := s.checkFriendRequestPolicy(ctx, req.FromUserID, req.ToUserID); err != nil {
+		return nil, err
+	}
+
+	// 黑名单检查：对方拉黑申请人且范围包含好友申请时拒绝
+	blocked, err := s.checkBlackScope(ctx, req.ToUserID, req.FromUserID, model.BlackScopeFriendRequest)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, servererrs.ErrBlockedByPeer.WrapMsg("blocked by peer", "toUserID", req.ToUserID)
+	}
+
+	// 7. 添加好友申请记录到数据库
 	// 将申请信息持久化存储，包括申请消息和扩展字段
 	if err = s.db.AddFriendRequest(ctx, req.FromUserID, req.ToUserID, req.ReqMsg, req.Ex); err != nil {
 		return nil, err
 	}
 
-	// 7. 异步发送好友申请通知
+	// 8. 异步发送好友申请通知
 	// 通知目标用户有新的好友申请，支持多端实时同步
 	s.notificationSender.FriendApplicationAddNotification(ctx, req)
 
-	// 8. Webhook后置回调：通知外部系统好友申请已完成
+	// 9. Webhook后置回调：通知外部系统好友申请已完成
 	// 异步调用，不会阻塞主流程
 	s.webhookAfterAddFriend(ctx, &s.config.WebhooksConfig.AfterAddFriend, req)
 
@@ -447,7 +494,8 @@ func (s *friendServer) ImportFriends(ctx context.Context, req *relation.ImportFr
 	// 6. 批量建立好友关系
 	// 使用BecomeFriends方法直接在数据库中建立好友关系
 	// constant.BecomeFriendByImport 标识这是通过导入方式建立的好友关系
-	if err := s.db.BecomeFriends(ctx, req.OwnerUserID, req.FriendUserIDs, constant.BecomeFriendByImport); err != nil {
+	events := friendEvents(ctx, model.FriendEventAdd, model.FriendEventSourceImport, req.OwnerUserID, req.FriendUserIDs...)
+	if err := s.db.BecomeFriends(ctx, req.OwnerUserID, req.FriendUserIDs, constant.BecomeFriendByImport, events); err != nil {
 		return nil, err
 	}
 
@@ -476,12 +524,13 @@ func (s *friendServer) ImportFriends(ctx context.Context, req *relation.ImportFr
 //
 // 业务流程：
 // 1. 权限验证：确认用户有权限处理此申请
-// 2. 构建申请响应记录
-// 3. 根据处理结果执行不同逻辑：
+// 2. 过期检查：已过期的申请返回错误码1306
+// 3. 构建申请响应记录
+// 4. 根据处理结果执行不同逻辑：
 //   - 同意：建立好友关系，发送同意通知
 //   - 拒绝：更新申请状态，发送拒绝通知
 //
-// 4. 触发相应的Webhook回调
+// 5. 触发相应的Webhook回调
 //
 // 参数说明：
 // - req.FromUserID: 申请发起者用户ID
@@ -519,6 +568,11 @@ func (s *friendServer) RespondFriendApply(ctx context.Context, req *relation.Res
 		return nil, err
 	}
 
+	// 已过期的申请不能再处理
+	if err := s.checkFriendRequestNotExpired(ctx, req.FromUserID, req.ToUserID); err != nil {
+		return nil, err
+	}
+
 	// 构建好友申请响应记录
 	friendRequest := model.FriendRequest{
 		FromUserID:   req.FromUserID,   // 申请发起者
@@ -535,7 +589,8 @@ func (s *friendServer) RespondFriendApply(ctx context.Context, req *relation.Res
 		}
 
 		// 数据库操作：同意好友申请，建立好友关系
-		err := s.db.AgreeFriendRequest(ctx, &friendRequest)
+		events := friendEvents(ctx, model.FriendEventAdd, model.FriendEventSourceApply, req.FromUserID, req.ToUserID)
+		err := s.db.AgreeFriendRequest(ctx, &friendRequest, events)
 		if err != nil {
 			return nil, err
 		}
@@ -622,7 +677,8 @@ func (s *friendServer) DeleteFriend(ctx context.Context, req *relation.DeleteFri
 
 	// 执行删除操作：从数据库中移除好友关系
 	// 注意：这是单向删除，只删除OwnerUserID对FriendUserID的好友关系
-	if err := s.db.Delete(ctx, req.OwnerUserID, []string{req.FriendUserID}); err != nil {
+	events := friendEvents(ctx, model.FriendEventDelete, model.FriendEventSourceDelete, req.OwnerUserID, req.FriendUserID)
+	if err := s.db.Delete(ctx, req.OwnerUserID, []string{req.FriendUserID}, events); err != nil {
 		return nil, err
 	}
 
@@ -888,8 +944,8 @@ func (s *friendServer) getFriend(ctx context.Context, ownerUserID string, friend
 	}
 
 	// 数据合并和转换：将好友关系数据和用户基本信息合并
-	// convert.FriendsDB2Pb会自动获取用户信息并合并到好友信息中
-	return convert.FriendsDB2Pb(ctx, friends, s.userClient.GetUsersInfoMap)
+	// convert.FriendsDB2Pb会自动获取用户信息并合并到好友信息中，用户资料按请求者的可见范围过滤
+	return convert.FriendsDB2Pb(ctx, friends, s.userClient.GetVisibleUsersInfoMap)
 }
 
 // Get the list of friend requests sent out proactively.
@@ -1023,7 +1079,7 @@ func (s *friendServer) GetPaginationFriends(ctx context.Context, req *relation.G
 	}
 
 	resp = &relation.GetPaginationFriendsResp{}
-	resp.FriendsInfo, err = convert.FriendsDB2Pb(ctx, friends, s.userClient.GetUsersInfoMap)
+	resp.FriendsInfo, err = convert.FriendsDB2Pb(ctx, friends, s.userClient.GetVisibleUsersInfoMap)
 	if err != nil {
 		return nil, err
 	}
@@ -1060,7 +1116,7 @@ func (s *friendServer) GetSpecifiedFriendsInfo(ctx context.Context, req *relatio
 		return nil, err
 	}
 
-	userMap, err := s.userClient.GetUsersInfoMap(ctx, req.UserIDList)
+	userMap, err := s.userClient.GetVisibleUsersInfoMap(ctx, req.UserIDList)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,98 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+func newFriendEvents(operatorUserID string, eventType int32, source string, ownerUserID string, peerUserIDs []string, now time.Time) []*model.FriendEvent {
+	events := make([]*model.FriendEvent, 0, len(peerUserIDs))
+	for _, peerUserID := range peerUserIDs {
+		events = append(events, &model.FriendEvent{
+			OwnerUserID:    ownerUserID,
+			PeerUserID:     peerUserID,
+			Type:           eventType,
+			OperatorUserID: operatorUserID,
+			Source:         source,
+			CreateTime:     now,
+		})
+	}
+	return events
+}
+
+func friendEventDB2Ext(event *model.FriendEvent) *relationext.FriendEvent {
+	return &relationext.FriendEvent{
+		OwnerUserID:    event.OwnerUserID,
+		PeerUserID:     event.PeerUserID,
+		Type:           event.Type,
+		OperatorUserID: event.OperatorUserID,
+		Source:         event.Source,
+		CreateTime:     event.CreateTime.UnixMilli(),
+	}
+}
+
+// friendEvents 构建本次关系变更的事件，操作者取自上下文，由数据库控制器与变更在同一事务中写入
+func friendEvents(ctx context.Context, eventType int32, source string, ownerUserID string, peerUserIDs ...string) []*model.FriendEvent {
+	return newFriendEvents(mcontext.GetOpUserID(ctx), eventType, source, ownerUserID, peerUserIDs, time.Now())
+}
+
+// GetFriendEvents 查询用户之间的好友关系事件，仅系统管理员可调用
+func (s *friendServer) GetFriendEvents(ctx context.Context, req *relationext.GetFriendEventsReq) (*relationext.GetFriendEventsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	if req.StartTime < 0 || req.EndTime < 0 || (req.EndTime > 0 && req.StartTime > req.EndTime) {
+		return nil, errs.ErrArgs.WrapMsg("invalid time range", "startTime", req.StartTime, "endTime", req.EndTime)
+	}
+	var start, end time.Time
+	if req.StartTime > 0 {
+		start = time.UnixMilli(req.StartTime)
+	}
+	if req.EndTime > 0 {
+		end = time.UnixMilli(req.EndTime)
+	}
+	total, events, err := s.db.FindFriendEvents(ctx, req.UserID, req.PeerUserID, start, end, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &relationext.GetFriendEventsResp{Total: total, Events: datautil.Slice(events, friendEventDB2Ext)}, nil
+}
+
+// ClearFriendEvents 删除超过保留期的好友关系事件，仅系统管理员可调用，由定时任务周期执行
+func (s *friendServer) ClearFriendEvents(ctx context.Context, req *relationext.ClearFriendEventsReq) (*relationext.ClearFriendEventsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.BeforeTime <= 0 {
+		return nil, errs.ErrArgs.WrapMsg("beforeTime must be greater than 0")
+	}
+	count, err := s.db.DeleteFriendEventsBefore(ctx, time.UnixMilli(req.BeforeTime))
+	if err != nil {
+		return nil, err
+	}
+	return &relationext.ClearFriendEventsResp{DeletedCount: count}, nil
+}
@@ -0,0 +1,40 @@
+package relation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFriendEvents(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	events := newFriendEvents("admin", model.FriendEventAdd, model.FriendEventSourceImport, "u1", []string{"u2", "u3"}, now)
+	assert.Len(t, events, 2)
+	for i, peer := range []string{"u2", "u3"} {
+		assert.Equal(t, "u1", events[i].OwnerUserID)
+		assert.Equal(t, peer, events[i].PeerUserID)
+		assert.Equal(t, model.FriendEventAdd, events[i].Type)
+		assert.Equal(t, "admin", events[i].OperatorUserID)
+		assert.Equal(t, model.FriendEventSourceImport, events[i].Source)
+	}
+
+	ext := friendEventDB2Ext(events[0])
+	assert.Equal(t, now.UnixMilli(), ext.CreateTime)
+	assert.Equal(t, model.FriendEventSourceImport, ext.Source)
+
+	assert.Empty(t, newFriendEvents("u1", model.FriendEventDelete, model.FriendEventSourceDelete, "u1", nil, now))
+}
+
+func TestGetFriendEventsRequiresUserID(t *testing.T) {
+	s := &friendServer{config: &Config{}}
+	s.config.Share.IMAdminUserID = []string{"admin"}
+	ctx := mcontext.WithOpUserIDContext(context.Background(), "admin")
+	_, err := s.GetFriendEvents(ctx, &relationext.GetFriendEventsReq{PeerUserID: "u2"})
+	assert.True(t, errs.ErrArgs.Is(err))
+}
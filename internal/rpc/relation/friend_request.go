@@ -0,0 +1,141 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/relation"
+	"github.com/openimsdk/tools/log"
+)
+
+const (
+	// friendRequestExpireBatchSize 过期处理时每批查询的申请数
+	friendRequestExpireBatchSize = 100
+	// friendRequestExpireMaxBatches 单次过期处理的最大批数，剩余的在下次执行时处理
+	friendRequestExpireMaxBatches = 100
+	// friendRequestExpiredMsg 申请过期时通知中的处理消息
+	friendRequestExpiredMsg = "expired"
+	// friendRequestLimitWindow 每日申请上限的统计区间
+	friendRequestLimitWindow = 24 * time.Hour
+)
+
+// friendRequestCooldownEnd 返回被拒绝的申请可以再次发起的时间，申请未被拒绝或未配置冷却时间时返回零值
+func friendRequestCooldownEnd(request *model.FriendRequest, cooldownSeconds int) time.Time {
+	if cooldownSeconds <= 0 || request.HandleResult != constant.FriendResponseRefuse {
+		return time.Time{}
+	}
+	return request.HandleTime.Add(time.Duration(cooldownSeconds) * time.Second)
+}
+
+// friendRequestExpired 判断申请是否已过期，包括定时任务尚未标记但已超过有效期的待处理申请
+func friendRequestExpired(request *model.FriendRequest, expireSeconds int, now time.Time) bool {
+	switch request.HandleResult {
+	case model.FriendResponseExpired:
+		return true
+	case constant.FriendResponseNotHandle:
+		return expireSeconds > 0 && request.CreateTime.Add(time.Duration(expireSeconds)*time.Second).Before(now)
+	default:
+		return false
+	}
+}
+
+// checkFriendRequestPolicy 校验发起好友申请的冷却时间和每日上限，系统管理员代为发起时不受限制
+func (s *friendServer) checkFriendRequestPolicy(ctx context.Context, fromUserID, toUserID string) error {
+	if authverify.IsAppManagerUid(ctx, s.config.Share.IMAdminUserID) {
+		return nil
+	}
+	policy := s.config.RpcConfig.FriendRequest
+	now := time.Now()
+	if policy.RefuseCooldownSeconds > 0 {
+		request, err := s.db.TakeFriendRequest(ctx, fromUserID, toUserID)
+		if err == nil {
+			if end := friendRequestCooldownEnd(request, policy.RefuseCooldownSeconds); now.Before(end) {
+				return servererrs.ErrFriendRequestCooldown.WrapMsg("friend request refused recently", "toUserID", toUserID, "retryAfter", end.UnixMilli())
+			}
+		} else if !mgo.IsNotFound(err) {
+			return err
+		}
+	}
+	if policy.DailyLimit > 0 {
+		count, err := s.db.CountFriendRequestsSince(ctx, fromUserID, now.Add(-friendRequestLimitWindow))
+		if err != nil {
+			return err
+		}
+		if count >= int64(policy.DailyLimit) {
+			return servererrs.ErrFriendRequestLimitExceeded.WrapMsg("too many friend requests", "limit", policy.DailyLimit)
+		}
+	}
+	return nil
+}
+
+// checkFriendRequestNotExpired 处理好友申请前确认申请未过期
+func (s *friendServer) checkFriendRequestNotExpired(ctx context.Context, fromUserID, toUserID string) error {
+	request, err := s.db.TakeFriendRequest(ctx, fromUserID, toUserID)
+	if err != nil {
+		return err
+	}
+	if friendRequestExpired(request, s.config.RpcConfig.FriendRequest.ExpireSeconds, time.Now()) {
+		return servererrs.ErrFriendRequestExpired.WrapMsg("friend request expired", "fromUserID", fromUserID, "toUserID", toUserID)
+	}
+	return nil
+}
+
+// ExpireFriendRequests 将超过有效期的待处理好友申请标记为已过期，并通知申请双方
+// 仅系统管理员可调用，由定时任务周期执行
+func (s *friendServer) ExpireFriendRequests(ctx context.Context, req *relationext.ExpireFriendRequestsReq) (*relationext.ExpireFriendRequestsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	resp := &relationext.ExpireFriendRequestsResp{}
+	expireSeconds := s.config.RpcConfig.FriendRequest.ExpireSeconds
+	if expireSeconds <= 0 {
+		return resp, nil
+	}
+	before := time.Now().Add(-time.Duration(expireSeconds) * time.Second)
+	for i := 0; i < friendRequestExpireMaxBatches; i++ {
+		requests, err := s.db.FindExpiredFriendRequests(ctx, before, friendRequestExpireBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, request := range requests {
+			if err := s.db.ExpireFriendRequest(ctx, request.FromUserID, request.ToUserID, before); err != nil {
+				// 申请已被并发处理或重新发起
+				if !mgo.IsNotFound(err) {
+					log.ZWarn(ctx, "expire friend request failed", err, "fromUserID", request.FromUserID, "toUserID", request.ToUserID)
+				}
+				continue
+			}
+			resp.ExpiredCount++
+			s.notificationSender.FriendApplicationRefusedNotification(ctx, &relation.RespondFriendApplyReq{
+				FromUserID:   request.FromUserID,
+				ToUserID:     request.ToUserID,
+				HandleResult: model.FriendResponseExpired,
+				HandleMsg:    friendRequestExpiredMsg,
+			})
+		}
+		if len(requests) < friendRequestExpireBatchSize {
+			break
+		}
+	}
+	return resp, nil
+}
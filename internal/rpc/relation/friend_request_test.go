@@ -0,0 +1,35 @@
+package relation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFriendRequestCooldownEnd(t *testing.T) {
+	handleTime := time.UnixMilli(1000000)
+	refused := &model.FriendRequest{HandleResult: constant.FriendResponseRefuse, HandleTime: handleTime}
+	assert.Equal(t, handleTime.Add(time.Minute), friendRequestCooldownEnd(refused, 60))
+	assert.True(t, friendRequestCooldownEnd(refused, 0).IsZero())
+
+	// 只有被拒绝的申请有冷却时间，过期或待处理的申请可以立即重新发起
+	for _, result := range []int32{constant.FriendResponseNotHandle, constant.FriendResponseAgree, model.FriendResponseExpired} {
+		request := &model.FriendRequest{HandleResult: result, HandleTime: handleTime}
+		assert.True(t, friendRequestCooldownEnd(request, 60).IsZero())
+	}
+}
+
+func TestFriendRequestExpired(t *testing.T) {
+	now := time.UnixMilli(10000000)
+	pending := &model.FriendRequest{HandleResult: constant.FriendResponseNotHandle, CreateTime: now.Add(-2 * time.Hour)}
+	assert.True(t, friendRequestExpired(pending, 3600, now))
+	assert.False(t, friendRequestExpired(pending, 3*3600, now))
+	assert.False(t, friendRequestExpired(pending, 0, now))
+
+	assert.True(t, friendRequestExpired(&model.FriendRequest{HandleResult: model.FriendResponseExpired}, 0, now))
+	refused := &model.FriendRequest{HandleResult: constant.FriendResponseRefuse, CreateTime: now.Add(-2 * time.Hour)}
+	assert.False(t, friendRequestExpired(refused, 3600, now))
+}
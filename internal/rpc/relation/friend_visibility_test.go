@@ -0,0 +1,62 @@
+package relation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/protocol/user"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type visibilityFriendDB struct {
+	controller.FriendDatabase
+}
+
+func (visibilityFriendDB) FindFriendsWithError(ctx context.Context, ownerUserID string, friendUserIDs []string) ([]*model.Friend, error) {
+	friends := make([]*model.Friend, 0, len(friendUserIDs))
+	for _, friendUserID := range friendUserIDs {
+		friends = append(friends, &model.Friend{OwnerUserID: ownerUserID, FriendUserID: friendUserID})
+	}
+	return friends, nil
+}
+
+// visibilityUserClient 记录获取用户资料时的查看者
+type visibilityUserClient struct {
+	user.UserClient
+	viewerUserIDs []string
+}
+
+func (c *visibilityUserClient) GetDesignateUsers(ctx context.Context, req *user.GetDesignateUsersReq, opts ...grpc.CallOption) (*user.GetDesignateUsersResp, error) {
+	c.viewerUserIDs = append(c.viewerUserIDs, mcontext.GetOpUserID(ctx))
+	resp := &user.GetDesignateUsersResp{}
+	for _, userID := range req.UserIDs {
+		resp.UsersInfo = append(resp.UsersInfo, &sdkws.UserInfo{UserID: userID, Ex: `{"city":"Shanghai"}`})
+	}
+	return resp, nil
+}
+
+func TestGetFriendUsesViewer(t *testing.T) {
+	userClient := &visibilityUserClient{}
+	s := &friendServer{db: visibilityFriendDB{}, userClient: &rpcli.UserClient{UserClient: userClient}}
+	ctx := mcontext.WithOpUserIDContext(context.Background(), "u1")
+
+	friends, err := s.getFriend(ctx, "u1", []string{"u2"})
+	require.NoError(t, err)
+	require.Len(t, friends, 1)
+	assert.Equal(t, "u2", friends[0].FriendUser.UserID)
+	assert.Equal(t, `{"city":"Shanghai"}`, friends[0].FriendUser.Ex)
+
+	res, err := s.getIncrementalFriends(ctx, "u1", []string{"u3"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	// 用户服务按查看者过滤自定义资料，好友信息不能以服务身份（空操作者）获取
+	assert.Equal(t, []string{"u1", "u1"}, userClient.viewerUserIDs)
+}
@@ -643,6 +643,18 @@ func (f *FriendNotificationSender) FriendsInfoUpdateNotification(ctx context.Con
 	f.Notification(ctx, toUserID, toUserID, constant.FriendsInfoUpdateNotification, &tips)
 }
 
+// FriendCategoriesChangedNotification 好友分组变更通知，发送给用户自己的其他设备
+// friendIDs为归属分组发生变化的好友，仅分组本身变更（创建、重命名、排序）时为空；
+// 携带最新好友版本，客户端据此增量同步好友并刷新分组
+func (f *FriendNotificationSender) FriendCategoriesChangedNotification(ctx context.Context, ownerUserID string, friendIDs []string) {
+	tips := sdkws.FriendsInfoUpdateTips{FromToUserID: &sdkws.FromToUserID{}}
+	tips.FromToUserID.FromUserID = ownerUserID
+	tips.FromToUserID.ToUserID = ownerUserID
+	tips.FriendIDs = friendIDs
+	f.setVersion(ctx, &tips.FriendVersion, &tips.FriendVersionID, database.FriendVersionName, ownerUserID)
+	f.Notification(ctx, ownerUserID, ownerUserID, constant.FriendsInfoUpdateNotification, &tips)
+}
+
 func (f *FriendNotificationSender) BlackAddedNotification(ctx context.Context, req *relation.AddBlackReq) {
 	tips := sdkws.BlackAddedTips{FromToUserID: &sdkws.FromToUserID{}}
 	tips.FromToUserID.FromUserID = req.OwnerUserID
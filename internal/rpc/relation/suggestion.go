@@ -0,0 +1,187 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relation
+
+import (
+	"context"
+	"sort"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+const (
+	// defaultFriendSuggestionCount 未指定数量时返回的推荐人数
+	defaultFriendSuggestionCount = 20
+	// maxFriendSuggestionCount 单次返回的最大推荐人数
+	maxFriendSuggestionCount = 100
+	// friendSuggestionCandidates 共同好友、共同群组各取的候选人数，合并排序后缓存
+	friendSuggestionCandidates = 200
+	// friendSuggestionScanFriends 计算共同好友时最多使用的好友数
+	friendSuggestionScanFriends = 2000
+)
+
+// rankFriendSuggestions 合并共同好友和共同群组的统计结果并排序
+// 每个共同好友、共同群组计为一次关联，按关联数降序，相同时共同好友多的在前，最后按用户ID排序保证结果稳定
+func rankFriendSuggestions(mutualFriends []*model.UserCount, sharedGroups []*groupext.SharedGroupCount, limit int) []*model.FriendSuggestion {
+	suggestionMap := make(map[string]*model.FriendSuggestion, len(mutualFriends)+len(sharedGroups))
+	get := func(userID string) *model.FriendSuggestion {
+		suggestion, ok := suggestionMap[userID]
+		if !ok {
+			suggestion = &model.FriendSuggestion{UserID: userID}
+			suggestionMap[userID] = suggestion
+		}
+		return suggestion
+	}
+	for _, count := range mutualFriends {
+		get(count.UserID).MutualFriendCount = count.Count
+	}
+	for _, count := range sharedGroups {
+		get(count.UserID).SharedGroupCount = count.Count
+	}
+	suggestions := datautil.Values(suggestionMap)
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if sa, sb := a.MutualFriendCount+a.SharedGroupCount, b.MutualFriendCount+b.SharedGroupCount; sa != sb {
+			return sa > sb
+		}
+		if a.MutualFriendCount != b.MutualFriendCount {
+			return a.MutualFriendCount > b.MutualFriendCount
+		}
+		return a.UserID < b.UserID
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// computeFriendSuggestions 计算用户的好友推荐候选人，结果由GetFriendSuggestions缓存
+func (s *friendServer) computeFriendSuggestions(ctx context.Context, userID string) ([]*model.FriendSuggestion, error) {
+	friendUserIDs, err := s.db.FindFriendUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	scanFriendUserIDs := friendUserIDs
+	if len(scanFriendUserIDs) > friendSuggestionScanFriends {
+		scanFriendUserIDs = scanFriendUserIDs[:friendSuggestionScanFriends]
+	}
+	excludeUserIDs := append([]string{userID}, friendUserIDs...)
+	mutualFriends, err := s.db.CountFriendsOfFriends(ctx, scanFriendUserIDs, excludeUserIDs, friendSuggestionCandidates)
+	if err != nil {
+		return nil, err
+	}
+	sharedGroups, err := s.groupClient.GetSharedGroupCounts(ctx, &groupext.GetSharedGroupCountsReq{
+		UserID:         userID,
+		ExcludeUserIDs: friendUserIDs,
+		Limit:          friendSuggestionCandidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rankFriendSuggestions(mutualFriends, sharedGroups.Users, friendSuggestionCandidates), nil
+}
+
+// filterFriendSuggestions 过滤缓存的推荐候选人
+// 排除已成为好友、任一方拉黑对方以及双方之间有未处理好友申请的用户，这些状态变化不清除推荐缓存
+func (s *friendServer) filterFriendSuggestions(ctx context.Context, userID string, suggestions []*model.FriendSuggestion) ([]*model.FriendSuggestion, error) {
+	if len(suggestions) == 0 {
+		return suggestions, nil
+	}
+	userIDs := datautil.Slice(suggestions, func(e *model.FriendSuggestion) string { return e.UserID })
+	friendUserIDs, err := s.db.FindFriendUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	excludeSet := datautil.SliceSet(friendUserIDs)
+	blacks, err := s.blackDatabase.FindBlacksBetween(ctx, userID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, black := range blacks {
+		excludeSet[black.OwnerUserID] = struct{}{}
+		excludeSet[black.BlockUserID] = struct{}{}
+	}
+	requests, err := s.db.FindPendingFriendRequestsBetween(ctx, userID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		excludeSet[request.FromUserID] = struct{}{}
+		excludeSet[request.ToUserID] = struct{}{}
+	}
+	excludeSet[userID] = struct{}{}
+	return datautil.Filter(suggestions, func(e *model.FriendSuggestion) (*model.FriendSuggestion, bool) {
+		_, ok := excludeSet[e.UserID]
+		return e, !ok
+	}), nil
+}
+
+// GetFriendSuggestions 获取“可能认识的人”
+// 按共同好友数和共同群组数排序，排除已有好友、黑名单用户和有未处理申请的用户
+// 候选人按用户缓存在Redis中，用户的好友列表或加入的群组变化时清除，其他情况在缓存过期后刷新
+func (s *friendServer) GetFriendSuggestions(ctx context.Context, req *relationext.GetFriendSuggestionsReq) (*relationext.GetFriendSuggestionsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	count := int(req.Count)
+	if count == 0 {
+		count = defaultFriendSuggestionCount
+	}
+	if count < 0 || count > maxFriendSuggestionCount {
+		return nil, errs.ErrArgs.WrapMsg("invalid count", "count", req.Count, "max", maxFriendSuggestionCount)
+	}
+	suggestions, err := s.db.GetFriendSuggestions(ctx, req.UserID, func(ctx context.Context) ([]*model.FriendSuggestion, error) {
+		return s.computeFriendSuggestions(ctx, req.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err = s.filterFriendSuggestions(ctx, req.UserID, suggestions)
+	if err != nil {
+		return nil, err
+	}
+	if len(suggestions) > count {
+		suggestions = suggestions[:count]
+	}
+	resp := &relationext.GetFriendSuggestionsResp{Suggestions: []*relationext.FriendSuggestion{}}
+	if len(suggestions) == 0 {
+		return resp, nil
+	}
+	users, err := s.userClient.GetUsersInfo(ctx, datautil.Slice(suggestions, func(e *model.FriendSuggestion) string { return e.UserID }))
+	if err != nil {
+		return nil, err
+	}
+	userMap := datautil.SliceToMap(users, func(e *sdkws.UserInfo) string { return e.UserID })
+	for _, suggestion := range suggestions {
+		user, ok := userMap[suggestion.UserID]
+		if !ok {
+			continue // 用户已被删除
+		}
+		resp.Suggestions = append(resp.Suggestions, &relationext.FriendSuggestion{
+			UserID:            suggestion.UserID,
+			Nickname:          user.Nickname,
+			FaceURL:           user.FaceURL,
+			MutualFriendCount: suggestion.MutualFriendCount,
+			SharedGroupCount:  suggestion.SharedGroupCount,
+		})
+	}
+	return resp, nil
+}
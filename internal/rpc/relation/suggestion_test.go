@@ -0,0 +1,33 @@
+package relation
+
+import (
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankFriendSuggestions(t *testing.T) {
+	mutualFriends := []*model.UserCount{
+		{UserID: "u1", Count: 3},
+		{UserID: "u2", Count: 1},
+		{UserID: "u3", Count: 2},
+	}
+	sharedGroups := []*groupext.SharedGroupCount{
+		{UserID: "u2", Count: 2},
+		{UserID: "u4", Count: 3},
+		{UserID: "u5", Count: 1},
+	}
+	suggestions := rankFriendSuggestions(mutualFriends, sharedGroups, 10)
+	userIDs := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		userIDs = append(userIDs, suggestion.UserID)
+	}
+	// u1、u2、u4关联数均为3，共同好友多的在前，再按用户ID排序
+	assert.Equal(t, []string{"u1", "u2", "u4", "u3", "u5"}, userIDs)
+	assert.Equal(t, &model.FriendSuggestion{UserID: "u2", MutualFriendCount: 1, SharedGroupCount: 2}, suggestions[1])
+
+	assert.Len(t, rankFriendSuggestions(mutualFriends, sharedGroups, 2), 2)
+	assert.Empty(t, rankFriendSuggestions(nil, nil, 10))
+}
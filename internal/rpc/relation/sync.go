@@ -194,6 +194,35 @@ func (s *friendServer) GetFullFriendUserIDs(ctx context.Context, req *relation.G
 	}, nil
 }
 
+// findFriendIncrVersion 返回查询好友版本日志的回调，从日志中分离出不对应具体好友的记录
+// 排序变更记录的版本写入sortVersion；存在好友分组变更记录时将categoryChanged置为true（categoryChanged可为nil）
+func (s *friendServer) findFriendIncrVersion(sortVersion *uint64, categoryChanged *bool) func(ctx context.Context, ownerUserID string, version uint, limit int) (*model.VersionLog, error) {
+	return func(ctx context.Context, ownerUserID string, version uint, limit int) (*model.VersionLog, error) {
+		// 查询用户好友关系的增量版本记录
+		vl, err := s.db.FindFriendIncrVersion(ctx, ownerUserID, version, limit)
+		if err != nil {
+			return nil, err
+		}
+		vl.Logs = slices.DeleteFunc(vl.Logs, func(elem model.VersionLogElem) bool {
+			switch elem.EID {
+			case model.VersionSortChangeID:
+				vl.LogLen--
+				*sortVersion = uint64(elem.Version)
+				return true
+			case model.VersionFriendCategoryChangeID:
+				vl.LogLen--
+				if categoryChanged != nil {
+					*categoryChanged = true
+				}
+				return true
+			default:
+				return false
+			}
+		})
+		return vl, nil
+	}
+}
+
 // GetIncrementalFriends 获取增量好友数据
 //
 // 基于版本号获取用户好友关系的增量变更数据。这是实现高效数据同步的核心方法，
@@ -253,29 +282,9 @@ func (s *friendServer) GetIncrementalFriends(ctx context.Context, req *relation.
 		VersionID:     req.VersionID, // 客户端版本ID
 		VersionNumber: req.Version,   // 客户端版本号
 
-		// Version 回调函数：获取指定版本之后的变更记录
-		Version: func(ctx context.Context, ownerUserID string, version uint, limit int) (*model.VersionLog, error) {
-			// 3.1 查询用户好友关系的增量版本记录
-			// FindFriendIncrVersion方法获取指定版本之后的所有变更记录
-			vl, err := s.db.FindFriendIncrVersion(ctx, ownerUserID, version, limit)
-			if err != nil {
-				return nil, err
-			}
-
-			// 3.2 处理排序变更记录
-			// 从版本日志中分离出排序变更记录，单独处理
-			vl.Logs = slices.DeleteFunc(vl.Logs, func(elem model.VersionLogElem) bool {
-				// 如果是排序变更记录（EID为VersionSortChangeID）
-				if elem.EID == model.VersionSortChangeID {
-					vl.LogLen--                        // 减少日志计数
-					sortVersion = uint64(elem.Version) // 记录排序版本号
-					return true                        // 从日志中删除此记录
-				}
-				return false
-			})
-
-			return vl, nil
-		},
+		// Version 回调函数：获取指定版本之后的变更记录，排序变更记录单独处理
+		// 好友分组变更记录不对应具体好友，proto响应无法携带分组，客户端通过get_friend_categories刷新分组
+		Version: s.findFriendIncrVersion(&sortVersion, nil),
 
 		// CacheMaxVersion 回调函数：获取最大版本号（通常从缓存获取）
 		CacheMaxVersion: s.db.FindMaxFriendVersionCache,
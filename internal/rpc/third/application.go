@@ -0,0 +1,334 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package third
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// appVersion 解析后的语义化版本号，省略的次版本号和修订号视为0
+type appVersion struct {
+	core [3]uint64
+	pre  []string
+}
+
+// parseAppVersion 解析语义化版本号，允许v前缀，构建元数据（+之后的部分）不参与比较
+func parseAppVersion(version string) (*appVersion, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var res appVersion
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		res.pre = strings.Split(s[i+1:], ".")
+		s = s[:i]
+		for _, id := range res.pre {
+			if id == "" {
+				return nil, errs.ErrArgs.WrapMsg("invalid version", "version", version)
+			}
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > len(res.core) {
+		return nil, errs.ErrArgs.WrapMsg("invalid version", "version", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, errs.ErrArgs.WrapMsg("invalid version", "version", version)
+		}
+		res.core[i] = n
+	}
+	return &res, nil
+}
+
+// compare 按语义化版本规则比较，返回-1、0或1；带预发布标识的版本低于对应的正式版本
+func (v *appVersion) compare(o *appVersion) int {
+	for i := range v.core {
+		if v.core[i] != o.core[i] {
+			if v.core[i] < o.core[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if c := comparePrerelease(v.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.pre) < len(o.pre):
+		return -1
+	case len(v.pre) > len(o.pre):
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease 比较预发布标识的单个字段，数字字段按数值比较且低于非数字字段
+func comparePrerelease(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		if an == bn {
+			return 0
+		}
+		if an < bn {
+			return -1
+		}
+		return 1
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// latestApplicationVersion 找出平台当前发布的版本，并根据客户端版本判断是否需要更新和强制更新
+// clientVersion为空时无法比较，只要有发布版本就视为需要更新，是否强制以发布版本的标记为准
+func latestApplicationVersion(apps []*model.Application, clientVersion string) (latest *model.Application, hasUpdate bool, force bool, err error) {
+	for _, app := range apps {
+		if app.Latest {
+			latest = app
+			break
+		}
+	}
+	if latest == nil {
+		return nil, false, false, nil
+	}
+	if clientVersion == "" {
+		return latest, true, latest.Force, nil
+	}
+	client, err := parseAppVersion(clientVersion)
+	if err != nil {
+		return nil, false, false, err
+	}
+	latestVersion, err := parseAppVersion(latest.Version)
+	if err != nil {
+		return nil, false, false, err
+	}
+	if client.compare(latestVersion) >= 0 {
+		return latest, false, false, nil
+	}
+	// 客户端跳过的版本中只要有一个要求强制更新，就必须更新到最新版本
+	for _, app := range apps {
+		if !app.Force {
+			continue
+		}
+		version, err := parseAppVersion(app.Version)
+		if err != nil {
+			continue
+		}
+		if client.compare(version) < 0 && version.compare(latestVersion) <= 0 {
+			return latest, true, true, nil
+		}
+	}
+	return latest, true, false, nil
+}
+
+func applicationDB2Ext(app *model.Application) *thirdext.ApplicationVersion {
+	return &thirdext.ApplicationVersion{
+		ID:         app.ID.Hex(),
+		Platform:   app.Platform,
+		Hot:        app.Hot,
+		Version:    app.Version,
+		Url:        app.Url,
+		Text:       app.Text,
+		Force:      app.Force,
+		Latest:     app.Latest,
+		CreateTime: app.CreateTime.UnixMilli(),
+	}
+}
+
+// checkApplicationVersion 校验版本内容，平台必须是constant中定义的平台名称（与网关按平台ID换算的名称一致），
+// 同一平台下语义相同的版本号（如1.0和1.0.0）视为重复
+func (t *thirdServer) checkApplicationVersion(ctx context.Context, app *model.Application) error {
+	if _, ok := constant.PlatformName2ID[app.Platform]; !ok {
+		return errs.ErrArgs.WrapMsg("invalid platform", "platform", app.Platform)
+	}
+	if app.Url == "" {
+		return errs.ErrArgs.WrapMsg("url is empty")
+	}
+	version, err := parseAppVersion(app.Version)
+	if err != nil {
+		return err
+	}
+	apps, err := t.applicationDatabase.FindPlatformVersions(ctx, app.Platform)
+	if err != nil {
+		return err
+	}
+	for _, other := range apps {
+		if other.ID == app.ID {
+			continue
+		}
+		otherVersion, err := parseAppVersion(other.Version)
+		if err != nil {
+			continue
+		}
+		if version.compare(otherVersion) == 0 {
+			return errs.ErrDuplicateKey.WrapMsg("version already exists", "platform", app.Platform, "version", other.Version)
+		}
+	}
+	return nil
+}
+
+// AddApplicationVersion 新增应用版本，仅系统管理员可用
+// 新版本带有最新标记时会取消同平台其他版本的标记
+func (t *thirdServer) AddApplicationVersion(ctx context.Context, req *thirdext.AddApplicationVersionReq) (*thirdext.AddApplicationVersionResp, error) {
+	if err := authverify.CheckAdmin(ctx, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	app := &model.Application{
+		ID:         primitive.NewObjectID(),
+		Platform:   req.Platform,
+		Hot:        req.Hot,
+		Version:    req.Version,
+		Url:        req.Url,
+		Text:       req.Text,
+		Force:      req.Force,
+		Latest:     req.Latest,
+		CreateTime: time.Now(),
+	}
+	if err := t.checkApplicationVersion(ctx, app); err != nil {
+		return nil, err
+	}
+	if err := t.applicationDatabase.AddVersion(ctx, app); err != nil {
+		if mgo.IsDuplicateKey(err) {
+			return nil, errs.ErrDuplicateKey.WrapMsg("version already exists", "platform", app.Platform, "version", app.Version)
+		}
+		return nil, err
+	}
+	return &thirdext.AddApplicationVersionResp{Version: applicationDB2Ext(app)}, nil
+}
+
+// UpdateApplicationVersion 更新应用版本，仅系统管理员可用
+func (t *thirdServer) UpdateApplicationVersion(ctx context.Context, req *thirdext.UpdateApplicationVersionReq) (*thirdext.UpdateApplicationVersionResp, error) {
+	if err := authverify.CheckAdmin(ctx, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(req.ID)
+	if err != nil {
+		return nil, errs.ErrArgs.WrapMsg("invalid id", "id", req.ID)
+	}
+	app, err := t.applicationDatabase.TakeVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Platform != nil {
+		app.Platform = *req.Platform
+	}
+	if req.Hot != nil {
+		app.Hot = *req.Hot
+	}
+	if req.Version != nil {
+		app.Version = *req.Version
+	}
+	if req.Url != nil {
+		app.Url = *req.Url
+	}
+	if req.Text != nil {
+		app.Text = *req.Text
+	}
+	if req.Force != nil {
+		app.Force = *req.Force
+	}
+	if req.Latest != nil {
+		app.Latest = *req.Latest
+	}
+	if err := t.checkApplicationVersion(ctx, app); err != nil {
+		return nil, err
+	}
+	if err := t.applicationDatabase.UpdateVersion(ctx, app); err != nil {
+		if mgo.IsDuplicateKey(err) {
+			return nil, errs.ErrDuplicateKey.WrapMsg("version already exists", "platform", app.Platform, "version", app.Version)
+		}
+		return nil, err
+	}
+	return &thirdext.UpdateApplicationVersionResp{Version: applicationDB2Ext(app)}, nil
+}
+
+// DeleteApplicationVersions 删除应用版本，仅系统管理员可用
+func (t *thirdServer) DeleteApplicationVersions(ctx context.Context, req *thirdext.DeleteApplicationVersionsReq) (*thirdext.DeleteApplicationVersionsResp, error) {
+	if err := authverify.CheckAdmin(ctx, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(req.IDs))
+	for _, s := range datautil.Distinct(req.IDs) {
+		id, err := primitive.ObjectIDFromHex(s)
+		if err != nil {
+			return nil, errs.ErrArgs.WrapMsg("invalid id", "id", s)
+		}
+		ids = append(ids, id)
+	}
+	if err := t.applicationDatabase.DeleteVersions(ctx, ids); err != nil {
+		return nil, err
+	}
+	return &thirdext.DeleteApplicationVersionsResp{}, nil
+}
+
+// SearchApplicationVersions 按创建时间倒序分页搜索应用版本，仅系统管理员可用
+func (t *thirdServer) SearchApplicationVersions(ctx context.Context, req *thirdext.SearchApplicationVersionsReq) (*thirdext.SearchApplicationVersionsResp, error) {
+	if err := authverify.CheckAdmin(ctx, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	total, apps, err := t.applicationDatabase.SearchVersions(ctx, req.Keyword, req.Platforms, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &thirdext.SearchApplicationVersionsResp{Total: total, Versions: datautil.Slice(apps, applicationDB2Ext)}, nil
+}
+
+// GetLatestApplicationVersion 获取平台当前发布的版本，客户端启动时和网关在连接建立时调用，不校验调用者身份
+func (t *thirdServer) GetLatestApplicationVersion(ctx context.Context, req *thirdext.GetLatestApplicationVersionReq) (*thirdext.GetLatestApplicationVersionResp, error) {
+	if req.Platform == "" {
+		return nil, errs.ErrArgs.WrapMsg("platform is empty")
+	}
+	apps, err := t.applicationDatabase.FindPlatformVersions(ctx, req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	latest, hasUpdate, force, err := latestApplicationVersion(apps, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	resp := &thirdext.GetLatestApplicationVersionResp{HasUpdate: hasUpdate, Force: force}
+	if latest != nil {
+		resp.Version = applicationDB2Ext(latest)
+	}
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+package third
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppVersion(t *testing.T) {
+	for _, s := range []string{"", "1..2", "1.2.3.4", "a.b", "1.0-", "1.0-beta..1"} {
+		_, err := parseAppVersion(s)
+		assert.Error(t, err, s)
+	}
+	v, err := parseAppVersion("v1.2.3-beta.1+build.5")
+	assert.NoError(t, err)
+	assert.Equal(t, [3]uint64{1, 2, 3}, v.core)
+	assert.Equal(t, []string{"beta", "1"}, v.pre)
+}
+
+func TestAppVersionCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2", "1.10.0", "2"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := parseAppVersion(ordered[i])
+		assert.NoError(t, err)
+		b, err := parseAppVersion(ordered[i+1])
+		assert.NoError(t, err)
+		assert.Equal(t, -1, a.compare(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, b.compare(a), "%s > %s", ordered[i+1], ordered[i])
+	}
+	a, _ := parseAppVersion("v1.0")
+	b, _ := parseAppVersion("1.0.0+build")
+	assert.Equal(t, 0, a.compare(b))
+}
+
+func TestLatestApplicationVersion(t *testing.T) {
+	apps := []*model.Application{
+		{Version: "1.0.0"},
+		{Version: "1.1.0", Force: true},
+		{Version: "1.2.0", Latest: true},
+		{Version: "2.0.0-beta", Force: true},
+	}
+
+	latest, hasUpdate, force, err := latestApplicationVersion(apps, "1.0.0")
+	assert.NoError(t, err)
+	assert.Same(t, apps[2], latest)
+	assert.True(t, hasUpdate)
+	assert.True(t, force)
+
+	_, hasUpdate, force, err = latestApplicationVersion(apps, "1.1.0")
+	assert.NoError(t, err)
+	assert.True(t, hasUpdate)
+	assert.False(t, force)
+
+	_, hasUpdate, force, err = latestApplicationVersion(apps, "1.2.0")
+	assert.NoError(t, err)
+	assert.False(t, hasUpdate)
+	assert.False(t, force)
+
+	_, hasUpdate, force, err = latestApplicationVersion(apps, "")
+	assert.NoError(t, err)
+	assert.True(t, hasUpdate)
+	assert.False(t, force)
+
+	_, _, _, err = latestApplicationVersion(apps, "bad")
+	assert.Error(t, err)
+
+	latest, hasUpdate, _, err = latestApplicationVersion(apps[:2], "1.0.0")
+	assert.NoError(t, err)
+	assert.Nil(t, latest)
+	assert.False(t, hasUpdate)
+}
+
+func TestCheckApplicationVersionPlatform(t *testing.T) {
+	var s thirdServer
+	for _, platform := range []string{"", "android", "Unknown"} {
+		err := s.checkApplicationVersion(context.Background(), &model.Application{Platform: platform, Version: "1.0.0", Url: "https://example.com/app"})
+		assert.True(t, errs.ErrArgs.Is(err), platform)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package third
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
+	"github.com/openimsdk/tools/errs"
+)
+
+const maxDeleteUserObjectsLimit = 1000
+
+// DeleteUserObjects 删除注销用户上传的对象，仅系统管理员可调用
+func (t *thirdServer) DeleteUserObjects(ctx context.Context, req *thirdext.DeleteUserObjectsReq) (*thirdext.DeleteUserObjectsResp, error) {
+	if err := authverify.CheckAdmin(ctx, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	if req.Limit <= 0 || req.Limit > maxDeleteUserObjectsLimit {
+		return nil, errs.ErrArgs.WrapMsg("limit must be between 1 and 1000")
+	}
+	engine := t.config.RpcConfig.Object.Enable
+	models, err := t.s3dataBase.FindUserObject(ctx, engine, req.UserID, int64(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	if err := t.deleteObjects(ctx, engine, models); err != nil {
+		return nil, err
+	}
+	return &thirdext.DeleteUserObjectsResp{DeletedCount: int64(len(models))}, nil
+}
@@ -299,25 +299,33 @@ func (t *thirdServer) DeleteOutdatedData(ctx context.Context, req *third.DeleteO
 	if err != nil {
 		return nil, err
 	}
+	if err := t.deleteObjects(ctx, engine, models); err != nil {
+		return nil, err
+	}
+	return &third.DeleteOutdatedDataResp{Count: int32(len(models))}, nil
+}
+
+// deleteObjects 删除对象记录，存储中的文件在没有其他记录引用时一并删除
+func (t *thirdServer) deleteObjects(ctx context.Context, engine string, models []*model.Object) error {
 	for i, obj := range models {
 		if err := t.s3dataBase.DeleteSpecifiedData(ctx, engine, []string{obj.Name}); err != nil {
-			return nil, errs.Wrap(err)
+			return errs.Wrap(err)
 		}
 		if err := t.s3dataBase.DelS3Key(ctx, engine, obj.Name); err != nil {
-			return nil, err
+			return err
 		}
 		count, err := t.s3dataBase.GetKeyCount(ctx, engine, obj.Key)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		log.ZDebug(ctx, "delete s3 object record", "index", i, "s3", obj, "count", count)
 		if count == 0 {
 			if err := t.s3.DeleteObject(ctx, obj.Key); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
-	return &third.DeleteOutdatedDataResp{Count: int32(len(models))}, nil
+	return nil
 }
 
 type FormDataMate struct {
@@ -17,6 +17,7 @@ package third
 import (
 	"context"
 	"fmt"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 	"time"
 
@@ -45,6 +46,8 @@ type thirdServer struct {
 	config        *Config
 	s3            s3.Interface
 	userClient    *rpcli.UserClient
+
+	applicationDatabase controller.ApplicationDatabase
 }
 
 type Config struct {
@@ -75,6 +78,10 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	if err != nil {
 		return err
 	}
+	applicationDB, err := mgo.NewApplicationMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
 
 	// Select the oss method according to the profile policy
 	enable := config.RpcConfig.Object.Enable
@@ -101,14 +108,18 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 	localcache.InitLocalCache(&config.LocalCacheConfig)
-	third.RegisterThirdServer(server, &thirdServer{
+	ts := &thirdServer{
 		thirdDatabase: controller.NewThirdDatabase(redis.NewThirdCache(rdb), logdb),
 		s3dataBase:    controller.NewS3Database(rdb, o, s3db),
 		defaultExpire: time.Hour * 24 * 7,
 		config:        config,
 		s3:            o,
 		userClient:    rpcli.NewUserClient(userConn),
-	})
+
+		applicationDatabase: controller.NewApplicationDatabase(applicationDB, mgocli.GetTx()),
+	}
+	third.RegisterThirdServer(server, ts)
+	thirdext.RegisterThirdExtServer(server, ts)
 	return nil
 }
 
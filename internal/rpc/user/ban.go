@@ -0,0 +1,141 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// userBanDB2Ext 转换用户的封禁状态，未封禁或在now时已到期返回nil
+func userBanDB2Ext(user *model.User, now time.Time) *userext.UserBan {
+	if !user.IsBanned(now) {
+		return nil
+	}
+	var expireTime int64
+	if !user.BanExpireTime.IsZero() {
+		expireTime = user.BanExpireTime.UnixMilli()
+	}
+	return &userext.UserBan{
+		UserID:         user.UserID,
+		Reason:         user.BanReason,
+		OperatorUserID: user.BanOperatorUserID,
+		BanTime:        user.BanTime.UnixMilli(),
+		ExpireTime:     expireTime,
+	}
+}
+
+// BanUser 封禁用户并强制其在所有平台下线，仅系统管理员可调用
+// 封禁期间无法获取令牌和发送消息，到期后自动解除
+func (s *userServer) BanUser(ctx context.Context, req *userext.BanUserReq) (*userext.BanUserResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if datautil.Contain(req.UserID, s.config.Share.IMAdminUserID...) {
+		return nil, errs.ErrArgs.WrapMsg("app manager cannot be banned", "userID", req.UserID)
+	}
+	now := time.Now()
+	var expireTime time.Time
+	if req.ExpireTime != 0 {
+		expireTime = time.UnixMilli(req.ExpireTime)
+		if !expireTime.After(now) {
+			return nil, errs.ErrArgs.WrapMsg("expireTime must be in the future", "expireTime", req.ExpireTime)
+		}
+	}
+	user, err := s.db.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	user.BanReason = req.Reason
+	user.BanOperatorUserID = mcontext.GetOpUserID(ctx)
+	user.BanTime = now
+	user.BanExpireTime = expireTime
+	if err := s.db.UpdateByMap(ctx, req.UserID, map[string]any{
+		"ban_reason":           user.BanReason,
+		"ban_operator_user_id": user.BanOperatorUserID,
+		"ban_time":             user.BanTime,
+		"ban_expire_time":      user.BanExpireTime,
+	}); err != nil {
+		return nil, err
+	}
+	// 封禁已生效，踢下线失败时由发送消息的封禁检查兜底
+	if err := s.revokeUserTokens(ctx, req.UserID); err != nil {
+		log.ZError(ctx, "kick banned user failed", err, "userID", req.UserID)
+	}
+	return &userext.BanUserResp{Ban: userBanDB2Ext(user, now)}, nil
+}
+
+// UnbanUser 解除用户封禁，仅系统管理员可调用
+func (s *userServer) UnbanUser(ctx context.Context, req *userext.UnbanUserReq) (*userext.UnbanUserResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.db.GetUserByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := s.db.UpdateByMap(ctx, req.UserID, map[string]any{
+		"ban_reason":           "",
+		"ban_operator_user_id": "",
+		"ban_time":             time.Time{},
+		"ban_expire_time":      time.Time{},
+	}); err != nil {
+		return nil, err
+	}
+	return &userext.UnbanUserResp{}, nil
+}
+
+// GetUserBan 获取用户当前的封禁，供认证和消息服务检查，不校验调用者身份
+// 用户不存在时视为未封禁，由调用方自行校验用户
+func (s *userServer) GetUserBan(ctx context.Context, req *userext.GetUserBanReq) (*userext.GetUserBanResp, error) {
+	users, err := s.db.Find(ctx, []string{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return &userext.GetUserBanResp{}, nil
+	}
+	return &userext.GetUserBanResp{Ban: userBanDB2Ext(users[0], time.Now())}, nil
+}
+
+// SearchBannedUsers 分页查询封禁中的用户，仅系统管理员可调用
+func (s *userServer) SearchBannedUsers(ctx context.Context, req *userext.SearchBannedUsersReq) (*userext.SearchBannedUsersResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	total, users, err := s.db.PageFindBannedUsers(ctx, req.Keyword, now, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.SearchBannedUsersResp{
+		Total: total,
+		Users: datautil.Slice(users, func(user *model.User) *userext.BannedUser {
+			return &userext.BannedUser{
+				UserID:   user.UserID,
+				Nickname: user.Nickname,
+				FaceURL:  user.FaceURL,
+				Ban:      userBanDB2Ext(user, now),
+			}
+		}),
+	}, nil
+}
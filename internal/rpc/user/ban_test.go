@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
+	"github.com/openimsdk/protocol/auth"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestUserBanDB2Ext(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	user := &model.User{UserID: "u1"}
+	assert.Nil(t, userBanDB2Ext(user, now))
+
+	user.BanReason = "spam"
+	user.BanOperatorUserID = "admin"
+	user.BanTime = now.Add(-time.Hour)
+	ban := userBanDB2Ext(user, now)
+	assert.Equal(t, "u1", ban.UserID)
+	assert.Equal(t, "spam", ban.Reason)
+	assert.Equal(t, "admin", ban.OperatorUserID)
+	assert.Equal(t, user.BanTime.UnixMilli(), ban.BanTime)
+	assert.Zero(t, ban.ExpireTime)
+	assert.True(t, ban.IsActive(now.Add(24*365*time.Hour)))
+
+	user.BanExpireTime = now.Add(time.Hour)
+	ban = userBanDB2Ext(user, now)
+	assert.Equal(t, user.BanExpireTime.UnixMilli(), ban.ExpireTime)
+	assert.True(t, ban.IsActive(now))
+	assert.False(t, ban.IsActive(user.BanExpireTime))
+	assert.Nil(t, userBanDB2Ext(user, user.BanExpireTime))
+
+	var none *userext.UserBan
+	assert.False(t, none.IsActive(now))
+}
+
+type forceLogoutAuthClient struct {
+	auth.AuthClient
+	platformIDs []int32
+}
+
+func (c *forceLogoutAuthClient) ForceLogout(ctx context.Context, in *auth.ForceLogoutReq, opts ...grpc.CallOption) (*auth.ForceLogoutResp, error) {
+	c.platformIDs = append(c.platformIDs, in.PlatformID)
+	if in.PlatformID == constant.IOSPlatformID {
+		return nil, errors.New("auth unavailable")
+	}
+	return &auth.ForceLogoutResp{}, nil
+}
+
+func TestRevokeUserTokensKicksAllPlatforms(t *testing.T) {
+	client := &forceLogoutAuthClient{}
+	s := &userServer{authClient: &rpcli.AuthClient{AuthClient: client}}
+	err := s.revokeUserTokens(context.Background(), "u1")
+	assert.Error(t, err)
+	assert.Len(t, client.platformIDs, len(constant.PlatformID2Name))
+}
@@ -73,6 +73,7 @@ import (
 
 	cbapi "github.com/openimsdk/open-im-server/v3/pkg/callbackstruct"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	pbuser "github.com/openimsdk/protocol/user"
 )
 
@@ -334,3 +335,17 @@ func (s *userServer) webhookAfterUserRegister(ctx context.Context, after *config
 	// 异步通知外部系统用户注册完成
 	s.webhookClient.AsyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, &cbapi.CallbackAfterUserRegisterResp{}, after)
 }
+
+// webhookAfterUserErased 用户注销完成后置回调
+//
+// 注销任务全部步骤完成后异步通知业务服务器，业务服务器可据此清理自身保存的用户数据
+func (s *userServer) webhookAfterUserErased(ctx context.Context, after *config.AfterConfig, erasure *model.UserErasure) {
+	cbReq := &cbapi.CallbackAfterUserErasedReq{
+		CallbackCommand: cbapi.CallbackAfterUserErasedCommand,
+		UserID:          erasure.UserID,
+		OperatorUserID:  erasure.OperatorUserID,
+		DeleteMsgs:      erasure.DeleteMsgs,
+		FinishTime:      erasure.FinishTime.UnixMilli(),
+	}
+	s.webhookClient.AsyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, &cbapi.CallbackAfterUserErasedResp{}, after)
+}
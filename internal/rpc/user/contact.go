@@ -0,0 +1,159 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// contactHashLen 标识哈希的长度，sha256的十六进制表示
+const contactHashLen = sha256.Size * 2
+
+// normalizePhone 规范化手机号：只保留数字和开头的+
+func normalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	var b strings.Builder
+	for i, r := range phone {
+		if (r >= '0' && r <= '9') || (r == '+' && i == 0) {
+			b.WriteRune(r)
+		}
+	}
+	if res := b.String(); res != "+" {
+		return res
+	}
+	return ""
+}
+
+// normalizeEmail 规范化邮箱：去除首尾空白并转为小写
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// hashContactIdentifier 计算规范化标识的加盐哈希，客户端使用相同的算法
+func hashContactIdentifier(salt string, identifier string) string {
+	sum := sha256.Sum256([]byte(salt + identifier))
+	return hex.EncodeToString(sum[:])
+}
+
+// userIdentifiersFromEx 从用户Ex中读取手机号、邮箱并计算哈希
+// Ex不是JSON对象或字段不是字符串时忽略对应标识
+func userIdentifiersFromEx(conf *config.ContactImport, userID string, ex string, now time.Time) []*model.UserIdentifier {
+	var fields map[string]any
+	if ex == "" || json.Unmarshal([]byte(ex), &fields) != nil {
+		return nil
+	}
+	var identifiers []*model.UserIdentifier
+	add := func(key string, typ int32, normalize func(string) string) {
+		if key == "" {
+			return
+		}
+		value, ok := fields[key].(string)
+		if !ok {
+			return
+		}
+		if value = normalize(value); value == "" {
+			return
+		}
+		identifiers = append(identifiers, &model.UserIdentifier{
+			UserID:     userID,
+			Type:       typ,
+			Hash:       hashContactIdentifier(conf.Salt, value),
+			CreateTime: now,
+		})
+	}
+	add(conf.PhoneExKey, model.UserIdentifierPhone, normalizePhone)
+	add(conf.EmailExKey, model.UserIdentifierEmail, normalizeEmail)
+	return identifiers
+}
+
+// syncUserIdentifiers 用户Ex变化后重建其在标识索引中的标识，未启用通讯录匹配时不处理
+func (s *userServer) syncUserIdentifiers(ctx context.Context, userID string, ex string) error {
+	conf := &s.config.RpcConfig.ContactImport
+	if !conf.Enable() {
+		return nil
+	}
+	return s.db.SetUserIdentifiers(ctx, userID, userIdentifiersFromEx(conf, userID, ex, time.Now()))
+}
+
+// GetContactImportConfig 获取通讯录匹配的哈希参数
+func (s *userServer) GetContactImportConfig(ctx context.Context, req *userext.GetContactImportConfigReq) (*userext.GetContactImportConfigResp, error) {
+	conf := &s.config.RpcConfig.ContactImport
+	return &userext.GetContactImportConfigResp{Enabled: conf.Enable(), Salt: conf.Salt}, nil
+}
+
+// MatchContacts 匹配通讯录中已注册的用户
+// 提交的哈希只用于查询，不写入存储；请求和响应在日志中只记录哈希数量和匹配到的用户（见userext中的String）；按用户统计提交的哈希数限制频率，系统管理员不受限制
+func (s *userServer) MatchContacts(ctx context.Context, req *userext.MatchContactsReq) (*userext.MatchContactsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	conf := &s.config.RpcConfig.ContactImport
+	if !conf.Enable() {
+		return nil, errs.ErrArgs.WrapMsg("contact import is disabled")
+	}
+	if len(req.Hashes) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("hashes is empty")
+	}
+	if conf.MaxHashesPerReq > 0 && len(req.Hashes) > conf.MaxHashesPerReq {
+		return nil, errs.ErrArgs.WrapMsg("too many hashes", "max", conf.MaxHashesPerReq)
+	}
+	hashes := make([]string, 0, len(req.Hashes))
+	for _, hash := range req.Hashes {
+		hash = strings.ToLower(hash)
+		if len(hash) != contactHashLen {
+			return nil, errs.ErrArgs.WrapMsg("invalid hash length", "len", len(hash))
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, errs.ErrArgs.WrapMsg("hash is not hex")
+		}
+		hashes = append(hashes, hash)
+	}
+	hashes = datautil.Distinct(hashes)
+	if conf.RateLimitHashes > 0 && !authverify.IsAppManagerUid(ctx, s.config.Share.IMAdminUserID) {
+		window := time.Duration(conf.RateLimitWindowSec) * time.Second
+		total, ttl, err := s.db.IncrContactMatchCount(ctx, req.UserID, int64(len(hashes)), window)
+		if err != nil {
+			return nil, err
+		}
+		if total > int64(conf.RateLimitHashes) {
+			return nil, servererrs.ErrContactMatchLimitExceeded.WrapMsg("too many contacts submitted", "limit", conf.RateLimitHashes, "retryAfter", time.Now().Add(ttl).UnixMilli())
+		}
+	}
+	identifiers, err := s.db.FindUserIdentifiers(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	resp := &userext.MatchContactsResp{Matches: make([]*userext.ContactMatch, 0, len(identifiers))}
+	for _, identifier := range identifiers {
+		if identifier.UserID == req.UserID {
+			continue
+		}
+		resp.Matches = append(resp.Matches, &userext.ContactMatch{Hash: identifier.Hash, UserID: identifier.UserID})
+	}
+	return resp, nil
+}
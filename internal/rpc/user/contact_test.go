@@ -0,0 +1,62 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeContactIdentifier(t *testing.T) {
+	assert.Equal(t, "+8613800000000", normalizePhone(" +86 138-0000-0000 "))
+	assert.Equal(t, "13800000000", normalizePhone("(138) 0000 0000"))
+	assert.Equal(t, "", normalizePhone("+"))
+	assert.Equal(t, "", normalizePhone("abc"))
+	assert.Equal(t, "alice@example.com", normalizeEmail(" Alice@Example.COM "))
+}
+
+func TestUserIdentifiersFromEx(t *testing.T) {
+	conf := &config.ContactImport{Salt: "salt", PhoneExKey: "phoneNumber", EmailExKey: "email"}
+	now := time.Now()
+
+	identifiers := userIdentifiersFromEx(conf, "u1", `{"phoneNumber":"+86 138 0000 0000","email":"Alice@Example.com","other":1}`, now)
+	require.Len(t, identifiers, 2)
+	assert.Equal(t, model.UserIdentifierPhone, identifiers[0].Type)
+	assert.Equal(t, hashContactIdentifier("salt", "+8613800000000"), identifiers[0].Hash)
+	assert.Equal(t, model.UserIdentifierEmail, identifiers[1].Type)
+	assert.Equal(t, hashContactIdentifier("salt", "alice@example.com"), identifiers[1].Hash)
+	assert.Equal(t, "u1", identifiers[1].UserID)
+	assert.Len(t, identifiers[0].Hash, contactHashLen)
+
+	// 非字符串字段、非JSON的Ex以及未配置的字段都不索引
+	assert.Empty(t, userIdentifiersFromEx(conf, "u1", `{"phoneNumber":13800000000}`, now))
+	assert.Empty(t, userIdentifiersFromEx(conf, "u1", "plain text", now))
+	assert.Empty(t, userIdentifiersFromEx(&config.ContactImport{Salt: "salt"}, "u1", `{"email":"a@b.c"}`, now))
+}
+
+func TestMatchContactsLogRedaction(t *testing.T) {
+	hash := strings.Repeat("ab", contactHashLen/2)
+	req := &userext.MatchContactsReq{UserID: "u1", Hashes: []string{hash, hash}}
+	resp := &userext.MatchContactsResp{Matches: []*userext.ContactMatch{{Hash: hash, UserID: "u2"}}}
+
+	// mw拦截器通过zap记录请求和响应，zap对实现了fmt.Stringer的值使用String()
+	for _, v := range []any{req, resp} {
+		_, ok := v.(fmt.Stringer)
+		assert.True(t, ok)
+		assert.NotContains(t, fmt.Sprint(v), hash)
+	}
+	assert.Equal(t, "{userID:u1 hashes:2}", fmt.Sprint(req))
+	assert.Equal(t, "{matches:[u2]}", fmt.Sprint(resp))
+
+	// 扩展RPC的JSON编码不受影响
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), hash)
+}
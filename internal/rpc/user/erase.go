@@ -0,0 +1,295 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/servererrs"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/protocol/auth"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+const (
+	// userErasureStaleTime 执行中的任务超过该时间没有进展时视为中断，可以被接管
+	userErasureStaleTime = 30 * time.Minute
+	// userErasureBatchSize 删除消息和文件时每批的数量
+	userErasureBatchSize = 500
+	// resumeStaleUserErasuresLimit 定时任务每次最多接管的任务数
+	resumeStaleUserErasuresLimit = 100
+)
+
+func userErasureDB2Ext(erasure *model.UserErasure) *userext.UserErasure {
+	var finishTime int64
+	if !erasure.FinishTime.IsZero() {
+		finishTime = erasure.FinishTime.UnixMilli()
+	}
+	return &userext.UserErasure{
+		UserID:     erasure.UserID,
+		DeleteMsgs: erasure.DeleteMsgs,
+		Status:     erasure.Status,
+		Steps: datautil.Slice(erasure.Steps, func(step *model.UserErasureStep) *userext.UserErasureStep {
+			return &userext.UserErasureStep{
+				Name:       step.Name,
+				Status:     step.Status,
+				Error:      step.Error,
+				UpdateTime: step.UpdateTime.UnixMilli(),
+			}
+		}),
+		OperatorUserID: erasure.OperatorUserID,
+		CreateTime:     erasure.CreateTime.UnixMilli(),
+		UpdateTime:     erasure.UpdateTime.UnixMilli(),
+		FinishTime:     finishTime,
+	}
+}
+
+// EraseUser 创建用户注销任务并在后台执行，仅系统管理员可调用
+func (s *userServer) EraseUser(ctx context.Context, req *userext.EraseUserReq) (*userext.EraseUserResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.UserID == "" {
+		return nil, errs.ErrArgs.WrapMsg("userID is empty")
+	}
+	if datautil.Contain(req.UserID, s.config.Share.IMAdminUserID...) {
+		return nil, errs.ErrArgs.WrapMsg("app manager cannot be erased", "userID", req.UserID)
+	}
+	if _, err := s.db.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	erasure := model.NewUserErasure(req.UserID, req.DeleteMsgs, mcontext.GetOpUserID(ctx), time.Now())
+	if err := s.userErasureDB.Create(ctx, erasure); err != nil {
+		if mgo.IsDuplicateKey(err) {
+			return nil, servererrs.ErrUserErasureExist.WrapMsg("user erasure already exists", "userID", req.UserID)
+		}
+		return nil, err
+	}
+	s.startUserErasure(ctx, erasure)
+	return &userext.EraseUserResp{Erasure: userErasureDB2Ext(erasure)}, nil
+}
+
+// ResumeUserErasure 继续执行失败或中断的注销任务，仅系统管理员可调用
+func (s *userServer) ResumeUserErasure(ctx context.Context, req *userext.ResumeUserErasureReq) (*userext.ResumeUserErasureResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	ok, err := s.userErasureDB.Claim(ctx, req.UserID, now.Add(-userErasureStaleTime), now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if _, err := s.userErasureDB.Take(ctx, req.UserID); err != nil {
+			if mgo.IsNotFound(err) {
+				return nil, errs.ErrRecordNotFound.WrapMsg("user erasure not found", "userID", req.UserID)
+			}
+			return nil, err
+		}
+		return nil, servererrs.ErrUserErasureNotResumable.WrapMsg("user erasure is finished or still running", "userID", req.UserID)
+	}
+	erasure, err := s.userErasureDB.Take(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	s.startUserErasure(ctx, erasure)
+	return &userext.ResumeUserErasureResp{Erasure: userErasureDB2Ext(erasure)}, nil
+}
+
+// GetUserErasures 分页查询注销任务，仅系统管理员可调用
+func (s *userServer) GetUserErasures(ctx context.Context, req *userext.GetUserErasuresReq) (*userext.GetUserErasuresResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	total, erasures, err := s.userErasureDB.FindPage(ctx, req.UserIDs, req.Status, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserErasuresResp{Total: total, Erasures: datautil.Slice(erasures, userErasureDB2Ext)}, nil
+}
+
+// ResumeStaleUserErasures 接管中断的注销任务，仅系统管理员可调用，由定时任务周期执行
+// 执行失败的任务需要管理员确认原因后通过ResumeUserErasure重试
+func (s *userServer) ResumeStaleUserErasures(ctx context.Context, req *userext.ResumeStaleUserErasuresReq) (*userext.ResumeStaleUserErasuresResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	staleBefore := now.Add(-userErasureStaleTime)
+	erasures, err := s.userErasureDB.FindStale(ctx, staleBefore, resumeStaleUserErasuresLimit)
+	if err != nil {
+		return nil, err
+	}
+	var count int64
+	for _, erasure := range erasures {
+		ok, err := s.userErasureDB.Claim(ctx, erasure.UserID, staleBefore, now)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		s.startUserErasure(ctx, erasure)
+		count++
+	}
+	return &userext.ResumeStaleUserErasuresResp{ResumedCount: count}, nil
+}
+
+// startUserErasure 在后台执行注销任务，任务不随请求结束而取消
+// 调用其他服务时沿用请求中的操作者，各服务的清理接口都要求系统管理员身份
+func (s *userServer) startUserErasure(ctx context.Context, erasure *model.UserErasure) {
+	ctx = context.WithoutCancel(ctx)
+	go s.runUserErasure(ctx, erasure)
+}
+
+// runUserErasure 依次执行未完成的步骤，每一步完成后保存结果，任一步失败时任务置为失败并停止
+func (s *userServer) runUserErasure(ctx context.Context, erasure *model.UserErasure) {
+	log.ZInfo(ctx, "user erasure start", "userID", erasure.UserID)
+	for _, step := range erasure.Steps {
+		if step.Status == model.UserErasureStatusSucceeded {
+			continue
+		}
+		err := s.runUserErasureStep(ctx, erasure, step.Name)
+		now := time.Now()
+		step.UpdateTime = now
+		if err == nil {
+			step.Status = model.UserErasureStatusSucceeded
+			step.Error = ""
+		} else {
+			step.Status = model.UserErasureStatusFailed
+			step.Error = err.Error()
+			erasure.Status = model.UserErasureStatusFailed
+		}
+		erasure.UpdateTime = now
+		args := map[string]any{"steps": erasure.Steps, "status": erasure.Status, "update_time": now}
+		if err := s.userErasureDB.Update(ctx, erasure.UserID, args); err != nil {
+			log.ZError(ctx, "save user erasure step failed", err, "userID", erasure.UserID, "step", step.Name)
+			return
+		}
+		if err != nil {
+			log.ZError(ctx, "user erasure step failed", err, "userID", erasure.UserID, "step", step.Name)
+			return
+		}
+	}
+	now := time.Now()
+	erasure.Status = model.UserErasureStatusSucceeded
+	erasure.UpdateTime = now
+	erasure.FinishTime = now
+	erasure.Finished = true
+	args := map[string]any{"status": erasure.Status, "finished": true, "update_time": now, "finish_time": now}
+	if err := s.userErasureDB.Update(ctx, erasure.UserID, args); err != nil {
+		log.ZError(ctx, "finish user erasure failed", err, "userID", erasure.UserID)
+		return
+	}
+	log.ZInfo(ctx, "user erasure finished", "userID", erasure.UserID)
+	s.webhookAfterUserErased(ctx, &s.config.WebhooksConfig.AfterUserErased, erasure)
+}
+
+func (s *userServer) runUserErasureStep(ctx context.Context, erasure *model.UserErasure, name string) error {
+	userID := erasure.UserID
+	switch name {
+	case model.UserErasureStepRevokeTokens:
+		return s.revokeUserTokens(ctx, userID)
+	case model.UserErasureStepGroups:
+		_, err := s.groupClient.GroupExtClient.EraseUserGroups(ctx, &groupext.EraseUserGroupsReq{UserID: userID})
+		return err
+	case model.UserErasureStepGroupRequests:
+		_, err := s.groupClient.GroupExtClient.EraseUserGroupRequests(ctx, &groupext.EraseUserGroupRequestsReq{UserID: userID})
+		return err
+	case model.UserErasureStepRelations:
+		_, err := s.relationClient.RelationExtClient.EraseUserRelations(ctx, &relationext.EraseUserRelationsReq{UserID: userID})
+		return err
+	case model.UserErasureStepFriendEvents:
+		_, err := s.relationClient.RelationExtClient.EraseUserFriendEvents(ctx, &relationext.EraseUserFriendEventsReq{UserID: userID})
+		return err
+	case model.UserErasureStepConversations:
+		_, err := s.conversationExtClient.EraseUserConversations(ctx, &conversationext.EraseUserConversationsReq{UserID: userID})
+		return err
+	case model.UserErasureStepMsgs:
+		if _, err := s.msgExtClient.EraseUserSeqs(ctx, &msgext.EraseUserSeqsReq{UserID: userID}); err != nil {
+			return err
+		}
+		if !erasure.DeleteMsgs {
+			return nil
+		}
+		return s.eraseUserBatches(ctx, userID, func() (int64, error) {
+			resp, err := s.msgExtClient.DeleteUserSentMsgs(ctx, &msgext.DeleteUserSentMsgsReq{UserID: userID, Limit: userErasureBatchSize})
+			if err != nil {
+				return 0, err
+			}
+			return resp.DeletedCount, nil
+		})
+	case model.UserErasureStepObjects:
+		return s.eraseUserBatches(ctx, userID, func() (int64, error) {
+			resp, err := s.thirdExtClient.DeleteUserObjects(ctx, &thirdext.DeleteUserObjectsReq{UserID: userID, Limit: userErasureBatchSize})
+			if err != nil {
+				return 0, err
+			}
+			return resp.DeletedCount, nil
+		})
+	case model.UserErasureStepUser:
+		if err := s.db.DeleteUser(ctx, userID); err != nil {
+			return err
+		}
+		// 前面的步骤执行期间用户可能重新获取了令牌
+		return s.revokeUserTokens(ctx, userID)
+	default:
+		return errs.ErrInternalServer.WrapMsg("unknown user erasure step", "step", name)
+	}
+}
+
+// eraseUserBatches 重复执行分批删除直到不足一批，每批完成后刷新任务的更新时间，避免长时间执行被视为中断
+func (s *userServer) eraseUserBatches(ctx context.Context, userID string, deleteBatch func() (int64, error)) error {
+	for {
+		count, err := deleteBatch()
+		if err != nil {
+			return err
+		}
+		if count < userErasureBatchSize {
+			return nil
+		}
+		if err := s.userErasureDB.Update(ctx, userID, map[string]any{"update_time": time.Now()}); err != nil {
+			return err
+		}
+	}
+}
+
+// revokeUserTokens 强制用户在所有平台下线，已签发的令牌全部标记为踢出
+// 使用ForceLogout而不是InvalidateToken：后者不断开已有连接，并且在平台没有令牌时返回错误
+// 某个平台失败时继续处理其他平台，返回全部平台的错误
+func (s *userServer) revokeUserTokens(ctx context.Context, userID string) error {
+	var errList []error
+	for platformID := range constant.PlatformID2Name {
+		req := &auth.ForceLogoutReq{UserID: userID, PlatformID: int32(platformID)}
+		if _, err := s.authClient.AuthClient.ForceLogout(ctx, req); err != nil {
+			errList = append(errList, errs.WrapMsg(err, "force logout failed", "platformID", platformID))
+		}
+	}
+	return errors.Join(errList...)
+}
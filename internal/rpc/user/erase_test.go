@@ -0,0 +1,33 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserErasureDB2Ext(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	erasure := model.NewUserErasure("u1", true, "admin", now)
+	assert.Equal(t, model.UserErasureStatusRunning, erasure.Status)
+	assert.Len(t, erasure.Steps, len(model.UserErasureSteps))
+	for i, step := range erasure.Steps {
+		assert.Equal(t, model.UserErasureSteps[i], step.Name)
+		assert.Equal(t, model.UserErasureStatusPending, step.Status)
+	}
+	assert.Same(t, erasure.Steps[len(erasure.Steps)-1], erasure.Step(model.UserErasureStepUser))
+	assert.Nil(t, erasure.Step("unknown"))
+
+	ext := userErasureDB2Ext(erasure)
+	assert.Equal(t, "u1", ext.UserID)
+	assert.True(t, ext.DeleteMsgs)
+	assert.Equal(t, "admin", ext.OperatorUserID)
+	assert.Equal(t, now.UnixMilli(), ext.CreateTime)
+	assert.Zero(t, ext.FinishTime)
+	assert.Equal(t, model.UserErasureStepRevokeTokens, ext.Steps[0].Name)
+
+	erasure.FinishTime = now.Add(time.Minute)
+	assert.Equal(t, erasure.FinishTime.UnixMilli(), userErasureDB2Ext(erasure).FinishTime)
+}
@@ -3,6 +3,8 @@ package user
 import (
 	"context"
 
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/tools/utils/datautil"
 
 	"github.com/openimsdk/protocol/constant"
@@ -66,10 +68,42 @@ func (s *userServer) getUsersOnlineStatus(ctx context.Context, userIDs []string)
 	return res, nil
 }
 
+// hidePresence 将对viewerUserID隐藏在线状态的用户改为离线
+// 设计思路：
+// 1. 用户把对方加入黑名单且范围包含在线状态时，对方看到的始终是离线
+// 2. 查看自己的状态时不做处理
+// 参数：
+//   - ctx: 上下文信息
+//   - viewerUserID: 查看状态的用户ID
+//   - statusList: 在线状态列表，原地修改
+//
+// 返回值：
+//   - error: 错误信息
+func (s *userServer) hidePresence(ctx context.Context, viewerUserID string, statusList []*pbuser.OnlineStatus) error {
+	ownerUserIDs := datautil.Slice(statusList, func(e *pbuser.OnlineStatus) string { return e.UserID })
+	scopes, err := s.relationClient.FindBlackScopes(ctx, ownerUserIDs, []string{viewerUserID})
+	if err != nil {
+		return err
+	}
+	hidden := make(map[string]struct{}, len(scopes))
+	for _, scope := range scopes {
+		if scope.Scope&model.BlackScopePresence != 0 {
+			hidden[scope.OwnerUserID] = struct{}{}
+		}
+	}
+	for _, status := range statusList {
+		if _, ok := hidden[status.UserID]; ok {
+			status.Status = constant.Offline
+			status.PlatformIDs = nil
+		}
+	}
+	return nil
+}
+
 // SubscribeOrCancelUsersStatus 订阅或取消订阅用户状态变更通知
 // 设计思路：
-// 1. 目前为空实现，预留接口用于后续实现用户状态变更的订阅机制
-// 2. 可用于实现实时状态推送、好友在线提醒等功能
+// 1. 实时的状态变更推送由网关的长连接订阅完成，这里只返回订阅时的当前状态
+// 2. 对req.UserID隐藏在线状态的用户返回离线
 // 参数：
 //   - ctx: 上下文信息
 //   - req: 订阅请求，包含要订阅的用户列表和操作类型
@@ -78,13 +112,27 @@ func (s *userServer) getUsersOnlineStatus(ctx context.Context, userIDs []string)
 //   - *pbuser.SubscribeOrCancelUsersStatusResp: 订阅响应
 //   - error: 错误信息
 func (s *userServer) SubscribeOrCancelUsersStatus(ctx context.Context, req *pbuser.SubscribeOrCancelUsersStatusReq) (*pbuser.SubscribeOrCancelUsersStatusResp, error) {
-	return &pbuser.SubscribeOrCancelUsersStatusResp{}, nil
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.Genre != constant.SubscriberUser || len(req.UserIDs) == 0 {
+		return &pbuser.SubscribeOrCancelUsersStatusResp{}, nil
+	}
+	res, err := s.getUsersOnlineStatus(ctx, req.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.hidePresence(ctx, req.UserID, res); err != nil {
+		return nil, err
+	}
+	return &pbuser.SubscribeOrCancelUsersStatusResp{StatusList: res}, nil
 }
 
 // GetUserStatus 对外提供的获取用户状态接口
 // 设计思路：
 // 1. 封装getUsersOnlineStatus方法，提供标准的RPC接口
 // 2. 支持批量查询，提高接口效率
+// 3. 指定了查看者req.UserID时，对其隐藏在线状态的用户返回离线；服务间调用不指定查看者，返回真实状态
 // 参数：
 //   - ctx: 上下文信息
 //   - req: 包含用户ID列表的请求
@@ -93,10 +141,20 @@ func (s *userServer) SubscribeOrCancelUsersStatus(ctx context.Context, req *pbus
 //   - *pbuser.GetUserStatusResp: 包含状态列表的响应
 //   - error: 错误信息
 func (s *userServer) GetUserStatus(ctx context.Context, req *pbuser.GetUserStatusReq) (*pbuser.GetUserStatusResp, error) {
+	if req.UserID != "" {
+		if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+			return nil, err
+		}
+	}
 	res, err := s.getUsersOnlineStatus(ctx, req.UserIDs)
 	if err != nil {
 		return nil, err
 	}
+	if req.UserID != "" {
+		if err := s.hidePresence(ctx, req.UserID, res); err != nil {
+			return nil, err
+		}
+	}
 	return &pbuser.GetUserStatusResp{StatusList: res}, nil
 }
 
@@ -0,0 +1,425 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/authverify"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/convert"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+const (
+	// maxUserProfileFields 自定义资料字段的数量上限，可搜索字段会在用户集合上建立索引
+	maxUserProfileFields = 32
+	// maxSearchProfileFields 单次搜索最多指定的字段数
+	maxSearchProfileFields = 8
+	// backfillUserProfileBatchSize 回填自定义资料字段时每批处理的用户数
+	backfillUserProfileBatchSize = 500
+)
+
+// userProfileFieldNameRegexp 字段名同时作为Ex的键和用户文档中的路径，不能包含.和$
+var userProfileFieldNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,31}$`)
+
+func userProfileFieldDB2Ext(field *model.UserProfileField) *userext.UserProfileField {
+	return &userext.UserProfileField{
+		Name:       field.Name,
+		Type:       field.Type,
+		Visibility: field.Visibility,
+		Searchable: field.Searchable,
+		CreateTime: field.CreateTime.UnixMilli(),
+		UpdateTime: field.UpdateTime.UnixMilli(),
+	}
+}
+
+func checkUserProfileField(field *userext.UserProfileField) error {
+	if !userProfileFieldNameRegexp.MatchString(field.Name) {
+		return errs.ErrArgs.WrapMsg("invalid profile field name", "name", field.Name)
+	}
+	switch field.Type {
+	case model.UserProfileFieldString, model.UserProfileFieldNumber, model.UserProfileFieldBool:
+	default:
+		return errs.ErrArgs.WrapMsg("invalid profile field type", "type", field.Type)
+	}
+	switch field.Visibility {
+	case model.UserProfileVisibilityPublic, model.UserProfileVisibilityFriends, model.UserProfileVisibilityPrivate:
+	default:
+		return errs.ErrArgs.WrapMsg("invalid profile field visibility", "visibility", field.Visibility)
+	}
+	return nil
+}
+
+// isUserProfileValue 检查JSON解码后的取值是否符合字段类型
+func isUserProfileValue(field *model.UserProfileField, value any) bool {
+	switch field.Type {
+	case model.UserProfileFieldString:
+		_, ok := value.(string)
+		return ok
+	case model.UserProfileFieldNumber:
+		_, ok := value.(float64)
+		return ok
+	case model.UserProfileFieldBool:
+		_, ok := value.(bool)
+		return ok
+	default:
+		return false
+	}
+}
+
+// parseUserEx 将Ex解析为JSON对象，Ex不是JSON对象时返回nil
+func parseUserEx(ex string) map[string]any {
+	var values map[string]any
+	if ex == "" || json.Unmarshal([]byte(ex), &values) != nil {
+		return nil
+	}
+	return values
+}
+
+// userProfileFromEx 按字段定义从Ex中提取并校验类型化取值
+// Ex不是JSON对象时不包含任何字段；值为null视为未设置；未定义的键不做校验
+func userProfileFromEx(fields []*model.UserProfileField, ex string) (map[string]any, error) {
+	values := parseUserEx(ex)
+	if len(fields) == 0 || len(values) == 0 {
+		return nil, nil
+	}
+	profile := make(map[string]any)
+	for _, field := range fields {
+		value, ok := values[field.Name]
+		if !ok || value == nil {
+			continue
+		}
+		if !isUserProfileValue(field, value) {
+			return nil, errs.ErrArgs.WrapMsg("invalid profile field value", "name", field.Name, "type", field.Type)
+		}
+		profile[field.Name] = value
+	}
+	return profile, nil
+}
+
+// buildUserProfile 按当前的字段定义从Ex中提取类型化取值，保存到用户文档的profile字段
+func (s *userServer) buildUserProfile(ctx context.Context, ex string) (map[string]any, error) {
+	fields, err := s.db.GetProfileFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return userProfileFromEx(fields, ex)
+}
+
+// setUserProfile 更新数据包含Ex时，同时更新从Ex中提取的类型化取值
+func (s *userServer) setUserProfile(ctx context.Context, data map[string]any) error {
+	ex, ok := data["ex"].(string)
+	if !ok {
+		return nil
+	}
+	profile, err := s.buildUserProfile(ctx, ex)
+	if err != nil {
+		return err
+	}
+	data["profile"] = profile
+	return nil
+}
+
+// hideUserProfile 从Ex中删除查看者无权查看的字段，friend表示查看者是否为该用户的好友
+// 返回处理后的Ex，没有需要删除的字段时原样返回
+func hideUserProfile(fields []*model.UserProfileField, ex string, friend bool) string {
+	values := parseUserEx(ex)
+	if len(values) == 0 {
+		return ex
+	}
+	var hidden bool
+	for _, field := range fields {
+		if field.Visibility == model.UserProfileVisibilityPublic {
+			continue
+		}
+		if field.Visibility == model.UserProfileVisibilityFriends && friend {
+			continue
+		}
+		if _, ok := values[field.Name]; ok {
+			delete(values, field.Name)
+			hidden = true
+		}
+	}
+	if !hidden {
+		return ex
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return ex
+	}
+	return string(data)
+}
+
+// hasFriendsOnlyProfile Ex中是否包含仅好友可见的字段，用于判断是否需要查询好友关系
+func hasFriendsOnlyProfile(fields []*model.UserProfileField, ex string) bool {
+	values := parseUserEx(ex)
+	if len(values) == 0 {
+		return false
+	}
+	for _, field := range fields {
+		if field.Visibility != model.UserProfileVisibilityFriends {
+			continue
+		}
+		if _, ok := values[field.Name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// userProfileForViewer 返回查看者看到的Ex
+// blackScope为用户拉黑查看者的范围，包含自定义资料时Ex为空；friend表示查看者是否为该用户的好友
+func userProfileForViewer(fields []*model.UserProfileField, ex string, blackScope int32, friend bool) string {
+	if blackScope&model.BlackScopeProfile != 0 {
+		return ""
+	}
+	return hideUserProfile(fields, ex, friend)
+}
+
+// applyUserProfileVisibility 按查看者（请求的操作者）过滤用户Ex中的自定义资料字段
+// 本人、管理员和不带操作者的服务间调用返回完整内容；好友可以查看仅好友可见的字段
+// 用户把查看者加入黑名单且范围包含自定义资料时，查看者看到的Ex为空
+// 黑名单范围和好友关系通过本地缓存查询，查询失败时按非好友处理，隐藏非公开字段
+func (s *userServer) applyUserProfileVisibility(ctx context.Context, users []*sdkws.UserInfo) error {
+	viewerUserID := mcontext.GetOpUserID(ctx)
+	if viewerUserID == "" || authverify.IsManagerUserID(viewerUserID, s.config.Share.IMAdminUserID) {
+		return nil
+	}
+	users = datautil.Filter(users, func(user *sdkws.UserInfo) (*sdkws.UserInfo, bool) {
+		return user, user.UserID != viewerUserID && user.Ex != ""
+	})
+	if len(users) == 0 {
+		return nil
+	}
+	fields, err := s.db.GetProfileFields(ctx)
+	if err != nil {
+		return err
+	}
+	fields = datautil.Filter(fields, func(field *model.UserProfileField) (*model.UserProfileField, bool) {
+		return field, field.Visibility != model.UserProfileVisibilityPublic
+	})
+	for _, user := range users {
+		blackScope, err := s.friendLocalCache.GetBlackScope(ctx, user.UserID, viewerUserID)
+		if err != nil {
+			log.ZWarn(ctx, "get black scope failed, hide restricted profile fields", err, "userID", user.UserID, "viewerUserID", viewerUserID)
+			user.Ex = hideUserProfile(fields, user.Ex, false)
+			continue
+		}
+		var friend bool
+		if blackScope&model.BlackScopeProfile == 0 && hasFriendsOnlyProfile(fields, user.Ex) {
+			friend, err = s.friendLocalCache.IsFriend(ctx, user.UserID, viewerUserID)
+			if err != nil {
+				log.ZWarn(ctx, "check friend failed, hide friends-only profile fields", err, "userID", user.UserID, "viewerUserID", viewerUserID)
+				friend = false
+			}
+		}
+		user.Ex = userProfileForViewer(fields, user.Ex, blackScope, friend)
+	}
+	return nil
+}
+
+// userProfileValueFromEx 按字段定义从单个用户的Ex中提取回填的取值
+// 未设置或值为null时返回nil；取值不符合字段类型时返回nil和false，回填时清除该字段的类型化取值
+func userProfileValueFromEx(field *model.UserProfileField, ex string) (any, bool) {
+	value, ok := parseUserEx(ex)[field.Name]
+	if !ok || value == nil {
+		return nil, true
+	}
+	if !isUserProfileValue(field, value) {
+		return nil, false
+	}
+	return value, true
+}
+
+// startUserProfileBackfill 在后台按字段定义回填存量用户的类型化取值，任务不随请求结束而取消
+func (s *userServer) startUserProfileBackfill(ctx context.Context, field *model.UserProfileField) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.backfillUserProfileField(ctx, field); err != nil {
+			log.ZError(ctx, "backfill user profile field failed", err, "name", field.Name)
+		}
+	}()
+}
+
+// backfillUserProfileField 分批遍历Ex不为空的用户，重新提取并校验该字段的取值
+// 取值不符合当前类型的用户清除类型化取值并记录日志，Ex保持不变；全部完成后标记字段已回填
+// 任务中断时字段保持未回填状态，由定时任务重新执行，重复执行不影响结果
+func (s *userServer) backfillUserProfileField(ctx context.Context, field *model.UserProfileField) error {
+	log.ZInfo(ctx, "backfill user profile field start", "name", field.Name, "type", field.Type)
+	var (
+		afterUserID string
+		count       int
+		invalid     int
+	)
+	for {
+		users, err := s.db.FindUsersExAfter(ctx, afterUserID, backfillUserProfileBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			value, ok := userProfileValueFromEx(field, user.Ex)
+			if !ok {
+				invalid++
+				log.ZWarn(ctx, "invalid profile field value", nil, "userID", user.UserID, "name", field.Name, "type", field.Type)
+			}
+			if old, exist := user.Profile[field.Name]; exist == (value != nil) && reflect.DeepEqual(old, value) {
+				continue
+			}
+			if err := s.db.SetUserProfileValue(ctx, user.UserID, user.Ex, field.Name, value); err != nil {
+				return err
+			}
+			count++
+		}
+		if len(users) < backfillUserProfileBatchSize {
+			break
+		}
+		afterUserID = users[len(users)-1].UserID
+	}
+	if err := s.db.SetProfileFieldBackfilled(ctx, field.Name, field.UpdateTime); err != nil {
+		return err
+	}
+	log.ZInfo(ctx, "backfill user profile field done", "name", field.Name, "updated", count, "invalid", invalid)
+	return nil
+}
+
+// BackfillUserProfileFields 继续未完成的自定义资料字段回填，由定时任务调用，仅系统管理员可调用
+func (s *userServer) BackfillUserProfileFields(ctx context.Context, req *userext.BackfillUserProfileFieldsReq) (*userext.BackfillUserProfileFieldsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	fields, err := s.db.GetProfileFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var count int64
+	for _, field := range fields {
+		if field.Backfilled {
+			continue
+		}
+		s.startUserProfileBackfill(ctx, field)
+		count++
+	}
+	return &userext.BackfillUserProfileFieldsResp{StartedCount: count}, nil
+}
+
+// SetUserProfileField 新增或覆盖自定义资料字段定义，仅系统管理员可调用
+// 新增字段或修改字段类型时，在后台按新类型回填存量用户的取值，不符合新类型的取值被清除
+func (s *userServer) SetUserProfileField(ctx context.Context, req *userext.SetUserProfileFieldReq) (*userext.SetUserProfileFieldResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if err := checkUserProfileField(req.Field); err != nil {
+		return nil, err
+	}
+	fields, err := s.db.GetProfileFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var old *model.UserProfileField
+	for _, field := range fields {
+		if field.Name == req.Field.Name {
+			old = field
+		}
+	}
+	if old == nil && len(fields) >= maxUserProfileFields {
+		return nil, errs.ErrArgs.WrapMsg("too many profile fields", "max", maxUserProfileFields)
+	}
+	// 与数据库保存的精度一致，回填完成时按更新时间判断字段是否被重新定义
+	now := time.UnixMilli(time.Now().UnixMilli())
+	field := &model.UserProfileField{
+		Name:       req.Field.Name,
+		Type:       req.Field.Type,
+		Visibility: req.Field.Visibility,
+		Searchable: req.Field.Searchable,
+		CreateTime: now,
+		UpdateTime: now,
+		Backfilled: old != nil && old.Type == req.Field.Type && old.Backfilled,
+	}
+	if err := s.db.SetProfileField(ctx, field); err != nil {
+		return nil, err
+	}
+	if !field.Backfilled {
+		s.startUserProfileBackfill(ctx, field)
+	}
+	return &userext.SetUserProfileFieldResp{}, nil
+}
+
+// DeleteUserProfileFields 删除自定义资料字段定义，仅系统管理员可调用
+func (s *userServer) DeleteUserProfileFields(ctx context.Context, req *userext.DeleteUserProfileFieldsReq) (*userext.DeleteUserProfileFieldsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	names := datautil.Distinct(req.Names)
+	for _, name := range names {
+		if !userProfileFieldNameRegexp.MatchString(name) {
+			return nil, errs.ErrArgs.WrapMsg("invalid profile field name", "name", name)
+		}
+	}
+	if err := s.db.DeleteProfileFields(ctx, names); err != nil {
+		return nil, err
+	}
+	return &userext.DeleteUserProfileFieldsResp{}, nil
+}
+
+// GetUserProfileFields 获取全部自定义资料字段定义，客户端据此展示和编辑资料
+func (s *userServer) GetUserProfileFields(ctx context.Context, req *userext.GetUserProfileFieldsReq) (*userext.GetUserProfileFieldsResp, error) {
+	fields, err := s.db.GetProfileFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.GetUserProfileFieldsResp{Fields: datautil.Slice(fields, userProfileFieldDB2Ext)}, nil
+}
+
+// SearchUsersByProfile 按可搜索的自定义资料字段查询用户，仅系统管理员可调用
+func (s *userServer) SearchUsersByProfile(ctx context.Context, req *userext.SearchUsersByProfileReq) (*userext.SearchUsersByProfileResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if len(req.Profile) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("profile is empty")
+	}
+	if len(req.Profile) > maxSearchProfileFields {
+		return nil, errs.ErrArgs.WrapMsg("too many profile fields", "max", maxSearchProfileFields)
+	}
+	fields, err := s.db.GetProfileFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fieldMap := datautil.SliceToMap(fields, func(field *model.UserProfileField) string { return field.Name })
+	for name, value := range req.Profile {
+		field, ok := fieldMap[name]
+		if !ok || !field.Searchable {
+			return nil, errs.ErrArgs.WrapMsg("profile field is not searchable", "name", name)
+		}
+		if !isUserProfileValue(field, value) {
+			return nil, errs.ErrArgs.WrapMsg("invalid profile field value", "name", name, "type", field.Type)
+		}
+	}
+	total, users, err := s.db.PageFindUsersByProfile(ctx, req.Keyword, req.Profile, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &userext.SearchUsersByProfileResp{Total: total, Users: convert.UsersDB2Pb(users)}, nil
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUserProfileFields() []*model.UserProfileField {
+	return []*model.UserProfileField{
+		{Name: "city", Type: model.UserProfileFieldString, Visibility: model.UserProfileVisibilityPublic},
+		{Name: "age", Type: model.UserProfileFieldNumber, Visibility: model.UserProfileVisibilityFriends},
+		{Name: "vip", Type: model.UserProfileFieldBool, Visibility: model.UserProfileVisibilityPrivate},
+	}
+}
+
+func TestCheckUserProfileField(t *testing.T) {
+	field := &userext.UserProfileField{Name: "city_1", Type: model.UserProfileFieldString, Visibility: model.UserProfileVisibilityPublic}
+	assert.NoError(t, checkUserProfileField(field))
+
+	for _, name := range []string{"", "1city", "profile.city", "$city", "a123456789012345678901234567890123"} {
+		invalid := *field
+		invalid.Name = name
+		assert.Error(t, checkUserProfileField(&invalid), name)
+	}
+	invalid := *field
+	invalid.Type = 4
+	assert.Error(t, checkUserProfileField(&invalid))
+	invalid = *field
+	invalid.Visibility = 0
+	assert.Error(t, checkUserProfileField(&invalid))
+}
+
+func TestUserProfileFromEx(t *testing.T) {
+	fields := testUserProfileFields()
+
+	profile, err := userProfileFromEx(fields, `{"city":"Shanghai","age":18,"vip":true,"other":1}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"city": "Shanghai", "age": float64(18), "vip": true}, profile)
+
+	profile, err = userProfileFromEx(fields, `{"city":null}`)
+	assert.NoError(t, err)
+	assert.Empty(t, profile)
+
+	profile, err = userProfileFromEx(fields, "not json")
+	assert.NoError(t, err)
+	assert.Nil(t, profile)
+
+	profile, err = userProfileFromEx(nil, `{"city":"Shanghai"}`)
+	assert.NoError(t, err)
+	assert.Nil(t, profile)
+
+	_, err = userProfileFromEx(fields, `{"age":"18"}`)
+	assert.Error(t, err)
+	_, err = userProfileFromEx(fields, `{"vip":1}`)
+	assert.Error(t, err)
+}
+
+func TestHideUserProfile(t *testing.T) {
+	fields := testUserProfileFields()
+	ex := `{"city":"Shanghai","age":18,"vip":true}`
+
+	assert.JSONEq(t, `{"city":"Shanghai"}`, hideUserProfile(fields, ex, false))
+	assert.JSONEq(t, `{"city":"Shanghai","age":18}`, hideUserProfile(fields, ex, true))
+	assert.Equal(t, `{"city":"Shanghai"}`, hideUserProfile(fields, `{"city":"Shanghai"}`, false))
+	assert.Equal(t, "not json", hideUserProfile(fields, "not json", false))
+}
+
+func TestHasFriendsOnlyProfile(t *testing.T) {
+	fields := testUserProfileFields()
+	assert.True(t, hasFriendsOnlyProfile(fields, `{"age":18}`))
+	assert.False(t, hasFriendsOnlyProfile(fields, `{"city":"Shanghai","vip":true}`))
+	assert.False(t, hasFriendsOnlyProfile(fields, ""))
+}
+
+func TestUserProfileForViewer(t *testing.T) {
+	fields := testUserProfileFields()
+	ex := `{"city":"Shanghai","age":18,"vip":true}`
+
+	assert.Equal(t, "", userProfileForViewer(fields, ex, model.BlackScopeProfile|model.BlackScopeMessage, true))
+	assert.JSONEq(t, `{"city":"Shanghai","age":18}`, userProfileForViewer(fields, ex, model.BlackScopeMessage|model.BlackScopePresence, true))
+	assert.JSONEq(t, `{"city":"Shanghai"}`, userProfileForViewer(fields, ex, 0, false))
+}
+
+func TestUserProfileValueFromEx(t *testing.T) {
+	fields := testUserProfileFields()
+
+	value, ok := userProfileValueFromEx(fields[1], `{"age":18}`)
+	assert.True(t, ok)
+	assert.Equal(t, float64(18), value)
+
+	value, ok = userProfileValueFromEx(fields[1], `{"age":null,"city":"Shanghai"}`)
+	assert.True(t, ok)
+	assert.Nil(t, value)
+
+	value, ok = userProfileValueFromEx(fields[1], "not json")
+	assert.True(t, ok)
+	assert.Nil(t, value)
+
+	value, ok = userProfileValueFromEx(fields[1], `{"age":"18"}`)
+	assert.False(t, ok)
+	assert.Nil(t, value)
+}
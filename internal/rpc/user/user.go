@@ -98,10 +98,16 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/common/prommetrics"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/redis"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database/mgo"
 	tablerelation "github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/webhook"
 	"github.com/openimsdk/open-im-server/v3/pkg/localcache"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpccache"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/conversationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/msgext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/thirdext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 	"github.com/openimsdk/protocol/group"
 	friendpb "github.com/openimsdk/protocol/relation"
@@ -149,6 +155,12 @@ type userServer struct {
 	webhookClient                  *webhook.Client                    // Webhook客户端，用于第三方集成
 	groupClient                    *rpcli.GroupClient                 // 群组服务客户端
 	relationClient                 *rpcli.RelationClient              // 关系服务客户端
+	friendLocalCache               *rpccache.FriendLocalCache         // 好友和黑名单本地缓存，用于自定义资料可见性
+	authClient                     *rpcli.AuthClient                  // 认证服务客户端，用于注销时踢下线
+	conversationExtClient          conversationext.ConversationExtClient
+	msgExtClient                   msgext.MsgExtClient
+	thirdExtClient                 thirdext.ThirdExtClient
+	userErasureDB                  database.UserErasure // 用户注销任务
 }
 
 // Config 用户服务配置结构体
@@ -251,15 +263,41 @@ func Start(ctx context.Context, config *Config, client registry.SvcDiscoveryRegi
 	if err != nil {
 		return err
 	}
+	authConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Auth)
+	if err != nil {
+		return err
+	}
+	conversationConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Conversation)
+	if err != nil {
+		return err
+	}
+	thirdConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Third)
+	if err != nil {
+		return err
+	}
 
 	// 创建服务客户端
 	msgClient := rpcli.NewMsgClient(msgConn)
 
+	// 初始化用户标识索引
+	userIdentifierDB, err := mgo.NewUserIdentifierMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+	userErasureDB, err := mgo.NewUserErasureMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+
 	// 初始化用户缓存系统
-	userCache := redis.NewUserCacheRedis(rdb, &config.LocalCacheConfig, userDB, redis.GetRocksCacheOptions())
+	userProfileFieldDB, err := mgo.NewUserProfileFieldMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+	userCache := redis.NewUserCacheRedis(rdb, &config.LocalCacheConfig, userDB, userProfileFieldDB, redis.GetRocksCacheOptions())
 
 	// 创建用户数据库控制器
-	database := controller.NewUserDatabase(userDB, userCache, mgocli.GetTx())
+	database := controller.NewUserDatabase(userDB, userIdentifierDB, userProfileFieldDB, userCache, mgocli.GetTx())
 
 	// 初始化本地缓存
 	localcache.InitLocalCache(&config.LocalCacheConfig)
@@ -275,10 +313,17 @@ func Start(ctx context.Context, config *Config, client registry.SvcDiscoveryRegi
 		webhookClient:            webhook.NewWebhookClient(config.WebhooksConfig.URL),
 		groupClient:              rpcli.NewGroupClient(groupConn),
 		relationClient:           rpcli.NewRelationClient(friendConn),
+		friendLocalCache:         rpccache.NewFriendLocalCache(rpcli.NewRelationClient(friendConn), &config.LocalCacheConfig, rdb),
+		authClient:               rpcli.NewAuthClient(authConn),
+		conversationExtClient:    conversationext.NewConversationExtClient(conversationConn),
+		msgExtClient:             msgext.NewMsgExtClient(msgConn),
+		thirdExtClient:           thirdext.NewThirdExtClient(thirdConn),
+		userErasureDB:            userErasureDB,
 	}
 
 	// 注册用户服务到gRPC服务器
 	pbuser.RegisterUserServer(server, u)
+	userext.RegisterUserExtServer(server, u)
 
 	// 初始化系统管理员账号
 	return u.db.InitOnce(context.Background(), users)
@@ -318,6 +363,11 @@ func (s *userServer) GetDesignateUsers(ctx context.Context, req *pbuser.GetDesig
 
 	// 将数据库模型转换为API响应格式
 	resp.UsersInfo = convert.UsersDB2Pb(users)
+
+	// 按查看者的身份隐藏无权查看的自定义资料字段
+	if err := s.applyUserProfileVisibility(ctx, resp.UsersInfo); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -334,6 +384,9 @@ func (s *userServer) UpdateUserInfo(ctx context.Context, req *pbuser.UpdateUserI
 		return nil, err
 	}
 	data := convert.UserPb2DBMap(req.UserInfo)
+	if err := s.setUserProfile(ctx, data); err != nil {
+		return nil, err
+	}
 	oldUser, err := s.db.GetUserByID(ctx, req.UserInfo.UserID)
 	if err != nil {
 		return nil, err
@@ -341,6 +394,11 @@ func (s *userServer) UpdateUserInfo(ctx context.Context, req *pbuser.UpdateUserI
 	if err := s.db.UpdateByMap(ctx, req.UserInfo.UserID, data); err != nil {
 		return nil, err
 	}
+	if req.UserInfo.Ex != "" {
+		if err := s.syncUserIdentifiers(ctx, req.UserInfo.UserID, req.UserInfo.Ex); err != nil {
+			return nil, err
+		}
+	}
 	s.friendNotificationSender.UserInfoUpdatedNotification(ctx, req.UserInfo.UserID)
 
 	s.webhookAfterUpdateUserInfo(ctx, &s.config.WebhooksConfig.AfterUpdateUserInfo, req)
@@ -367,9 +425,18 @@ func (s *userServer) UpdateUserInfoEx(ctx context.Context, req *pbuser.UpdateUse
 	}
 
 	data := convert.UserPb2DBMapEx(req.UserInfo)
+	// Ex中的自定义资料字段按定义校验类型
+	if err := s.setUserProfile(ctx, data); err != nil {
+		return nil, err
+	}
 	if err = s.db.UpdateByMap(ctx, req.UserInfo.UserID, data); err != nil {
 		return nil, err
 	}
+	if req.UserInfo.Ex != nil {
+		if err := s.syncUserIdentifiers(ctx, req.UserInfo.UserID, req.UserInfo.Ex.Value); err != nil {
+			return nil, err
+		}
+	}
 
 	s.friendNotificationSender.UserInfoUpdatedNotification(ctx, req.UserInfo.UserID)
 	//friends, err := s.friendRpcClient.GetFriendIDs(ctx, req.UserInfo.UserID)
@@ -435,21 +502,23 @@ func (s *userServer) AccountCheck(ctx context.Context, req *pbuser.AccountCheckR
 }
 
 func (s *userServer) GetPaginationUsers(ctx context.Context, req *pbuser.GetPaginationUsersReq) (resp *pbuser.GetPaginationUsersResp, err error) {
+	var (
+		total int64
+		users []*tablerelation.User
+	)
 	if req.UserID == "" && req.NickName == "" {
-		total, users, err := s.db.PageFindUser(ctx, constant.IMOrdinaryUser, constant.AppOrdinaryUsers, req.Pagination)
-		if err != nil {
-			return nil, err
-		}
-		return &pbuser.GetPaginationUsersResp{Total: int32(total), Users: convert.UsersDB2Pb(users)}, err
+		total, users, err = s.db.PageFindUser(ctx, constant.IMOrdinaryUser, constant.AppOrdinaryUsers, req.Pagination)
 	} else {
-		total, users, err := s.db.PageFindUserWithKeyword(ctx, constant.IMOrdinaryUser, constant.AppOrdinaryUsers, req.UserID, req.NickName, req.Pagination)
-		if err != nil {
-			return nil, err
-		}
-		return &pbuser.GetPaginationUsersResp{Total: int32(total), Users: convert.UsersDB2Pb(users)}, err
-
+		total, users, err = s.db.PageFindUserWithKeyword(ctx, constant.IMOrdinaryUser, constant.AppOrdinaryUsers, req.UserID, req.NickName, req.Pagination)
 	}
-
+	if err != nil {
+		return nil, err
+	}
+	resp = &pbuser.GetPaginationUsersResp{Total: int32(total), Users: convert.UsersDB2Pb(users)}
+	if err := s.applyUserProfileVisibility(ctx, resp.Users); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (s *userServer) UserRegister(ctx context.Context, req *pbuser.UserRegisterReq) (resp *pbuser.UserRegisterResp, err error) {
@@ -488,6 +557,10 @@ func (s *userServer) UserRegister(ctx context.Context, req *pbuser.UserRegisterR
 	now := time.Now()
 	users := make([]*tablerelation.User, 0, len(req.Users))
 	for _, user := range req.Users {
+		profile, err := s.buildUserProfile(ctx, user.Ex)
+		if err != nil {
+			return nil, err
+		}
 		users = append(users, &tablerelation.User{
 			UserID:           user.UserID,
 			Nickname:         user.Nickname,
@@ -496,11 +569,17 @@ func (s *userServer) UserRegister(ctx context.Context, req *pbuser.UserRegisterR
 			CreateTime:       now,
 			AppMangerLevel:   user.AppMangerLevel,
 			GlobalRecvMsgOpt: user.GlobalRecvMsgOpt,
+			Profile:          profile,
 		})
 	}
 	if err := s.db.Create(ctx, users); err != nil {
 		return nil, err
 	}
+	for _, user := range users {
+		if err := s.syncUserIdentifiers(ctx, user.UserID, user.Ex); err != nil {
+			return nil, err
+		}
+	}
 
 	prommetrics.UserRegisterCounter.Add(float64(len(users)))
 
@@ -869,5 +948,9 @@ func (s *userServer) SortQuery(ctx context.Context, req *pbuser.SortQueryReq) (*
 	if err != nil {
 		return nil, err
 	}
-	return &pbuser.SortQueryResp{Users: convert.UsersDB2Pb(users)}, nil
+	resp := &pbuser.SortQueryResp{Users: convert.UsersDB2Pb(users)}
+	if err := s.applyUserProfileVisibility(ctx, resp.Users); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
@@ -19,6 +19,9 @@ import (
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
 	kdisc "github.com/openimsdk/open-im-server/v3/pkg/common/discoveryregister"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
 	pbconversation "github.com/openimsdk/protocol/conversation"
 	"github.com/openimsdk/protocol/msg"
 	"github.com/openimsdk/protocol/third"
@@ -66,6 +69,21 @@ func Start(ctx context.Context, config *CronTaskConfig) error {
 		return err
 	}
 
+	groupConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Group)
+	if err != nil {
+		return err
+	}
+
+	friendConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Friend)
+	if err != nil {
+		return err
+	}
+
+	userConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.User)
+	if err != nil {
+		return err
+	}
+
 	srv := &cronServer{
 		ctx:                ctx,
 		config:             config,
@@ -73,6 +91,9 @@ func Start(ctx context.Context, config *CronTaskConfig) error {
 		msgClient:          msg.NewMsgClient(msgConn),
 		conversationClient: pbconversation.NewConversationClient(conversationConn),
 		thirdClient:        third.NewThirdClient(thirdConn),
+		groupExtClient:     groupext.NewGroupExtClient(groupConn),
+		relationExtClient:  relationext.NewRelationExtClient(friendConn),
+		userExtClient:      userext.NewUserExtClient(userConn),
 	}
 
 	if err := srv.registerClearS3(); err != nil {
@@ -84,6 +105,24 @@ func Start(ctx context.Context, config *CronTaskConfig) error {
 	if err := srv.registerClearUserMsg(); err != nil {
 		return err
 	}
+	if err := srv.registerGroupMuteSchedule(); err != nil {
+		return err
+	}
+	if err := srv.registerGroupRequestExpire(); err != nil {
+		return err
+	}
+	if err := srv.registerFriendRequestExpire(); err != nil {
+		return err
+	}
+	if err := srv.registerClearFriendEvents(); err != nil {
+		return err
+	}
+	if err := srv.registerUserErasureResume(); err != nil {
+		return err
+	}
+	if err := srv.registerUserProfileBackfill(); err != nil {
+		return err
+	}
 	log.ZDebug(ctx, "start cron task", "CronExecuteTime", config.CronTask.CronExecuteTime)
 	srv.cron.Start()
 	<-ctx.Done()
@@ -97,6 +136,9 @@ type cronServer struct {
 	msgClient          msg.MsgClient
 	conversationClient pbconversation.ConversationClient
 	thirdClient        third.ThirdClient
+	groupExtClient     groupext.GroupExtClient
+	relationExtClient  relationext.RelationExtClient
+	userExtClient      userext.UserExtClient
 }
 
 func (c *cronServer) registerClearS3() error {
@@ -121,3 +163,53 @@ func (c *cronServer) registerClearUserMsg() error {
 	_, err := c.cron.AddFunc(c.config.CronTask.CronExecuteTime, c.clearUserMsg)
 	return errs.WrapMsg(err, "failed to register clear user msg cron task")
 }
+
+func (c *cronServer) registerGroupMuteSchedule() error {
+	if c.config.CronTask.GroupMuteScheduleTime == "" {
+		log.ZInfo(c.ctx, "disable scheduled group mute check")
+		return nil
+	}
+	_, err := c.cron.AddFunc(c.config.CronTask.GroupMuteScheduleTime, c.processGroupMuteSchedules)
+	return errs.WrapMsg(err, "failed to register group mute schedule cron task")
+}
+
+func (c *cronServer) registerGroupRequestExpire() error {
+	if c.config.CronTask.GroupRequestExpireTime == "" {
+		log.ZInfo(c.ctx, "disable group request expire check")
+		return nil
+	}
+	_, err := c.cron.AddFunc(c.config.CronTask.GroupRequestExpireTime, c.expireGroupApplications)
+	return errs.WrapMsg(err, "failed to register group request expire cron task")
+}
+
+func (c *cronServer) registerFriendRequestExpire() error {
+	if c.config.CronTask.FriendRequestExpireTime == "" {
+		log.ZInfo(c.ctx, "disable friend request expire check")
+		return nil
+	}
+	_, err := c.cron.AddFunc(c.config.CronTask.FriendRequestExpireTime, c.expireFriendRequests)
+	return errs.WrapMsg(err, "failed to register friend request expire cron task")
+}
+
+func (c *cronServer) registerClearFriendEvents() error {
+	if c.config.CronTask.RetainFriendEvents <= 0 {
+		log.ZInfo(c.ctx, "disable scheduled cleanup of friend events", "retainFriendEvents", c.config.CronTask.RetainFriendEvents)
+		return nil
+	}
+	_, err := c.cron.AddFunc(c.config.CronTask.CronExecuteTime, c.clearFriendEvents)
+	return errs.WrapMsg(err, "failed to register clear friend events cron task")
+}
+
+func (c *cronServer) registerUserErasureResume() error {
+	if c.config.CronTask.UserErasureResumeTime == "" {
+		log.ZInfo(c.ctx, "disable user erasure resume check")
+		return nil
+	}
+	_, err := c.cron.AddFunc(c.config.CronTask.UserErasureResumeTime, c.resumeStaleUserErasures)
+	return errs.WrapMsg(err, "failed to register user erasure resume cron task")
+}
+
+func (c *cronServer) registerUserProfileBackfill() error {
+	_, err := c.cron.AddFunc(c.config.CronTask.CronExecuteTime, c.backfillUserProfileFields)
+	return errs.WrapMsg(err, "failed to register user profile backfill cron task")
+}
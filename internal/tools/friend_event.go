@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+func (c *cronServer) clearFriendEvents() {
+	now := time.Now()
+	before := now.Add(-time.Hour * 24 * time.Duration(c.config.CronTask.RetainFriendEvents))
+	operationID := fmt.Sprintf("cron_friend_event_%d_%d", os.Getpid(), now.UnixMilli())
+	ctx := mcontext.SetOperationID(c.ctx, operationID)
+	resp, err := c.relationExtClient.ClearFriendEvents(ctx, &relationext.ClearFriendEventsReq{BeforeTime: before.UnixMilli()})
+	if err != nil {
+		log.ZError(ctx, "cron clear friend events failed", err)
+		return
+	}
+	log.ZDebug(ctx, "cron clear friend events end", "before", before, "cost", time.Since(now), "deleted", resp.DeletedCount)
+}
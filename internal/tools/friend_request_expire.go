@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+func (c *cronServer) expireFriendRequests() {
+	now := time.Now()
+	operationID := fmt.Sprintf("cron_friend_request_expire_%d_%d", os.Getpid(), now.UnixMilli())
+	ctx := mcontext.SetOperationID(c.ctx, operationID)
+	resp, err := c.relationExtClient.ExpireFriendRequests(ctx, &relationext.ExpireFriendRequestsReq{})
+	if err != nil {
+		log.ZError(ctx, "cron expire friend requests failed", err)
+		return
+	}
+	log.ZDebug(ctx, "cron expire friend requests end", "cost", time.Since(now), "expired", resp.ExpiredCount)
+}
@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+func (c *cronServer) processGroupMuteSchedules() {
+	now := time.Now()
+	operationID := fmt.Sprintf("cron_group_mute_%d_%d", os.Getpid(), now.UnixMilli())
+	ctx := mcontext.SetOperationID(c.ctx, operationID)
+	resp, err := c.groupExtClient.ProcessGroupMuteSchedules(ctx, &groupext.ProcessGroupMuteSchedulesReq{})
+	if err != nil {
+		log.ZError(ctx, "cron process group mute schedules failed", err)
+		return
+	}
+	log.ZDebug(ctx, "cron process group mute schedules end", "cost", time.Since(now), "muted", resp.MutedGroupIDs, "unmuted", resp.UnmutedGroupIDs)
+}
@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+func (c *cronServer) expireGroupApplications() {
+	now := time.Now()
+	operationID := fmt.Sprintf("cron_group_request_expire_%d_%d", os.Getpid(), now.UnixMilli())
+	ctx := mcontext.SetOperationID(c.ctx, operationID)
+	resp, err := c.groupExtClient.ExpireGroupApplications(ctx, &groupext.ExpireGroupApplicationsReq{})
+	if err != nil {
+		log.ZError(ctx, "cron expire group applications failed", err)
+		return
+	}
+	log.ZDebug(ctx, "cron expire group applications end", "cost", time.Since(now), "expired", resp.ExpiredCount)
+}
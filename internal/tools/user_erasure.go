@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+func (c *cronServer) resumeStaleUserErasures() {
+	now := time.Now()
+	operationID := fmt.Sprintf("cron_user_erasure_%d_%d", os.Getpid(), now.UnixMilli())
+	ctx := mcontext.SetOperationID(c.ctx, operationID)
+	resp, err := c.userExtClient.ResumeStaleUserErasures(ctx, &userext.ResumeStaleUserErasuresReq{})
+	if err != nil {
+		log.ZError(ctx, "cron resume user erasures failed", err)
+		return
+	}
+	log.ZDebug(ctx, "cron resume user erasures end", "cost", time.Since(now), "resumed", resp.ResumedCount)
+}
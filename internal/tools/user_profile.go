@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
+	"github.com/openimsdk/tools/log"
+	"github.com/openimsdk/tools/mcontext"
+)
+
+func (c *cronServer) backfillUserProfileFields() {
+	now := time.Now()
+	operationID := fmt.Sprintf("cron_user_profile_backfill_%d_%d", os.Getpid(), now.UnixMilli())
+	ctx := mcontext.SetOperationID(c.ctx, operationID)
+	resp, err := c.userExtClient.BackfillUserProfileFields(ctx, &userext.BackfillUserProfileFieldsReq{})
+	if err != nil {
+		log.ZError(ctx, "cron backfill user profile fields failed", err)
+		return
+	}
+	log.ZDebug(ctx, "cron backfill user profile fields end", "cost", time.Since(now), "started", resp.StartedCount)
+}
@@ -35,11 +35,21 @@ type SendMsg struct {
 	// SenderPlatformID is an integer identifier for the sender's platform.
 	SenderPlatformID int32 `json:"senderPlatformID"`
 
-	// Content is the actual content of the message, required and excluded from Swagger documentation.
-	Content map[string]any `json:"content" binding:"required" swaggerignore:"true"`
+	// Content is the actual content of the message, required unless TemplateID is set, and excluded from Swagger documentation.
+	Content map[string]any `json:"content" binding:"required_without=TemplateID" swaggerignore:"true"`
 
 	// ContentType is an integer that represents the type of the content.
-	ContentType int32 `json:"contentType" binding:"required"`
+	// When TemplateID is set it is derived from the template, except for OANotification.
+	ContentType int32 `json:"contentType" binding:"required_without=TemplateID"`
+
+	// TemplateID references a server-side message template whose rendered result becomes the content.
+	TemplateID string `json:"templateID"`
+
+	// TemplateParams holds the values of the template variables.
+	TemplateParams map[string]string `json:"templateParams"`
+
+	// TemplateLocale selects the locale variant of the template, falling back to its default locale.
+	TemplateLocale string `json:"templateLocale"`
 
 	// SessionType is an integer that represents the type of session for the message.
 	SessionType int32 `json:"sessionType" binding:"required"`
@@ -14,6 +14,8 @@
 
 package apistruct
 
+import "github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
+
 type PictureBaseInfo struct {
 	UUID   string `mapstructure:"uuid"`
 	Type   string `mapstructure:"type"   validate:"required"`
@@ -89,7 +91,7 @@ type OANotificationElem struct {
 	NotificationName    string       `mapstructure:"notificationName"    json:"notificationName"    validate:"required"`
 	NotificationFaceURL string       `mapstructure:"notificationFaceURL" json:"notificationFaceURL"`
 	NotificationType    int32        `mapstructure:"notificationType"    json:"notificationType"    validate:"required"`
-	Text                string       `mapstructure:"text"                json:"text"                validate:"required"`
+	Text                string       `mapstructure:"text"                json:"text"                validate:"required_without=Template"`
 	Url                 string       `mapstructure:"url"                 json:"url"`
 	MixType             int32        `mapstructure:"mixType"             json:"mixType"             validate:"gte=0,lte=5"`
 	PictureElem         *PictureElem `mapstructure:"pictureElem"         json:"pictureElem"`
@@ -97,6 +99,8 @@ type OANotificationElem struct {
 	VideoElem           *VideoElem   `mapstructure:"videoElem"           json:"videoElem"`
 	FileElem            *FileElem    `mapstructure:"fileElem"            json:"fileElem"`
 	Ex                  string       `mapstructure:"ex"                  json:"ex"`
+	// Template references a message template rendered by the msg service into Text and Ex.
+	Template *msgprocessor.TemplateElem `mapstructure:"template" json:"template,omitempty"`
 }
 type MessageRevoked struct {
 	RevokerID       string `mapstructure:"revokerID"       json:"revokerID"       validate:"required"`
@@ -41,6 +41,7 @@ const (
 	CallbackBeforeUpdateUserInfoExCommand   = "callbackBeforeUpdateUserInfoExCommand"
 	CallbackBeforeUserRegisterCommand       = "callbackBeforeUserRegisterCommand"
 	CallbackAfterUserRegisterCommand        = "callbackAfterUserRegisterCommand"
+	CallbackAfterUserErasedCommand          = "callbackAfterUserErasedCommand"
 	CallbackAfterTransferGroupOwnerCommand  = "callbackAfterTransferGroupOwnerCommand"
 	CallbackBeforeSetFriendRemarkCommand    = "callbackBeforeSetFriendRemarkCommand"
 	CallbackAfterSetFriendRemarkCommand     = "callbackAfterSetFriendRemarkCommand"
@@ -88,3 +88,15 @@ type CallbackAfterUserRegisterReq struct {
 type CallbackAfterUserRegisterResp struct {
 	CommonCallbackResp
 }
+
+type CallbackAfterUserErasedReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	UserID          string `json:"userID"`
+	OperatorUserID  string `json:"operatorUserID"`
+	DeleteMsgs      bool   `json:"deleteMsgs"`
+	FinishTime      int64  `json:"finishTime"`
+}
+
+type CallbackAfterUserErasedResp struct {
+	CommonCallbackResp
+}
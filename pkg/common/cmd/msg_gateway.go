@@ -39,6 +39,7 @@ func NewMsgGatewayCmd() *MsgGatewayCmd {
 		ShareFileName:               &msgGatewayConfig.Share,
 		RedisConfigFileName:         &msgGatewayConfig.RedisConfig,
 		WebhooksConfigFileName:      &msgGatewayConfig.WebhooksConfig,
+		LocalCacheConfigFileName:    &msgGatewayConfig.LocalCacheConfig,
 		DiscoveryConfigFilename:     &msgGatewayConfig.Discovery,
 	}
 	ret.RootCmd = NewRootCmd(program.GetProcessName(), WithConfigMap(ret.configMap))
@@ -241,6 +241,10 @@ type Mongo struct {
 // - ToPushGroupID: 推送消费者组ID
 // - ToOfflineGroupID: 离线推送消费者组ID
 //
+// 优先级通道配置（信令和系统通知使用独立的主题和消费者组，未配置时与普通消息共用）：
+// - ToRedisPriorityTopic / ToRedisPriorityGroupID: 高优先级消息的Redis主题及消费者组
+// - ToPushPriorityTopic / ToPushPriorityGroupID: 高优先级消息的推送主题及消费者组
+//
 // 安全配置：
 // - Tls: TLS/SSL配置
 type Kafka struct {
@@ -258,6 +262,21 @@ type Kafka struct {
 	ToPushGroupID      string    `mapstructure:"toPushGroupID"`        // 推送消费者组
 	ToOfflineGroupID   string    `mapstructure:"toOfflinePushGroupID"` // 离线推送消费者组
 	Tls                TLSConfig `mapstructure:"tls"`                  // TLS配置
+
+	ToRedisPriorityTopic   string `mapstructure:"toRedisPriorityTopic"`   // 高优先级Redis主题
+	ToPushPriorityTopic    string `mapstructure:"toPushPriorityTopic"`    // 高优先级推送主题
+	ToRedisPriorityGroupID string `mapstructure:"toRedisPriorityGroupID"` // 高优先级Redis消费者组
+	ToPushPriorityGroupID  string `mapstructure:"toPushPriorityGroupID"`  // 高优先级推送消费者组
+}
+
+// EnableRedisPriorityLane 是否启用msgtransfer高优先级通道
+func (k *Kafka) EnableRedisPriorityLane() bool {
+	return k.ToRedisPriorityTopic != "" && k.ToRedisPriorityGroupID != ""
+}
+
+// EnablePushPriorityLane 是否启用push高优先级通道
+func (k *Kafka) EnablePushPriorityLane() bool {
+	return k.ToPushPriorityTopic != "" && k.ToPushPriorityGroupID != ""
 }
 
 // TLSConfig TLS/SSL安全配置
@@ -345,6 +364,16 @@ type CronTask struct {
 	RetainChatRecords int      `mapstructure:"retainChatRecords"` // 保留聊天记录天数
 	FileExpireTime    int      `mapstructure:"fileExpireTime"`    // 文件过期时间
 	DeleteObjectType  []string `mapstructure:"deleteObjectType"`  // 删除对象类型
+	// 群组定时禁言检查的Cron表达式，用于在禁言/解禁切换时发送通知，为空表示不检查
+	GroupMuteScheduleTime string `mapstructure:"groupMuteScheduleTime"`
+	// 入群申请过期检查的Cron表达式，自动拒绝超过群组有效期的待处理申请，为空表示不检查
+	GroupRequestExpireTime string `mapstructure:"groupRequestExpireTime"`
+	// 好友申请过期检查的Cron表达式，将超过有效期的待处理申请标记为已过期，为空表示不检查
+	FriendRequestExpireTime string `mapstructure:"friendRequestExpireTime"`
+	// 好友关系事件保留天数，在CronExecuteTime清理更早的事件，0表示永久保留
+	RetainFriendEvents int `mapstructure:"retainFriendEvents"`
+	// 中断的用户注销任务接管检查的Cron表达式，为空表示不检查
+	UserErasureResumeTime string `mapstructure:"userErasureResumeTime"`
 }
 
 // OfflinePushConfig 离线推送配置
@@ -661,7 +690,15 @@ type Friend struct {
 		AutoSetPorts bool   `mapstructure:"autoSetPorts"`
 		Ports        []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
-	Prometheus Prometheus `mapstructure:"prometheus"`
+	Prometheus    Prometheus          `mapstructure:"prometheus"`
+	FriendRequest FriendRequestPolicy `mapstructure:"friendRequest"`
+}
+
+// FriendRequestPolicy 好友申请生命周期配置，系统管理员代为发起的申请不受限制
+type FriendRequestPolicy struct {
+	ExpireSeconds         int `mapstructure:"expireSeconds"`         // 待处理申请的有效期（秒），过期后由定时任务标记为已过期，0表示不过期
+	RefuseCooldownSeconds int `mapstructure:"refuseCooldownSeconds"` // 被拒绝后再次向对方发起申请的冷却时间（秒），0表示不限制
+	DailyLimit            int `mapstructure:"dailyLimit"`            // 每个用户24小时内最多发起的申请数，0表示不限制
 }
 
 type Group struct {
@@ -671,8 +708,16 @@ type Group struct {
 		AutoSetPorts bool   `mapstructure:"autoSetPorts"`
 		Ports        []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
-	Prometheus                 Prometheus `mapstructure:"prometheus"`
-	EnableHistoryForNewMembers bool       `mapstructure:"enableHistoryForNewMembers"`
+	Prometheus                 Prometheus      `mapstructure:"prometheus"`
+	EnableHistoryForNewMembers bool            `mapstructure:"enableHistoryForNewMembers"`
+	OwnerSuccession            OwnerSuccession `mapstructure:"ownerSuccession"`
+}
+
+// OwnerSuccession 群主继任配置
+// 群主退出群组或账号被删除时，依次选择入群最早的管理员、入群最早的普通成员作为新群主，都没有时解散群组
+type OwnerSuccession struct {
+	Enable        bool `mapstructure:"enable"`        // 是否启用，关闭时群主必须先转让群主才能退出
+	PromoteMember bool `mapstructure:"promoteMember"` // 没有管理员时是否由普通成员继任，为false时直接解散群组
 }
 
 type Msg struct {
@@ -682,8 +727,22 @@ type Msg struct {
 		AutoSetPorts bool   `mapstructure:"autoSetPorts"`
 		Ports        []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
-	Prometheus   Prometheus `mapstructure:"prometheus"`
-	FriendVerify bool       `mapstructure:"friendVerify"`
+	Prometheus   Prometheus   `mapstructure:"prometheus"`
+	FriendVerify bool         `mapstructure:"friendVerify"`
+	SendMsgDedup SendMsgDedup `mapstructure:"sendMsgDedup"`
+	// MaxAtUserCount 单条消息最多@的成员数，群组未单独设置时使用，0表示不限制
+	MaxAtUserCount int `mapstructure:"maxAtUserCount"`
+}
+
+// SendMsgDedup 发送幂等配置
+// 以(sendID, clientMsgID)为键在Redis中记录首次发送结果，窗口期内的重发直接返回首次结果
+type SendMsgDedup struct {
+	Enable bool `mapstructure:"enable"` // 是否启用发送去重
+	Window int  `mapstructure:"window"` // 去重窗口（秒）
+}
+
+func (s *SendMsgDedup) WindowDuration() time.Duration {
+	return time.Second * time.Duration(s.Window)
 }
 
 type Third struct {
@@ -745,7 +804,24 @@ type User struct {
 		AutoSetPorts bool   `mapstructure:"autoSetPorts"`
 		Ports        []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
-	Prometheus Prometheus `mapstructure:"prometheus"`
+	Prometheus    Prometheus    `mapstructure:"prometheus"`
+	ContactImport ContactImport `mapstructure:"contactImport"`
+}
+
+// ContactImport 通讯录匹配配置
+// 用户注册或修改资料时从Ex(JSON对象)中读取手机号、邮箱，规范化后计算加盐哈希写入标识索引
+type ContactImport struct {
+	Salt               string `mapstructure:"salt"`               // 哈希盐值，为空时关闭通讯录匹配；修改后已有的索引失效，需要重新写入用户资料
+	PhoneExKey         string `mapstructure:"phoneExKey"`         // Ex中手机号的字段名，为空表示不索引手机号
+	EmailExKey         string `mapstructure:"emailExKey"`         // Ex中邮箱的字段名，为空表示不索引邮箱
+	MaxHashesPerReq    int    `mapstructure:"maxHashesPerReq"`    // 单次最多提交匹配的哈希数
+	RateLimitWindowSec int    `mapstructure:"rateLimitWindowSec"` // 频率限制的统计窗口（秒）
+	RateLimitHashes    int    `mapstructure:"rateLimitHashes"`    // 每个用户在统计窗口内最多提交的哈希数，0表示不限制
+}
+
+// Enable 是否启用通讯录匹配
+func (c *ContactImport) Enable() bool {
+	return c.Salt != ""
 }
 
 // Redis 缓存数据库配置
@@ -889,6 +965,7 @@ type Webhooks struct {
 	BeforeImportFriends      BeforeConfig `mapstructure:"beforeImportFriends"`
 	AfterImportFriends       AfterConfig  `mapstructure:"afterImportFriends"`
 	AfterRemoveBlack         AfterConfig  `mapstructure:"afterRemoveBlack"`
+	AfterUserErased          AfterConfig  `mapstructure:"afterUserErased"`
 }
 
 type ZooKeeper struct {
@@ -27,4 +27,16 @@ var (
 		Name: "msg_long_time_push_total",
 		Help: "The number of messages with a push time exceeding 10 seconds",
 	})
+	MsgPushLaneConsumedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_push_lane_consumed_total",
+		Help: "The number of msg consumed by push, partitioned by priority lane",
+	}, []string{"lane"})
+	MsgPushLaneLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "msg_push_lane_latency_seconds",
+		Help:    "The latency from msg send time to push consumption, partitioned by priority lane",
+		Buckets: laneLatencyBuckets,
+	}, []string{"lane"})
 )
+
+// laneLatencyBuckets 优先级通道延迟分布的桶，覆盖10ms到30s
+var laneLatencyBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
@@ -58,6 +58,8 @@ func GetGrpcCusMetrics(registerName string, share *config.Share) []prometheus.Co
 		return []prometheus.Collector{
 			MsgOfflinePushFailedCounter,
 			MsgLoneTimePushCounter,
+			MsgPushLaneConsumedCounter,
+			MsgPushLaneLatency,
 		}
 	case share.RpcRegisterName.Auth:
 		return []prometheus.Collector{UserLoginCounter}
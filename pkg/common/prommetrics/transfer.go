@@ -41,6 +41,15 @@ var (
 		Name: "seq_set_failed_total",
 		Help: "The number of failed set seq",
 	})
+	MsgTransferLaneConsumedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_transfer_lane_consumed_total",
+		Help: "The number of msg consumed by msg transfer, partitioned by priority lane",
+	}, []string{"lane"})
+	MsgTransferLaneLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "msg_transfer_lane_latency_seconds",
+		Help:    "The latency from msg send time to msg transfer consumption, partitioned by priority lane",
+		Buckets: laneLatencyBuckets,
+	}, []string{"lane"})
 )
 
 func TransferInit(listener net.Listener) error {
@@ -52,6 +61,8 @@ func TransferInit(listener net.Listener) error {
 		MsgInsertMongoSuccessCounter,
 		MsgInsertMongoFailedCounter,
 		SeqSetFailedCounter,
+		MsgTransferLaneConsumedCounter,
+		MsgTransferLaneLatency,
 	)
 	return Init(reg, listener, commonPath, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}), cs...)
 }
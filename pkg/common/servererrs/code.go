@@ -52,28 +52,46 @@ const (
 	RecordNotFoundError = 1004 // Record does not exist
 
 	// Account error codes.
-	UserIDNotFoundError    = 1101 // UserID does not exist or is not registered
-	RegisteredAlreadyError = 1102 // user is already registered
+	UserIDNotFoundError       = 1101 // UserID does not exist or is not registered
+	RegisteredAlreadyError    = 1102 // user is already registered
+	ContactMatchLimitExceeded = 1103 // Contact matching rate limit reached
+	UserErasureExist          = 1104 // An erasure job already exists for the user
+	UserErasureNotResumable   = 1105 // The erasure job is finished or still running
+	UserBanned                = 1106 // The user is banned
 
 	// Group error codes.
-	GroupIDNotFoundError  = 1201 // GroupID does not exist
-	GroupIDExisted        = 1202 // GroupID already exists
-	NotInGroupYetError    = 1203 // Not in the group yet
-	DismissedAlreadyError = 1204 // Group has already been dismissed
-	GroupTypeNotSupport   = 1205
-	GroupRequestHandled   = 1206
+	GroupIDNotFoundError   = 1201 // GroupID does not exist
+	GroupIDExisted         = 1202 // GroupID already exists
+	NotInGroupYetError     = 1203 // Not in the group yet
+	DismissedAlreadyError  = 1204 // Group has already been dismissed
+	GroupTypeNotSupport    = 1205
+	GroupRequestHandled    = 1206
+	GroupInviteLinkInvalid = 1207 // Invite link has been revoked or has expired
+	GroupInviteLinkUsedUp  = 1208 // Invite link has reached its usage limit
+	GroupChannelNotFound   = 1209 // Group channel does not exist or has been deleted
+	GroupArchived          = 1210 // Group is archived and read-only
 
 	// Relationship error codes.
-	CanNotAddYourselfError   = 1301 // Cannot add yourself as a friend
-	BlockedByPeer            = 1302 // Blocked by the peer
-	NotPeersFriend           = 1303 // Not the peer's friend
-	RelationshipAlreadyError = 1304 // Already in a friend relationship
+	CanNotAddYourselfError     = 1301 // Cannot add yourself as a friend
+	BlockedByPeer              = 1302 // Blocked by the peer
+	NotPeersFriend             = 1303 // Not the peer's friend
+	RelationshipAlreadyError   = 1304 // Already in a friend relationship
+	FriendCategoryNotFound     = 1305 // Friend category does not exist or has been deleted
+	FriendRequestExpired       = 1306 // Friend request expired before being handled
+	FriendRequestCooldown      = 1307 // Re-requesting a user who refused you is still cooling down
+	FriendRequestLimitExceeded = 1308 // Daily friend request limit reached
+	NotInBlackList             = 1309 // The user is not in the blacklist
 
 	// Message error codes.
 	MessageHasReadDisable = 1401
 	MutedInGroup          = 1402 // Member muted in the group
 	MutedGroup            = 1403 // Group is muted
 	MsgAlreadyRevoke      = 1404 // Message already revoked
+	MsgSendInProgress     = 1405 // The same clientMsgID is still being sent
+	AtAllNotAllowed       = 1406 // Sender is not allowed to @all in the group
+	TooManyAtUsers        = 1407 // Too many @ targets in one message
+	GroupSlowMode         = 1408 // Group slow mode, sender must wait before sending again
+	GroupChannelReadOnly  = 1409 // Group channel is read-only for the sender
 
 	// Token error codes.
 	TokenExpiredError     = 1501
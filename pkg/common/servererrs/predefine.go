@@ -32,11 +32,20 @@ var (
 	ErrGroupIDNotFound = errs.NewCodeError(GroupIDNotFoundError, "GroupIDNotFoundError")
 	ErrGroupIDExisted  = errs.NewCodeError(GroupIDExisted, "GroupIDExisted")
 
-	ErrNotInGroupYet       = errs.NewCodeError(NotInGroupYetError, "NotInGroupYetError")
-	ErrDismissedAlready    = errs.NewCodeError(DismissedAlreadyError, "DismissedAlreadyError")
-	ErrRegisteredAlready   = errs.NewCodeError(RegisteredAlreadyError, "RegisteredAlreadyError")
-	ErrGroupTypeNotSupport = errs.NewCodeError(GroupTypeNotSupport, "")
-	ErrGroupRequestHandled = errs.NewCodeError(GroupRequestHandled, "GroupRequestHandled")
+	ErrNotInGroupYet          = errs.NewCodeError(NotInGroupYetError, "NotInGroupYetError")
+	ErrDismissedAlready       = errs.NewCodeError(DismissedAlreadyError, "DismissedAlreadyError")
+	ErrRegisteredAlready      = errs.NewCodeError(RegisteredAlreadyError, "RegisteredAlreadyError")
+	ErrGroupTypeNotSupport    = errs.NewCodeError(GroupTypeNotSupport, "")
+	ErrGroupRequestHandled    = errs.NewCodeError(GroupRequestHandled, "GroupRequestHandled")
+	ErrGroupInviteLinkInvalid = errs.NewCodeError(GroupInviteLinkInvalid, "GroupInviteLinkInvalid")
+	ErrGroupInviteLinkUsedUp  = errs.NewCodeError(GroupInviteLinkUsedUp, "GroupInviteLinkUsedUp")
+	ErrGroupChannelNotFound   = errs.NewCodeError(GroupChannelNotFound, "GroupChannelNotFound")
+	ErrGroupArchived          = errs.NewCodeError(GroupArchived, "GroupArchived")
+
+	ErrContactMatchLimitExceeded = errs.NewCodeError(ContactMatchLimitExceeded, "ContactMatchLimitExceeded")
+	ErrUserErasureExist          = errs.NewCodeError(UserErasureExist, "UserErasureExist")
+	ErrUserErasureNotResumable   = errs.NewCodeError(UserErasureNotResumable, "UserErasureNotResumable")
+	ErrUserBanned                = errs.NewCodeError(UserBanned, "UserBanned")
 
 	ErrData             = errs.NewCodeError(DataError, "DataError")
 	ErrTokenExpired     = errs.NewCodeError(TokenExpiredError, "TokenExpiredError")
@@ -49,14 +58,24 @@ var (
 
 	ErrMessageHasReadDisable = errs.NewCodeError(MessageHasReadDisable, "MessageHasReadDisable")
 
-	ErrCanNotAddYourself   = errs.NewCodeError(CanNotAddYourselfError, "CanNotAddYourselfError")
-	ErrBlockedByPeer       = errs.NewCodeError(BlockedByPeer, "BlockedByPeer")
-	ErrNotPeersFriend      = errs.NewCodeError(NotPeersFriend, "NotPeersFriend")
-	ErrRelationshipAlready = errs.NewCodeError(RelationshipAlreadyError, "RelationshipAlreadyError")
+	ErrCanNotAddYourself          = errs.NewCodeError(CanNotAddYourselfError, "CanNotAddYourselfError")
+	ErrBlockedByPeer              = errs.NewCodeError(BlockedByPeer, "BlockedByPeer")
+	ErrNotPeersFriend             = errs.NewCodeError(NotPeersFriend, "NotPeersFriend")
+	ErrRelationshipAlready        = errs.NewCodeError(RelationshipAlreadyError, "RelationshipAlreadyError")
+	ErrFriendCategoryNotFound     = errs.NewCodeError(FriendCategoryNotFound, "FriendCategoryNotFound")
+	ErrFriendRequestExpired       = errs.NewCodeError(FriendRequestExpired, "FriendRequestExpired")
+	ErrFriendRequestCooldown      = errs.NewCodeError(FriendRequestCooldown, "FriendRequestCooldown")
+	ErrFriendRequestLimitExceeded = errs.NewCodeError(FriendRequestLimitExceeded, "FriendRequestLimitExceeded")
+	ErrNotInBlackList             = errs.NewCodeError(NotInBlackList, "NotInBlackList")
 
-	ErrMutedInGroup     = errs.NewCodeError(MutedInGroup, "MutedInGroup")
-	ErrMutedGroup       = errs.NewCodeError(MutedGroup, "MutedGroup")
-	ErrMsgAlreadyRevoke = errs.NewCodeError(MsgAlreadyRevoke, "MsgAlreadyRevoke")
+	ErrMutedInGroup         = errs.NewCodeError(MutedInGroup, "MutedInGroup")
+	ErrMutedGroup           = errs.NewCodeError(MutedGroup, "MutedGroup")
+	ErrMsgAlreadyRevoke     = errs.NewCodeError(MsgAlreadyRevoke, "MsgAlreadyRevoke")
+	ErrMsgSendInProgress    = errs.NewCodeError(MsgSendInProgress, "MsgSendInProgress")
+	ErrAtAllNotAllowed      = errs.NewCodeError(AtAllNotAllowed, "AtAllNotAllowed")
+	ErrTooManyAtUsers       = errs.NewCodeError(TooManyAtUsers, "TooManyAtUsers")
+	ErrGroupSlowMode        = errs.NewCodeError(GroupSlowMode, "GroupSlowMode")
+	ErrGroupChannelReadOnly = errs.NewCodeError(GroupChannelReadOnly, "GroupChannelReadOnly")
 
 	ErrConnOverMaxNumLimit = errs.NewCodeError(ConnOverMaxNumLimit, "ConnOverMaxNumLimit")
 
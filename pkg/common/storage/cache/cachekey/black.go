@@ -16,7 +16,8 @@ package cachekey
 
 const (
 	BlackIDsKey = "BLACK_IDS:"
-	IsBlackKey  = "IS_BLACK:" // local cache
+	IsBlackKey  = "IS_BLACK:"    // local cache
+	BlackScope  = "BLACK_SCOPE:" // local cache
 )
 
 func GetBlackIDsKey(ownerUserID string) string {
@@ -27,3 +28,7 @@ func GetBlackIDsKey(ownerUserID string) string {
 func GetIsBlackIDsKey(possibleBlackUserID, userID string) string {
 	return IsBlackKey + userID + "-" + possibleBlackUserID
 }
+
+func GetBlackScopeKey(ownerUserID, blockUserID string) string {
+	return BlackScope + ownerUserID + "-" + blockUserID
+}
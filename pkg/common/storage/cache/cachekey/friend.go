@@ -21,6 +21,7 @@ const (
 	IsFriendKey         = "IS_FRIEND:" // local cache key
 	//FriendSyncSortUserIDsKey = "FRIEND_SYNC_SORT_USER_IDS:"
 	FriendMaxVersionKey = "FRIEND_MAX_VERSION:"
+	FriendSuggestionKey = "FRIEND_SUGGESTION:"
 )
 
 func GetFriendIDsKey(ownerUserID string) string {
@@ -39,6 +40,10 @@ func GetFriendMaxVersionKey(ownerUserID string) string {
 	return FriendMaxVersionKey + ownerUserID
 }
 
+func GetFriendSuggestionKey(ownerUserID string) string {
+	return FriendSuggestionKey + ownerUserID
+}
+
 func GetIsFriendKey(possibleFriendUserID, userID string) string {
 	return IsFriendKey + possibleFriendUserID + "-" + userID
 }
@@ -31,12 +31,30 @@ const (
 	GroupAdminLevelMemberIDsKey = "GROUP_ADMIN_LEVEL_MEMBER_IDS:"
 	GroupMemberMaxVersionKey    = "GROUP_MEMBER_MAX_VERSION:"
 	GroupJoinMaxVersionKey      = "GROUP_JOIN_MAX_VERSION:"
+	GroupSettingsKey            = "GROUP_SETTINGS:"
+	GroupMemberPermissionsKey   = "GROUP_MEMBER_PERMISSIONS:"
+	GroupChannelsKey            = "GROUP_CHANNELS:"
 )
 
 func GetGroupInfoKey(groupID string) string {
 	return GroupInfoKey + groupID
 }
 
+// GetGroupSettingsKey 群组扩展设置的本地缓存键，随群组信息（GetGroupInfoKey）一同失效
+func GetGroupSettingsKey(groupID string) string {
+	return GroupSettingsKey + groupID
+}
+
+// GetGroupMemberPermissionsKey 群成员有效权限的本地缓存键，随群组信息和群成员信息一同失效
+func GetGroupMemberPermissionsKey(groupID, userID string) string {
+	return GroupMemberPermissionsKey + groupID + "-" + userID
+}
+
+// GetGroupChannelsKey 群组频道列表的本地缓存键，随群组信息（GetGroupInfoKey）一同失效
+func GetGroupChannelsKey(groupID string) string {
+	return GroupChannelsKey + groupID
+}
+
 func GetJoinedGroupsKey(userID string) string {
 	return JoinedGroupsKey + userID
 }
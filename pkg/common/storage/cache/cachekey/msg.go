@@ -21,6 +21,8 @@ import (
 const (
 	sendMsgFailedFlag = "SEND_MSG_FAILED_FLAG:"
 	messageCache      = "MSG_CACHE:"
+	sendMsgDedup      = "SEND_MSG_DEDUP:"
+	groupSlowMode     = "GROUP_SLOW_MODE:"
 )
 
 func GetMsgCacheKey(conversationID string, seq int64) string {
@@ -30,3 +32,11 @@ func GetMsgCacheKey(conversationID string, seq int64) string {
 func GetSendMsgKey(id string) string {
 	return sendMsgFailedFlag + id
 }
+
+func GetSendMsgDedupKey(sendID string, clientMsgID string) string {
+	return sendMsgDedup + sendID + ":" + clientMsgID
+}
+
+func GetGroupSlowModeKey(groupID string, userID string) string {
+	return groupSlowMode + groupID + ":" + userID
+}
@@ -17,6 +17,9 @@ package cachekey
 const (
 	UserInfoKey             = "USER_INFO:"
 	UserGlobalRecvMsgOptKey = "USER_GLOBAL_RECV_MSG_OPT_KEY:"
+	ContactMatchCountKey    = "CONTACT_MATCH_COUNT:"
+	UserBanKey              = "USER_BAN:"
+	UserProfileFieldsKey    = "USER_PROFILE_FIELDS"
 )
 
 func GetUserInfoKey(userID string) string {
@@ -26,3 +29,16 @@ func GetUserInfoKey(userID string) string {
 func GetUserGlobalRecvMsgOptKey(userID string) string {
 	return UserGlobalRecvMsgOptKey + userID
 }
+
+func GetContactMatchCountKey(userID string) string {
+	return ContactMatchCountKey + userID
+}
+
+// GetUserBanKey 用户封禁状态的本地缓存键，随用户信息（GetUserInfoKey）一同失效
+func GetUserBanKey(userID string) string {
+	return UserBanKey + userID
+}
+
+func GetUserProfileFieldsKey() string {
+	return UserProfileFieldsKey
+}
@@ -44,4 +44,8 @@ type FriendCache interface {
 	//FindFriendIncrVersion(ctx context.Context, ownerUserID string, version uint, limit int) (*relationtb.VersionLog, error)
 
 	FindMaxFriendVersion(ctx context.Context, ownerUserID string) (*relationtb.VersionLog, error)
+
+	// GetFriendSuggestions returns the cached friend suggestions, computing them with fn on a miss.
+	// The cache is cleared together with the friend ID list and the joined group list.
+	GetFriendSuggestions(ctx context.Context, ownerUserID string, fn func(ctx context.Context) ([]*relationtb.FriendSuggestion, error)) ([]*relationtb.FriendSuggestion, error)
 }
@@ -16,9 +16,25 @@ package cache
 
 import (
 	"context"
+	"time"
+
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 )
 
+const (
+	SendMsgDedupPending = 0 // 首次请求已占位，消息尚未成功投递到MQ
+	SendMsgDedupSent    = 1 // 消息已成功投递到MQ
+)
+
+// SendMsgDedup 发送幂等记录，以(sendID, clientMsgID)为键
+// 客户端超时重发时据此返回首次发送的结果，Seq在msgtransfer分配序列号后回写
+type SendMsgDedup struct {
+	ServerMsgID string `json:"serverMsgID"`
+	SendTime    int64  `json:"sendTime"`
+	Seq         int64  `json:"seq"`
+	Status      int32  `json:"status"`
+}
+
 type MsgCache interface {
 	SetSendMsgStatus(ctx context.Context, id string, status int32) error
 	GetSendMsgStatus(ctx context.Context, id string) (int32, error)
@@ -26,4 +42,16 @@ type MsgCache interface {
 	GetMessageBySeqs(ctx context.Context, conversationID string, seqs []int64) ([]*model.MsgInfoModel, error)
 	DelMessageBySeqs(ctx context.Context, conversationID string, seqs []int64) error
 	SetMessageBySeqs(ctx context.Context, conversationID string, msgs []*model.MsgInfoModel) error
+
+	// ClaimSendMsgDedup 以(sendID, clientMsgID)占位，已存在时返回已有记录且claimed为false
+	ClaimSendMsgDedup(ctx context.Context, sendID string, clientMsgID string, record *SendMsgDedup, expire time.Duration) (exist *SendMsgDedup, claimed bool, err error)
+	// GetSendMsgDedup 获取幂等记录，不存在时返回nil
+	GetSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) (*SendMsgDedup, error)
+	SetSendMsgDedupStatus(ctx context.Context, sendID string, clientMsgID string, status int32) error
+	// SetSendMsgDedupSeqs 回写已分配的序列号，仅更新仍在去重窗口内的记录
+	SetSendMsgDedupSeqs(ctx context.Context, msgs []*model.MsgDataModel) error
+	DelSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) error
+
+	// ClaimGroupSlowMode 慢速模式发言占位，返回还需等待的时长，0表示允许发言并已记录本次发言时间
+	ClaimGroupSlowMode(ctx context.Context, groupID string, userID string, interval time.Duration) (time.Duration, error)
 }
@@ -64,6 +64,9 @@ const (
 	// friendExpireTime 好友缓存过期时间：12小时
 	// 设计考虑：好友关系相对稳定，12小时的缓存时间平衡了性能和数据一致性
 	friendExpireTime = time.Second * 60 * 60 * 12
+	// friendSuggestionExpireTime 好友推荐缓存过期时间：1小时
+	// 好友和群成员变更只清除变更用户本人的推荐，其他用户的共同好友数、共同群组数在过期后刷新
+	friendSuggestionExpireTime = time.Hour
 )
 
 // FriendCacheRedis 好友缓存Redis实现
@@ -256,6 +259,8 @@ func (f *FriendCacheRedis) GetFriendIDs(ctx context.Context, ownerUserID string)
 // - 好友关系状态变更时
 // - 批量好友操作时
 //
+// 好友列表变化时同时删除用户的好友推荐缓存
+//
 // **批量处理：**
 // 支持同时删除多个用户的好友ID列表缓存
 // 适用于好友关系变更影响多个用户的场景
@@ -273,9 +278,9 @@ func (f *FriendCacheRedis) GetFriendIDs(ctx context.Context, ownerUserID string)
 // - cache.FriendCache: 新的缓存实例，支持链式调用
 func (f *FriendCacheRedis) DelFriendIDs(ownerUserIDs ...string) cache.FriendCache {
 	newFriendCache := f.CloneFriendCache()
-	keys := make([]string, 0, len(ownerUserIDs))
+	keys := make([]string, 0, len(ownerUserIDs)*2)
 	for _, userID := range ownerUserIDs {
-		keys = append(keys, f.getFriendIDsKey(userID))
+		keys = append(keys, f.getFriendIDsKey(userID), cachekey.GetFriendSuggestionKey(userID))
 	}
 	newFriendCache.AddKeys(keys...)
 
@@ -579,3 +584,8 @@ func (f *FriendCacheRedis) FindMaxFriendVersion(ctx context.Context, ownerUserID
 		return f.friendDB.FindIncrVersion(ctx, ownerUserID, 0, 0)
 	})
 }
+
+// GetFriendSuggestions 获取好友推荐缓存，未命中时调用fn计算
+func (f *FriendCacheRedis) GetFriendSuggestions(ctx context.Context, ownerUserID string, fn func(ctx context.Context) ([]*model.FriendSuggestion, error)) ([]*model.FriendSuggestion, error) {
+	return getCache(ctx, f.rcClient, cachekey.GetFriendSuggestionKey(ownerUserID), friendSuggestionExpireTime, fn)
+}
@@ -776,6 +776,7 @@ func (g *GroupCacheRedis) GetJoinedGroupIDs(ctx context.Context, userID string)
 // **影响范围：**
 // 删除此缓存会影响用户的群组列表显示
 // 下次访问时会重新从数据库加载最新数据
+// 加入的群组变化时同时删除用户的好友推荐缓存（共同群组数随之变化）
 //
 // **参数说明：**
 // - userIDs: 用户ID列表（可变参数）
@@ -783,9 +784,9 @@ func (g *GroupCacheRedis) GetJoinedGroupIDs(ctx context.Context, userID string)
 // **返回值：**
 // - cache.GroupCache: 新的缓存实例，支持链式调用
 func (g *GroupCacheRedis) DelJoinedGroupID(userIDs ...string) cache.GroupCache {
-	keys := make([]string, 0, len(userIDs))
+	keys := make([]string, 0, len(userIDs)*2)
 	for _, userID := range userIDs {
-		keys = append(keys, g.getJoinedGroupsKey(userID))
+		keys = append(keys, g.getJoinedGroupsKey(userID), cachekey.GetFriendSuggestionKey(userID))
 	}
 	cache := g.CloneGroupCache()
 	cache.AddKeys(keys...)
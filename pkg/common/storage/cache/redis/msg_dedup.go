@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sendMsgDedupServerMsgID = "serverMsgID"
+	sendMsgDedupSendTime    = "sendTime"
+	sendMsgDedupSeq         = "seq"
+	sendMsgDedupStatus      = "status"
+)
+
+var (
+	// claimSendMsgDedupScript 原子地检查并占位：已存在则返回全部字段，否则写入记录并设置过期时间
+	claimSendMsgDedupScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+    return redis.call('HGETALL', KEYS[1])
+end
+redis.call('HSET', KEYS[1], 'serverMsgID', ARGV[1], 'sendTime', ARGV[2], 'seq', 0, 'status', ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return {}
+`)
+
+	// setSendMsgDedupFieldScript 仅在记录存在时更新字段，避免为已过期的记录创建无TTL的键
+	setSendMsgDedupFieldScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+    return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+)
+
+// ClaimSendMsgDedup 以(sendID, clientMsgID)为键占位发送幂等记录
+// 首个请求占位成功返回claimed=true；并发或超时重发的请求返回首个请求写入的记录
+func (c *msgCache) ClaimSendMsgDedup(ctx context.Context, sendID string, clientMsgID string, record *cache.SendMsgDedup, expire time.Duration) (*cache.SendMsgDedup, bool, error) {
+	key := cachekey.GetSendMsgDedupKey(sendID, clientMsgID)
+	args := []any{record.ServerMsgID, record.SendTime, record.Status, expire.Milliseconds()}
+	res, err := callLua(ctx, c.rdb, claimSendMsgDedupScript, []string{key}, args)
+	if err != nil {
+		return nil, false, err
+	}
+	values, ok := res.([]any)
+	if !ok {
+		return nil, false, errs.New("invalid lua result", "key", key, "result", res).Wrap()
+	}
+	if len(values) == 0 {
+		return nil, true, nil
+	}
+	fields := make(map[string]string, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		k, _ := values[i].(string)
+		v, _ := values[i+1].(string)
+		fields[k] = v
+	}
+	exist, err := parseSendMsgDedup(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return exist, false, nil
+}
+
+// GetSendMsgDedup 获取发送幂等记录，记录不存在（未占位或已过期）时返回nil
+func (c *msgCache) GetSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) (*cache.SendMsgDedup, error) {
+	fields, err := c.rdb.HGetAll(ctx, cachekey.GetSendMsgDedupKey(sendID, clientMsgID)).Result()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return parseSendMsgDedup(fields)
+}
+
+func (c *msgCache) SetSendMsgDedupStatus(ctx context.Context, sendID string, clientMsgID string, status int32) error {
+	key := cachekey.GetSendMsgDedupKey(sendID, clientMsgID)
+	_, err := callLua(ctx, c.rdb, setSendMsgDedupFieldScript, []string{key}, []any{sendMsgDedupStatus, status})
+	return err
+}
+
+// SetSendMsgDedupSeqs 回写msgtransfer分配的序列号
+// 单条失败只记录日志，不影响后续消息的回写
+func (c *msgCache) SetSendMsgDedupSeqs(ctx context.Context, msgs []*model.MsgDataModel) error {
+	var lastErr error
+	for _, msg := range msgs {
+		if msg == nil || msg.ClientMsgID == "" || msg.Seq <= 0 {
+			continue
+		}
+		key := cachekey.GetSendMsgDedupKey(msg.SendID, msg.ClientMsgID)
+		if _, err := callLua(ctx, c.rdb, setSendMsgDedupFieldScript, []string{key}, []any{sendMsgDedupSeq, msg.Seq}); err != nil {
+			log.ZWarn(ctx, "set send msg dedup seq failed", err, "key", key, "seq", msg.Seq)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (c *msgCache) DelSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) error {
+	return errs.Wrap(c.rdb.Del(ctx, cachekey.GetSendMsgDedupKey(sendID, clientMsgID)).Err())
+}
+
+func parseSendMsgDedup(fields map[string]string) (*cache.SendMsgDedup, error) {
+	var (
+		record cache.SendMsgDedup
+		err    error
+	)
+	record.ServerMsgID = fields[sendMsgDedupServerMsgID]
+	if v := fields[sendMsgDedupSendTime]; v != "" {
+		if record.SendTime, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, errs.WrapMsg(err, "parse send msg dedup sendTime failed", "value", v)
+		}
+	}
+	if v := fields[sendMsgDedupSeq]; v != "" {
+		if record.Seq, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, errs.WrapMsg(err, "parse send msg dedup seq failed", "value", v)
+		}
+	}
+	if v := fields[sendMsgDedupStatus]; v != "" {
+		status, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "parse send msg dedup status failed", "value", v)
+		}
+		record.Status = int32(status)
+	}
+	return &record, nil
+}
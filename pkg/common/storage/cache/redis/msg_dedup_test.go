@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimSendMsgDedup(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	ctx := context.Background()
+	c := &msgCache{rdb: rdb}
+
+	key := cachekey.GetSendMsgDedupKey("user1", "client1")
+	record := &cache.SendMsgDedup{ServerMsgID: "server1", SendTime: 1000, Status: cache.SendMsgDedupPending}
+	args := []any{"server1", int64(1000), int32(cache.SendMsgDedupPending), int64(60000)}
+
+	mock.ExpectEvalSha(claimSendMsgDedupScript.Hash(), []string{key}, args).SetVal([]any{})
+	exist, claimed, err := c.ClaimSendMsgDedup(ctx, "user1", "client1", record, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Nil(t, exist)
+
+	mock.ExpectEvalSha(claimSendMsgDedupScript.Hash(), []string{key}, args).
+		SetVal([]any{"serverMsgID", "server0", "sendTime", "900", "seq", "7", "status", "1"})
+	exist, claimed, err = c.ClaimSendMsgDedup(ctx, "user1", "client1", record, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, &cache.SendMsgDedup{ServerMsgID: "server0", SendTime: 900, Seq: 7, Status: cache.SendMsgDedupSent}, exist)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSendMsgDedupNotExist(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	c := &msgCache{rdb: rdb}
+
+	mock.ExpectHGetAll(cachekey.GetSendMsgDedupKey("user1", "client1")).SetVal(map[string]string{})
+	record, err := c.GetSendMsgDedup(context.Background(), "user1", "client1")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
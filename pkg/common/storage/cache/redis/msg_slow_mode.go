@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/openimsdk/tools/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+// claimGroupSlowModeScript 慢速模式发言占位：距上次发言不足间隔时返回剩余毫秒数，否则记录本次发言时间并返回0
+// 按当前间隔计算剩余时间，群组调小间隔后立即生效
+var claimGroupSlowModeScript = redis.NewScript(`
+local last = tonumber(redis.call('GET', KEYS[1]))
+local now = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+if last and now - last < interval then
+    return interval - (now - last)
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 0
+`)
+
+// ClaimGroupSlowMode 记录成员在慢速模式群组中的发言时间，返回还需等待的时长，0表示允许发言
+func (c *msgCache) ClaimGroupSlowMode(ctx context.Context, groupID string, userID string, interval time.Duration) (time.Duration, error) {
+	key := cachekey.GetGroupSlowModeKey(groupID, userID)
+	args := []any{time.Now().UnixMilli(), interval.Milliseconds()}
+	res, err := callLua(ctx, c.rdb, claimGroupSlowModeScript, []string{key}, args)
+	if err != nil {
+		return 0, err
+	}
+	remaining, ok := res.(int64)
+	if !ok {
+		return 0, errs.New("invalid lua result", "key", key, "result", res).Wrap()
+	}
+	return time.Duration(remaining) * time.Millisecond, nil
+}
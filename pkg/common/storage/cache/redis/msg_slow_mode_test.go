@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimGroupSlowMode(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	ctx := context.Background()
+	c := &msgCache{rdb: rdb}
+
+	key := cachekey.GetGroupSlowModeKey("group1", "user1")
+	// 参数中的当前时间无法预知，只比较命令、键和间隔
+	match := func(expected, actual []any) error {
+		if len(actual) != 6 {
+			return fmt.Errorf("unexpected args %v", actual)
+		}
+		if fmt.Sprint(actual[:4]) != fmt.Sprint(expected[:4]) || fmt.Sprint(actual[5]) != fmt.Sprint(expected[5]) {
+			return fmt.Errorf("expected %v, actual %v", expected, actual)
+		}
+		return nil
+	}
+	args := []any{int64(0), int64(30000)}
+
+	mock.CustomMatch(match).ExpectEvalSha(claimGroupSlowModeScript.Hash(), []string{key}, args...).SetVal(int64(0))
+	remaining, err := c.ClaimGroupSlowMode(ctx, "group1", "user1", 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), remaining)
+
+	mock.CustomMatch(match).ExpectEvalSha(claimGroupSlowModeScript.Hash(), []string{key}, args...).SetVal(int64(12500))
+	remaining, err = c.ClaimGroupSlowMode(ctx, "group1", "user1", 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 12500*time.Millisecond, remaining)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -162,6 +162,25 @@ func (s *seqUserCacheRedis) GetUserReadSeqs(ctx context.Context, userID string,
 	return data, nil
 }
 
+func (s *seqUserCacheRedis) DeleteUserSeqs(ctx context.Context, userID string) (int64, error) {
+	conversationIDs, err := s.mgo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	keys := make([]string, 0, len(conversationIDs)*3)
+	for _, conversationID := range conversationIDs {
+		keys = append(keys,
+			s.getSeqUserMaxSeqKey(conversationID, userID),
+			s.getSeqUserMinSeqKey(conversationID, userID),
+			s.getSeqUserReadSeqKey(conversationID, userID),
+		)
+	}
+	if err := DeleteCacheBySlot(ctx, s.rocks, keys); err != nil {
+		return 0, err
+	}
+	return int64(len(conversationIDs)), nil
+}
+
 var _ BatchCacheCallback[string] = (*readSeqModel)(nil)
 
 type readSeqModel struct {
@@ -37,11 +37,12 @@ type UserCacheRedis struct {
 	cache.BatchDeleter
 	rdb        redis.UniversalClient
 	userDB     database.User
+	fieldDB    database.UserProfileField
 	expireTime time.Duration
 	rcClient   *rockscache.Client
 }
 
-func NewUserCacheRedis(rdb redis.UniversalClient, localCache *config.LocalCache, userDB database.User, options *rockscache.Options) cache.UserCache {
+func NewUserCacheRedis(rdb redis.UniversalClient, localCache *config.LocalCache, userDB database.User, fieldDB database.UserProfileField, options *rockscache.Options) cache.UserCache {
 	batchHandler := NewBatchDeleterRedis(rdb, options, []string{localCache.User.Topic})
 	u := localCache.User
 	log.ZDebug(context.Background(), "user local cache init", "Topic", u.Topic, "SlotNum", u.SlotNum, "SlotSize", u.SlotSize, "enable", u.Enable())
@@ -49,6 +50,7 @@ func NewUserCacheRedis(rdb redis.UniversalClient, localCache *config.LocalCache,
 		BatchDeleter: batchHandler,
 		rdb:          rdb,
 		userDB:       userDB,
+		fieldDB:      fieldDB,
 		expireTime:   userExpireTime,
 		rcClient:     rockscache.NewClient(rdb, *options),
 	}
@@ -63,6 +65,7 @@ func (u *UserCacheRedis) CloneUserCache() cache.UserCache {
 		BatchDeleter: u.BatchDeleter.Clone(),
 		rdb:          u.rdb,
 		userDB:       u.userDB,
+		fieldDB:      u.fieldDB,
 		expireTime:   u.expireTime,
 		rcClient:     u.rcClient,
 	}
@@ -119,3 +122,15 @@ func (u *UserCacheRedis) DelUsersGlobalRecvMsgOpt(userIDs ...string) cache.UserC
 
 	return cache
 }
+
+func (u *UserCacheRedis) GetProfileFields(ctx context.Context) ([]*model.UserProfileField, error) {
+	return getCache(ctx, u.rcClient, cachekey.GetUserProfileFieldsKey(), u.expireTime, func(ctx context.Context) ([]*model.UserProfileField, error) {
+		return u.fieldDB.FindAll(ctx)
+	})
+}
+
+func (u *UserCacheRedis) DelProfileFields() cache.UserCache {
+	cache := u.CloneUserCache()
+	cache.AddKeys(cachekey.GetUserProfileFieldsKey())
+	return cache
+}
@@ -0,0 +1,40 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/openimsdk/tools/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+// incrContactMatchCountScript 固定窗口计数：累加提交数，窗口内首次提交时设置过期时间，返回累加后的总数和窗口剩余毫秒数
+var incrContactMatchCountScript = redis.NewScript(`
+local total = redis.call('INCRBY', KEYS[1], ARGV[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+    redis.call('PEXPIRE', KEYS[1], ARGV[2])
+    ttl = tonumber(ARGV[2])
+end
+return {total, ttl}
+`)
+
+// IncrContactMatchCount 累加用户在当前统计窗口内提交匹配的标识数
+func (u *UserCacheRedis) IncrContactMatchCount(ctx context.Context, userID string, count int64, window time.Duration) (int64, time.Duration, error) {
+	key := cachekey.GetContactMatchCountKey(userID)
+	res, err := callLua(ctx, u.rdb, incrContactMatchCountScript, []string{key}, []any{count, window.Milliseconds()})
+	if err != nil {
+		return 0, 0, err
+	}
+	values, ok := res.([]any)
+	if !ok || len(values) != 2 {
+		return 0, 0, errs.New("invalid lua result", "key", key, "result", res).Wrap()
+	}
+	total, ok1 := values[0].(int64)
+	ttl, ok2 := values[1].(int64)
+	if !ok1 || !ok2 {
+		return 0, 0, errs.New("invalid lua result", "key", key, "result", res).Wrap()
+	}
+	return total, time.Duration(ttl) * time.Millisecond, nil
+}
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrContactMatchCount(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	ctx := context.Background()
+	c := &UserCacheRedis{rdb: rdb}
+
+	key := cachekey.GetContactMatchCountKey("user1")
+	mock.ExpectEvalSha(incrContactMatchCountScript.Hash(), []string{key}, int64(100), int64(3600000)).SetVal([]any{int64(100), int64(3600000)})
+	total, ttl, err := c.IncrContactMatchCount(ctx, "user1", 100, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), total)
+	assert.Equal(t, time.Hour, ttl)
+
+	mock.ExpectEvalSha(incrContactMatchCountScript.Hash(), []string{key}, int64(50), int64(3600000)).SetVal([]any{int64(150), int64(1200000)})
+	total, ttl, err = c.IncrContactMatchCount(ctx, "user1", 50, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), total)
+	assert.Equal(t, 20*time.Minute, ttl)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -13,4 +13,5 @@ type SeqUser interface {
 	SetUserMinSeqs(ctx context.Context, userID string, seqs map[string]int64) error
 	SetUserReadSeqs(ctx context.Context, userID string, seqs map[string]int64) error
 	GetUserReadSeqs(ctx context.Context, userID string, conversationIDs []string) (map[string]int64, error)
+	DeleteUserSeqs(ctx context.Context, userID string) (int64, error)
 }
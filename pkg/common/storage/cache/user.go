@@ -16,6 +16,8 @@ package cache
 
 import (
 	"context"
+	"time"
+
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 )
 
@@ -27,6 +29,11 @@ type UserCache interface {
 	DelUsersInfo(userIDs ...string) UserCache
 	GetUserGlobalRecvMsgOpt(ctx context.Context, userID string) (opt int, err error)
 	DelUsersGlobalRecvMsgOpt(userIDs ...string) UserCache
+	// GetProfileFields 获取全部自定义资料字段定义
+	GetProfileFields(ctx context.Context) ([]*model.UserProfileField, error)
+	DelProfileFields() UserCache
+	// IncrContactMatchCount 累加用户在当前统计窗口内提交匹配的标识数，返回累加后的总数和窗口剩余时间
+	IncrContactMatchCount(ctx context.Context, userID string, count int64, window time.Duration) (total int64, ttl time.Duration, err error)
 	//GetUserStatus(ctx context.Context, userIDs []string) ([]*user.OnlineStatus, error)
 	//SetUserStatus(ctx context.Context, userID string, status, platformID int32) error
 }
@@ -0,0 +1,97 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/db/tx"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ApplicationDatabase 应用版本数据库操作接口
+// 每个平台最多只有一个版本带有最新标记，新增或更新带最新标记的版本时会取消同平台其他版本的标记
+type ApplicationDatabase interface {
+	// AddVersion 新增版本
+	AddVersion(ctx context.Context, app *model.Application) error
+	// UpdateVersion 使用app的全部字段覆盖已有版本
+	UpdateVersion(ctx context.Context, app *model.Application) error
+	// DeleteVersions 删除版本
+	DeleteVersions(ctx context.Context, ids []primitive.ObjectID) error
+	// TakeVersion 获取单个版本
+	TakeVersion(ctx context.Context, id primitive.ObjectID) (*model.Application, error)
+	// FindPlatformVersions 获取平台的全部版本
+	FindPlatformVersions(ctx context.Context, platform string) ([]*model.Application, error)
+	// SearchVersions 分页搜索版本
+	SearchVersions(ctx context.Context, keyword string, platforms []string, pagination pagination.Pagination) (int64, []*model.Application, error)
+}
+
+func NewApplicationDatabase(db database.Application, tx tx.Tx) ApplicationDatabase {
+	return &applicationDatabase{db: db, tx: tx}
+}
+
+type applicationDatabase struct {
+	db database.Application
+	tx tx.Tx
+}
+
+func (a *applicationDatabase) AddVersion(ctx context.Context, app *model.Application) error {
+	return a.tx.Transaction(ctx, func(ctx context.Context) error {
+		if app.Latest {
+			if err := a.db.ClearLatest(ctx, app.Platform, app.ID); err != nil {
+				return err
+			}
+		}
+		return a.db.Create(ctx, app)
+	})
+}
+
+func (a *applicationDatabase) UpdateVersion(ctx context.Context, app *model.Application) error {
+	return a.tx.Transaction(ctx, func(ctx context.Context) error {
+		if app.Latest {
+			if err := a.db.ClearLatest(ctx, app.Platform, app.ID); err != nil {
+				return err
+			}
+		}
+		return a.db.Update(ctx, app.ID, map[string]any{
+			"platform": app.Platform,
+			"hot":      app.Hot,
+			"version":  app.Version,
+			"url":      app.Url,
+			"text":     app.Text,
+			"force":    app.Force,
+			"latest":   app.Latest,
+		})
+	})
+}
+
+func (a *applicationDatabase) DeleteVersions(ctx context.Context, ids []primitive.ObjectID) error {
+	return a.db.Delete(ctx, ids)
+}
+
+func (a *applicationDatabase) TakeVersion(ctx context.Context, id primitive.ObjectID) (*model.Application, error) {
+	return a.db.Take(ctx, id)
+}
+
+func (a *applicationDatabase) FindPlatformVersions(ctx context.Context, platform string) ([]*model.Application, error) {
+	return a.db.FindByPlatform(ctx, platform)
+}
+
+func (a *applicationDatabase) SearchVersions(ctx context.Context, keyword string, platforms []string, pagination pagination.Pagination) (int64, []*model.Application, error) {
+	return a.db.Search(ctx, keyword, platforms, pagination)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/db/tx"
 	"github.com/openimsdk/tools/log"
 	"github.com/openimsdk/tools/utils/datautil"
 )
@@ -33,14 +34,16 @@ type BlackDatabase interface {
 	// Create 添加黑名单记录
 	// 将指定用户添加到黑名单中，支持批量添加
 	// blacks: 黑名单记录列表
+	// events: 好友关系事件，与黑名单在同一事务中写入
 	// 返回: 错误信息
-	Create(ctx context.Context, blacks []*model.Black) (err error)
+	Create(ctx context.Context, blacks []*model.Black, events []*model.FriendEvent) (err error)
 
 	// Delete 删除黑名单记录
 	// 从黑名单中移除指定用户，支持批量删除
 	// blacks: 要删除的黑名单记录列表
+	// events: 好友关系事件，与删除在同一事务中写入，为空时不记录
 	// 返回: 错误信息
-	Delete(ctx context.Context, blacks []*model.Black) (err error)
+	Delete(ctx context.Context, blacks []*model.Black, events []*model.FriendEvent) (err error)
 
 	// FindOwnerBlacks 获取用户的黑名单列表
 	// 分页查询指定用户的所有黑名单记录
@@ -63,44 +66,79 @@ type BlackDatabase interface {
 	// userID2: 第二个用户ID
 	// 返回: user2是否在user1黑名单中、user1是否在user2黑名单中、错误信息
 	CheckIn(ctx context.Context, userID1, userID2 string) (inUser1Blacks bool, inUser2Blacks bool, err error)
+
+	// FindBlacksBetween 查找用户与指定用户之间任一方向的黑名单记录
+	// 包括userID拉黑的用户和拉黑了userID的用户
+	FindBlacksBetween(ctx context.Context, userID string, peerUserIDs []string) (blacks []*model.Black, err error)
+
+	// FindBlacksIn 查找ownerUserIDs中的用户拉黑blockUserIDs中用户的黑名单记录
+	FindBlacksIn(ctx context.Context, ownerUserIDs, blockUserIDs []string) (blacks []*model.Black, err error)
+
+	// UpdateScope 修改黑名单的生效范围并清除拥有者的黑名单缓存
+	UpdateScope(ctx context.Context, ownerUserID, blockUserID string, scope int32) (err error)
+
+	// FindUserBlacks 查找用户拉黑的和拉黑了用户的全部黑名单记录
+	FindUserBlacks(ctx context.Context, userID string) (blacks []*model.Black, err error)
 }
 
 // blackDatabase 黑名单数据库实现
 // 整合了数据库操作和缓存管理，提供高性能的黑名单服务
 type blackDatabase struct {
-	black database.Black   // 黑名单数据库接口，提供持久化存储
-	cache cache.BlackCache // 黑名单缓存接口，提供快速查询能力
+	black database.Black       // 黑名单数据库接口，提供持久化存储
+	event database.FriendEvent // 好友关系事件日志接口
+	cache cache.BlackCache     // 黑名单缓存接口，提供快速查询能力
+	tx    tx.Tx                // 事务管理接口
 }
 
 // NewBlackDatabase 创建黑名单数据库实例
 // 初始化黑名单管理所需的数据库和缓存组件
 // black: 黑名单数据库接口
+// event: 好友关系事件日志接口
 // cache: 黑名单缓存接口
+// tx: 事务管理接口
 // 返回: BlackDatabase接口实例
-func NewBlackDatabase(black database.Black, cache cache.BlackCache) BlackDatabase {
-	return &blackDatabase{black, cache}
+func NewBlackDatabase(black database.Black, event database.FriendEvent, cache cache.BlackCache, tx tx.Tx) BlackDatabase {
+	return &blackDatabase{black: black, event: event, cache: cache, tx: tx}
 }
 
 // Create 添加黑名单记录
-// 将用户添加到黑名单中，同时更新数据库和缓存
-func (b *blackDatabase) Create(ctx context.Context, blacks []*model.Black) (err error) {
-	// 先更新数据库
-	if err := b.black.Create(ctx, blacks); err != nil {
-		return err
-	}
-	// 再清除相关缓存，保证数据一致性
-	return b.deleteBlackIDsCache(ctx, blacks)
+// 在事务中写入黑名单和好友关系事件，同时更新数据库和缓存
+func (b *blackDatabase) Create(ctx context.Context, blacks []*model.Black, events []*model.FriendEvent) (err error) {
+	return b.tx.Transaction(ctx, func(ctx context.Context) error {
+		// 先更新数据库
+		if err := b.black.Create(ctx, blacks); err != nil {
+			return err
+		}
+		if err := b.createFriendEvents(ctx, events); err != nil {
+			return err
+		}
+		// 再清除相关缓存，保证数据一致性
+		return b.deleteBlackIDsCache(ctx, blacks)
+	})
 }
 
 // Delete 删除黑名单记录
-// 从黑名单中移除用户，同时更新数据库和缓存
-func (b *blackDatabase) Delete(ctx context.Context, blacks []*model.Black) (err error) {
-	// 先更新数据库
-	if err := b.black.Delete(ctx, blacks); err != nil {
-		return err
+// 在事务中删除黑名单并写入好友关系事件，同时更新数据库和缓存
+func (b *blackDatabase) Delete(ctx context.Context, blacks []*model.Black, events []*model.FriendEvent) (err error) {
+	return b.tx.Transaction(ctx, func(ctx context.Context) error {
+		// 先更新数据库
+		if err := b.black.Delete(ctx, blacks); err != nil {
+			return err
+		}
+		if err := b.createFriendEvents(ctx, events); err != nil {
+			return err
+		}
+		// 再清除相关缓存，保证数据一致性
+		return b.deleteBlackIDsCache(ctx, blacks)
+	})
+}
+
+// createFriendEvents 追加好友关系事件，在调用方的事务中执行
+func (b *blackDatabase) createFriendEvents(ctx context.Context, events []*model.FriendEvent) error {
+	if len(events) == 0 {
+		return nil
 	}
-	// 再清除相关缓存，保证数据一致性
-	return b.deleteBlackIDsCache(ctx, blacks)
+	return b.event.Create(ctx, events)
 }
 
 // deleteBlackIDsCache 删除黑名单ID缓存
@@ -154,3 +192,60 @@ func (b *blackDatabase) FindBlackIDs(ctx context.Context, ownerUserID string) (b
 func (b *blackDatabase) FindBlackInfos(ctx context.Context, ownerUserID string, userIDs []string) (blacks []*model.Black, err error) {
 	return b.black.FindOwnerBlackInfos(ctx, ownerUserID, userIDs)
 }
+
+// FindBlacksBetween 查找用户与指定用户之间任一方向的黑名单记录
+func (b *blackDatabase) FindBlacksBetween(ctx context.Context, userID string, peerUserIDs []string) (blacks []*model.Black, err error) {
+	if len(peerUserIDs) == 0 {
+		return nil, nil
+	}
+	pairs := make([]*model.Black, 0, len(peerUserIDs)*2)
+	for _, peerUserID := range peerUserIDs {
+		pairs = append(pairs,
+			&model.Black{OwnerUserID: userID, BlockUserID: peerUserID},
+			&model.Black{OwnerUserID: peerUserID, BlockUserID: userID},
+		)
+	}
+	return b.black.Find(ctx, pairs)
+}
+
+// FindBlacksIn 查找ownerUserIDs中的用户拉黑blockUserIDs中用户的黑名单记录
+func (b *blackDatabase) FindBlacksIn(ctx context.Context, ownerUserIDs, blockUserIDs []string) (blacks []*model.Black, err error) {
+	if len(ownerUserIDs) == 0 || len(blockUserIDs) == 0 {
+		return nil, nil
+	}
+	pairs := make([]*model.Black, 0, len(ownerUserIDs)*len(blockUserIDs))
+	for _, ownerUserID := range ownerUserIDs {
+		for _, blockUserID := range blockUserIDs {
+			if ownerUserID == blockUserID {
+				continue
+			}
+			pairs = append(pairs, &model.Black{OwnerUserID: ownerUserID, BlockUserID: blockUserID})
+		}
+	}
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	return b.black.Find(ctx, pairs)
+}
+
+// UpdateScope 修改黑名单的生效范围
+// 本地缓存的黑名单范围关联到拥有者的黑名单ID缓存键，清除该缓存即可通知各服务刷新
+func (b *blackDatabase) UpdateScope(ctx context.Context, ownerUserID, blockUserID string, scope int32) (err error) {
+	if err := b.black.UpdateScope(ctx, ownerUserID, blockUserID, scope); err != nil {
+		return err
+	}
+	return b.deleteBlackIDsCache(ctx, []*model.Black{{OwnerUserID: ownerUserID, BlockUserID: blockUserID}})
+}
+
+// FindUserBlacks 查找用户拉黑的和拉黑了用户的全部黑名单记录，用于注销用户时清理
+func (b *blackDatabase) FindUserBlacks(ctx context.Context, userID string) (blacks []*model.Black, err error) {
+	owned, err := b.black.FindOwnerBlackInfos(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := b.black.FindByBlockUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(owned, blocked...), nil
+}
@@ -155,6 +155,14 @@ type ConversationDatabase interface {
 	// ts: 时间戳
 	// limit: 限制数量
 	FindRandConversation(ctx context.Context, ts int64, limit int) ([]*relationtb.Conversation, error)
+
+	// DeleteUserConversations 删除用户的全部会话并清除相关缓存，返回删除的会话数
+	// 用于注销用户，其他用户与该用户的会话不受影响
+	DeleteUserConversations(ctx context.Context, ownerUserID string) (int64, error)
+
+	// DeleteConversations 删除全部用户的指定会话并清除相关缓存，返回删除的会话数
+	// 用于删除群组频道，按会话所有者逐个删除
+	DeleteConversations(ctx context.Context, conversationIDs []string) (int64, error)
 }
 
 // NewConversationDatabase 创建会话数据库实例
@@ -602,3 +610,84 @@ func (c *conversationDatabase) GetPinnedConversationIDs(ctx context.Context, use
 func (c *conversationDatabase) FindRandConversation(ctx context.Context, ts int64, limit int) ([]*relationtb.Conversation, error) {
 	return c.conversationDB.FindRandConversation(ctx, ts, limit)
 }
+
+// DeleteUserConversations 删除用户的全部会话
+func (c *conversationDatabase) DeleteUserConversations(ctx context.Context, ownerUserID string) (int64, error) {
+	var count int64
+	err := c.tx.Transaction(ctx, func(ctx context.Context) error {
+		conversations, err := c.conversationDB.FindUserIDAllConversations(ctx, ownerUserID)
+		if err != nil {
+			return err
+		}
+		if len(conversations) == 0 {
+			return nil
+		}
+		conversationIDs := datautil.Slice(conversations, func(e *relationtb.Conversation) string { return e.ConversationID })
+		if err := c.conversationDB.DeleteByOwner(ctx, ownerUserID, conversationIDs); err != nil {
+			return err
+		}
+		count = int64(len(conversationIDs))
+		cache := c.cache.CloneConversationCache().
+			DelConversationIDs(ownerUserID).
+			DelUserConversationIDsHash(ownerUserID).
+			DelConversations(ownerUserID, conversationIDs...).
+			DelUserAllHasReadSeqs(ownerUserID, conversationIDs...).
+			DelConversationNotReceiveMessageUserIDs(conversationIDs...).
+			DelConversationVersionUserIDs(ownerUserID).
+			DelConversationNotNotifyMessageUserIDs(ownerUserID).
+			DelConversationPinnedMessageUserIDs(ownerUserID)
+		for _, conversation := range conversations {
+			cache = cache.DelUserRecvMsgOpt(ownerUserID, conversation.ConversationID)
+			if conversation.GroupID != "" {
+				cache = cache.DelSuperGroupRecvMsgNotNotifyUserIDs(conversation.GroupID).DelSuperGroupRecvMsgNotNotifyUserIDsHash(conversation.GroupID)
+			}
+		}
+		return cache.ChainExecDel(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteConversations 删除全部用户的指定会话
+func (c *conversationDatabase) DeleteConversations(ctx context.Context, conversationIDs []string) (int64, error) {
+	if len(conversationIDs) == 0 {
+		return 0, nil
+	}
+	conversations, err := c.conversationDB.GetConversationsByConversationID(ctx, conversationIDs)
+	if err != nil {
+		return 0, err
+	}
+	owners := make(map[string][]*relationtb.Conversation)
+	for _, conversation := range conversations {
+		owners[conversation.OwnerUserID] = append(owners[conversation.OwnerUserID], conversation)
+	}
+	var count int64
+	for ownerUserID, ownerConversations := range owners {
+		ownerConversationIDs := datautil.Slice(ownerConversations, func(e *relationtb.Conversation) string { return e.ConversationID })
+		if err := c.conversationDB.DeleteByOwner(ctx, ownerUserID, ownerConversationIDs); err != nil {
+			return count, err
+		}
+		count += int64(len(ownerConversationIDs))
+		cache := c.cache.CloneConversationCache().
+			DelConversationIDs(ownerUserID).
+			DelUserConversationIDsHash(ownerUserID).
+			DelConversations(ownerUserID, ownerConversationIDs...).
+			DelUserAllHasReadSeqs(ownerUserID, ownerConversationIDs...).
+			DelConversationNotReceiveMessageUserIDs(ownerConversationIDs...).
+			DelConversationVersionUserIDs(ownerUserID).
+			DelConversationNotNotifyMessageUserIDs(ownerUserID).
+			DelConversationPinnedMessageUserIDs(ownerUserID)
+		for _, conversation := range ownerConversations {
+			cache = cache.DelUserRecvMsgOpt(ownerUserID, conversation.ConversationID)
+			if conversation.GroupID != "" {
+				cache = cache.DelSuperGroupRecvMsgNotNotifyUserIDs(conversation.GroupID).DelSuperGroupRecvMsgNotNotifyUserIDsHash(conversation.GroupID)
+			}
+		}
+		if err := cache.ChainExecDel(ctx); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
@@ -46,13 +46,13 @@
 // 使用示例：
 //
 //	// 创建用户控制器
-//	userCtrl := NewUserDatabase(userDB, cache, tx)
+//	userCtrl := NewUserDatabase(userDB, identifierDB, profileFieldDB, cache, tx)
 //
 //	// 查找用户
 //	users, err := userCtrl.Find(ctx, userIDs)
 //
 //	// 创建好友控制器
-//	friendCtrl := NewFriendDatabase(friendDB, friendRequestDB, cache, tx)
+//	friendCtrl := NewFriendDatabase(friendDB, friendRequestDB, friendCategoryDB, cache, tx)
 //
 //	// 检查好友关系
 //	inFriends, _, err := friendCtrl.CheckIn(ctx, userID1, userID2)
@@ -58,7 +58,8 @@ type FriendDatabase interface {
 	// ownerUserID: 好友关系拥有者
 	// friendUserIDs: 要建立关系的好友ID列表
 	// addSource: 添加来源（通过申请、管理员添加等）
-	BecomeFriends(ctx context.Context, ownerUserID string, friendUserIDs []string, addSource int32) (err error)
+	// events: 好友关系事件，与好友关系在同一事务中写入
+	BecomeFriends(ctx context.Context, ownerUserID string, friendUserIDs []string, addSource int32, events []*model.FriendEvent) (err error)
 
 	// RefuseFriendRequest 拒绝好友申请
 	// 将好友申请标记为拒绝状态
@@ -68,13 +69,15 @@ type FriendDatabase interface {
 	// AgreeFriendRequest 同意好友申请
 	// 同意好友申请并建立双向好友关系，处理复杂的状态转换
 	// friendRequest: 好友申请对象
-	AgreeFriendRequest(ctx context.Context, friendRequest *model.FriendRequest) (err error)
+	// events: 好友关系事件，与好友关系在同一事务中写入
+	AgreeFriendRequest(ctx context.Context, friendRequest *model.FriendRequest, events []*model.FriendEvent) (err error)
 
 	// Delete 删除好友关系
 	// 从拥有者的好友列表中移除指定好友
 	// ownerUserID: 好友关系拥有者
 	// friendUserIDs: 要删除的好友ID列表
-	Delete(ctx context.Context, ownerUserID string, friendUserIDs []string) (err error)
+	// events: 好友关系事件，与删除在同一事务中写入，为空时不记录
+	Delete(ctx context.Context, ownerUserID string, friendUserIDs []string, events []*model.FriendEvent) (err error)
 
 	// UpdateRemark 更新好友备注
 	// 修改指定好友的备注名称
@@ -162,21 +165,95 @@ type FriendDatabase interface {
 	// userID: 用户ID
 	// ts: 时间戳
 	GetUnhandledCount(ctx context.Context, userID string, ts int64) (int64, error)
+
+	// TakeFriendRequest 获取单条好友申请，不存在时返回ErrRecordNotFound
+	TakeFriendRequest(ctx context.Context, fromUserID, toUserID string) (*model.FriendRequest, error)
+
+	// CountFriendRequestsSince 统计用户在since之后发起的好友申请数，用于每日申请上限
+	CountFriendRequestsSince(ctx context.Context, fromUserID string, since time.Time) (int64, error)
+
+	// FindExpiredFriendRequests 查找申请时间早于before的未处理好友申请
+	FindExpiredFriendRequests(ctx context.Context, before time.Time, limit int64) ([]*model.FriendRequest, error)
+
+	// ExpireFriendRequest 将过期的未处理好友申请标记为已过期
+	// 申请已被处理或重新发起时返回ErrRecordNotFound
+	ExpireFriendRequest(ctx context.Context, fromUserID, toUserID string, before time.Time) error
+
+	// FindPendingFriendRequestsBetween 查找用户与指定用户之间任一方向的未处理好友申请
+	FindPendingFriendRequestsBetween(ctx context.Context, userID string, peerUserIDs []string) ([]*model.FriendRequest, error)
+
+	// DeleteUserFriendRequests 删除用户发起的和收到的全部好友申请
+	DeleteUserFriendRequests(ctx context.Context, userID string) error
+
+	// CountFriendsOfFriends 统计ownerUserIDs的好友中每个用户出现的次数，用于计算共同好友数
+	// excludeUserIDs中的用户不参与统计，按次数降序返回前limit个
+	CountFriendsOfFriends(ctx context.Context, ownerUserIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error)
+
+	// GetFriendSuggestions 获取用户的好友推荐缓存，未命中时调用fn计算
+	// 用户的好友列表或加入的群组变化时缓存被清除
+	GetFriendSuggestions(ctx context.Context, ownerUserID string, fn func(ctx context.Context) ([]*model.FriendSuggestion, error)) ([]*model.FriendSuggestion, error)
+
+	// ==================== 好友分组 ====================
+	// 分组的创建、修改、排序和删除在拥有者的好友版本日志中记录VersionFriendCategoryChangeID，
+	// 好友归属的变更记录为对应好友的更新，增量同步好友时一并下发
+
+	// CreateFriendCategory 创建好友分组
+	CreateFriendCategory(ctx context.Context, category *model.FriendCategory) error
+
+	// TakeFriendCategory 获取单个好友分组，不存在时返回ErrRecordNotFound
+	TakeFriendCategory(ctx context.Context, ownerUserID string, categoryID string) (*model.FriendCategory, error)
+
+	// FindFriendCategories 获取用户的好友分组，categoryIDs为空时返回全部分组
+	FindFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.FriendCategory, error)
+
+	// CountFriendCategories 统计用户的好友分组数
+	CountFriendCategories(ctx context.Context, ownerUserID string) (int64, error)
+
+	// UpdateFriendCategory 修改好友分组（名称、排序）
+	UpdateFriendCategory(ctx context.Context, ownerUserID string, categoryID string, data map[string]any) error
+
+	// SortFriendCategories 按categoryIDs的顺序重新排列好友分组
+	SortFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) error
+
+	// DeleteFriendCategories 删除好友分组，并将分组从所属好友中移除
+	// 返回受影响的好友ID列表，用于通知多端刷新
+	DeleteFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) ([]string, error)
+
+	// FindCategorizedFriends 获取属于指定分组的好友，categoryIDs为空时返回属于任意分组的好友
+	FindCategorizedFriends(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.Friend, error)
+
+	// AddFriendsToCategory 将好友加入分组，不影响好友所属的其他分组
+	AddFriendsToCategory(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryID string) error
+
+	// RemoveFriendsFromCategories 将好友从指定分组中移除
+	RemoveFriendsFromCategories(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryIDs []string) error
+
+	// FindFriendEvents 按时间倒序分页查询userID与peerUserID之间任一方向的好友关系事件
+	// peerUserID为空时查询userID参与的全部事件，start/end为零值表示不限制
+	FindFriendEvents(ctx context.Context, userID, peerUserID string, start, end time.Time, pagination pagination.Pagination) (int64, []*model.FriendEvent, error)
+
+	// DeleteFriendEventsBefore 删除发生时间早于before的好友关系事件
+	DeleteFriendEventsBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// DeleteUserFriendEvents 删除userID作为任一方或操作者的好友关系事件
+	DeleteUserFriendEvents(ctx context.Context, userID string) (int64, error)
 }
 
 // friendDatabase 好友数据库实现
 // 整合了好友数据库、好友申请数据库、缓存和事务管理
 type friendDatabase struct {
-	friend        database.Friend        // 好友关系数据库接口
-	friendRequest database.FriendRequest // 好友申请数据库接口
-	tx            tx.Tx                  // 事务管理接口
-	cache         cache.FriendCache      // 好友缓存接口
+	friend        database.Friend         // 好友关系数据库接口
+	friendRequest database.FriendRequest  // 好友申请数据库接口
+	category      database.FriendCategory // 好友分组数据库接口
+	event         database.FriendEvent    // 好友关系事件日志接口
+	tx            tx.Tx                   // 事务管理接口
+	cache         cache.FriendCache       // 好友缓存接口
 }
 
 // NewFriendDatabase 创建好友数据库实例
 // 初始化好友管理所需的所有组件
-func NewFriendDatabase(friend database.Friend, friendRequest database.FriendRequest, cache cache.FriendCache, tx tx.Tx) FriendDatabase {
-	return &friendDatabase{friend: friend, friendRequest: friendRequest, cache: cache, tx: tx}
+func NewFriendDatabase(friend database.Friend, friendRequest database.FriendRequest, category database.FriendCategory, event database.FriendEvent, cache cache.FriendCache, tx tx.Tx) FriendDatabase {
+	return &friendDatabase{friend: friend, friendRequest: friendRequest, category: category, event: event, cache: cache, tx: tx}
 }
 
 // CheckIn 检查双向好友关系
@@ -243,8 +320,11 @@ func (f *friendDatabase) AddFriendRequest(ctx context.Context, fromUserID, toUse
 // 批量建立双向好友关系，支持去重和增量添加
 // (1) 首先检查是否已在好友列表中 (在或不在都不返回错误)
 // (2) 对于不在好友列表中的可以插入
-func (f *friendDatabase) BecomeFriends(ctx context.Context, ownerUserID string, friendUserIDs []string, addSource int32) (err error) {
+func (f *friendDatabase) BecomeFriends(ctx context.Context, ownerUserID string, friendUserIDs []string, addSource int32, events []*model.FriendEvent) (err error) {
 	return f.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := f.createFriendEvents(ctx, events); err != nil {
+			return err
+		}
 		cache := f.cache.CloneFriendCache()
 
 		// 查找用户现有的好友关系
@@ -367,7 +447,7 @@ func (f *friendDatabase) RefuseFriendRequest(ctx context.Context, friendRequest
 
 // AgreeFriendRequest 同意好友申请
 // 处理复杂的好友申请同意逻辑，包括双向申请处理和好友关系建立
-func (f *friendDatabase) AgreeFriendRequest(ctx context.Context, friendRequest *model.FriendRequest) (err error) {
+func (f *friendDatabase) AgreeFriendRequest(ctx context.Context, friendRequest *model.FriendRequest, events []*model.FriendEvent) (err error) {
 	return f.tx.Transaction(ctx, func(ctx context.Context) error {
 		now := time.Now()
 
@@ -452,6 +532,9 @@ func (f *friendDatabase) AgreeFriendRequest(ctx context.Context, friendRequest *
 				return err
 			}
 		}
+		if err := f.createFriendEvents(ctx, events); err != nil {
+			return err
+		}
 
 		// 清理缓存并更新版本
 		return f.cache.DelFriendIDs(friendRequest.ToUserID, friendRequest.FromUserID).
@@ -462,14 +545,42 @@ func (f *friendDatabase) AgreeFriendRequest(ctx context.Context, friendRequest *
 
 // Delete 删除好友关系
 // 从好友列表中移除指定好友，外部调用者需要验证好友关系状态
-func (f *friendDatabase) Delete(ctx context.Context, ownerUserID string, friendUserIDs []string) (err error) {
-	if err := f.friend.Delete(ctx, ownerUserID, friendUserIDs); err != nil {
-		return err
+func (f *friendDatabase) Delete(ctx context.Context, ownerUserID string, friendUserIDs []string, events []*model.FriendEvent) (err error) {
+	return f.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := f.friend.Delete(ctx, ownerUserID, friendUserIDs); err != nil {
+			return err
+		}
+		if err := f.createFriendEvents(ctx, events); err != nil {
+			return err
+		}
+
+		// 清理相关用户的缓存
+		userIds := append(friendUserIDs, ownerUserID)
+		return f.cache.DelFriendIDs(userIds...).DelMaxFriendVersion(userIds...).ChainExecDel(ctx)
+	})
+}
+
+// createFriendEvents 追加好友关系事件，在调用方的事务中执行
+func (f *friendDatabase) createFriendEvents(ctx context.Context, events []*model.FriendEvent) error {
+	if len(events) == 0 {
+		return nil
 	}
+	return f.event.Create(ctx, events)
+}
+
+// FindFriendEvents 分页查询好友关系事件
+func (f *friendDatabase) FindFriendEvents(ctx context.Context, userID, peerUserID string, start, end time.Time, pagination pagination.Pagination) (int64, []*model.FriendEvent, error) {
+	return f.event.FindPage(ctx, userID, peerUserID, start, end, pagination)
+}
+
+// DeleteFriendEventsBefore 删除超过保留期的好友关系事件
+func (f *friendDatabase) DeleteFriendEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return f.event.DeleteBefore(ctx, before)
+}
 
-	// 清理相关用户的缓存
-	userIds := append(friendUserIDs, ownerUserID)
-	return f.cache.DelFriendIDs(userIds...).DelMaxFriendVersion(userIds...).ChainExecDel(ctx)
+// DeleteUserFriendEvents 删除与用户相关的好友关系事件
+func (f *friendDatabase) DeleteUserFriendEvents(ctx context.Context, userID string) (int64, error) {
+	return f.event.DeleteByUser(ctx, userID)
 }
 
 // UpdateRemark 更新好友备注
@@ -575,3 +686,113 @@ func (f *friendDatabase) OwnerIncrVersion(ctx context.Context, ownerUserID strin
 func (f *friendDatabase) GetUnhandledCount(ctx context.Context, userID string, ts int64) (int64, error) {
 	return f.friendRequest.GetUnhandledCount(ctx, userID, ts)
 }
+
+func (f *friendDatabase) TakeFriendRequest(ctx context.Context, fromUserID, toUserID string) (*model.FriendRequest, error) {
+	return f.friendRequest.Take(ctx, fromUserID, toUserID)
+}
+
+func (f *friendDatabase) CountFriendRequestsSince(ctx context.Context, fromUserID string, since time.Time) (int64, error) {
+	return f.friendRequest.CountFromUserIDSince(ctx, fromUserID, since)
+}
+
+func (f *friendDatabase) FindExpiredFriendRequests(ctx context.Context, before time.Time, limit int64) ([]*model.FriendRequest, error) {
+	return f.friendRequest.FindExpired(ctx, before, limit)
+}
+
+func (f *friendDatabase) ExpireFriendRequest(ctx context.Context, fromUserID, toUserID string, before time.Time) error {
+	return f.friendRequest.Expire(ctx, fromUserID, toUserID, before, time.Now())
+}
+
+func (f *friendDatabase) FindPendingFriendRequestsBetween(ctx context.Context, userID string, peerUserIDs []string) ([]*model.FriendRequest, error) {
+	return f.friendRequest.FindPendingBetween(ctx, userID, peerUserIDs)
+}
+
+func (f *friendDatabase) DeleteUserFriendRequests(ctx context.Context, userID string) error {
+	return f.friendRequest.DeleteByUserID(ctx, userID)
+}
+
+func (f *friendDatabase) CountFriendsOfFriends(ctx context.Context, ownerUserIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error) {
+	return f.friend.CountFriendsOfFriends(ctx, ownerUserIDs, excludeUserIDs, limit)
+}
+
+func (f *friendDatabase) GetFriendSuggestions(ctx context.Context, ownerUserID string, fn func(ctx context.Context) ([]*model.FriendSuggestion, error)) ([]*model.FriendSuggestion, error) {
+	return f.cache.GetFriendSuggestions(ctx, ownerUserID, fn)
+}
+
+func (f *friendDatabase) CreateFriendCategory(ctx context.Context, category *model.FriendCategory) error {
+	if err := f.category.Create(ctx, category); err != nil {
+		return err
+	}
+	return f.cache.DelMaxFriendVersion(category.OwnerUserID).ChainExecDel(ctx)
+}
+
+func (f *friendDatabase) TakeFriendCategory(ctx context.Context, ownerUserID string, categoryID string) (*model.FriendCategory, error) {
+	return f.category.Take(ctx, ownerUserID, categoryID)
+}
+
+func (f *friendDatabase) FindFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.FriendCategory, error) {
+	return f.category.Find(ctx, ownerUserID, categoryIDs)
+}
+
+func (f *friendDatabase) CountFriendCategories(ctx context.Context, ownerUserID string) (int64, error) {
+	return f.category.Count(ctx, ownerUserID)
+}
+
+func (f *friendDatabase) UpdateFriendCategory(ctx context.Context, ownerUserID string, categoryID string, data map[string]any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := f.category.UpdateMap(ctx, ownerUserID, categoryID, data); err != nil {
+		return err
+	}
+	return f.cache.DelMaxFriendVersion(ownerUserID).ChainExecDel(ctx)
+}
+
+func (f *friendDatabase) SortFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) error {
+	if err := f.category.UpdateOrders(ctx, ownerUserID, categoryIDs); err != nil {
+		return err
+	}
+	return f.cache.DelMaxFriendVersion(ownerUserID).ChainExecDel(ctx)
+}
+
+// DeleteFriendCategories 删除好友分组
+// 在事务中删除分组并从所属好友中移除，好友缓存和版本缓存一并清理
+func (f *friendDatabase) DeleteFriendCategories(ctx context.Context, ownerUserID string, categoryIDs []string) ([]string, error) {
+	var friendUserIDs []string
+	err := f.tx.Transaction(ctx, func(ctx context.Context) error {
+		friends, err := f.friend.FindCategorized(ctx, ownerUserID, categoryIDs)
+		if err != nil {
+			return err
+		}
+		friendUserIDs = datautil.Slice(friends, func(e *model.Friend) string { return e.FriendUserID })
+		if err := f.category.Delete(ctx, ownerUserID, categoryIDs); err != nil {
+			return err
+		}
+		if err := f.friend.RemoveCategories(ctx, ownerUserID, friendUserIDs, categoryIDs); err != nil {
+			return err
+		}
+		return f.cache.DelFriends(ownerUserID, friendUserIDs).DelMaxFriendVersion(ownerUserID).ChainExecDel(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return friendUserIDs, nil
+}
+
+func (f *friendDatabase) FindCategorizedFriends(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.Friend, error) {
+	return f.friend.FindCategorized(ctx, ownerUserID, categoryIDs)
+}
+
+func (f *friendDatabase) AddFriendsToCategory(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryID string) error {
+	if err := f.friend.AddCategory(ctx, ownerUserID, friendUserIDs, categoryID); err != nil {
+		return err
+	}
+	return f.cache.DelFriends(ownerUserID, friendUserIDs).DelMaxFriendVersion(ownerUserID).ChainExecDel(ctx)
+}
+
+func (f *friendDatabase) RemoveFriendsFromCategories(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryIDs []string) error {
+	if err := f.friend.RemoveCategories(ctx, ownerUserID, friendUserIDs, categoryIDs); err != nil {
+		return err
+	}
+	return f.cache.DelFriends(ownerUserID, friendUserIDs).DelMaxFriendVersion(ownerUserID).ChainExecDel(ctx)
+}
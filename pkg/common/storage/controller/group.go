@@ -120,6 +120,45 @@ type GroupDatabase interface {
 	// 特性：事务保证、缓存失效、版本更新
 	UpdateGroup(ctx context.Context, groupID string, data map[string]any) error
 
+	// FindMuteScheduledGroups 分页查找需要检查定时禁言状态的群组
+	// 功能：直接查询数据库，供定时任务按群组ID游标遍历
+	FindMuteScheduledGroups(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error)
+
+	// FindJoinRequestExpireGroups 分页查找设置了入群申请有效期的群组
+	// 功能：直接查询数据库，供定时任务按群组ID游标遍历
+	FindJoinRequestExpireGroups(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error)
+
+	// ==================== 群组批量导入 ====================
+
+	// FindGroupsByExternalIDs 按外部系统ID查找导入的群组
+	FindGroupsByExternalIDs(ctx context.Context, externalIDs []string) ([]*model.Group, error)
+
+	// ImportGroup 导入单个群组及其成员
+	// 功能：groupUpdate为nil时创建群组，否则按groupUpdate更新已有群组；新成员批量插入，已有成员批量整体替换
+	// 特性：事务保证、缓存失效、版本更新，不发送任何通知
+	ImportGroup(ctx context.Context, group *model.Group, groupUpdate map[string]any, insertMembers []*model.GroupMember, replaceMembers []*model.GroupMember) error
+
+	// ==================== 群组频道管理 ====================
+
+	// CreateGroupChannel 创建群组频道
+	// 特性：记录频道列表版本，同时使群组信息缓存失效，使依赖群组信息缓存键的本地频道缓存一同失效
+	CreateGroupChannel(ctx context.Context, channel *model.GroupChannel) error
+
+	// UpdateGroupChannel 更新群组频道
+	UpdateGroupChannel(ctx context.Context, groupID string, channelID string, data map[string]any) error
+
+	// DeleteGroupChannels 删除群组频道，频道会话中的历史消息保留
+	DeleteGroupChannels(ctx context.Context, groupID string, channelIDs []string) error
+
+	// TakeGroupChannel 获取单个群组频道
+	TakeGroupChannel(ctx context.Context, groupID string, channelID string) (*model.GroupChannel, error)
+
+	// FindGroupChannels 获取群组频道，channelIDs为空时返回全部频道
+	FindGroupChannels(ctx context.Context, groupID string, channelIDs []string) ([]*model.GroupChannel, error)
+
+	// FindGroupChannelIncrVersion 查询群组频道列表的增量版本
+	FindGroupChannelIncrVersion(ctx context.Context, groupID string, version uint, limit int) (*model.VersionLog, error)
+
 	// DismissGroup 解散群组
 	// 功能：解散群组并可选择删除所有成员
 	// 参数：deleteMember 是否删除群成员记录
@@ -168,6 +207,10 @@ type GroupDatabase interface {
 	// 优化：优先从缓存获取，提高查询效率
 	FindGroupMemberNum(ctx context.Context, groupID string) (uint32, error)
 
+	// FindGroupRoleMembers 获取拥有指定自定义角色的成员
+	// 功能：直接查询数据库，用于角色管理等低频场景
+	FindGroupRoleMembers(ctx context.Context, groupID string, roleIDs []string) ([]*model.GroupMember, error)
+
 	// FindUserManagedGroupID 获取用户管理的群组ID列表
 	// 功能：查询用户作为管理员或群主的群组
 	FindUserManagedGroupID(ctx context.Context, userID string) (groupIDs []string, err error)
@@ -181,7 +224,8 @@ type GroupDatabase interface {
 	// PageGetJoinGroup 分页获取用户加入的群组
 	// 功能：分页查询用户参与的所有群组
 	// 优化：先获取群组ID列表，再分页查询详细信息
-	PageGetJoinGroup(ctx context.Context, userID string, pagination pagination.Pagination) (total int64, totalGroupMembers []*model.GroupMember, err error)
+	// 注意：includeArchived为false时先排除已归档的群组再分页
+	PageGetJoinGroup(ctx context.Context, userID string, includeArchived bool, pagination pagination.Pagination) (total int64, totalGroupMembers []*model.GroupMember, err error)
 
 	// PageGetGroupMember 分页获取群组成员
 	// 功能：分页查询群组的成员列表
@@ -192,6 +236,15 @@ type GroupDatabase interface {
 	// 功能：在群组内搜索成员，支持关键词匹配
 	SearchGroupMember(ctx context.Context, keyword string, groupID string, pagination pagination.Pagination) (int64, []*model.GroupMember, error)
 
+	// FilterGroupMembers 按条件过滤群组成员
+	// 功能：支持按入群时间、禁言状态、邀请者、入群方式、Ex等字段过滤和排序，使用游标翻页
+	// 注意：直接查询数据库，不走缓存
+	FilterGroupMembers(ctx context.Context, search *model.GroupMemberSearch) (int64, []*model.GroupMember, error)
+
+	// CountSharedGroups 统计指定群组中每个成员所在的群组数，用于计算共同群组数
+	// 注意：直接聚合数据库，不走缓存
+	CountSharedGroups(ctx context.Context, groupIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error)
+
 	// ==================== 群组申请管理 ====================
 
 	// PageGroupRequest 分页获取群组申请
@@ -200,9 +253,18 @@ type GroupDatabase interface {
 	PageGroupRequest(ctx context.Context, groupIDs []string, handleResults []int, pagination pagination.Pagination) (int64, []*model.GroupRequest, error)
 
 	// HandlerGroupRequest 处理群组申请
-	// 功能：处理入群申请（同意/拒绝），如果同意则创建群成员
-	// 特性：事务保证、条件性成员创建、缓存更新
-	HandlerGroupRequest(ctx context.Context, groupID string, userID string, handledMsg string, handleResult int32, member *model.GroupMember) error
+	// 功能：处理入群申请（同意/拒绝/过期），记录最终审批，如果同意则创建群成员
+	// 特性：事务保证、条件性成员创建、缓存更新；申请已被处理时返回ErrRecordNotFound
+	HandlerGroupRequest(ctx context.Context, groupID string, userID string, handleResult int32, decision *model.GroupRequestDecision, member *model.GroupMember) error
+
+	// AddGroupRequestDecision 追加审批记录
+	// 功能：多人审批模式下记录一位审批人的同意，返回更新后的申请
+	// 特性：申请已处理或该审批人已审批过时返回ErrRecordNotFound
+	AddGroupRequestDecision(ctx context.Context, groupID string, userID string, decision *model.GroupRequestDecision) (*model.GroupRequest, error)
+
+	// FindExpiredGroupRequests 查找过期的入群申请
+	// 功能：查询群组中申请时间早于before的未处理申请
+	FindExpiredGroupRequests(ctx context.Context, groupID string, before time.Time, limit int64) ([]*model.GroupRequest, error)
 
 	// CreateGroupRequest 创建群组申请
 	// 功能：创建新的入群申请记录
@@ -217,6 +279,9 @@ type GroupDatabase interface {
 	// 功能：批量查询多个用户的入群申请
 	FindGroupRequests(ctx context.Context, groupID string, userIDs []string) ([]*model.GroupRequest, error)
 
+	// EraseUserGroupRequests 删除注销用户提交的入群申请，并匿名化其作为处理人、邀请人和审批人的记录
+	EraseUserGroupRequests(ctx context.Context, userID string) (deleted int64, anonymized int64, err error)
+
 	// PageGroupRequestUser 分页获取用户的群组申请
 	// 功能：分页查询用户发起的入群申请
 	PageGroupRequestUser(ctx context.Context, userID string, groupIDs []string, handleResults []int, pagination pagination.Pagination) (int64, []*model.GroupRequest, error)
@@ -237,6 +302,12 @@ type GroupDatabase interface {
 	// 特性：原子性角色变更、缓存更新
 	TransferGroupOwner(ctx context.Context, groupID string, oldOwnerUserID, newOwnerUserID string, roleLevel int32) error
 
+	// SucceedGroupOwner 群主继任
+	// 功能：在同一事务中选出入群最早的管理员作为新群主，没有管理员且promoteMember为true时选入群最早的普通成员，
+	// 并将原群主移出群组；没有可继任的成员时解散群组（保留其余成员）
+	// 返回：新群主成员信息，群组被解散时返回nil
+	SucceedGroupOwner(ctx context.Context, groupID string, oldOwnerUserID string, promoteMember bool) (*model.GroupMember, error)
+
 	// UpdateGroupMember 更新群成员信息
 	// 功能：更新单个群成员的属性
 	// 特性：条件性缓存失效（角色变更时清理更多缓存）
@@ -349,6 +420,7 @@ func NewGroupDatabase(
 	groupDB database.Group,
 	groupMemberDB database.GroupMember,
 	groupRequestDB database.GroupRequest,
+	groupChannelDB database.GroupChannel,
 	ctxTx tx.Tx,
 	groupHash cache.GroupHash,
 ) GroupDatabase {
@@ -356,6 +428,7 @@ func NewGroupDatabase(
 		groupDB:        groupDB,
 		groupMemberDB:  groupMemberDB,
 		groupRequestDB: groupRequestDB,
+		groupChannelDB: groupChannelDB,
 		ctxTx:          ctxTx,
 		cache:          redis2.NewGroupCacheRedis(rdb, localCache, groupDB, groupMemberDB, groupRequestDB, groupHash, redis2.GetRocksCacheOptions()),
 	}
@@ -373,12 +446,14 @@ func NewGroupDatabase(
 // - groupDB: 群组基础数据操作
 // - groupMemberDB: 群成员数据操作
 // - groupRequestDB: 群申请数据操作
+// - groupChannelDB: 群组频道数据操作
 // - ctxTx: 分布式事务管理
 // - cache: 统一缓存操作接口
 type groupDatabase struct {
 	groupDB        database.Group        // 群组数据库操作接口
 	groupMemberDB  database.GroupMember  // 群成员数据库操作接口
 	groupRequestDB database.GroupRequest // 群申请数据库操作接口
+	groupChannelDB database.GroupChannel // 群组频道数据库操作接口
 	ctxTx          tx.Tx                 // 分布式事务管理器
 	cache          cache.GroupCache      // 群组缓存操作接口
 }
@@ -627,6 +702,10 @@ func (g *groupDatabase) FindGroupMemberNum(ctx context.Context, groupID string)
 	return uint32(num), nil
 }
 
+func (g *groupDatabase) FindGroupRoleMembers(ctx context.Context, groupID string, roleIDs []string) ([]*model.GroupMember, error) {
+	return g.groupMemberDB.FindByRoleIDs(ctx, groupID, roleIDs)
+}
+
 func (g *groupDatabase) TakeGroup(ctx context.Context, groupID string) (*model.Group, error) {
 	return g.cache.GetGroupInfo(ctx, groupID)
 }
@@ -651,43 +730,139 @@ func (g *groupDatabase) UpdateGroup(ctx context.Context, groupID string, data ma
 	})
 }
 
-func (g *groupDatabase) DismissGroup(ctx context.Context, groupID string, deleteMember bool) error {
+func (g *groupDatabase) FindMuteScheduledGroups(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error) {
+	return g.groupDB.FindMuteScheduled(ctx, afterGroupID, limit)
+}
+
+func (g *groupDatabase) FindJoinRequestExpireGroups(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error) {
+	return g.groupDB.FindJoinRequestExpire(ctx, afterGroupID, limit)
+}
+
+func (g *groupDatabase) FindGroupsByExternalIDs(ctx context.Context, externalIDs []string) ([]*model.Group, error) {
+	return g.groupDB.FindByExternalIDs(ctx, externalIDs)
+}
+
+func (g *groupDatabase) ImportGroup(ctx context.Context, group *model.Group, groupUpdate map[string]any, insertMembers []*model.GroupMember, replaceMembers []*model.GroupMember) error {
 	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
-		c := g.cache.CloneGroupCache()
-		if err := g.groupDB.UpdateStatus(ctx, groupID, constant.GroupStatusDismissed); err != nil {
-			return err
-		}
-		if deleteMember {
-			userIDs, err := g.cache.GetGroupMemberIDs(ctx, groupID)
-			if err != nil {
+		if groupUpdate == nil {
+			if err := g.groupDB.Create(ctx, []*model.Group{group}); err != nil {
 				return err
 			}
-			if err := g.groupMemberDB.Delete(ctx, groupID, nil); err != nil {
+		} else {
+			if err := g.groupDB.UpdateMap(ctx, group.GroupID, groupUpdate); err != nil {
 				return err
 			}
-			c = c.DelJoinedGroupID(userIDs...).
-				DelGroupMemberIDs(groupID).
-				DelGroupsMemberNum(groupID).
-				DelGroupMembersHash(groupID).
-				DelGroupAllRoleLevel(groupID).
-				DelGroupMembersInfo(groupID, userIDs...).
-				DelMaxGroupMemberVersion(groupID).
-				DelMaxJoinGroupVersion(userIDs...)
-			for _, userID := range userIDs {
-				if err := g.groupMemberDB.JoinGroupIncrVersion(ctx, userID, []string{groupID}, model.VersionStateDelete); err != nil {
-					return err
-				}
+			if err := g.groupMemberDB.MemberGroupIncrVersion(ctx, group.GroupID, []string{""}, model.VersionStateUpdate); err != nil {
+				return err
 			}
-		} else {
-			if err := g.groupMemberDB.MemberGroupIncrVersion(ctx, groupID, []string{""}, model.VersionStateUpdate); err != nil {
+		}
+		if len(insertMembers) > 0 {
+			if err := g.groupMemberDB.Create(ctx, insertMembers); err != nil {
 				return err
 			}
-			c = c.DelMaxGroupMemberVersion(groupID)
 		}
-		return c.DelGroupsInfo(groupID).ChainExecDel(ctx)
+		if err := g.groupMemberDB.ReplaceMany(ctx, group.GroupID, replaceMembers); err != nil {
+			return err
+		}
+		c := g.cache.CloneGroupCache().DelGroupsInfo(group.GroupID).
+			DelGroupMembersHash(group.GroupID).
+			DelGroupsMemberNum(group.GroupID).
+			DelGroupMemberIDs(group.GroupID).
+			DelGroupAllRoleLevel(group.GroupID).
+			DelMaxGroupMemberVersion(group.GroupID)
+		for _, member := range insertMembers {
+			c = c.DelJoinedGroupID(member.UserID).
+				DelGroupMembersInfo(group.GroupID, member.UserID).
+				DelMaxJoinGroupVersion(member.UserID)
+		}
+		for _, member := range replaceMembers {
+			c = c.DelGroupMembersInfo(group.GroupID, member.UserID)
+		}
+		return c.ChainExecDel(ctx)
+	})
+}
+
+func (g *groupDatabase) CreateGroupChannel(ctx context.Context, channel *model.GroupChannel) error {
+	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
+		if err := g.groupChannelDB.Create(ctx, channel); err != nil {
+			return err
+		}
+		return g.cache.CloneGroupCache().DelGroupsInfo(channel.GroupID).ChainExecDel(ctx)
+	})
+}
+
+func (g *groupDatabase) UpdateGroupChannel(ctx context.Context, groupID string, channelID string, data map[string]any) error {
+	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
+		if err := g.groupChannelDB.UpdateMap(ctx, groupID, channelID, data); err != nil {
+			return err
+		}
+		return g.cache.CloneGroupCache().DelGroupsInfo(groupID).ChainExecDel(ctx)
 	})
 }
 
+func (g *groupDatabase) DeleteGroupChannels(ctx context.Context, groupID string, channelIDs []string) error {
+	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
+		if err := g.groupChannelDB.Delete(ctx, groupID, channelIDs); err != nil {
+			return err
+		}
+		return g.cache.CloneGroupCache().DelGroupsInfo(groupID).ChainExecDel(ctx)
+	})
+}
+
+func (g *groupDatabase) TakeGroupChannel(ctx context.Context, groupID string, channelID string) (*model.GroupChannel, error) {
+	return g.groupChannelDB.Take(ctx, groupID, channelID)
+}
+
+func (g *groupDatabase) FindGroupChannels(ctx context.Context, groupID string, channelIDs []string) ([]*model.GroupChannel, error) {
+	return g.groupChannelDB.Find(ctx, groupID, channelIDs)
+}
+
+func (g *groupDatabase) FindGroupChannelIncrVersion(ctx context.Context, groupID string, version uint, limit int) (*model.VersionLog, error) {
+	return g.groupChannelDB.FindIncrVersion(ctx, groupID, version, limit)
+}
+
+func (g *groupDatabase) DismissGroup(ctx context.Context, groupID string, deleteMember bool) error {
+	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
+		return g.dismissGroup(ctx, groupID, deleteMember)
+	})
+}
+
+// dismissGroup 解散群组，由调用方开启事务
+func (g *groupDatabase) dismissGroup(ctx context.Context, groupID string, deleteMember bool) error {
+	c := g.cache.CloneGroupCache()
+	if err := g.groupDB.UpdateStatus(ctx, groupID, constant.GroupStatusDismissed); err != nil {
+		return err
+	}
+	if deleteMember {
+		userIDs, err := g.cache.GetGroupMemberIDs(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if err := g.groupMemberDB.Delete(ctx, groupID, nil); err != nil {
+			return err
+		}
+		c = c.DelJoinedGroupID(userIDs...).
+			DelGroupMemberIDs(groupID).
+			DelGroupsMemberNum(groupID).
+			DelGroupMembersHash(groupID).
+			DelGroupAllRoleLevel(groupID).
+			DelGroupMembersInfo(groupID, userIDs...).
+			DelMaxGroupMemberVersion(groupID).
+			DelMaxJoinGroupVersion(userIDs...)
+		for _, userID := range userIDs {
+			if err := g.groupMemberDB.JoinGroupIncrVersion(ctx, userID, []string{groupID}, model.VersionStateDelete); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := g.groupMemberDB.MemberGroupIncrVersion(ctx, groupID, []string{""}, model.VersionStateUpdate); err != nil {
+			return err
+		}
+		c = c.DelMaxGroupMemberVersion(groupID)
+	}
+	return c.DelGroupsInfo(groupID).ChainExecDel(ctx)
+}
+
 func (g *groupDatabase) TakeGroupMember(ctx context.Context, groupID string, userID string) (*model.GroupMember, error) {
 	return g.cache.GetGroupMemberInfo(ctx, groupID, userID)
 }
@@ -704,11 +879,29 @@ func (g *groupDatabase) PageGroupRequest(ctx context.Context, groupIDs []string,
 	return g.groupRequestDB.PageGroup(ctx, groupIDs, handleResults, pagination)
 }
 
-func (g *groupDatabase) PageGetJoinGroup(ctx context.Context, userID string, pagination pagination.Pagination) (total int64, totalGroupMembers []*model.GroupMember, err error) {
+func (g *groupDatabase) PageGetJoinGroup(ctx context.Context, userID string, includeArchived bool, pagination pagination.Pagination) (total int64, totalGroupMembers []*model.GroupMember, err error) {
 	groupIDs, err := g.cache.GetJoinedGroupIDs(ctx, userID)
 	if err != nil {
 		return 0, nil, err
 	}
+	if !includeArchived && len(groupIDs) > 0 {
+		groups, err := g.cache.GetGroupsInfo(ctx, groupIDs)
+		if err != nil {
+			return 0, nil, err
+		}
+		archived := make(map[string]struct{})
+		for _, group := range groups {
+			if group.Archived {
+				archived[group.GroupID] = struct{}{}
+			}
+		}
+		if len(archived) > 0 {
+			groupIDs = datautil.Filter(groupIDs, func(groupID string) (string, bool) {
+				_, ok := archived[groupID]
+				return groupID, !ok
+			})
+		}
+	}
 	for _, groupID := range datautil.Paginate(groupIDs, int(pagination.GetPageNumber()), int(pagination.GetShowNumber())) {
 		groupMembers, err := g.cache.GetGroupMembersInfo(ctx, groupID, []string{userID})
 		if err != nil {
@@ -735,13 +928,21 @@ func (g *groupDatabase) PageGetGroupMember(ctx context.Context, groupID string,
 	return int64(len(groupMemberIDs)), members, nil
 }
 
+func (g *groupDatabase) FilterGroupMembers(ctx context.Context, search *model.GroupMemberSearch) (int64, []*model.GroupMember, error) {
+	return g.groupMemberDB.FilterMembers(ctx, search)
+}
+
+func (g *groupDatabase) CountSharedGroups(ctx context.Context, groupIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error) {
+	return g.groupMemberDB.CountSharedGroups(ctx, groupIDs, excludeUserIDs, limit)
+}
+
 func (g *groupDatabase) SearchGroupMember(ctx context.Context, keyword string, groupID string, pagination pagination.Pagination) (int64, []*model.GroupMember, error) {
 	return g.groupMemberDB.SearchMember(ctx, keyword, groupID, pagination)
 }
 
-func (g *groupDatabase) HandlerGroupRequest(ctx context.Context, groupID string, userID string, handledMsg string, handleResult int32, member *model.GroupMember) error {
+func (g *groupDatabase) HandlerGroupRequest(ctx context.Context, groupID string, userID string, handleResult int32, decision *model.GroupRequestDecision, member *model.GroupMember) error {
 	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
-		if err := g.groupRequestDB.UpdateHandler(ctx, groupID, userID, handledMsg, handleResult); err != nil {
+		if err := g.groupRequestDB.Handle(ctx, groupID, userID, handleResult, decision); err != nil {
 			return err
 		}
 		if member != nil {
@@ -821,6 +1022,60 @@ func (g *groupDatabase) TransferGroupOwner(ctx context.Context, groupID string,
 	})
 }
 
+func (g *groupDatabase) SucceedGroupOwner(ctx context.Context, groupID string, oldOwnerUserID string, promoteMember bool) (newOwner *model.GroupMember, err error) {
+	roleLevels := []int32{constant.GroupAdmin}
+	if promoteMember {
+		roleLevels = append(roleLevels, constant.GroupOrdinaryUsers)
+	}
+	err = g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
+		newOwner = nil
+		for _, roleLevel := range roleLevels {
+			member, err := g.groupMemberDB.TakeEarliestJoined(ctx, groupID, roleLevel, oldOwnerUserID)
+			if err != nil {
+				return err
+			}
+			if member != nil {
+				newOwner = member
+				break
+			}
+		}
+		c := g.cache.CloneGroupCache()
+		if oldOwnerUserID != "" {
+			if err := g.groupMemberDB.Delete(ctx, groupID, []string{oldOwnerUserID}); err != nil {
+				return err
+			}
+			c = c.DelGroupMembersHash(groupID).
+				DelGroupMemberIDs(groupID).
+				DelGroupsMemberNum(groupID).
+				DelJoinedGroupID(oldOwnerUserID).
+				DelGroupMembersInfo(groupID, oldOwnerUserID).
+				DelGroupAllRoleLevel(groupID).
+				DelMaxGroupMemberVersion(groupID).
+				DelMaxJoinGroupVersion(oldOwnerUserID)
+		}
+		if newOwner == nil {
+			if err := c.ChainExecDel(ctx); err != nil {
+				return err
+			}
+			return g.dismissGroup(ctx, groupID, false)
+		}
+		if err := g.groupMemberDB.UpdateRoleLevel(ctx, groupID, newOwner.UserID, constant.GroupOwner); err != nil {
+			return err
+		}
+		newOwner.RoleLevel = constant.GroupOwner
+		return c.DelGroupMembersInfo(groupID, newOwner.UserID).
+			DelGroupAllRoleLevel(groupID).
+			DelGroupMembersHash(groupID).
+			DelMaxGroupMemberVersion(groupID).
+			DelGroupMemberIDs(groupID).
+			ChainExecDel(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newOwner, nil
+}
+
 func (g *groupDatabase) UpdateGroupMember(ctx context.Context, groupID string, userID string, data map[string]any) error {
 	if len(data) == 0 {
 		return nil
@@ -855,9 +1110,16 @@ func (g *groupDatabase) UpdateGroupMembers(ctx context.Context, data []*common.B
 	})
 }
 
+// CreateGroupRequest 创建入群申请，同一用户重新申请时上一次申请及其审批记录归档到新申请的历史中
 func (g *groupDatabase) CreateGroupRequest(ctx context.Context, requests []*model.GroupRequest) error {
 	return g.ctxTx.Transaction(ctx, func(ctx context.Context) error {
 		for _, request := range requests {
+			old, err := g.groupRequestDB.Take(ctx, request.GroupID, request.UserID)
+			if err == nil {
+				old.ArchiveTo(request)
+			} else if !errs.ErrRecordNotFound.Is(err) {
+				return err
+			}
 			if err := g.groupRequestDB.Delete(ctx, request.GroupID, request.UserID); err != nil {
 				return err
 			}
@@ -866,6 +1128,26 @@ func (g *groupDatabase) CreateGroupRequest(ctx context.Context, requests []*mode
 	})
 }
 
+func (g *groupDatabase) AddGroupRequestDecision(ctx context.Context, groupID string, userID string, decision *model.GroupRequestDecision) (*model.GroupRequest, error) {
+	return g.groupRequestDB.AddDecision(ctx, groupID, userID, decision)
+}
+
+func (g *groupDatabase) FindExpiredGroupRequests(ctx context.Context, groupID string, before time.Time, limit int64) ([]*model.GroupRequest, error) {
+	return g.groupRequestDB.FindExpired(ctx, groupID, before, limit)
+}
+
+func (g *groupDatabase) EraseUserGroupRequests(ctx context.Context, userID string) (deleted int64, anonymized int64, err error) {
+	deleted, err = g.groupRequestDB.DeleteByUser(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	anonymized, err = g.groupRequestDB.AnonymizeUser(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return deleted, anonymized, nil
+}
+
 func (g *groupDatabase) TakeGroupRequest(ctx context.Context, groupID string, userID string) (*model.GroupRequest, error) {
 	return g.groupRequestDB.Take(ctx, groupID, userID)
 }
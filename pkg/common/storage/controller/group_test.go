@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+type succeedOwnerTx struct{}
+
+func (succeedOwnerTx) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type succeedOwnerGroupDB struct {
+	database.Group
+	status map[string]int32
+}
+
+func (s *succeedOwnerGroupDB) UpdateStatus(ctx context.Context, groupID string, status int32) error {
+	s.status[groupID] = status
+	return nil
+}
+
+type succeedOwnerMemberDB struct {
+	database.GroupMember
+	candidates map[int32]*model.GroupMember
+	deleted    []string
+}
+
+func (s *succeedOwnerMemberDB) TakeEarliestJoined(ctx context.Context, groupID string, roleLevel int32, excludeUserID string) (*model.GroupMember, error) {
+	return s.candidates[roleLevel], nil
+}
+
+func (s *succeedOwnerMemberDB) Delete(ctx context.Context, groupID string, userIDs []string) error {
+	s.deleted = append(s.deleted, userIDs...)
+	return nil
+}
+
+func (s *succeedOwnerMemberDB) UpdateRoleLevel(ctx context.Context, groupID string, userID string, roleLevel int32) error {
+	return nil
+}
+
+func (s *succeedOwnerMemberDB) MemberGroupIncrVersion(ctx context.Context, groupID string, userIDs []string, state int32) error {
+	return nil
+}
+
+// succeedOwnerCache 记录链式删除的缓存项，ChainExecDel时提交
+type succeedOwnerCache struct {
+	cache.GroupCache
+	pending []string
+	deleted []string
+}
+
+func (s *succeedOwnerCache) add(key string) cache.GroupCache {
+	s.pending = append(s.pending, key)
+	return s
+}
+
+func (s *succeedOwnerCache) CloneGroupCache() cache.GroupCache { return s }
+
+func (s *succeedOwnerCache) DelGroupsInfo(groupIDs ...string) cache.GroupCache {
+	return s.add("groupInfo")
+}
+
+func (s *succeedOwnerCache) DelGroupMembersHash(groupID string) cache.GroupCache {
+	return s.add("membersHash")
+}
+
+func (s *succeedOwnerCache) DelGroupMemberIDs(groupID string) cache.GroupCache {
+	return s.add("memberIDs")
+}
+
+func (s *succeedOwnerCache) DelJoinedGroupID(userID ...string) cache.GroupCache {
+	return s.add("joinedGroupID")
+}
+
+func (s *succeedOwnerCache) DelGroupAllRoleLevel(groupID string) cache.GroupCache {
+	return s.add("allRoleLevel")
+}
+
+func (s *succeedOwnerCache) DelGroupMembersInfo(groupID string, userID ...string) cache.GroupCache {
+	return s.add("membersInfo")
+}
+
+func (s *succeedOwnerCache) DelGroupsMemberNum(groupID ...string) cache.GroupCache {
+	return s.add("memberNum")
+}
+
+func (s *succeedOwnerCache) DelMaxGroupMemberVersion(groupIDs ...string) cache.GroupCache {
+	return s.add("maxGroupMemberVersion")
+}
+
+func (s *succeedOwnerCache) DelMaxJoinGroupVersion(userIDs ...string) cache.GroupCache {
+	return s.add("maxJoinGroupVersion")
+}
+
+func (s *succeedOwnerCache) ChainExecDel(ctx context.Context) error {
+	s.deleted = append(s.deleted, s.pending...)
+	s.pending = nil
+	return nil
+}
+
+func newSucceedOwnerDatabase(candidates map[int32]*model.GroupMember) (*groupDatabase, *succeedOwnerGroupDB, *succeedOwnerMemberDB, *succeedOwnerCache) {
+	groupDB := &succeedOwnerGroupDB{status: make(map[string]int32)}
+	memberDB := &succeedOwnerMemberDB{candidates: candidates}
+	groupCache := &succeedOwnerCache{}
+	return &groupDatabase{groupDB: groupDB, groupMemberDB: memberDB, ctxTx: succeedOwnerTx{}, cache: groupCache}, groupDB, memberDB, groupCache
+}
+
+func TestSucceedGroupOwnerDismiss(t *testing.T) {
+	db, groupDB, memberDB, groupCache := newSucceedOwnerDatabase(nil)
+	newOwner, err := db.SucceedGroupOwner(context.Background(), "g1", "owner", true)
+	assert.NoError(t, err)
+	assert.Nil(t, newOwner)
+	assert.Equal(t, int32(constant.GroupStatusDismissed), groupDB.status["g1"])
+	assert.Equal(t, []string{"owner"}, memberDB.deleted)
+	// 与DeleteGroupMember清理相同的成员缓存，避免解散后残留成员列表
+	assert.Subset(t, groupCache.deleted, []string{"membersHash", "memberIDs", "memberNum", "joinedGroupID", "membersInfo", "allRoleLevel", "maxGroupMemberVersion", "maxJoinGroupVersion", "groupInfo"})
+}
+
+func TestSucceedGroupOwnerPromote(t *testing.T) {
+	admin := &model.GroupMember{GroupID: "g1", UserID: "admin", RoleLevel: constant.GroupAdmin}
+	member := &model.GroupMember{GroupID: "g1", UserID: "member", RoleLevel: constant.GroupOrdinaryUsers}
+
+	db, groupDB, memberDB, groupCache := newSucceedOwnerDatabase(map[int32]*model.GroupMember{constant.GroupOrdinaryUsers: member})
+	newOwner, err := db.SucceedGroupOwner(context.Background(), "g1", "owner", false)
+	assert.NoError(t, err)
+	assert.Nil(t, newOwner)
+	assert.Equal(t, int32(constant.GroupStatusDismissed), groupDB.status["g1"])
+
+	db, groupDB, memberDB, groupCache = newSucceedOwnerDatabase(map[int32]*model.GroupMember{constant.GroupAdmin: admin, constant.GroupOrdinaryUsers: member})
+	newOwner, err = db.SucceedGroupOwner(context.Background(), "g1", "owner", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", newOwner.UserID)
+	assert.Equal(t, int32(constant.GroupOwner), newOwner.RoleLevel)
+	assert.Empty(t, groupDB.status)
+	assert.Equal(t, []string{"owner"}, memberDB.deleted)
+	assert.Subset(t, groupCache.deleted, []string{"membersHash", "memberIDs", "memberNum", "joinedGroupID", "membersInfo", "allRoleLevel", "maxGroupMemberVersion", "maxJoinGroupVersion"})
+}
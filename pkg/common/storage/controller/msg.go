@@ -38,6 +38,7 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/convert"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
 	"github.com/openimsdk/protocol/constant"
 	pbmsg "github.com/openimsdk/protocol/msg"
 	"github.com/openimsdk/protocol/sdkws"
@@ -108,6 +109,18 @@ type CommonMsgDatabase interface {
 
 	SetSendMsgStatus(ctx context.Context, id string, status int32) error
 	GetSendMsgStatus(ctx context.Context, id string) (int32, error)
+
+	// ClaimSendMsgDedup 发送幂等占位，(sendID, clientMsgID)已存在时返回首次发送的记录
+	ClaimSendMsgDedup(ctx context.Context, sendID string, clientMsgID string, record *cache.SendMsgDedup, expire time.Duration) (exist *cache.SendMsgDedup, claimed bool, err error)
+	// GetSendMsgDedup 获取发送幂等记录，不存在时返回nil
+	GetSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) (*cache.SendMsgDedup, error)
+	// SetSendMsgDedupSent 标记首次发送已成功投递到MQ
+	SetSendMsgDedupSent(ctx context.Context, sendID string, clientMsgID string) error
+	// DelSendMsgDedup 首次发送失败时释放占位，允许客户端重试
+	DelSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) error
+	// ClaimGroupSlowMode 群组慢速模式发言占位，返回还需等待的时长，0表示允许发言
+	ClaimGroupSlowMode(ctx context.Context, groupID string, userID string, interval time.Duration) (time.Duration, error)
+
 	SearchMessage(ctx context.Context, req *pbmsg.SearchMessageReq) (total int64, msgData []*pbmsg.SearchedMsgData, err error)
 	FindOneByDocIDs(ctx context.Context, docIDs []string, seqs map[string]int64) (map[string]*sdkws.MsgData, error)
 
@@ -120,6 +133,8 @@ type CommonMsgDatabase interface {
 	GetRandBeforeMsg(ctx context.Context, ts int64, limit int) ([]*model.MsgDocModel, error)
 
 	SetUserConversationsMaxSeq(ctx context.Context, conversationID string, userID string, seq int64) error
+	// DeleteUserSeqs 删除用户在所有会话中的seq记录，返回涉及的会话数
+	DeleteUserSeqs(ctx context.Context, userID string) (int64, error)
 	SetUserConversationsMinSeq(ctx context.Context, conversationID string, userID string, seq int64) error
 
 	DeleteDoc(ctx context.Context, docID string) error
@@ -138,26 +153,40 @@ func NewCommonMsgDatabase(msgDocModel database.Msg, msg cache.MsgCache, seqUser
 	if err != nil {
 		return nil, err
 	}
+	var producerToRedisPriority *kafka.Producer
+	if kafkaConf.EnableRedisPriorityLane() {
+		producerToRedisPriority, err = kafka.NewKafkaProducer(conf, kafkaConf.Address, kafkaConf.ToRedisPriorityTopic)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &commonMsgDatabase{
-		msgDocDatabase:  msgDocModel,
-		msgCache:        msg,
-		seqUser:         seqUser,
-		seqConversation: seqConversation,
-		producer:        producerToRedis,
+		msgDocDatabase:   msgDocModel,
+		msgCache:         msg,
+		seqUser:          seqUser,
+		seqConversation:  seqConversation,
+		producer:         producerToRedis,
+		producerPriority: producerToRedisPriority,
 	}, nil
 }
 
 type commonMsgDatabase struct {
-	msgDocDatabase  database.Msg
-	msgTable        model.MsgDocModel
-	msgCache        cache.MsgCache
-	seqConversation cache.SeqConversationCache
-	seqUser         cache.SeqUser
-	producer        *kafka.Producer
+	msgDocDatabase   database.Msg
+	msgTable         model.MsgDocModel
+	msgCache         cache.MsgCache
+	seqConversation  cache.SeqConversationCache
+	seqUser          cache.SeqUser
+	producer         *kafka.Producer
+	producerPriority *kafka.Producer // 高优先级消息生产者，未配置高优先级通道时为nil
 }
 
+// MsgToMQ 发送消息到ToRedis主题，通知会话的消息（见msgprocessor.SetMsgPriority）发送到独立的优先级主题
 func (db *commonMsgDatabase) MsgToMQ(ctx context.Context, key string, msg2mq *sdkws.MsgData) error {
-	_, _, err := db.producer.SendMessage(ctx, key, msg2mq)
+	producer := db.producer
+	if db.producerPriority != nil && msgprocessor.IsHighPriorityMsg(msg2mq) {
+		producer = db.producerPriority
+	}
+	_, _, err := producer.SendMessage(ctx, key, msg2mq)
 	return err
 }
 
@@ -608,6 +637,10 @@ func (db *commonMsgDatabase) SetUserConversationsMaxSeq(ctx context.Context, con
 	return db.seqUser.SetUserMaxSeq(ctx, conversationID, userID, seq)
 }
 
+func (db *commonMsgDatabase) DeleteUserSeqs(ctx context.Context, userID string) (int64, error) {
+	return db.seqUser.DeleteUserSeqs(ctx, userID)
+}
+
 func (db *commonMsgDatabase) SetUserConversationsMinSeq(ctx context.Context, conversationID string, userID string, seq int64) error {
 	return db.seqUser.SetUserMinSeq(ctx, conversationID, userID, seq)
 }
@@ -636,6 +669,26 @@ func (db *commonMsgDatabase) GetSendMsgStatus(ctx context.Context, id string) (i
 	return db.msgCache.GetSendMsgStatus(ctx, id)
 }
 
+func (db *commonMsgDatabase) ClaimSendMsgDedup(ctx context.Context, sendID string, clientMsgID string, record *cache.SendMsgDedup, expire time.Duration) (*cache.SendMsgDedup, bool, error) {
+	return db.msgCache.ClaimSendMsgDedup(ctx, sendID, clientMsgID, record, expire)
+}
+
+func (db *commonMsgDatabase) GetSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) (*cache.SendMsgDedup, error) {
+	return db.msgCache.GetSendMsgDedup(ctx, sendID, clientMsgID)
+}
+
+func (db *commonMsgDatabase) SetSendMsgDedupSent(ctx context.Context, sendID string, clientMsgID string) error {
+	return db.msgCache.SetSendMsgDedupStatus(ctx, sendID, clientMsgID, cache.SendMsgDedupSent)
+}
+
+func (db *commonMsgDatabase) DelSendMsgDedup(ctx context.Context, sendID string, clientMsgID string) error {
+	return db.msgCache.DelSendMsgDedup(ctx, sendID, clientMsgID)
+}
+
+func (db *commonMsgDatabase) ClaimGroupSlowMode(ctx context.Context, groupID string, userID string, interval time.Duration) (time.Duration, error) {
+	return db.msgCache.ClaimGroupSlowMode(ctx, groupID, userID, interval)
+}
+
 func (db *commonMsgDatabase) GetConversationMinMaxSeqInMongoAndCache(ctx context.Context, conversationID string) (minSeqMongo, maxSeqMongo, minSeqCache, maxSeqCache int64, err error) {
 	minSeqMongo, maxSeqMongo, err = db.GetMinMaxSeqMongo(ctx, conversationID)
 	if err != nil {
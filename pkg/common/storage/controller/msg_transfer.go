@@ -12,6 +12,7 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/kafka"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/open-im-server/v3/pkg/msgprocessor"
 	"github.com/openimsdk/protocol/constant"
 	pbmsg "github.com/openimsdk/protocol/msg"
 	"github.com/openimsdk/protocol/sdkws"
@@ -112,26 +113,37 @@ func NewMsgTransferDatabase(msgDocModel database.Msg, msg cache.MsgCache, seqUse
 		return nil, err
 	}
 
+	// 创建高优先级推送队列生产者（未配置高优先级通道时与普通消息共用推送队列）
+	var producerToPushPriority *kafka.Producer
+	if kafkaConf.EnablePushPriorityLane() {
+		producerToPushPriority, err = kafka.NewKafkaProducer(conf, kafkaConf.Address, kafkaConf.ToPushPriorityTopic)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &msgTransferDatabase{
-		msgDocDatabase:  msgDocModel,     // MongoDB消息存储
-		msgCache:        msg,             // Redis消息缓存
-		seqUser:         seqUser,         // 用户序列号管理
-		seqConversation: seqConversation, // 会话序列号管理
-		producerToMongo: producerToMongo, // MongoDB队列生产者
-		producerToPush:  producerToPush,  // 推送队列生产者
+		msgDocDatabase:         msgDocModel,            // MongoDB消息存储
+		msgCache:               msg,                    // Redis消息缓存
+		seqUser:                seqUser,                // 用户序列号管理
+		seqConversation:        seqConversation,        // 会话序列号管理
+		producerToMongo:        producerToMongo,        // MongoDB队列生产者
+		producerToPush:         producerToPush,         // 推送队列生产者
+		producerToPushPriority: producerToPushPriority, // 高优先级推送队列生产者
 	}, nil
 }
 
 // msgTransferDatabase 消息传输数据库实现
 // 整合了消息存储、缓存、序列号管理和消息队列的完整实现
 type msgTransferDatabase struct {
-	msgDocDatabase  database.Msg               // MongoDB消息文档数据库接口
-	msgTable        model.MsgDocModel          // 消息文档模型，提供分片和索引策略
-	msgCache        cache.MsgCache             // Redis消息缓存接口
-	seqConversation cache.SeqConversationCache // 会话序列号缓存，管理消息序列号分配
-	seqUser         cache.SeqUser              // 用户序列号缓存，管理用户已读状态
-	producerToMongo *kafka.Producer            // MongoDB持久化队列生产者
-	producerToPush  *kafka.Producer            // 消息推送队列生产者
+	msgDocDatabase         database.Msg               // MongoDB消息文档数据库接口
+	msgTable               model.MsgDocModel          // 消息文档模型，提供分片和索引策略
+	msgCache               cache.MsgCache             // Redis消息缓存接口
+	seqConversation        cache.SeqConversationCache // 会话序列号缓存，管理消息序列号分配
+	seqUser                cache.SeqUser              // 用户序列号缓存，管理用户已读状态
+	producerToMongo        *kafka.Producer            // MongoDB持久化队列生产者
+	producerToPush         *kafka.Producer            // 消息推送队列生产者
+	producerToPushPriority *kafka.Producer            // 高优先级推送队列生产者，未配置时为nil
 }
 
 // BatchInsertChat2DB 批量插入聊天消息到数据库
@@ -402,6 +414,19 @@ func (db *msgTransferDatabase) BatchInsertChat2Cache(ctx context.Context, conver
 		return 0, false, nil, err
 	}
 
+	// 回写发送幂等记录的序列号，便于客户端重发时返回首次分配的seq
+	var dedupMsgs []*model.MsgDataModel
+	for _, m := range msgs {
+		if msgprocessor.Options(m.Options).IsSendMsgDedup() {
+			dedupMsgs = append(dedupMsgs, &model.MsgDataModel{SendID: m.SendID, ClientMsgID: m.ClientMsgID, Seq: m.Seq})
+		}
+	}
+	if len(dedupMsgs) > 0 {
+		if err := db.msgCache.SetSendMsgDedupSeqs(ctx, dedupMsgs); err != nil {
+			log.ZWarn(ctx, "SetSendMsgDedupSeqs failed", err, "conversationID", conversationID)
+		}
+	}
+
 	return lastMaxSeq, isNew, userSeqMap, nil
 }
 
@@ -444,8 +469,13 @@ func (db *msgTransferDatabase) SetHasReadSeqToDB(ctx context.Context, conversati
 // msg2mq: 待推送的消息数据
 // 返回: Kafka分区号、偏移量、错误信息
 func (db *msgTransferDatabase) MsgToPushMQ(ctx context.Context, key, conversationID string, msg2mq *sdkws.MsgData) (int32, int64, error) {
+	// 通知会话的消息发送到独立的推送队列，避免被大批量聊天消息阻塞；按会话选择通道，同一会话的消息保持顺序
+	producer := db.producerToPush
+	if db.producerToPushPriority != nil && msgprocessor.IsHighPriorityConversation(conversationID) {
+		producer = db.producerToPushPriority
+	}
 	// 发送消息到推送队列，包装消息数据和会话ID
-	partition, offset, err := db.producerToPush.SendMessage(ctx, key, &pbmsg.PushMsgDataToMQ{
+	partition, offset, err := producer.SendMessage(ctx, key, &pbmsg.PushMsgDataToMQ{
 		MsgData:        msg2mq,
 		ConversationID: conversationID,
 	})
@@ -177,6 +177,10 @@ type S3Database interface {
 	//   - count: 返回数量限制
 	FindExpirationObject(ctx context.Context, engine string, expiration time.Time, needDelType []string, count int64) ([]*model.Object, error)
 
+	// FindUserObject 查找用户上传的对象
+	// 应用：用户注销时清理其上传的文件
+	FindUserObject(ctx context.Context, engine string, userID string, count int64) ([]*model.Object, error)
+
 	// DeleteSpecifiedData 删除指定数据
 	// 功能：批量删除指定的对象数据
 	// 范围：仅删除数据库记录，不删除存储文件
@@ -396,6 +400,11 @@ func (s *s3Database) FindExpirationObject(ctx context.Context, engine string, ex
 	return s.db.FindExpirationObject(ctx, engine, expiration, needDelType, count)
 }
 
+// FindUserObject 查找用户对象实现
+func (s *s3Database) FindUserObject(ctx context.Context, engine string, userID string, count int64) ([]*model.Object, error) {
+	return s.db.FindByUserID(ctx, engine, userID, count)
+}
+
 // GetKeyCount 获取对象统计实现
 // 统计指定条件下的对象数量
 func (s *s3Database) GetKeyCount(ctx context.Context, engine string, key string) (int64, error) {
@@ -73,6 +73,12 @@ type UserDatabase interface {
 	// 返回: 错误信息
 	UpdateByMap(ctx context.Context, userID string, args map[string]any) (err error)
 
+	// DeleteUser 删除用户
+	// 删除用户资料、自定义命令和通讯录标识，用户不存在时不返回错误
+	// userID: 用户ID
+	// 返回: 错误信息
+	DeleteUser(ctx context.Context, userID string) (err error)
+
 	// PageFindUser 分页查找用户
 	// 根据用户级别范围分页查询用户列表
 	// level1: 最小级别
@@ -102,6 +108,44 @@ type UserDatabase interface {
 	// 返回: 是否存在、错误信息
 	IsExist(ctx context.Context, userIDs []string) (exist bool, err error)
 
+	// PageFindBannedUsers 分页查询封禁中的用户
+	// 按封禁时间倒序，已到期的封禁不包含在结果中
+	// keyword: 匹配用户ID或昵称的关键词，为空时不过滤
+	// now: 判断封禁是否到期的时间
+	// pagination: 分页参数
+	// 返回: 总数、用户列表、错误信息
+	PageFindBannedUsers(ctx context.Context, keyword string, now time.Time, pagination pagination.Pagination) (count int64, users []*model.User, err error)
+
+	// PageFindUsersByProfile 分页查询自定义资料字段取值全部相等的用户
+	// 按注册时间倒序，调用方需保证profile中的字段都是可搜索字段
+	// keyword: 匹配用户ID或昵称的关键词，为空时不过滤
+	// profile: 字段名到取值的映射
+	// pagination: 分页参数
+	// 返回: 总数、用户列表、错误信息
+	PageFindUsersByProfile(ctx context.Context, keyword string, profile map[string]any, pagination pagination.Pagination) (count int64, users []*model.User, err error)
+
+	// GetProfileFields 获取全部自定义资料字段定义，优先从缓存获取
+	GetProfileFields(ctx context.Context) ([]*model.UserProfileField, error)
+
+	// SetProfileField 新增或覆盖自定义资料字段定义
+	// 字段可搜索时先建立索引再保存定义，不可搜索时保存定义后删除索引
+	SetProfileField(ctx context.Context, field *model.UserProfileField) error
+
+	// DeleteProfileFields 删除自定义资料字段定义
+	// 同时清除所有用户的类型化取值和字段索引，用户Ex中的原始内容保持不变
+	DeleteProfileFields(ctx context.Context, names []string) error
+
+	// FindUsersExAfter 按用户ID升序分页获取Ex不为空的用户，用于回填自定义资料字段
+	// 只返回用户ID、Ex和profile，afterUserID为上一批最后一个用户ID
+	FindUsersExAfter(ctx context.Context, afterUserID string, limit int64) ([]*model.User, error)
+
+	// SetUserProfileValue 回填单个用户的自定义资料字段取值，value为nil时清除
+	// 用户Ex在此期间被修改时不做修改，更新Ex时已按最新定义重新提取
+	SetUserProfileValue(ctx context.Context, userID string, ex string, name string, value any) error
+
+	// SetProfileFieldBackfilled 标记字段已完成回填，字段在回填期间被重新定义时不做修改
+	SetProfileFieldBackfilled(ctx context.Context, name string, updateTime time.Time) error
+
 	// GetAllUserID 获取所有用户ID
 	// 分页获取系统中所有用户的ID列表
 	// pagination: 分页参数
@@ -146,20 +190,31 @@ type UserDatabase interface {
 	UpdateUserCommand(ctx context.Context, userID string, Type int32, UUID string, val map[string]any) error
 	GetUserCommands(ctx context.Context, userID string, Type int32) ([]*user.CommandInfoResp, error)
 	GetAllUserCommands(ctx context.Context, userID string) ([]*user.AllCommandInfoResp, error)
+
+	// SetUserIdentifiers 覆盖用户在标识索引中的全部标识，identifiers为空时删除
+	SetUserIdentifiers(ctx context.Context, userID string, identifiers []*model.UserIdentifier) error
+
+	// FindUserIdentifiers 查找哈希匹配的用户标识
+	FindUserIdentifiers(ctx context.Context, hashes []string) ([]*model.UserIdentifier, error)
+
+	// IncrContactMatchCount 累加用户在统计窗口内提交匹配的标识数，返回累加后的总数和窗口剩余时间
+	IncrContactMatchCount(ctx context.Context, userID string, count int64, window time.Duration) (total int64, ttl time.Duration, err error)
 }
 
 // userDatabase 用户数据库实现
 // 整合了用户数据库操作、缓存管理和事务控制
 type userDatabase struct {
-	tx     tx.Tx           // 事务管理接口，确保数据一致性
-	userDB database.User   // 用户数据库接口，提供持久化存储
-	cache  cache.UserCache // 用户缓存接口，提供高性能查询
+	tx         tx.Tx                     // 事务管理接口，确保数据一致性
+	userDB     database.User             // 用户数据库接口，提供持久化存储
+	identifier database.UserIdentifier   // 用户标识索引，用于通讯录匹配
+	fieldDB    database.UserProfileField // 自定义资料字段定义
+	cache      cache.UserCache           // 用户缓存接口，提供高性能查询
 }
 
 // NewUserDatabase 创建用户数据库实例
 // 初始化用户管理所需的所有组件：数据库、缓存、事务管理
-func NewUserDatabase(userDB database.User, cache cache.UserCache, tx tx.Tx) UserDatabase {
-	return &userDatabase{userDB: userDB, cache: cache, tx: tx}
+func NewUserDatabase(userDB database.User, identifier database.UserIdentifier, fieldDB database.UserProfileField, cache cache.UserCache, tx tx.Tx) UserDatabase {
+	return &userDatabase{userDB: userDB, identifier: identifier, fieldDB: fieldDB, cache: cache, tx: tx}
 }
 
 // InitOnce 初始化用户（幂等操作）
@@ -258,6 +313,94 @@ func (u *userDatabase) UpdateByMap(ctx context.Context, userID string, args map[
 	})
 }
 
+// PageFindBannedUsers 分页查询封禁中的用户，直接从数据库查询
+func (u *userDatabase) PageFindBannedUsers(ctx context.Context, keyword string, now time.Time, pagination pagination.Pagination) (count int64, users []*model.User, err error) {
+	return u.userDB.PageFindBanned(ctx, keyword, now, pagination)
+}
+
+// PageFindUsersByProfile 按自定义资料字段分页查询用户，直接从数据库查询
+func (u *userDatabase) PageFindUsersByProfile(ctx context.Context, keyword string, profile map[string]any, pagination pagination.Pagination) (count int64, users []*model.User, err error) {
+	return u.userDB.PageFindByProfile(ctx, keyword, profile, pagination)
+}
+
+// GetProfileFields 获取自定义资料字段定义
+func (u *userDatabase) GetProfileFields(ctx context.Context) ([]*model.UserProfileField, error) {
+	return u.cache.GetProfileFields(ctx)
+}
+
+// SetProfileField 保存自定义资料字段定义并维护字段索引
+// 建立和删除索引不能放在事务中，两者都是幂等操作，失败后重试即可
+func (u *userDatabase) SetProfileField(ctx context.Context, field *model.UserProfileField) error {
+	if field.Searchable {
+		if err := u.userDB.CreateProfileIndex(ctx, field.Name); err != nil {
+			return err
+		}
+	}
+	if err := u.fieldDB.Set(ctx, field); err != nil {
+		return err
+	}
+	if !field.Searchable {
+		if err := u.userDB.DropProfileIndex(ctx, field.Name); err != nil {
+			return err
+		}
+	}
+	return u.cache.DelProfileFields().ChainExecDel(ctx)
+}
+
+// DeleteProfileFields 删除自定义资料字段定义，并清除取值和索引
+func (u *userDatabase) DeleteProfileFields(ctx context.Context, names []string) error {
+	if err := u.fieldDB.Delete(ctx, names); err != nil {
+		return err
+	}
+	if err := u.cache.DelProfileFields().ChainExecDel(ctx); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := u.userDB.UnsetProfile(ctx, name); err != nil {
+			return err
+		}
+		if err := u.userDB.DropProfileIndex(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindUsersExAfter 分页获取Ex不为空的用户，直接从数据库查询
+func (u *userDatabase) FindUsersExAfter(ctx context.Context, afterUserID string, limit int64) ([]*model.User, error) {
+	return u.userDB.FindExAfter(ctx, afterUserID, limit)
+}
+
+// SetUserProfileValue 回填用户的自定义资料字段取值并清理用户信息缓存
+func (u *userDatabase) SetUserProfileValue(ctx context.Context, userID string, ex string, name string, value any) error {
+	if err := u.userDB.SetProfileValue(ctx, userID, ex, name, value); err != nil {
+		return err
+	}
+	return u.cache.DelUsersInfo(userID).ChainExecDel(ctx)
+}
+
+// SetProfileFieldBackfilled 标记字段已完成回填并清理字段定义缓存
+func (u *userDatabase) SetProfileFieldBackfilled(ctx context.Context, name string, updateTime time.Time) error {
+	if err := u.fieldDB.SetBackfilled(ctx, name, updateTime); err != nil {
+		return err
+	}
+	return u.cache.DelProfileFields().ChainExecDel(ctx)
+}
+
+// DeleteUser 删除用户
+// 在事务中删除用户资料和通讯录标识，再清理用户信息和全局免打扰缓存
+func (u *userDatabase) DeleteUser(ctx context.Context, userID string) (err error) {
+	return u.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.userDB.Delete(ctx, userID); err != nil {
+			return err
+		}
+		if err := u.identifier.Replace(ctx, userID, nil); err != nil {
+			return err
+		}
+		return u.cache.DelUsersInfo(userID).DelUsersGlobalRecvMsgOpt(userID).ChainExecDel(ctx)
+	})
+}
+
 // Page 分页获取用户
 // 不带过滤条件的分页查询，未找到记录不返回错误
 func (u *userDatabase) Page(ctx context.Context, pagination pagination.Pagination) (count int64, users []*model.User, err error) {
@@ -350,3 +493,21 @@ func (u *userDatabase) GetAllUserCommands(ctx context.Context, userID string) ([
 	commands, err := u.userDB.GetAllUserCommand(ctx, userID)
 	return commands, err
 }
+
+// SetUserIdentifiers 覆盖用户在标识索引中的全部标识
+// 先删除后写入，在事务中执行避免并发更新时留下重复标识
+func (u *userDatabase) SetUserIdentifiers(ctx context.Context, userID string, identifiers []*model.UserIdentifier) error {
+	return u.tx.Transaction(ctx, func(ctx context.Context) error {
+		return u.identifier.Replace(ctx, userID, identifiers)
+	})
+}
+
+// FindUserIdentifiers 查找哈希匹配的用户标识，直接从数据库查询
+func (u *userDatabase) FindUserIdentifiers(ctx context.Context, hashes []string) ([]*model.UserIdentifier, error) {
+	return u.identifier.FindByHashes(ctx, hashes)
+}
+
+// IncrContactMatchCount 累加用户在统计窗口内提交匹配的标识数
+func (u *userDatabase) IncrContactMatchCount(ctx context.Context, userID string, count int64, window time.Duration) (int64, time.Duration, error) {
+	return u.cache.IncrContactMatchCount(ctx, userID, count, window)
+}
@@ -0,0 +1,36 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/pagination"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Application interface {
+	Create(ctx context.Context, app *model.Application) error
+	Update(ctx context.Context, id primitive.ObjectID, data map[string]any) error
+	Delete(ctx context.Context, ids []primitive.ObjectID) error
+	Take(ctx context.Context, id primitive.ObjectID) (*model.Application, error)
+	// FindByPlatform 获取平台的全部版本
+	FindByPlatform(ctx context.Context, platform string) ([]*model.Application, error)
+	// ClearLatest 取消平台上除excludeID以外版本的最新标记
+	ClearLatest(ctx context.Context, platform string, excludeID primitive.ObjectID) error
+	// Search 按版本号或更新说明搜索，platforms为空时不限制平台
+	Search(ctx context.Context, keyword string, platforms []string, pagination pagination.Pagination) (int64, []*model.Application, error)
+}
@@ -28,4 +28,8 @@ type Black interface {
 	FindOwnerBlacks(ctx context.Context, ownerUserID string, pagination pagination.Pagination) (total int64, blacks []*model.Black, err error)
 	FindOwnerBlackInfos(ctx context.Context, ownerUserID string, userIDs []string) (blacks []*model.Black, err error)
 	FindBlackUserIDs(ctx context.Context, ownerUserID string) (blackUserIDs []string, err error)
+	// UpdateScope 修改黑名单的生效范围，记录不存在时返回NotFound
+	UpdateScope(ctx context.Context, ownerUserID, blockUserID string, scope int32) (err error)
+	// FindByBlockUserID 查找拉黑了blockUserID的全部记录
+	FindByBlockUserID(ctx context.Context, blockUserID string) (blacks []*model.Black, err error)
 }
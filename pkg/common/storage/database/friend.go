@@ -57,4 +57,14 @@ type Friend interface {
 	FindOwnerFriendUserIds(ctx context.Context, ownerUserID string, limit int) ([]string, error)
 
 	IncrVersion(ctx context.Context, ownerUserID string, friendUserIDs []string, state int32) error
+
+	// FindCategorized retrieves the owner's friends that belong to any of the categories, or to any category when categoryIDs is empty.
+	FindCategorized(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.Friend, error)
+	// AddCategory adds the category to the specified friends and records a friend version update.
+	AddCategory(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryID string) error
+	// RemoveCategories removes the categories from the specified friends and records a friend version update.
+	RemoveCategories(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryIDs []string) error
+	// CountFriendsOfFriends counts, for each user in the friend lists of ownerUserIDs, how many of those lists contain them.
+	// Users in excludeUserIDs are skipped. Results are sorted by count descending and truncated to limit.
+	CountFriendsOfFriends(ctx context.Context, ownerUserIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error)
 }
@@ -0,0 +1,34 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+)
+
+// FriendCategory 好友分组，写操作同时在拥有者的好友版本日志中记录分组变更
+type FriendCategory interface {
+	Create(ctx context.Context, category *model.FriendCategory) error
+	Take(ctx context.Context, ownerUserID string, categoryID string) (*model.FriendCategory, error)
+	// Find 获取用户的好友分组，categoryIDs为空时返回全部分组，按Order、创建时间升序
+	Find(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.FriendCategory, error)
+	Count(ctx context.Context, ownerUserID string) (int64, error)
+	UpdateMap(ctx context.Context, ownerUserID string, categoryID string, args map[string]any) error
+	// UpdateOrders 按categoryIDs的顺序重新设置分组排序
+	UpdateOrders(ctx context.Context, ownerUserID string, categoryIDs []string) error
+	Delete(ctx context.Context, ownerUserID string, categoryIDs []string) error
+}
@@ -0,0 +1,36 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/pagination"
+)
+
+// FriendEvent 好友关系事件日志
+type FriendEvent interface {
+	// Create 追加事件
+	Create(ctx context.Context, events []*model.FriendEvent) error
+	// FindPage 按时间倒序分页查询userID与peerUserID之间任一方向的事件
+	// peerUserID为空时查询userID参与的全部事件，start/end为零值表示不限制
+	FindPage(ctx context.Context, userID, peerUserID string, start, end time.Time, pagination pagination.Pagination) (int64, []*model.FriendEvent, error)
+	// DeleteBefore 删除发生时间早于before的事件
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	// DeleteByUser 删除userID作为任一方或操作者的事件
+	DeleteByUser(ctx context.Context, userID string) (int64, error)
+}
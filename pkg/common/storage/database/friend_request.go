@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/tools/db/pagination"
@@ -39,4 +40,14 @@ type FriendRequest interface {
 	FindFromUserID(ctx context.Context, fromUserID string, handleResults []int, pagination pagination.Pagination) (total int64, friendRequests []*model.FriendRequest, err error)
 	FindBothFriendRequests(ctx context.Context, fromUserID, toUserID string) (friends []*model.FriendRequest, err error)
 	GetUnhandledCount(ctx context.Context, userID string, ts int64) (int64, error)
+	// CountFromUserIDSince 统计fromUserID在since之后发起（或重新发起）的申请数
+	CountFromUserIDSince(ctx context.Context, fromUserID string, since time.Time) (int64, error)
+	// FindExpired 查找申请时间早于before的未处理申请，按申请时间升序返回
+	FindExpired(ctx context.Context, before time.Time, limit int64) ([]*model.FriendRequest, error)
+	// Expire 将申请时间早于before的未处理申请标记为已过期，申请已被处理或重新发起时返回ErrRecordNotFound
+	Expire(ctx context.Context, fromUserID, toUserID string, before time.Time, handleTime time.Time) error
+	// FindPendingBetween 查找userID与peerUserIDs之间任一方向的未处理申请
+	FindPendingBetween(ctx context.Context, userID string, peerUserIDs []string) ([]*model.FriendRequest, error)
+	// DeleteByUserID 删除用户发起的和收到的全部申请
+	DeleteByUserID(ctx context.Context, userID string) error
 }
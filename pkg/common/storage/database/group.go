@@ -36,4 +36,11 @@ type Group interface {
 	FindJoinSortGroupID(ctx context.Context, groupIDs []string) ([]string, error)
 
 	SearchJoin(ctx context.Context, groupIDs []string, keyword string, pagination pagination.Pagination) (int64, []*model.Group, error)
+
+	// FindMuteScheduled 按群组ID升序查找设置了定时禁言计划或仍处于定时禁言中的未解散群组，afterGroupID为翻页游标
+	FindMuteScheduled(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error)
+	// FindByExternalIDs 按外部系统ID查找导入的群组
+	FindByExternalIDs(ctx context.Context, externalIDs []string) ([]*model.Group, error)
+	// FindJoinRequestExpire 按群组ID升序查找设置了入群申请有效期的未解散群组，afterGroupID为翻页游标
+	FindJoinRequestExpire(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error)
 }
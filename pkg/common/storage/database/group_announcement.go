@@ -0,0 +1,39 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/pagination"
+)
+
+type GroupAnnouncement interface {
+	Create(ctx context.Context, announcement *model.GroupAnnouncement) error
+	Take(ctx context.Context, groupID string, announcementID string) (*model.GroupAnnouncement, error)
+	// TakeLatest 获取群组最新发布的公告，没有公告时返回ErrRecordNotFound
+	TakeLatest(ctx context.Context, groupID string) (*model.GroupAnnouncement, error)
+	// FindPage 分页获取群组公告，置顶公告在前，其余按发布时间倒序
+	FindPage(ctx context.Context, groupID string, pagination pagination.Pagination) (int64, []*model.GroupAnnouncement, error)
+	SetPinned(ctx context.Context, groupID string, announcementID string, pinned bool) error
+	// Delete 删除公告及其确认记录
+	Delete(ctx context.Context, groupID string, announcementIDs []string) error
+	// Ack 记录成员确认，重复确认保留第一次的确认时间
+	Ack(ctx context.Context, ack *model.GroupAnnouncementAck) error
+	FindAcks(ctx context.Context, announcementID string) ([]*model.GroupAnnouncementAck, error)
+	// FindUserAckedIDs 返回announcementIDs中用户已确认的公告ID
+	FindUserAckedIDs(ctx context.Context, userID string, announcementIDs []string) ([]string, error)
+}
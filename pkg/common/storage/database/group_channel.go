@@ -0,0 +1,35 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+)
+
+type GroupChannel interface {
+	// Create 创建频道并记录频道列表版本
+	Create(ctx context.Context, channel *model.GroupChannel) error
+	Take(ctx context.Context, groupID string, channelID string) (*model.GroupChannel, error)
+	// Find 获取群组频道，channelIDs为空时返回全部频道，按Order、创建时间升序
+	Find(ctx context.Context, groupID string, channelIDs []string) ([]*model.GroupChannel, error)
+	// UpdateMap 更新频道并记录频道列表版本
+	UpdateMap(ctx context.Context, groupID string, channelID string, args map[string]any) error
+	// Delete 删除频道并记录频道列表版本
+	Delete(ctx context.Context, groupID string, channelIDs []string) error
+	// FindIncrVersion 查询群组频道列表的增量版本
+	FindIncrVersion(ctx context.Context, groupID string, version uint, limit int) (*model.VersionLog, error)
+}
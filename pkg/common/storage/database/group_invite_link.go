@@ -0,0 +1,31 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+)
+
+type GroupInviteLink interface {
+	Create(ctx context.Context, link *model.GroupInviteLink) error
+	Take(ctx context.Context, token string) (*model.GroupInviteLink, error)
+	FindByGroup(ctx context.Context, groupID string) ([]*model.GroupInviteLink, error)
+	Revoke(ctx context.Context, groupID string, tokens []string) error
+	// IncrUsedCount 未撤销且未达到使用上限时使用次数加一，返回是否成功
+	IncrUsedCount(ctx context.Context, token string) (bool, error)
+	DecrUsedCount(ctx context.Context, token string) error
+}
@@ -23,6 +23,8 @@ import (
 
 type GroupMember interface {
 	Create(ctx context.Context, groupMembers []*model.GroupMember) (err error)
+	// ReplaceMany 批量整体替换同一群组中已存在的成员记录
+	ReplaceMany(ctx context.Context, groupID string, groupMembers []*model.GroupMember) (err error)
 	Delete(ctx context.Context, groupID string, userIDs []string) (err error)
 	Update(ctx context.Context, groupID string, userID string, data map[string]any) (err error)
 	UpdateRoleLevel(ctx context.Context, groupID string, userID string, roleLevel int32) error
@@ -33,8 +35,15 @@ type GroupMember interface {
 	FindInGroup(ctx context.Context, userID string, groupIDs []string) ([]*model.GroupMember, error)
 	TakeOwner(ctx context.Context, groupID string) (groupMember *model.GroupMember, err error)
 	SearchMember(ctx context.Context, keyword string, groupID string, pagination pagination.Pagination) (total int64, groupList []*model.GroupMember, err error)
+	// FilterMembers 按条件过滤并排序群成员，使用游标翻页，返回符合条件的成员总数和当前页成员
+	FilterMembers(ctx context.Context, search *model.GroupMemberSearch) (total int64, groupMembers []*model.GroupMember, err error)
 	FindRoleLevelUserIDs(ctx context.Context, groupID string, roleLevel int32) ([]string, error)
+	FindByRoleIDs(ctx context.Context, groupID string, roleIDs []string) ([]*model.GroupMember, error)
+	// TakeEarliestJoined 获取指定角色中入群最早的成员，排除excludeUserID，没有符合条件的成员时返回nil
+	TakeEarliestJoined(ctx context.Context, groupID string, roleLevel int32, excludeUserID string) (*model.GroupMember, error)
 	FindUserJoinedGroupID(ctx context.Context, userID string) (groupIDs []string, err error)
+	// CountSharedGroups 统计groupIDs中每个成员所在的群组数，排除excludeUserIDs，按群组数降序返回前limit个
+	CountSharedGroups(ctx context.Context, groupIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error)
 	TakeGroupMemberNum(ctx context.Context, groupID string) (count int64, err error)
 	FindUserManagedGroupID(ctx context.Context, userID string) (groupIDs []string, err error)
 	IsUpdateRoleLevel(data map[string]any) bool
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
 	"github.com/openimsdk/tools/db/pagination"
@@ -30,4 +31,14 @@ type GroupRequest interface {
 	Page(ctx context.Context, userID string, groupIDs []string, handleResults []int, pagination pagination.Pagination) (total int64, groups []*model.GroupRequest, err error)
 	PageGroup(ctx context.Context, groupIDs []string, handleResults []int, pagination pagination.Pagination) (total int64, groups []*model.GroupRequest, err error)
 	GetUnhandledCount(ctx context.Context, groupIDs []string, ts int64) (int64, error)
+	// AddDecision 向未处理且该审批人尚未审批过的申请追加审批记录，返回更新后的申请，不满足条件时返回ErrRecordNotFound
+	AddDecision(ctx context.Context, groupID string, userID string, decision *model.GroupRequestDecision) (*model.GroupRequest, error)
+	// Handle 将未处理的申请置为最终结果并记录审批，申请已被处理时返回ErrRecordNotFound
+	Handle(ctx context.Context, groupID string, userID string, handleResult int32, decision *model.GroupRequestDecision) error
+	// FindExpired 查找群组中申请时间早于before的未处理申请
+	FindExpired(ctx context.Context, groupID string, before time.Time, limit int64) ([]*model.GroupRequest, error)
+	// DeleteByUser 删除userID提交的全部申请
+	DeleteByUser(ctx context.Context, userID string) (int64, error)
+	// AnonymizeUser 清空其他用户的申请中userID作为处理人、邀请人和审批人的记录，返回修改的申请数
+	AnonymizeUser(ctx context.Context, userID string) (int64, error)
 }
@@ -0,0 +1,103 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewApplicationMongo(db *mongo.Database) (database.Application, error) {
+	coll := db.Collection(database.ApplicationName)
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "platform", Value: 1},
+				{Key: "version", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "create_time", Value: -1},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &ApplicationMgo{coll: coll}, nil
+}
+
+type ApplicationMgo struct {
+	coll *mongo.Collection
+}
+
+func (a *ApplicationMgo) Create(ctx context.Context, app *model.Application) error {
+	return mongoutil.InsertOne(ctx, a.coll, app)
+}
+
+func (a *ApplicationMgo) Update(ctx context.Context, id primitive.ObjectID, data map[string]any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return mongoutil.UpdateOne(ctx, a.coll, bson.M{"_id": id}, bson.M{"$set": data}, true)
+}
+
+func (a *ApplicationMgo) Delete(ctx context.Context, ids []primitive.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return mongoutil.DeleteMany(ctx, a.coll, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+func (a *ApplicationMgo) Take(ctx context.Context, id primitive.ObjectID) (*model.Application, error) {
+	return mongoutil.FindOne[*model.Application](ctx, a.coll, bson.M{"_id": id})
+}
+
+func (a *ApplicationMgo) FindByPlatform(ctx context.Context, platform string) ([]*model.Application, error) {
+	return mongoutil.Find[*model.Application](ctx, a.coll, bson.M{"platform": platform})
+}
+
+func (a *ApplicationMgo) ClearLatest(ctx context.Context, platform string, excludeID primitive.ObjectID) error {
+	filter := bson.M{"platform": platform, "latest": true, "_id": bson.M{"$ne": excludeID}}
+	_, err := mongoutil.UpdateMany(ctx, a.coll, filter, bson.M{"$set": bson.M{"latest": false}})
+	return err
+}
+
+func (a *ApplicationMgo) Search(ctx context.Context, keyword string, platforms []string, pagination pagination.Pagination) (int64, []*model.Application, error) {
+	filter := bson.M{}
+	if len(platforms) > 0 {
+		filter["platform"] = bson.M{"$in": platforms}
+	}
+	if keyword != "" {
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(keyword), Options: "i"}
+		filter["$or"] = []bson.M{
+			{"version": regex},
+			{"text": regex},
+		}
+	}
+	return mongoutil.FindPage[*model.Application](ctx, a.coll, filter, pagination, options.Find().SetSort(bson.M{"create_time": -1}))
+}
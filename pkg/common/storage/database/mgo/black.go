@@ -81,6 +81,10 @@ func (b *BlackMgo) UpdateByMap(ctx context.Context, ownerUserID, blockUserID str
 	return mongoutil.UpdateOne(ctx, b.coll, b.blackFilter(ownerUserID, blockUserID), bson.M{"$set": args}, false)
 }
 
+func (b *BlackMgo) UpdateScope(ctx context.Context, ownerUserID, blockUserID string, scope int32) (err error) {
+	return mongoutil.UpdateOne(ctx, b.coll, b.blackFilter(ownerUserID, blockUserID), bson.M{"$set": bson.M{"scope": scope}}, true)
+}
+
 func (b *BlackMgo) Find(ctx context.Context, blacks []*model.Black) (blackList []*model.Black, err error) {
 	return mongoutil.Find[*model.Black](ctx, b.coll, b.blacksFilter(blacks))
 }
@@ -103,3 +107,7 @@ func (b *BlackMgo) FindOwnerBlackInfos(ctx context.Context, ownerUserID string,
 func (b *BlackMgo) FindBlackUserIDs(ctx context.Context, ownerUserID string) (blackUserIDs []string, err error) {
 	return mongoutil.Find[string](ctx, b.coll, bson.M{"owner_user_id": ownerUserID}, options.Find().SetProjection(bson.M{"_id": 0, "block_user_id": 1}))
 }
+
+func (b *BlackMgo) FindByBlockUserID(ctx context.Context, blockUserID string) (blacks []*model.Black, err error) {
+	return mongoutil.Find[*model.Black](ctx, b.coll, bson.M{"block_user_id": blockUserID})
+}
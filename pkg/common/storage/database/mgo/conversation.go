@@ -151,6 +151,17 @@ func (c *ConversationMgo) FindUserIDAllConversations(ctx context.Context, userID
 	return mongoutil.Find[*model.Conversation](ctx, c.coll, bson.M{"owner_user_id": userID})
 }
 
+func (c *ConversationMgo) DeleteByOwner(ctx context.Context, ownerUserID string, conversationIDs []string) error {
+	if len(conversationIDs) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.DeleteMany(ctx, c.coll, bson.M{"owner_user_id": ownerUserID, "conversation_id": bson.M{"$in": conversationIDs}})
+	}, func() error {
+		return c.version.IncrVersion(ctx, ownerUserID, conversationIDs, model.VersionStateDelete)
+	})
+}
+
 func (c *ConversationMgo) FindRecvMsgUserIDs(ctx context.Context, conversationID string, recvOpts []int) ([]string, error) {
 	var filter any
 	if len(recvOpts) == 0 {
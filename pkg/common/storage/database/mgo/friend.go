@@ -518,6 +518,40 @@ func (f *FriendMgo) IncrVersion(ctx context.Context, ownerUserID string, friendU
 	return f.owner.IncrVersion(ctx, ownerUserID, friendUserIDs, state)
 }
 
+func (f *FriendMgo) FindCategorized(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.Friend, error) {
+	filter := bson.M{"owner_user_id": ownerUserID}
+	if len(categoryIDs) > 0 {
+		filter["category_ids"] = bson.M{"$in": categoryIDs}
+	} else {
+		filter["category_ids.0"] = bson.M{"$exists": true}
+	}
+	return f.find(ctx, filter)
+}
+
+func (f *FriendMgo) AddCategory(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryID string) error {
+	if len(friendUserIDs) == 0 {
+		return nil
+	}
+	filter := bson.M{"owner_user_id": ownerUserID, "friend_user_id": bson.M{"$in": friendUserIDs}}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.Ignore(mongoutil.UpdateMany(ctx, f.coll, filter, bson.M{"$addToSet": bson.M{"category_ids": categoryID}}))
+	}, func() error {
+		return f.owner.IncrVersion(ctx, ownerUserID, friendUserIDs, model.VersionStateUpdate)
+	})
+}
+
+func (f *FriendMgo) RemoveCategories(ctx context.Context, ownerUserID string, friendUserIDs []string, categoryIDs []string) error {
+	if len(friendUserIDs) == 0 || len(categoryIDs) == 0 {
+		return nil
+	}
+	filter := bson.M{"owner_user_id": ownerUserID, "friend_user_id": bson.M{"$in": friendUserIDs}}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.Ignore(mongoutil.UpdateMany(ctx, f.coll, filter, bson.M{"$pull": bson.M{"category_ids": bson.M{"$in": categoryIDs}}}))
+	}, func() error {
+		return f.owner.IncrVersion(ctx, ownerUserID, friendUserIDs, model.VersionStateUpdate)
+	})
+}
+
 func (f *FriendMgo) IsUpdateIsPinned(data map[string]any) bool {
 	if data == nil {
 		return false
@@ -525,3 +559,21 @@ func (f *FriendMgo) IsUpdateIsPinned(data map[string]any) bool {
 	_, ok := data["is_pinned"]
 	return ok
 }
+
+func (f *FriendMgo) CountFriendsOfFriends(ctx context.Context, ownerUserIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error) {
+	if len(ownerUserIDs) == 0 || limit <= 0 {
+		return nil, nil
+	}
+	filter := bson.M{"owner_user_id": bson.M{"$in": ownerUserIDs}}
+	if len(excludeUserIDs) > 0 {
+		filter["friend_user_id"] = bson.M{"$nin": excludeUserIDs}
+	}
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$group": bson.M{"_id": "$friend_user_id", "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": limit},
+		{"$project": bson.M{"_id": 0, "user_id": "$_id", "count": 1}},
+	}
+	return mongoutil.Aggregate[*model.UserCount](ctx, f.coll, pipeline)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewFriendCategoryMongo(db *mongo.Database) (database.FriendCategory, error) {
+	coll := db.Collection(database.FriendCategoryName)
+	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "owner_user_id", Value: 1}, {Key: "category_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	owner, err := NewVersionLog(db.Collection(database.FriendVersionName))
+	if err != nil {
+		return nil, err
+	}
+	return &FriendCategoryMgo{coll: coll, owner: owner}, nil
+}
+
+type FriendCategoryMgo struct {
+	coll  *mongo.Collection
+	owner database.VersionLog // 好友版本日志，分组变更以VersionFriendCategoryChangeID记录
+}
+
+// incrVersion 在拥有者的好友版本日志中记录分组变更，客户端增量同步好友时据此得知需要刷新分组
+func (f *FriendCategoryMgo) incrVersion(ctx context.Context, ownerUserID string) error {
+	return f.owner.IncrVersion(ctx, ownerUserID, []string{model.VersionFriendCategoryChangeID}, model.VersionStateUpdate)
+}
+
+func (f *FriendCategoryMgo) Create(ctx context.Context, category *model.FriendCategory) error {
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.InsertOne(ctx, f.coll, category)
+	}, func() error {
+		return f.incrVersion(ctx, category.OwnerUserID)
+	})
+}
+
+func (f *FriendCategoryMgo) Take(ctx context.Context, ownerUserID string, categoryID string) (*model.FriendCategory, error) {
+	return mongoutil.FindOne[*model.FriendCategory](ctx, f.coll, bson.M{"owner_user_id": ownerUserID, "category_id": categoryID})
+}
+
+func (f *FriendCategoryMgo) Find(ctx context.Context, ownerUserID string, categoryIDs []string) ([]*model.FriendCategory, error) {
+	filter := bson.M{"owner_user_id": ownerUserID}
+	if len(categoryIDs) > 0 {
+		filter["category_id"] = bson.M{"$in": categoryIDs}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "create_time", Value: 1}})
+	return mongoutil.Find[*model.FriendCategory](ctx, f.coll, filter, opts)
+}
+
+func (f *FriendCategoryMgo) Count(ctx context.Context, ownerUserID string) (int64, error) {
+	return mongoutil.Count(ctx, f.coll, bson.M{"owner_user_id": ownerUserID})
+}
+
+func (f *FriendCategoryMgo) UpdateMap(ctx context.Context, ownerUserID string, categoryID string, args map[string]any) error {
+	if len(args) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.UpdateOne(ctx, f.coll, bson.M{"owner_user_id": ownerUserID, "category_id": categoryID}, bson.M{"$set": args}, true)
+	}, func() error {
+		return f.incrVersion(ctx, ownerUserID)
+	})
+}
+
+func (f *FriendCategoryMgo) UpdateOrders(ctx context.Context, ownerUserID string, categoryIDs []string) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(func() error {
+		models := make([]mongo.WriteModel, 0, len(categoryIDs))
+		for i, categoryID := range categoryIDs {
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"owner_user_id": ownerUserID, "category_id": categoryID}).
+				SetUpdate(bson.M{"$set": bson.M{"order": int32(i)}}))
+		}
+		_, err := f.coll.BulkWrite(ctx, models)
+		return errs.Wrap(err)
+	}, func() error {
+		return f.incrVersion(ctx, ownerUserID)
+	})
+}
+
+func (f *FriendCategoryMgo) Delete(ctx context.Context, ownerUserID string, categoryIDs []string) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.DeleteMany(ctx, f.coll, bson.M{"owner_user_id": ownerUserID, "category_id": bson.M{"$in": categoryIDs}})
+	}, func() error {
+		return f.incrVersion(ctx, ownerUserID)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewFriendEventMongo(db *mongo.Database) (database.FriendEvent, error) {
+	coll := db.Collection(database.FriendEventName)
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "owner_user_id", Value: 1}, {Key: "peer_user_id", Value: 1}, {Key: "create_time", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "peer_user_id", Value: 1}, {Key: "owner_user_id", Value: 1}, {Key: "create_time", Value: -1}},
+		},
+		{
+			// 按保留期清理
+			Keys: bson.D{{Key: "create_time", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &FriendEventMgo{coll: coll}, nil
+}
+
+type FriendEventMgo struct {
+	coll *mongo.Collection
+}
+
+func (f *FriendEventMgo) Create(ctx context.Context, events []*model.FriendEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return mongoutil.InsertMany(ctx, f.coll, events)
+}
+
+func (f *FriendEventMgo) FindPage(ctx context.Context, userID, peerUserID string, start, end time.Time, pagination pagination.Pagination) (int64, []*model.FriendEvent, error) {
+	var filter bson.M
+	if peerUserID == "" {
+		filter = bson.M{"$or": []bson.M{{"owner_user_id": userID}, {"peer_user_id": userID}}}
+	} else {
+		filter = bson.M{"$or": []bson.M{
+			{"owner_user_id": userID, "peer_user_id": peerUserID},
+			{"owner_user_id": peerUserID, "peer_user_id": userID},
+		}}
+	}
+	createTime := bson.M{}
+	if !start.IsZero() {
+		createTime["$gte"] = start
+	}
+	if !end.IsZero() {
+		createTime["$lt"] = end
+	}
+	if len(createTime) > 0 {
+		filter["create_time"] = createTime
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	return mongoutil.FindPage[*model.FriendEvent](ctx, f.coll, filter, pagination, opts)
+}
+
+func (f *FriendEventMgo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := mongoutil.DeleteManyResult(ctx, f.coll, bson.M{"create_time": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (f *FriendEventMgo) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"owner_user_id": userID},
+		{"peer_user_id": userID},
+		{"operator_user_id": userID},
+	}}
+	res, err := mongoutil.DeleteManyResult(ctx, f.coll, filter)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -36,6 +36,8 @@
 // **索引策略：**
 // 1. 联合唯一索引：(from_user_id, to_user_id) - 防止重复申请
 // 2. 时间排序索引：create_time - 支持按时间排序查询
+// 3. 发起者时间索引：(from_user_id, create_time) - 支持每日申请上限统计
+// 4. 状态时间索引：(handle_result, create_time) - 支持过期申请查找
 //
 // **业务规则：**
 // - 同一对用户之间只能有一个有效的好友申请
@@ -104,6 +106,20 @@ func NewFriendRequestMongo(db *mongo.Database) (database.FriendRequest, error) {
 				{Key: "create_time", Value: -1},
 			},
 		},
+		{
+			// 统计用户发起的申请数，用于每日申请上限
+			Keys: bson.D{
+				{Key: "from_user_id", Value: 1},
+				{Key: "create_time", Value: -1},
+			},
+		},
+		{
+			// 查找过期的未处理申请
+			Keys: bson.D{
+				{Key: "handle_result", Value: 1},
+				{Key: "create_time", Value: 1},
+			},
+		},
 	})
 	if err != nil {
 		return nil, err
@@ -345,6 +361,11 @@ func (f *FriendRequestMgo) Delete(ctx context.Context, fromUserID, toUserID stri
 	return mongoutil.DeleteOne(ctx, f.coll, bson.M{"from_user_id": fromUserID, "to_user_id": toUserID})
 }
 
+// DeleteByUserID 删除用户发起的和收到的全部好友申请，用于注销用户
+func (f *FriendRequestMgo) DeleteByUserID(ctx context.Context, userID string) error {
+	return mongoutil.DeleteMany(ctx, f.coll, bson.M{"$or": []bson.M{{"from_user_id": userID}, {"to_user_id": userID}}})
+}
+
 // UpdateByMap 通过Map更新好友申请信息
 //
 // **功能说明：**
@@ -464,3 +485,52 @@ func (f *FriendRequestMgo) GetUnhandledCount(ctx context.Context, userID string,
 	}
 	return mongoutil.Count(ctx, f.coll, filter)
 }
+
+// CountFromUserIDSince 统计用户在since之后发起的好友申请数
+//
+// 重新发起申请会刷新create_time，同一对用户在统计区间内只计一次
+func (f *FriendRequestMgo) CountFromUserIDSince(ctx context.Context, fromUserID string, since time.Time) (int64, error) {
+	return mongoutil.Count(ctx, f.coll, bson.M{"from_user_id": fromUserID, "create_time": bson.M{"$gte": since}})
+}
+
+// FindExpired 查找已过期的未处理申请，按申请时间升序返回
+func (f *FriendRequestMgo) FindExpired(ctx context.Context, before time.Time, limit int64) ([]*model.FriendRequest, error) {
+	filter := bson.M{
+		"handle_result": 0,
+		"create_time":   bson.M{"$lt": before},
+	}
+	opts := options.Find().SetSort(bson.M{"create_time": 1}).SetLimit(limit)
+	return mongoutil.Find[*model.FriendRequest](ctx, f.coll, filter, opts)
+}
+
+// Expire 将过期的未处理申请标记为已过期
+//
+// 仅匹配未处理且申请时间早于before的记录，申请在此期间被处理或重新发起时不做修改
+func (f *FriendRequestMgo) Expire(ctx context.Context, fromUserID, toUserID string, before time.Time, handleTime time.Time) error {
+	filter := bson.M{
+		"from_user_id":  fromUserID,
+		"to_user_id":    toUserID,
+		"handle_result": 0,
+		"create_time":   bson.M{"$lt": before},
+	}
+	update := bson.M{"$set": bson.M{
+		"handle_result": model.FriendResponseExpired,
+		"handle_time":   handleTime,
+	}}
+	return mongoutil.UpdateOne(ctx, f.coll, filter, update, true)
+}
+
+// FindPendingBetween 查找用户与指定用户之间任一方向的未处理申请
+func (f *FriendRequestMgo) FindPendingBetween(ctx context.Context, userID string, peerUserIDs []string) ([]*model.FriendRequest, error) {
+	if len(peerUserIDs) == 0 {
+		return nil, nil
+	}
+	filter := bson.M{
+		"handle_result": 0,
+		"$or": bson.A{
+			bson.M{"from_user_id": userID, "to_user_id": bson.M{"$in": peerUserIDs}},
+			bson.M{"to_user_id": userID, "from_user_id": bson.M{"$in": peerUserIDs}},
+		},
+	}
+	return mongoutil.Find[*model.FriendRequest](ctx, f.coll, filter)
+}
@@ -107,6 +107,18 @@ func NewGroupMongo(db *mongo.Database) (database.Group, error) {
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
+
+	// 创建外部系统ID唯一索引，仅对批量导入的群组生效
+	_, err = coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "external_id", Value: 1},
+		},
+		Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"external_id": bson.M{"$gt": ""}}),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
 	return &GroupMgo{coll: coll}, nil
 }
 
@@ -512,3 +524,35 @@ func (g *GroupMgo) SearchJoin(ctx context.Context, groupIDs []string, keyword st
 	// 执行分页搜索
 	return mongoutil.FindPage[*model.Group](ctx, g.coll, filter, pagination, opts)
 }
+
+// FindMuteScheduled 查找需要检查定时禁言状态的群组
+//
+// 包括设置了定时禁言计划的群组，以及计划已清除但最近一次通知仍为禁言中的群组（需要补发解禁通知）。
+// 使用群组ID游标翻页，处理过程中计划被清除不会导致漏查。
+func (g *GroupMgo) FindMuteScheduled(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error) {
+	filter := bson.M{
+		"group_id": bson.M{"$gt": afterGroupID},
+		"status":   bson.M{"$ne": constant.GroupStatusDismissed},
+		"$or": bson.A{
+			bson.M{"mute_schedule": bson.M{"$type": "object"}},
+			bson.M{"mute_schedule_active": true},
+		},
+	}
+	opts := options.Find().SetSort(bson.M{"group_id": 1}).SetLimit(limit)
+	return mongoutil.Find[*model.Group](ctx, g.coll, filter, opts)
+}
+
+func (g *GroupMgo) FindByExternalIDs(ctx context.Context, externalIDs []string) ([]*model.Group, error) {
+	return mongoutil.Find[*model.Group](ctx, g.coll, bson.M{"external_id": bson.M{"$in": externalIDs}})
+}
+
+// FindJoinRequestExpire 查找设置了入群申请有效期的群组，使用群组ID游标翻页
+func (g *GroupMgo) FindJoinRequestExpire(ctx context.Context, afterGroupID string, limit int64) ([]*model.Group, error) {
+	filter := bson.M{
+		"group_id":                    bson.M{"$gt": afterGroupID},
+		"status":                      bson.M{"$ne": constant.GroupStatusDismissed},
+		"join_request_expire_seconds": bson.M{"$gt": 0},
+	}
+	opts := options.Find().SetSort(bson.M{"group_id": 1}).SetLimit(limit)
+	return mongoutil.Find[*model.Group](ctx, g.coll, filter, opts)
+}
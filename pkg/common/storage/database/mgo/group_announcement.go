@@ -0,0 +1,112 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewGroupAnnouncementMongo(db *mongo.Database) (database.GroupAnnouncement, error) {
+	coll := db.Collection(database.GroupAnnouncementName)
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "announcement_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "group_id", Value: 1}, {Key: "pinned", Value: -1}, {Key: "create_time", Value: -1}},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	ackColl := db.Collection(database.GroupAnnouncementAckName)
+	_, err = ackColl.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "announcement_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "announcement_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &GroupAnnouncementMgo{coll: coll, ackColl: ackColl}, nil
+}
+
+type GroupAnnouncementMgo struct {
+	coll    *mongo.Collection
+	ackColl *mongo.Collection
+}
+
+func (g *GroupAnnouncementMgo) Create(ctx context.Context, announcement *model.GroupAnnouncement) error {
+	return mongoutil.InsertOne(ctx, g.coll, announcement)
+}
+
+func (g *GroupAnnouncementMgo) Take(ctx context.Context, groupID string, announcementID string) (*model.GroupAnnouncement, error) {
+	return mongoutil.FindOne[*model.GroupAnnouncement](ctx, g.coll, bson.M{"group_id": groupID, "announcement_id": announcementID})
+}
+
+func (g *GroupAnnouncementMgo) TakeLatest(ctx context.Context, groupID string) (*model.GroupAnnouncement, error) {
+	return mongoutil.FindOne[*model.GroupAnnouncement](ctx, g.coll, bson.M{"group_id": groupID}, options.FindOne().SetSort(bson.M{"create_time": -1}))
+}
+
+func (g *GroupAnnouncementMgo) FindPage(ctx context.Context, groupID string, pagination pagination.Pagination) (int64, []*model.GroupAnnouncement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "pinned", Value: -1}, {Key: "create_time", Value: -1}})
+	return mongoutil.FindPage[*model.GroupAnnouncement](ctx, g.coll, bson.M{"group_id": groupID}, pagination, opts)
+}
+
+func (g *GroupAnnouncementMgo) SetPinned(ctx context.Context, groupID string, announcementID string, pinned bool) error {
+	return mongoutil.UpdateOne(ctx, g.coll, bson.M{"group_id": groupID, "announcement_id": announcementID}, bson.M{"$set": bson.M{"pinned": pinned}}, true)
+}
+
+func (g *GroupAnnouncementMgo) Delete(ctx context.Context, groupID string, announcementIDs []string) error {
+	if len(announcementIDs) == 0 {
+		return nil
+	}
+	if err := mongoutil.DeleteMany(ctx, g.coll, bson.M{"group_id": groupID, "announcement_id": bson.M{"$in": announcementIDs}}); err != nil {
+		return err
+	}
+	return mongoutil.DeleteMany(ctx, g.ackColl, bson.M{"group_id": groupID, "announcement_id": bson.M{"$in": announcementIDs}})
+}
+
+func (g *GroupAnnouncementMgo) Ack(ctx context.Context, ack *model.GroupAnnouncementAck) error {
+	filter := bson.M{"announcement_id": ack.AnnouncementID, "user_id": ack.UserID}
+	update := bson.M{"$setOnInsert": ack}
+	return mongoutil.UpdateOne(ctx, g.ackColl, filter, update, false, options.Update().SetUpsert(true))
+}
+
+func (g *GroupAnnouncementMgo) FindAcks(ctx context.Context, announcementID string) ([]*model.GroupAnnouncementAck, error) {
+	return mongoutil.Find[*model.GroupAnnouncementAck](ctx, g.ackColl, bson.M{"announcement_id": announcementID}, options.Find().SetSort(bson.M{"ack_time": 1}))
+}
+
+func (g *GroupAnnouncementMgo) FindUserAckedIDs(ctx context.Context, userID string, announcementIDs []string) ([]string, error) {
+	if len(announcementIDs) == 0 {
+		return nil, nil
+	}
+	return mongoutil.Find[string](ctx, g.ackColl, bson.M{"user_id": userID, "announcement_id": bson.M{"$in": announcementIDs}},
+		options.Find().SetProjection(bson.M{"_id": 0, "announcement_id": 1}))
+}
@@ -0,0 +1,95 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewGroupChannelMongo(db *mongo.Database) (database.GroupChannel, error) {
+	coll := db.Collection(database.GroupChannelName)
+	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "group_id", Value: 1}, {Key: "channel_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	version, err := NewVersionLog(db.Collection(database.GroupChannelVersionName))
+	if err != nil {
+		return nil, err
+	}
+	return &GroupChannelMgo{coll: coll, version: version}, nil
+}
+
+type GroupChannelMgo struct {
+	coll    *mongo.Collection
+	version database.VersionLog // 频道列表版本日志（群组维度）
+}
+
+func (g *GroupChannelMgo) Create(ctx context.Context, channel *model.GroupChannel) error {
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.InsertOne(ctx, g.coll, channel)
+	}, func() error {
+		return g.version.IncrVersion(ctx, channel.GroupID, []string{channel.ChannelID}, model.VersionStateInsert)
+	})
+}
+
+func (g *GroupChannelMgo) Take(ctx context.Context, groupID string, channelID string) (*model.GroupChannel, error) {
+	return mongoutil.FindOne[*model.GroupChannel](ctx, g.coll, bson.M{"group_id": groupID, "channel_id": channelID})
+}
+
+func (g *GroupChannelMgo) Find(ctx context.Context, groupID string, channelIDs []string) ([]*model.GroupChannel, error) {
+	filter := bson.M{"group_id": groupID}
+	if len(channelIDs) > 0 {
+		filter["channel_id"] = bson.M{"$in": channelIDs}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "create_time", Value: 1}})
+	return mongoutil.Find[*model.GroupChannel](ctx, g.coll, filter, opts)
+}
+
+func (g *GroupChannelMgo) UpdateMap(ctx context.Context, groupID string, channelID string, args map[string]any) error {
+	if len(args) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.UpdateOne(ctx, g.coll, bson.M{"group_id": groupID, "channel_id": channelID}, bson.M{"$set": args}, true)
+	}, func() error {
+		return g.version.IncrVersion(ctx, groupID, []string{channelID}, model.VersionStateUpdate)
+	})
+}
+
+func (g *GroupChannelMgo) Delete(ctx context.Context, groupID string, channelIDs []string) error {
+	if len(channelIDs) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(func() error {
+		return mongoutil.DeleteMany(ctx, g.coll, bson.M{"group_id": groupID, "channel_id": bson.M{"$in": channelIDs}})
+	}, func() error {
+		return g.version.IncrVersion(ctx, groupID, channelIDs, model.VersionStateDelete)
+	})
+}
+
+func (g *GroupChannelMgo) FindIncrVersion(ctx context.Context, groupID string, version uint, limit int) (*model.VersionLog, error) {
+	return g.version.FindChangeLog(ctx, groupID, version, limit)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewGroupInviteLinkMongo(db *mongo.Database) (database.GroupInviteLink, error) {
+	coll := db.Collection(database.GroupInviteLinkName)
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "group_id", Value: 1}, {Key: "create_time", Value: -1}},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &GroupInviteLinkMgo{coll: coll}, nil
+}
+
+type GroupInviteLinkMgo struct {
+	coll *mongo.Collection
+}
+
+func (g *GroupInviteLinkMgo) Create(ctx context.Context, link *model.GroupInviteLink) error {
+	return mongoutil.InsertOne(ctx, g.coll, link)
+}
+
+func (g *GroupInviteLinkMgo) Take(ctx context.Context, token string) (*model.GroupInviteLink, error) {
+	return mongoutil.FindOne[*model.GroupInviteLink](ctx, g.coll, bson.M{"token": token})
+}
+
+func (g *GroupInviteLinkMgo) FindByGroup(ctx context.Context, groupID string) ([]*model.GroupInviteLink, error) {
+	return mongoutil.Find[*model.GroupInviteLink](ctx, g.coll, bson.M{"group_id": groupID}, options.Find().SetSort(bson.M{"create_time": -1}))
+}
+
+func (g *GroupInviteLinkMgo) Revoke(ctx context.Context, groupID string, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	_, err := mongoutil.UpdateMany(ctx, g.coll, bson.M{"group_id": groupID, "token": bson.M{"$in": tokens}}, bson.M{"$set": bson.M{"revoked": true}})
+	return err
+}
+
+func (g *GroupInviteLinkMgo) IncrUsedCount(ctx context.Context, token string) (bool, error) {
+	filter := bson.M{
+		"token":   token,
+		"revoked": false,
+		"$or": []bson.M{
+			{"max_uses": 0},
+			{"$expr": bson.M{"$lt": []string{"$used_count", "$max_uses"}}},
+		},
+	}
+	res, err := mongoutil.UpdateOneResult(ctx, g.coll, filter, bson.M{"$inc": bson.M{"used_count": 1}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (g *GroupInviteLinkMgo) DecrUsedCount(ctx context.Context, token string) error {
+	return mongoutil.UpdateOne(ctx, g.coll, bson.M{"token": token, "used_count": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"used_count": -1}}, false)
+}
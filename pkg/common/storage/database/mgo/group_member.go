@@ -60,6 +60,7 @@ package mgo
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
@@ -111,6 +112,27 @@ func NewGroupMember(db *mongo.Database) (database.GroupMember, error) {
 		return nil, errs.Wrap(err)
 	}
 
+	// 创建成员搜索使用的排序索引，同值按user_id排序以支持游标翻页
+	var indexes []mongo.IndexModel
+	for _, field := range []string{
+		model.GroupMemberSortJoinTime,
+		model.GroupMemberSortRoleLevel,
+		model.GroupMemberSortMuteEndTime,
+		model.GroupMemberSortNickname,
+		"inviter_user_id",
+	} {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "group_id", Value: 1},
+				{Key: field, Value: 1},
+				{Key: "user_id", Value: 1},
+			},
+		})
+	}
+	if _, err := coll.Indexes().CreateMany(context.Background(), indexes); err != nil {
+		return nil, errs.Wrap(err)
+	}
+
 	// 初始化群组成员版本日志（群组维度）
 	// 用于追踪群组成员列表的变更历史
 	member, err := NewVersionLog(db.Collection(database.GroupMemberVersionName))
@@ -234,6 +256,34 @@ func (g *GroupMemberMgo) Create(ctx context.Context, groupMembers []*model.Group
 		})
 }
 
+// ReplaceMany 批量整体替换同一群组中已存在的成员记录，并记录成员更新版本
+func (g *GroupMemberMgo) ReplaceMany(ctx context.Context, groupID string, groupMembers []*model.GroupMember) (err error) {
+	if len(groupMembers) == 0 {
+		return nil
+	}
+	return mongoutil.IncrVersion(
+		func() error {
+			models := make([]mongo.WriteModel, 0, len(groupMembers))
+			for _, member := range groupMembers {
+				models = append(models, mongo.NewReplaceOneModel().
+					SetFilter(bson.M{"group_id": groupID, "user_id": member.UserID}).
+					SetReplacement(member))
+			}
+			if _, err := g.coll.BulkWrite(ctx, models); err != nil {
+				return errs.WrapMsg(err, "replace group members failed", "groupID", groupID)
+			}
+			return nil
+		},
+		func() error {
+			// 角色等级可能变化，附带排序变更标记
+			userIDs := []string{model.VersionSortChangeID}
+			for _, member := range groupMembers {
+				userIDs = append(userIDs, member.UserID)
+			}
+			return g.member.IncrVersion(ctx, groupID, userIDs, model.VersionStateUpdate)
+		})
+}
+
 // Delete 删除群组成员
 //
 // 此方法实现了群组成员的删除操作，支持单个用户删除和批量删除。
@@ -612,6 +662,29 @@ func (g *GroupMemberMgo) FindRoleLevelUserIDs(ctx context.Context, groupID strin
 		options.Find().SetProjection(bson.M{"_id": 0, "user_id": 1}))
 }
 
+// TakeEarliestJoined 查找指定角色中入群最早的成员，用于群主继任
+// 入群时间相同时按用户ID排序，保证结果稳定
+func (g *GroupMemberMgo) TakeEarliestJoined(ctx context.Context, groupID string, roleLevel int32, excludeUserID string) (*model.GroupMember, error) {
+	filter := bson.M{"group_id": groupID, "role_level": roleLevel, "user_id": bson.M{"$ne": excludeUserID}}
+	opts := options.Find().SetSort(bson.D{{Key: "join_time", Value: 1}, {Key: "user_id", Value: 1}}).SetLimit(1)
+	members, err := mongoutil.Find[*model.GroupMember](ctx, g.coll, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members[0], nil
+}
+
+// FindByRoleIDs 查找拥有指定自定义角色的群成员
+func (g *GroupMemberMgo) FindByRoleIDs(ctx context.Context, groupID string, roleIDs []string) ([]*model.GroupMember, error) {
+	if len(roleIDs) == 0 {
+		return nil, nil
+	}
+	return mongoutil.Find[*model.GroupMember](ctx, g.coll, bson.M{"group_id": groupID, "role_id": bson.M{"$in": roleIDs}})
+}
+
 // SearchMember 搜索群组成员
 //
 // 此方法提供基于昵称的群组成员搜索功能，支持模糊匹配和分页。
@@ -647,6 +720,77 @@ func (g *GroupMemberMgo) SearchMember(ctx context.Context, keyword string, group
 		options.Find().SetSort(g.memberSort()))
 }
 
+func (g *GroupMemberMgo) FilterMembers(ctx context.Context, search *model.GroupMemberSearch) (int64, []*model.GroupMember, error) {
+	filter := bson.M{"group_id": search.GroupID}
+	if search.Keyword != "" {
+		keyword := regexp.QuoteMeta(search.Keyword)
+		filter["$or"] = bson.A{
+			bson.M{"nickname": bson.M{"$regex": keyword, "$options": "i"}},
+			bson.M{"user_id": bson.M{"$regex": keyword, "$options": "i"}},
+		}
+	}
+	if len(search.RoleLevels) > 0 {
+		filter["role_level"] = bson.M{"$in": search.RoleLevels}
+	}
+	if len(search.RoleIDs) > 0 {
+		filter["role_id"] = bson.M{"$in": search.RoleIDs}
+	}
+	if len(search.InviterUserIDs) > 0 {
+		filter["inviter_user_id"] = bson.M{"$in": search.InviterUserIDs}
+	}
+	if len(search.JoinSources) > 0 {
+		filter["join_source"] = bson.M{"$in": search.JoinSources}
+	}
+	if !search.JoinTimeBegin.IsZero() || !search.JoinTimeEnd.IsZero() {
+		joinTime := bson.M{}
+		if !search.JoinTimeBegin.IsZero() {
+			joinTime["$gte"] = search.JoinTimeBegin
+		}
+		if !search.JoinTimeEnd.IsZero() {
+			joinTime["$lt"] = search.JoinTimeEnd
+		}
+		filter["join_time"] = joinTime
+	}
+	if search.Muted != nil {
+		if *search.Muted {
+			filter["mute_end_time"] = bson.M{"$gt": search.MuteTime}
+		} else {
+			filter["mute_end_time"] = bson.M{"$lte": search.MuteTime}
+		}
+	}
+	if search.Ex != "" {
+		filter["ex"] = bson.M{"$regex": regexp.QuoteMeta(search.Ex)}
+	}
+	total, err := mongoutil.Count(ctx, g.coll, filter)
+	if err != nil {
+		return 0, nil, err
+	}
+	order, op := 1, "$gt"
+	if search.SortDesc {
+		order, op = -1, "$lt"
+	}
+	if search.AfterUserID != "" {
+		after := bson.A{
+			bson.M{search.SortField: bson.M{op: search.AfterValue}},
+			bson.M{search.SortField: search.AfterValue, "user_id": bson.M{op: search.AfterUserID}},
+		}
+		if keyword, ok := filter["$or"]; ok {
+			delete(filter, "$or")
+			filter["$and"] = bson.A{bson.M{"$or": keyword}, bson.M{"$or": after}}
+		} else {
+			filter["$or"] = after
+		}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: search.SortField, Value: order}, {Key: "user_id", Value: order}}).
+		SetLimit(search.Limit)
+	members, err := mongoutil.Find[*model.GroupMember](ctx, g.coll, filter, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return total, members, nil
+}
+
 // FindUserJoinedGroupID 查询用户加入的所有群组ID
 //
 // 此方法从用户维度查询其加入的所有群组ID列表。
@@ -883,3 +1027,22 @@ func (g *GroupMemberMgo) FindJoinIncrVersion(ctx context.Context, userID string,
 	log.ZDebug(ctx, "find join incr version", "userID", userID, "version", version)
 	return g.join.FindChangeLog(ctx, userID, version, limit)
 }
+
+// CountSharedGroups 统计成员在指定群组中出现的次数，即与群组所属用户的共同群组数
+func (g *GroupMemberMgo) CountSharedGroups(ctx context.Context, groupIDs []string, excludeUserIDs []string, limit int) ([]*model.UserCount, error) {
+	if len(groupIDs) == 0 || limit <= 0 {
+		return nil, nil
+	}
+	filter := bson.M{"group_id": bson.M{"$in": groupIDs}}
+	if len(excludeUserIDs) > 0 {
+		filter["user_id"] = bson.M{"$nin": excludeUserIDs}
+	}
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$group": bson.M{"_id": "$user_id", "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": limit},
+		{"$project": bson.M{"_id": 0, "user_id": "$_id", "count": 1}},
+	}
+	return mongoutil.Aggregate[*model.UserCount](ctx, g.coll, pipeline)
+}
@@ -443,3 +443,88 @@ func (g *GroupRequestMgo) GetUnhandledCount(ctx context.Context, groupIDs []stri
 	// 执行计数查询
 	return mongoutil.Count(ctx, g.coll, filter)
 }
+
+// AddDecision 追加审批记录
+//
+// 仅匹配未处理且该审批人尚未审批过的申请，保证同一审批人不会重复计票。
+// 使用FindOneAndUpdate返回更新后的申请，调用方据此统计同意人数。
+func (g *GroupRequestMgo) AddDecision(ctx context.Context, groupID string, userID string, decision *model.GroupRequestDecision) (*model.GroupRequest, error) {
+	filter := bson.M{
+		"group_id":          groupID,
+		"user_id":           userID,
+		"handle_result":     0,
+		"decisions.user_id": bson.M{"$ne": decision.UserID},
+	}
+	update := bson.M{"$push": bson.M{"decisions": decision}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	return mongoutil.FindOneAndUpdate[*model.GroupRequest](ctx, g.coll, filter, update, opts)
+}
+
+// Handle 处理申请并记录最终审批
+//
+// 仅匹配未处理的申请，并发处理同一申请时只有一次成功。
+// 审批记录使用$addToSet写入：多人审批模式下最后一票已由AddDecision写入，再次写入同一条记录不会重复。
+func (g *GroupRequestMgo) Handle(ctx context.Context, groupID string, userID string, handleResult int32, decision *model.GroupRequestDecision) error {
+	filter := bson.M{"group_id": groupID, "user_id": userID, "handle_result": 0}
+	update := bson.M{
+		"$set": bson.M{
+			"handle_result":  handleResult,
+			"handled_msg":    decision.Reason,
+			"handle_user_id": decision.UserID,
+			"handled_time":   decision.Time,
+		},
+		"$addToSet": bson.M{"decisions": decision},
+	}
+	return mongoutil.UpdateOne(ctx, g.coll, filter, update, true)
+}
+
+// FindExpired 查找已过期的未处理申请，按申请时间升序返回
+func (g *GroupRequestMgo) FindExpired(ctx context.Context, groupID string, before time.Time, limit int64) ([]*model.GroupRequest, error) {
+	filter := bson.M{
+		"group_id":      groupID,
+		"handle_result": 0,
+		"req_time":      bson.M{"$lt": before},
+	}
+	opts := options.Find().SetSort(bson.M{"req_time": 1}).SetLimit(limit)
+	return mongoutil.Find[*model.GroupRequest](ctx, g.coll, filter, opts)
+}
+
+// DeleteByUser 删除用户提交的全部申请，用于注销用户
+func (g *GroupRequestMgo) DeleteByUser(ctx context.Context, userID string) (int64, error) {
+	res, err := mongoutil.DeleteManyResult(ctx, g.coll, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// AnonymizeUser 将申请及其历史中的处理人、邀请人和审批人为该用户的字段置空，用于注销用户
+// 申请和审批结果保留，审批记录的结果仍可区分同意、拒绝和过期
+func (g *GroupRequestMgo) AnonymizeUser(ctx context.Context, userID string) (int64, error) {
+	var modified int64
+	for _, field := range []string{"handle_user_id", "inviter_user_id"} {
+		res, err := mongoutil.UpdateMany(ctx, g.coll, bson.M{field: userID}, bson.M{"$set": bson.M{field: ""}})
+		if err != nil {
+			return 0, err
+		}
+		modified += res.ModifiedCount
+	}
+	anonymize := []struct {
+		filter bson.M
+		field  string
+		array  bson.M
+	}{
+		{filter: bson.M{"decisions.user_id": userID}, field: "decisions.$[d].user_id", array: bson.M{"d.user_id": userID}},
+		{filter: bson.M{"history.handle_user_id": userID}, field: "history.$[d].handle_user_id", array: bson.M{"d.handle_user_id": userID}},
+		{filter: bson.M{"history.decisions.user_id": userID}, field: "history.$[].decisions.$[d].user_id", array: bson.M{"d.user_id": userID}},
+	}
+	for _, item := range anonymize {
+		opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []any{item.array}})
+		res, err := mongoutil.UpdateMany(ctx, g.coll, item.filter, bson.M{"$set": bson.M{item.field: ""}}, opts)
+		if err != nil {
+			return 0, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, nil
+}
@@ -22,3 +22,7 @@ import (
 func IsNotFound(err error) bool {
 	return errs.Unwrap(err) == mongo.ErrNoDocuments
 }
+
+func IsDuplicateKey(err error) bool {
+	return mongo.IsDuplicateKeyError(errs.Unwrap(err))
+}
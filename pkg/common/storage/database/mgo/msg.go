@@ -22,11 +22,20 @@ import (
 
 func NewMsgMongo(db *mongo.Database) (database.Msg, error) {
 	coll := db.Collection(new(model.MsgDocModel).TableName())
-	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
-		Keys: bson.D{
-			{Key: "doc_id", Value: 1},
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "doc_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// 按发送者查找消息（注销用户时删除其发送的消息），与searchMessageIndex的_id排序一致
+			Keys: bson.D{
+				{Key: "msgs.msg.send_id", Value: 1},
+				{Key: "_id", Value: 1},
+			},
 		},
-		Options: options.Index().SetUnique(true),
 	})
 	if err != nil {
 		return nil, errs.Wrap(err)
@@ -0,0 +1,79 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewMsgTemplateMongo(db *mongo.Database) (database.MsgTemplate, error) {
+	coll := db.Collection(database.MsgTemplateName)
+	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "template_id", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &MsgTemplateMgo{coll: coll}, nil
+}
+
+type MsgTemplateMgo struct {
+	coll *mongo.Collection
+}
+
+func (m *MsgTemplateMgo) Create(ctx context.Context, template *model.MsgTemplate) error {
+	return mongoutil.InsertOne(ctx, m.coll, template)
+}
+
+func (m *MsgTemplateMgo) Update(ctx context.Context, templateID string, data map[string]any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return mongoutil.UpdateOne(ctx, m.coll, bson.M{"template_id": templateID}, bson.M{"$set": data}, true)
+}
+
+func (m *MsgTemplateMgo) Delete(ctx context.Context, templateIDs []string) error {
+	if len(templateIDs) == 0 {
+		return nil
+	}
+	return mongoutil.DeleteMany(ctx, m.coll, bson.M{"template_id": bson.M{"$in": templateIDs}})
+}
+
+func (m *MsgTemplateMgo) Take(ctx context.Context, templateID string) (*model.MsgTemplate, error) {
+	return mongoutil.FindOne[*model.MsgTemplate](ctx, m.coll, bson.M{"template_id": templateID})
+}
+
+func (m *MsgTemplateMgo) Search(ctx context.Context, keyword string, pagination pagination.Pagination) (int64, []*model.MsgTemplate, error) {
+	filter := bson.M{}
+	if keyword != "" {
+		filter["$or"] = []bson.M{
+			{"template_id": bson.M{"$regex": keyword, "$options": "i"}},
+			{"name": bson.M{"$regex": keyword, "$options": "i"}},
+		}
+	}
+	return mongoutil.FindPage[*model.MsgTemplate](ctx, m.coll, filter, pagination, options.Find().SetSort(bson.M{"create_time": -1}))
+}
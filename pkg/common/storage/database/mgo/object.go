@@ -62,6 +62,16 @@ func NewS3Mongo(db *mongo.Database) (database.ObjectInfo, error) {
 		return nil, errs.Wrap(err)
 	}
 
+	// Create index for user_id
+	_, err = coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
 	return &S3Mongo{coll: coll}, nil
 }
 
@@ -109,6 +119,14 @@ func (o *S3Mongo) FindExpirationObject(ctx context.Context, engine string, expir
 	}, opt)
 }
 
+func (o *S3Mongo) FindByUserID(ctx context.Context, engine string, userID string, count int64) ([]*model.Object, error) {
+	opt := options.Find()
+	if count > 0 {
+		opt.SetLimit(count)
+	}
+	return mongoutil.Find[*model.Object](ctx, o.coll, bson.M{"engine": engine, "user_id": userID}, opt)
+}
+
 func (o *S3Mongo) GetKeyCount(ctx context.Context, engine string, key string) (int64, error) {
 	return mongoutil.Count(ctx, o.coll, bson.M{"engine": engine, "key": key})
 }
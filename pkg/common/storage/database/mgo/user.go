@@ -16,8 +16,10 @@ package mgo
 
 import (
 	"context"
+	"errors"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"regexp"
 	"time"
 
 	"github.com/openimsdk/protocol/user"
@@ -32,11 +34,19 @@ import (
 
 func NewUserMongo(db *mongo.Database) (database.User, error) {
 	coll := db.Collection(database.UserName)
-	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
-		Keys: bson.D{
-			{Key: "user_id", Value: 1},
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// 查询封禁中的用户，未封禁用户没有该字段或为零值
+			Keys: bson.D{
+				{Key: "ban_time", Value: 1},
+			},
 		},
-		Options: options.Index().SetUnique(true),
 	})
 	if err != nil {
 		return nil, errs.Wrap(err)
@@ -59,6 +69,13 @@ func (u *UserMgo) UpdateByMap(ctx context.Context, userID string, args map[strin
 	return mongoutil.UpdateOne(ctx, u.coll, bson.M{"user_id": userID}, bson.M{"$set": args}, true)
 }
 
+func (u *UserMgo) Delete(ctx context.Context, userID string) (err error) {
+	if err := mongoutil.DeleteOne(ctx, u.coll, bson.M{"user_id": userID}); err != nil {
+		return err
+	}
+	return mongoutil.DeleteMany(ctx, u.coll.Database().Collection("userCommands"), bson.M{"userID": userID})
+}
+
 func (u *UserMgo) Find(ctx context.Context, userIDs []string) (users []*model.User, err error) {
 	return mongoutil.Find[*model.User](ctx, u.coll, bson.M{"user_id": bson.M{"$in": userIDs}})
 }
@@ -125,6 +142,101 @@ func (u *UserMgo) PageFindUserWithKeyword(
 	return mongoutil.FindPage[*model.User](ctx, u.coll, query, pagination)
 }
 
+func (u *UserMgo) PageFindBanned(ctx context.Context, keyword string, now time.Time, pagination pagination.Pagination) (count int64, users []*model.User, err error) {
+	conditions := []bson.M{
+		{"ban_time": bson.M{"$gt": time.Time{}}},
+		{"$or": []bson.M{
+			{"ban_expire_time": time.Time{}},
+			{"ban_expire_time": bson.M{"$gt": now}},
+		}},
+	}
+	if keyword != "" {
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(keyword), Options: "i"}
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"user_id": regex},
+			{"nickname": regex},
+		}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "ban_time", Value: -1}})
+	return mongoutil.FindPage[*model.User](ctx, u.coll, bson.M{"$and": conditions}, pagination, opts)
+}
+
+// profileKey 自定义资料字段在用户文档中的路径
+func profileKey(name string) string {
+	return "profile." + name
+}
+
+// profileIndexName 自定义资料字段的索引名，删除字段时按名称删除索引
+func profileIndexName(name string) string {
+	return "profile_" + name
+}
+
+func (u *UserMgo) PageFindByProfile(ctx context.Context, keyword string, profile map[string]any, pagination pagination.Pagination) (count int64, users []*model.User, err error) {
+	filter := bson.M{}
+	for name, value := range profile {
+		filter[profileKey(name)] = value
+	}
+	if keyword != "" {
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(keyword), Options: "i"}
+		filter["$or"] = []bson.M{
+			{"user_id": regex},
+			{"nickname": regex},
+		}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	return mongoutil.FindPage[*model.User](ctx, u.coll, filter, pagination, opts)
+}
+
+func (u *UserMgo) CreateProfileIndex(ctx context.Context, name string) error {
+	_, err := u.coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: profileKey(name), Value: 1},
+		},
+		Options: options.Index().SetName(profileIndexName(name)).SetSparse(true),
+	})
+	return errs.Wrap(err)
+}
+
+func (u *UserMgo) DropProfileIndex(ctx context.Context, name string) error {
+	if _, err := u.coll.Indexes().DropOne(ctx, profileIndexName(name)); err != nil {
+		var cmdErr mongo.CommandError
+		// IndexNotFound
+		if errors.As(err, &cmdErr) && cmdErr.Code == 27 {
+			return nil
+		}
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+func (u *UserMgo) UnsetProfile(ctx context.Context, name string) error {
+	key := profileKey(name)
+	_, err := mongoutil.UpdateMany(ctx, u.coll, bson.M{key: bson.M{"$exists": true}}, bson.M{"$unset": bson.M{key: ""}})
+	return err
+}
+
+func (u *UserMgo) FindExAfter(ctx context.Context, afterUserID string, limit int64) ([]*model.User, error) {
+	filter := bson.M{"user_id": bson.M{"$gt": afterUserID}, "ex": bson.M{"$ne": ""}}
+	opts := options.Find().
+		SetSort(bson.M{"user_id": 1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"_id": 0, "user_id": 1, "ex": 1, "profile": 1})
+	return mongoutil.Find[*model.User](ctx, u.coll, filter, opts)
+}
+
+func (u *UserMgo) SetProfileValue(ctx context.Context, userID string, ex string, name string, value any) error {
+	filter := bson.M{"user_id": userID, "ex": ex}
+	if value == nil {
+		return mongoutil.UpdateOne(ctx, u.coll, filter, bson.M{"$unset": bson.M{profileKey(name): ""}}, false)
+	}
+	// profile可能为null，使用管道更新先补为空对象再写入取值
+	update := bson.A{
+		bson.M{"$set": bson.M{"profile": bson.M{"$ifNull": bson.A{"$profile", bson.M{}}}}},
+		bson.M{"$set": bson.M{profileKey(name): bson.M{"$literal": value}}},
+	}
+	return mongoutil.UpdateOne(ctx, u.coll, filter, update, false)
+}
+
 func (u *UserMgo) GetAllUserID(ctx context.Context, pagination pagination.Pagination) (int64, []string, error) {
 	return mongoutil.FindPage[string](ctx, u.coll, bson.M{}, pagination, options.Find().SetProjection(bson.M{"_id": 0, "user_id": 1}))
 }
@@ -0,0 +1,118 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/db/pagination"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewUserErasureMongo(db *mongo.Database) (database.UserErasure, error) {
+	coll := db.Collection(database.UserErasureName)
+	// 旧版本对user_id建立了全局唯一索引，重新注册的用户无法再次注销
+	if _, err := coll.Indexes().DropOne(context.Background(), "user_id_1"); err != nil {
+		var cmdErr mongo.CommandError
+		// IndexNotFound、NamespaceNotFound
+		if !errors.As(err, &cmdErr) || (cmdErr.Code != 27 && cmdErr.Code != 26) {
+			return nil, errs.Wrap(err)
+		}
+	}
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			// 每个用户只能有一个未完成的任务，已完成的任务保留为历史记录
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_id_unfinished").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"finished": false}),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "create_time", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "update_time", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "create_time", Value: -1}},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &UserErasureMgo{coll: coll}, nil
+}
+
+type UserErasureMgo struct {
+	coll *mongo.Collection
+}
+
+func (u *UserErasureMgo) Create(ctx context.Context, erasure *model.UserErasure) error {
+	return mongoutil.InsertOne(ctx, u.coll, erasure)
+}
+
+func (u *UserErasureMgo) Take(ctx context.Context, userID string) (*model.UserErasure, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	return mongoutil.FindOne[*model.UserErasure](ctx, u.coll, bson.M{"user_id": userID}, opts)
+}
+
+func (u *UserErasureMgo) Claim(ctx context.Context, userID string, staleBefore time.Time, now time.Time) (bool, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"$or": []bson.M{
+			{"status": model.UserErasureStatusFailed},
+			{"status": model.UserErasureStatusRunning, "update_time": bson.M{"$lt": staleBefore}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"status": model.UserErasureStatusRunning, "update_time": now}}
+	res, err := mongoutil.UpdateOneResult(ctx, u.coll, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (u *UserErasureMgo) Update(ctx context.Context, userID string, args map[string]any) error {
+	if len(args) == 0 {
+		return nil
+	}
+	filter := bson.M{"user_id": userID, "finished": bson.M{"$ne": true}}
+	return mongoutil.UpdateOne(ctx, u.coll, filter, bson.M{"$set": args}, false)
+}
+
+func (u *UserErasureMgo) FindPage(ctx context.Context, userIDs []string, status int32, pagination pagination.Pagination) (int64, []*model.UserErasure, error) {
+	filter := bson.M{}
+	if len(userIDs) > 0 {
+		filter["user_id"] = bson.M{"$in": userIDs}
+	}
+	if status != 0 {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}})
+	return mongoutil.FindPage[*model.UserErasure](ctx, u.coll, filter, pagination, opts)
+}
+
+func (u *UserErasureMgo) FindStale(ctx context.Context, staleBefore time.Time, limit int64) ([]*model.UserErasure, error) {
+	filter := bson.M{"status": model.UserErasureStatusRunning, "update_time": bson.M{"$lt": staleBefore}}
+	opts := options.Find().SetSort(bson.D{{Key: "update_time", Value: 1}}).SetLimit(limit)
+	return mongoutil.Find[*model.UserErasure](ctx, u.coll, filter, opts)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewUserIdentifierMongo(db *mongo.Database) (database.UserIdentifier, error) {
+	coll := db.Collection(database.UserIdentifierName)
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "type", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "hash", Value: 1}},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &UserIdentifierMgo{coll: coll}, nil
+}
+
+type UserIdentifierMgo struct {
+	coll *mongo.Collection
+}
+
+func (u *UserIdentifierMgo) Replace(ctx context.Context, userID string, identifiers []*model.UserIdentifier) error {
+	if err := mongoutil.DeleteMany(ctx, u.coll, bson.M{"user_id": userID}); err != nil {
+		return err
+	}
+	if len(identifiers) == 0 {
+		return nil
+	}
+	return mongoutil.InsertMany(ctx, u.coll, identifiers)
+}
+
+func (u *UserIdentifierMgo) FindByHashes(ctx context.Context, hashes []string) ([]*model.UserIdentifier, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	return mongoutil.Find[*model.UserIdentifier](ctx, u.coll, bson.M{"hash": bson.M{"$in": hashes}})
+}
@@ -0,0 +1,82 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/database"
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/mongoutil"
+	"github.com/openimsdk/tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewUserProfileFieldMongo(db *mongo.Database) (database.UserProfileField, error) {
+	coll := db.Collection(database.UserProfileFieldName)
+	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "name", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &UserProfileFieldMgo{coll: coll}, nil
+}
+
+type UserProfileFieldMgo struct {
+	coll *mongo.Collection
+}
+
+func (u *UserProfileFieldMgo) Set(ctx context.Context, field *model.UserProfileField) error {
+	update := bson.M{
+		"$set": bson.M{
+			"type":        field.Type,
+			"visibility":  field.Visibility,
+			"searchable":  field.Searchable,
+			"backfilled":  field.Backfilled,
+			"update_time": field.UpdateTime,
+		},
+		"$setOnInsert": bson.M{
+			"create_time": field.CreateTime,
+		},
+	}
+	return mongoutil.UpdateOne(ctx, u.coll, bson.M{"name": field.Name}, update, false, options.Update().SetUpsert(true))
+}
+
+func (u *UserProfileFieldMgo) Delete(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	return mongoutil.DeleteMany(ctx, u.coll, bson.M{"name": bson.M{"$in": names}})
+}
+
+func (u *UserProfileFieldMgo) Take(ctx context.Context, name string) (*model.UserProfileField, error) {
+	return mongoutil.FindOne[*model.UserProfileField](ctx, u.coll, bson.M{"name": name})
+}
+
+func (u *UserProfileFieldMgo) FindAll(ctx context.Context) ([]*model.UserProfileField, error) {
+	return mongoutil.Find[*model.UserProfileField](ctx, u.coll, bson.M{}, options.Find().SetSort(bson.M{"create_time": 1}))
+}
+
+func (u *UserProfileFieldMgo) SetBackfilled(ctx context.Context, name string, updateTime time.Time) error {
+	filter := bson.M{"name": name, "update_time": updateTime}
+	return mongoutil.UpdateOne(ctx, u.coll, filter, bson.M{"$set": bson.M{"backfilled": true}}, false)
+}
@@ -1,20 +1,32 @@
 package database
 
 const (
-	BlackName               = "black"
-	ConversationName        = "conversation"
-	FriendName              = "friend"
-	FriendVersionName       = "friend_version"
-	FriendRequestName       = "friend_request"
-	GroupName               = "group"
-	GroupMemberName         = "group_member"
-	GroupMemberVersionName  = "group_member_version"
-	GroupJoinVersionName    = "group_join_version"
-	ConversationVersionName = "conversation_version"
-	GroupRequestName        = "group_request"
-	LogName                 = "log"
-	ObjectName              = "s3"
-	UserName                = "user"
-	SeqConversationName     = "seq"
-	SeqUserName             = "seq_user"
+	ApplicationName          = "application"
+	BlackName                = "black"
+	ConversationName         = "conversation"
+	FriendName               = "friend"
+	FriendVersionName        = "friend_version"
+	FriendRequestName        = "friend_request"
+	FriendCategoryName       = "friend_category"
+	FriendEventName          = "friend_event"
+	GroupName                = "group"
+	GroupMemberName          = "group_member"
+	GroupMemberVersionName   = "group_member_version"
+	GroupJoinVersionName     = "group_join_version"
+	ConversationVersionName  = "conversation_version"
+	GroupRequestName         = "group_request"
+	GroupInviteLinkName      = "group_invite_link"
+	GroupAnnouncementName    = "group_announcement"
+	GroupAnnouncementAckName = "group_announcement_ack"
+	GroupChannelName         = "group_channel"
+	GroupChannelVersionName  = "group_channel_version"
+	LogName                  = "log"
+	MsgTemplateName          = "msg_template"
+	ObjectName               = "s3"
+	UserName                 = "user"
+	UserIdentifierName       = "user_identifier"
+	UserErasureName          = "user_erasure"
+	UserProfileFieldName     = "user_profile_field"
+	SeqConversationName      = "seq"
+	SeqUserName              = "seq_user"
 )
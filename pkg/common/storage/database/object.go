@@ -26,6 +26,7 @@ type ObjectInfo interface {
 	Take(ctx context.Context, engine string, name string) (*model.Object, error)
 	Delete(ctx context.Context, engine string, name []string) error
 	FindExpirationObject(ctx context.Context, engine string, expiration time.Time, needDelType []string, count int64) ([]*model.Object, error)
+	FindByUserID(ctx context.Context, engine string, userID string, count int64) ([]*model.Object, error)
 	GetKeyCount(ctx context.Context, engine string, key string) (int64, error)
 
 	GetEngineCount(ctx context.Context, engine string) (int64, error)
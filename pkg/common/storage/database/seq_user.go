@@ -10,4 +10,6 @@ type SeqUser interface {
 	GetUserReadSeq(ctx context.Context, conversationID string, userID string) (int64, error)
 	SetUserReadSeq(ctx context.Context, conversationID string, userID string, seq int64) error
 	GetUserReadSeqs(ctx context.Context, userID string, conversationID []string) (map[string]int64, error)
+	// DeleteByUserID 删除用户在所有会话中的seq记录，返回被删除记录对应的会话ID
+	DeleteByUserID(ctx context.Context, userID string) ([]string, error)
 }
@@ -25,6 +25,8 @@ import (
 type User interface {
 	Create(ctx context.Context, users []*model.User) (err error)
 	UpdateByMap(ctx context.Context, userID string, args map[string]any) (err error)
+	// Delete 删除用户及其自定义命令
+	Delete(ctx context.Context, userID string) (err error)
 	Find(ctx context.Context, userIDs []string) (users []*model.User, err error)
 	Take(ctx context.Context, userID string) (user *model.User, err error)
 	TakeNotification(ctx context.Context, level int64) (user []*model.User, err error)
@@ -33,6 +35,20 @@ type User interface {
 	PageFindUser(ctx context.Context, level1 int64, level2 int64, pagination pagination.Pagination) (count int64, users []*model.User, err error)
 	PageFindUserWithKeyword(ctx context.Context, level1 int64, level2 int64, userID, nickName string, pagination pagination.Pagination) (count int64, users []*model.User, err error)
 	Exist(ctx context.Context, userID string) (exist bool, err error)
+	// PageFindBanned 分页查询在now时处于封禁中的用户，keyword匹配用户ID或昵称
+	PageFindBanned(ctx context.Context, keyword string, now time.Time, pagination pagination.Pagination) (count int64, users []*model.User, err error)
+	// PageFindByProfile 分页查询自定义资料字段取值全部相等的用户，keyword匹配用户ID或昵称
+	PageFindByProfile(ctx context.Context, keyword string, profile map[string]any, pagination pagination.Pagination) (count int64, users []*model.User, err error)
+	// CreateProfileIndex 为可搜索的自定义资料字段建立索引
+	CreateProfileIndex(ctx context.Context, name string) error
+	// DropProfileIndex 删除自定义资料字段的索引，索引不存在时忽略
+	DropProfileIndex(ctx context.Context, name string) error
+	// UnsetProfile 清除所有用户的自定义资料字段取值
+	UnsetProfile(ctx context.Context, name string) error
+	// FindExAfter 按user_id升序查询user_id大于afterUserID且Ex不为空的用户，只返回user_id、ex和profile
+	FindExAfter(ctx context.Context, afterUserID string, limit int64) ([]*model.User, error)
+	// SetProfileValue 在用户Ex仍为ex时设置单个自定义资料字段的取值，value为nil时清除
+	SetProfileValue(ctx context.Context, userID string, ex string, name string, value any) error
 	GetAllUserID(ctx context.Context, pagination pagination.Pagination) (count int64, userIDs []string, err error)
 	GetUserGlobalRecvMsgOpt(ctx context.Context, userID string) (opt int, err error)
 	// Get user total quantity
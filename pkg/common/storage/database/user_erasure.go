@@ -0,0 +1,39 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+	"github.com/openimsdk/tools/db/pagination"
+)
+
+// UserErasure 用户注销任务
+type UserErasure interface {
+	// Create 创建任务，用户已有未完成的任务时返回重复键错误
+	Create(ctx context.Context, erasure *model.UserErasure) error
+	// Take 获取用户最近创建的任务
+	Take(ctx context.Context, userID string) (*model.UserErasure, error)
+	// Claim 接管失败的任务或update_time早于staleBefore的执行中任务，成功时任务状态置为执行中
+	Claim(ctx context.Context, userID string, staleBefore time.Time, now time.Time) (bool, error)
+	// Update 更新用户未完成的任务字段
+	Update(ctx context.Context, userID string, args map[string]any) error
+	// FindPage 按创建时间倒序分页查询任务，userIDs为空时不限制用户，status为0时不限制状态
+	FindPage(ctx context.Context, userIDs []string, status int32, pagination pagination.Pagination) (int64, []*model.UserErasure, error)
+	// FindStale 查找update_time早于staleBefore的执行中任务
+	FindStale(ctx context.Context, staleBefore time.Time, limit int64) ([]*model.UserErasure, error)
+}
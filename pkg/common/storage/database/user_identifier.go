@@ -0,0 +1,29 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+)
+
+// UserIdentifier 用户标识索引
+type UserIdentifier interface {
+	// Replace 覆盖用户的全部标识，identifiers为空时删除用户的全部标识
+	Replace(ctx context.Context, userID string, identifiers []*model.UserIdentifier) error
+	// FindByHashes 查找哈希匹配的标识
+	FindByHashes(ctx context.Context, hashes []string) ([]*model.UserIdentifier, error)
+}
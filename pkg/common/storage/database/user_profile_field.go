@@ -0,0 +1,32 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/model"
+)
+
+type UserProfileField interface {
+	// Set 按字段名新增或覆盖字段定义
+	Set(ctx context.Context, field *model.UserProfileField) error
+	Delete(ctx context.Context, names []string) error
+	Take(ctx context.Context, name string) (*model.UserProfileField, error)
+	FindAll(ctx context.Context) ([]*model.UserProfileField, error)
+	// SetBackfilled 标记字段已完成回填，字段在回填期间被重新定义（update_time变化）时不做修改
+	SetBackfilled(ctx context.Context, name string, updateTime time.Time) error
+}
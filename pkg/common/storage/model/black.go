@@ -18,6 +18,19 @@ import (
 	"time"
 )
 
+// 黑名单生效范围位
+const (
+	BlackScopeMessage       int32 = 1 << iota // 禁止对方发送单聊消息
+	BlackScopePresence                        // 对对方隐藏在线状态
+	BlackScopeFriendRequest                   // 禁止对方发起好友申请
+	BlackScopeProfile                         // 对对方隐藏自定义资料（用户Ex）
+
+	BlackScopeAll = BlackScopeMessage | BlackScopePresence | BlackScopeFriendRequest | BlackScopeProfile
+
+	// DefaultBlackScope 加入黑名单时的默认范围，与引入范围前的行为一致，只禁止对方发送消息
+	DefaultBlackScope = BlackScopeMessage
+)
+
 type Black struct {
 	OwnerUserID    string    `bson:"owner_user_id"`
 	BlockUserID    string    `bson:"block_user_id"`
@@ -25,4 +38,13 @@ type Black struct {
 	AddSource      int32     `bson:"add_source"`
 	OperatorUserID string    `bson:"operator_user_id"`
 	Ex             string    `bson:"ex"`
+	Scope          int32     `bson:"scope"`
+}
+
+// EffectiveScope 返回黑名单实际生效的范围，引入范围前创建的记录没有scope字段，按默认范围处理
+func (b *Black) EffectiveScope() int32 {
+	if b.Scope == 0 {
+		return DefaultBlackScope
+	}
+	return b.Scope & BlackScopeAll
 }
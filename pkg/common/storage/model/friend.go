@@ -30,4 +30,5 @@ type Friend struct {
 	OperatorUserID string             `bson:"operator_user_id"`
 	Ex             string             `bson:"ex"`
 	IsPinned       bool               `bson:"is_pinned"`
+	CategoryIDs    []string           `bson:"category_ids"` // 所属的好友分组，一个好友可属于多个分组
 }
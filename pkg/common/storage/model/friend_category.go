@@ -0,0 +1,34 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// MaxFriendCategories 每个用户最多创建的好友分组数
+const MaxFriendCategories = 100
+
+// MaxFriendCategoryNameLen 好友分组名称的最大长度（字符数）
+const MaxFriendCategoryNameLen = 32
+
+// FriendCategory 用户的好友分组，好友通过Friend.CategoryIDs归属到分组
+type FriendCategory struct {
+	OwnerUserID string    `bson:"owner_user_id"`
+	CategoryID  string    `bson:"category_id"`
+	Name        string    `bson:"name"`
+	Order       int32     `bson:"order"` // 分组列表排序，升序
+	CreateTime  time.Time `bson:"create_time"`
+}
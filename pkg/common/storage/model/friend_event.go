@@ -0,0 +1,48 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// 好友关系事件类型
+const (
+	FriendEventAdd     int32 = 1 // 成为好友
+	FriendEventDelete  int32 = 2 // 删除好友
+	FriendEventBlock   int32 = 3 // 加入黑名单
+	FriendEventUnblock int32 = 4 // 移出黑名单
+)
+
+// 好友关系事件来源，对应触发变更的操作
+const (
+	FriendEventSourceApply       = "respond_apply" // 同意好友申请
+	FriendEventSourceImport      = "import_friend" // 管理员导入好友
+	FriendEventSourceDelete      = "delete_friend" // 删除好友
+	FriendEventSourceAddBlack    = "add_black"     // 加入黑名单
+	FriendEventSourceRemoveBlack = "remove_black"  // 移出黑名单
+)
+
+// FriendEvent 好友关系事件，只追加不修改，用于追溯关系变化的时间和原因
+// 删除好友和黑名单是单向的，OwnerUserID为关系发生变化的一方；
+// 成为好友是双向的，OwnerUserID为申请人或导入时的拥有者
+type FriendEvent struct {
+	OwnerUserID    string    `bson:"owner_user_id"`
+	PeerUserID     string    `bson:"peer_user_id"`
+	Type           int32     `bson:"type"`
+	OperatorUserID string    `bson:"operator_user_id"`
+	Source         string    `bson:"source"`
+	CreateTime     time.Time `bson:"create_time"`
+}
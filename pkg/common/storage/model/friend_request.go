@@ -29,3 +29,7 @@ type FriendRequest struct {
 	HandleTime    time.Time `bson:"handle_time"`
 	Ex            string    `bson:"ex"`
 }
+
+// FriendResponseExpired 好友申请超过有效期未处理，由定时任务标记
+// 与constant.FriendResponseNotHandle/Agree/Refuse共用HandleResult字段，已过期的申请不能再处理
+const FriendResponseExpired = -2
@@ -0,0 +1,22 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// FriendSuggestion 好友推荐候选人，按用户缓存在Redis中
+type FriendSuggestion struct {
+	UserID            string `json:"userID"`
+	MutualFriendCount int64  `json:"mutualFriendCount"` // 共同好友数
+	SharedGroupCount  int64  `json:"sharedGroupCount"`  // 共同群组数
+}
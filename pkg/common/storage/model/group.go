@@ -34,4 +34,60 @@ type Group struct {
 	ApplyMemberFriend      int32     `bson:"apply_member_friend"`
 	NotificationUpdateTime time.Time `bson:"notification_update_time"`
 	NotificationUserID     string    `bson:"notification_user_id"`
+	// @提及策略
+	AtAllPolicy    int32 `bson:"at_all_policy"`     // 允许@所有人的成员范围，见AtAllPolicyXxx
+	MaxAtUserCount int32 `bson:"max_at_user_count"` // 单条消息最多@的成员数，0表示使用全局配置
+	// 慢速模式：普通成员两次发言的最小间隔（秒），0表示关闭，群主和管理员不受限制
+	SlowModeSeconds int32 `bson:"slow_mode_seconds"`
+	// 自定义角色，成员通过GroupMember.RoleID关联
+	Roles []*GroupRole `bson:"roles"`
+	// 定时禁言计划，为nil表示未设置
+	MuteSchedule *GroupMuteSchedule `bson:"mute_schedule"`
+	// 定时禁言计划最近一次通知的状态，定时任务据此识别禁言/解禁切换并发送通知
+	MuteScheduleActive bool `bson:"mute_schedule_active"`
+	// 外部系统中的群组ID，批量导入时作为幂等键，为空表示非导入群组
+	ExternalID string `bson:"external_id"`
+	// 归档状态：已归档的群组保留成员和历史消息，但拒绝发送消息和成员、资料变动
+	Archived      bool      `bson:"archived"`
+	ArchiveTime   time.Time `bson:"archive_time"`
+	ArchiveUserID string    `bson:"archive_user_id"`
+	// 入群审批策略
+	JoinApprovalMode         int32 `bson:"join_approval_mode"`          // 审批模式，见GroupJoinApprovalXxx
+	JoinApprovalCount        int32 `bson:"join_approval_count"`         // 多人审批模式下通过所需的同意人数
+	JoinRequestExpireSeconds int32 `bson:"join_request_expire_seconds"` // 待处理申请的有效期（秒），0表示不过期
+}
+
+// 群组@所有人策略，缺省（0）为所有成员均可@所有人，与历史行为一致
+const (
+	AtAllPolicyEveryone = 0 // 所有成员
+	AtAllPolicyAdmin    = 1 // 群主和管理员
+	AtAllPolicyOwner    = 2 // 仅群主
+)
+
+// MaxSlowModeSeconds 慢速模式允许设置的最大间隔（秒）
+const MaxSlowModeSeconds = 24 * 60 * 60
+
+// 入群审批模式，缺省（0）为任一群主或管理员同意即通过，与历史行为一致
+const (
+	GroupJoinApprovalAny    = 0 // 任一审批人同意即通过
+	GroupJoinApprovalQuorum = 1 // 需JoinApprovalCount名审批人同意才通过
+)
+
+// MaxJoinRequestExpireSeconds 入群申请有效期允许设置的最大值（秒）
+const MaxJoinRequestExpireSeconds = 30 * 24 * 60 * 60
+
+// RequiredJoinApprovals 返回入群申请通过所需的同意人数
+// 多人审批模式下所需人数不超过当前审批人数，避免管理员减少后申请无法通过
+func (g *Group) RequiredJoinApprovals(approverNum int) int {
+	if g.JoinApprovalMode != GroupJoinApprovalQuorum {
+		return 1
+	}
+	required := int(g.JoinApprovalCount)
+	if required > approverNum {
+		required = approverNum
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
 }
@@ -0,0 +1,39 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// GroupAnnouncement 群公告，每次发布保存一条记录，Group.Notification保留最新一条公告的内容
+type GroupAnnouncement struct {
+	AnnouncementID string    `bson:"announcement_id"`
+	GroupID        string    `bson:"group_id"`
+	Content        string    `bson:"content"`
+	CreatorUserID  string    `bson:"creator_user_id"`
+	Pinned         bool      `bson:"pinned"`
+	RequireAck     bool      `bson:"require_ack"` // 为true时成员需要确认已读，管理员可以查看确认情况并提醒未确认的成员
+	Ex             string    `bson:"ex"`
+	CreateTime     time.Time `bson:"create_time"`
+}
+
+// GroupAnnouncementAck 群成员对公告的确认记录
+type GroupAnnouncementAck struct {
+	AnnouncementID string    `bson:"announcement_id"`
+	GroupID        string    `bson:"group_id"`
+	UserID         string    `bson:"user_id"`
+	AckTime        time.Time `bson:"ack_time"`
+}
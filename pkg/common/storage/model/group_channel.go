@@ -0,0 +1,56 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/openimsdk/protocol/constant"
+)
+
+// MaxGroupChannels 每个群组允许创建的频道数
+const MaxGroupChannels = 50
+
+// GroupChannel 群组频道（话题），共享父群的成员列表，拥有独立的会话、seq和已读状态
+type GroupChannel struct {
+	GroupID           string    `bson:"group_id"`
+	ChannelID         string    `bson:"channel_id"`
+	Name              string    `bson:"name"`
+	Description       string    `bson:"description"`
+	FaceURL           string    `bson:"face_url"`
+	Order             int32     `bson:"order"`              // 频道列表排序，升序
+	ReadOnly          bool      `bson:"read_only"`          // 只读频道：仅群主、管理员和拥有管理频道权限的成员可发言
+	DeniedPermissions int64     `bson:"denied_permissions"` // 在父群权限基础上对普通成员收回的权限位
+	CreatorUserID     string    `bson:"creator_user_id"`
+	Ex                string    `bson:"ex"`
+	CreateTime        time.Time `bson:"create_time"`
+}
+
+// GroupChannelPermissions 计算成员在频道内的有效权限
+// 继承父群内的有效权限（见GroupMemberPermissions），普通成员再收回频道的DeniedPermissions，群主和管理员不受影响
+func GroupChannelPermissions(groupPerms int64, roleLevel int32, deniedPermissions int64) int64 {
+	if roleLevel == constant.GroupOwner || roleLevel == constant.GroupAdmin {
+		return groupPerms
+	}
+	return groupPerms &^ deniedPermissions
+}
+
+// CanSendGroupChannelMessage 判断成员能否在频道内发言，channelPerms为GroupChannelPermissions的结果
+func CanSendGroupChannelMessage(readOnly bool, roleLevel int32, channelPerms int64) bool {
+	if !readOnly || roleLevel == constant.GroupOwner || roleLevel == constant.GroupAdmin {
+		return true
+	}
+	return channelPerms&GroupPermManageChannel != 0
+}
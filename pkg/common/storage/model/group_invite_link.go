@@ -0,0 +1,43 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// GroupInviteLink 群组邀请链接，Token可生成链接或二维码分享
+type GroupInviteLink struct {
+	Token            string    `bson:"token"`
+	GroupID          string    `bson:"group_id"`
+	CreatorUserID    string    `bson:"creator_user_id"`
+	ExpireTime       time.Time `bson:"expire_time"` // 零值表示永不过期
+	MaxUses          int32     `bson:"max_uses"`    // 0表示不限次数
+	UsedCount        int32     `bson:"used_count"`
+	SkipVerification bool      `bson:"skip_verification"` // 为true时忽略群组的加群验证设置直接入群
+	Revoked          bool      `bson:"revoked"`
+	Ex               string    `bson:"ex"`
+	CreateTime       time.Time `bson:"create_time"`
+}
+
+// Expired 判断链接在指定时间是否已过期
+func (l *GroupInviteLink) Expired(now time.Time) bool {
+	return !l.ExpireTime.IsZero() && !now.Before(l.ExpireTime)
+}
+
+// UsedUp 判断链接是否已达到使用次数上限
+func (l *GroupInviteLink) UsedUp() bool {
+	return l.MaxUses > 0 && l.UsedCount >= l.MaxUses
+}
@@ -29,5 +29,35 @@ type GroupMember struct {
 	InviterUserID  string    `bson:"inviter_user_id"`
 	OperatorUserID string    `bson:"operator_user_id"`
 	MuteEndTime    time.Time `bson:"mute_end_time"`
+	RoleID         string    `bson:"role_id"` // 自定义角色ID，为空表示只有RoleLevel对应的内置权限
 	Ex             string    `bson:"ex"`
 }
+
+// 群成员搜索支持的排序字段，取值为对应的bson字段名
+const (
+	GroupMemberSortJoinTime    = "join_time"
+	GroupMemberSortRoleLevel   = "role_level"
+	GroupMemberSortMuteEndTime = "mute_end_time"
+	GroupMemberSortNickname    = "nickname"
+)
+
+// GroupMemberSearch 群成员搜索条件，零值字段不参与过滤
+// 结果按SortField排序，相同值按UserID排序，AfterUserID不为空时从(AfterValue, AfterUserID)之后继续翻页
+type GroupMemberSearch struct {
+	GroupID        string
+	Keyword        string // 匹配昵称或用户ID
+	RoleLevels     []int32
+	RoleIDs        []string
+	InviterUserIDs []string
+	JoinSources    []int32
+	JoinTimeBegin  time.Time
+	JoinTimeEnd    time.Time
+	Muted          *bool     // 按MuteTime判断成员当前是否处于禁言中
+	MuteTime       time.Time // 判断禁言状态的时间点
+	Ex             string    // 匹配Ex字段的子串
+	SortField      string
+	SortDesc       bool
+	AfterValue     any
+	AfterUserID    string
+	Limit          int64
+}
@@ -0,0 +1,109 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxGroupMuteWindows 单个群组允许配置的周期禁言时段数量上限
+const MaxGroupMuteWindows = 10
+
+// MinutesPerDay 一天的分钟数，禁言时段的起止以当天零点起的分钟数表示
+const MinutesPerDay = 24 * 60
+
+// GroupMuteSchedule 群组定时禁言计划，与Status上的手动全员禁言叠加生效
+type GroupMuteSchedule struct {
+	Timezone  string             `bson:"timezone"`   // IANA时区名，如Asia/Shanghai，为空表示UTC
+	Windows   []*GroupMuteWindow `bson:"windows"`    // 每日重复的禁言时段
+	MuteUntil time.Time          `bson:"mute_until"` // 一次性禁言的结束时间，到期自动解除，零值表示未设置
+}
+
+// GroupMuteWindow 每日重复的禁言时段，EndMinute小于StartMinute表示跨越零点（如23:00-07:00）
+type GroupMuteWindow struct {
+	StartMinute int32   `bson:"start_minute"` // 开始时间，当天零点起的分钟数，包含
+	EndMinute   int32   `bson:"end_minute"`   // 结束时间，当天零点起的分钟数，不包含
+	Weekdays    []int32 `bson:"weekdays"`     // 生效的星期（0为周日），跨零点时段按开始当天计算，为空表示每天
+}
+
+// Empty 判断计划是否没有任何禁言规则
+func (s *GroupMuteSchedule) Empty() bool {
+	return s == nil || (len(s.Windows) == 0 && s.MuteUntil.IsZero())
+}
+
+// Muted 判断群组在指定时间是否处于定时禁言中
+func (s *GroupMuteSchedule) Muted(now time.Time) bool {
+	if s == nil {
+		return false
+	}
+	if now.Before(s.MuteUntil) {
+		return true
+	}
+	if len(s.Windows) == 0 {
+		return false
+	}
+	local := now.In(muteScheduleLocation(s.Timezone))
+	minute := int32(local.Hour()*60 + local.Minute())
+	weekday := int32(local.Weekday())
+	yesterday := (weekday + 6) % 7
+	for _, w := range s.Windows {
+		if w.StartMinute < w.EndMinute {
+			if minute >= w.StartMinute && minute < w.EndMinute && w.onWeekday(weekday) {
+				return true
+			}
+			continue
+		}
+		// 跨零点时段：开始当天的后半段或次日的前半段
+		if minute >= w.StartMinute && w.onWeekday(weekday) {
+			return true
+		}
+		if minute < w.EndMinute && w.onWeekday(yesterday) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *GroupMuteWindow) onWeekday(weekday int32) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// muteScheduleLocations 缓存已加载的时区，time.LoadLocation每次都会读取时区文件
+var muteScheduleLocations sync.Map
+
+// muteScheduleLocation 获取时区，无效时区回退到UTC（写入时已校验，这里只做兜底）
+func muteScheduleLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	if loc, ok := muteScheduleLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	muteScheduleLocations.Store(name, loc)
+	return loc
+}
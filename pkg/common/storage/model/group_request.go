@@ -30,4 +30,75 @@ type GroupRequest struct {
 	JoinSource    int32     `bson:"join_source"`
 	InviterUserID string    `bson:"inviter_user_id"`
 	Ex            string    `bson:"ex"`
+	// 审批记录，按时间顺序保存每位审批人的决定以及过期处理
+	Decisions []*GroupRequestDecision `bson:"decisions"`
+	// 重新申请前的历史申请，按时间顺序保存，最多保留MaxGroupRequestHistory条
+	History []*GroupRequestHistory `bson:"history"`
+}
+
+// GroupRequestHistory 重新申请时归档的上一次申请，保留其处理结果和审批记录
+type GroupRequestHistory struct {
+	HandleResult int32                   `bson:"handle_result"`
+	ReqMsg       string                  `bson:"req_msg"`
+	HandledMsg   string                  `bson:"handled_msg"`
+	ReqTime      time.Time               `bson:"req_time"`
+	HandleUserID string                  `bson:"handle_user_id"`
+	HandledTime  time.Time               `bson:"handled_time"`
+	Decisions    []*GroupRequestDecision `bson:"decisions"`
+}
+
+// MaxGroupRequestHistory 每条入群申请最多保留的历史申请数
+const MaxGroupRequestHistory = 20
+
+// GroupRequestDecision 入群申请的一条审批记录
+type GroupRequestDecision struct {
+	UserID string    `bson:"user_id"` // 审批人，过期处理时为空
+	Result int32     `bson:"result"`  // 见GroupRequestDecisionXxx
+	Reason string    `bson:"reason"`
+	Time   time.Time `bson:"time"`
+}
+
+// 审批记录的结果，同意和拒绝与constant.GroupResponseAgree/GroupResponseRefuse取值一致
+const (
+	GroupRequestDecisionApprove = 1
+	GroupRequestDecisionReject  = -1
+	GroupRequestDecisionExpire  = 2 // 超过有效期被自动拒绝
+)
+
+// Approvals 返回申请已获得的同意人数
+func (g *GroupRequest) Approvals() int {
+	var n int
+	for _, decision := range g.Decisions {
+		if decision.Result == GroupRequestDecisionApprove {
+			n++
+		}
+	}
+	return n
+}
+
+// Decision 返回指定审批人的审批记录，未审批时返回nil
+func (g *GroupRequest) Decision(userID string) *GroupRequestDecision {
+	for _, decision := range g.Decisions {
+		if decision.UserID == userID {
+			return decision
+		}
+	}
+	return nil
+}
+
+// ArchiveTo 将当前申请归档到新申请的历史中，超出MaxGroupRequestHistory时丢弃最早的记录
+func (g *GroupRequest) ArchiveTo(request *GroupRequest) {
+	history := append(g.History, &GroupRequestHistory{
+		HandleResult: g.HandleResult,
+		ReqMsg:       g.ReqMsg,
+		HandledMsg:   g.HandledMsg,
+		ReqTime:      g.ReqTime,
+		HandleUserID: g.HandleUserID,
+		HandledTime:  g.HandledTime,
+		Decisions:    g.Decisions,
+	})
+	if len(history) > MaxGroupRequestHistory {
+		history = history[len(history)-MaxGroupRequestHistory:]
+	}
+	request.History = history
 }
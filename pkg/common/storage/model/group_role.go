@@ -0,0 +1,89 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/openimsdk/protocol/constant"
+)
+
+// 群组权限位
+const (
+	GroupPermKickMember    int64 = 1 << iota // 移除成员
+	GroupPermMuteMember                      // 禁言成员、全员禁言
+	GroupPermEditInfo                        // 修改群资料和群设置
+	GroupPermApproveJoin                     // 审批加群申请、直接邀请入群、管理邀请链接
+	GroupPermPinMessage                      // 置顶消息和公告
+	GroupPermRevokeMessage                   // 撤回他人消息
+	GroupPermAtAll                           // @所有人
+	GroupPermManageChannel                   // 管理频道、在只读频道发言
+
+	GroupPermAll = GroupPermKickMember | GroupPermMuteMember | GroupPermEditInfo | GroupPermApproveJoin |
+		GroupPermPinMessage | GroupPermRevokeMessage | GroupPermAtAll | GroupPermManageChannel
+)
+
+// MaxGroupRoles 每个群组允许创建的自定义角色数
+const MaxGroupRoles = 20
+
+// GroupRole 群组自定义角色，权限在成员RoleLevel对应的内置权限基础上叠加
+type GroupRole struct {
+	RoleID      string `bson:"role_id"`
+	Name        string `bson:"name"`
+	Permissions int64  `bson:"permissions"`
+}
+
+// BuiltinGroupPermissions 返回RoleLevel对应的内置权限
+// 与引入自定义角色前的行为一致：群主拥有全部权限，管理员拥有除@所有人外的管理权限，
+// @所有人仍由群组的AtAllPolicy决定
+func BuiltinGroupPermissions(roleLevel int32, atAllPolicy int32) int64 {
+	switch roleLevel {
+	case constant.GroupOwner:
+		return GroupPermAll
+	case constant.GroupAdmin:
+		perms := GroupPermAll &^ GroupPermAtAll
+		if atAllPolicy != AtAllPolicyOwner {
+			perms |= GroupPermAtAll
+		}
+		return perms
+	default:
+		if atAllPolicy == AtAllPolicyEveryone {
+			return GroupPermAtAll
+		}
+		return 0
+	}
+}
+
+// GroupMemberPermissions 计算成员在群内的有效权限：内置权限叠加自定义角色权限
+// 角色已被删除时只保留内置权限
+func GroupMemberPermissions(group *Group, member *GroupMember) int64 {
+	perms := BuiltinGroupPermissions(member.RoleLevel, group.AtAllPolicy)
+	if member.RoleID == "" {
+		return perms
+	}
+	for _, role := range group.Roles {
+		if role.RoleID == member.RoleID {
+			return perms | (role.Permissions & GroupPermAll)
+		}
+	}
+	return perms
+}
+
+// CanManageGroupMember 判断操作者能否对目标成员执行管理操作（移除、禁言、撤回消息等）
+// 群主可以管理除自己外的所有成员，其他成员（管理员或拥有自定义角色的普通成员）只能管理普通成员
+func CanManageGroupMember(opRoleLevel int32, targetRoleLevel int32) bool {
+	if opRoleLevel == constant.GroupOwner {
+		return targetRoleLevel != constant.GroupOwner
+	}
+	return targetRoleLevel == constant.GroupOrdinaryUsers
+}
@@ -0,0 +1,69 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// 消息模板的载荷类型
+const (
+	MsgTemplatePayloadText   = "text"   // 纯文本，渲染为Text消息
+	MsgTemplatePayloadCard   = "card"   // 图文卡片，渲染为Custom消息
+	MsgTemplatePayloadButton = "button" // 带按钮的卡片，渲染为Custom消息
+)
+
+// 消息模板参数类型
+const (
+	MsgTemplateParamString = "string"
+	MsgTemplateParamNumber = "number"
+	MsgTemplateParamURL    = "url"
+)
+
+// MsgTemplate 服务端消息模板，内容中以{{name}}引用参数，按语言提供多个版本
+type MsgTemplate struct {
+	TemplateID    string                         `bson:"template_id"`
+	Name          string                         `bson:"name"`
+	PayloadType   string                         `bson:"payload_type"`
+	Params        []*MsgTemplateParam            `bson:"params"`
+	DefaultLocale string                         `bson:"default_locale"`
+	Locales       map[string]*MsgTemplateContent `bson:"locales"`
+	Ex            string                         `bson:"ex"`
+	CreateTime    time.Time                      `bson:"create_time"`
+	UpdateTime    time.Time                      `bson:"update_time"`
+}
+
+// MsgTemplateParam 模板参数声明
+type MsgTemplateParam struct {
+	Name     string `bson:"name"`
+	Type     string `bson:"type"`
+	Required bool   `bson:"required"`
+}
+
+// MsgTemplateContent 某一语言下的模板内容，文本模板只使用Text
+type MsgTemplateContent struct {
+	Title    string               `bson:"title"`
+	Text     string               `bson:"text"`
+	ImageURL string               `bson:"image_url"`
+	URL      string               `bson:"url"`
+	Buttons  []*MsgTemplateButton `bson:"buttons"`
+}
+
+// MsgTemplateButton 卡片按钮
+type MsgTemplateButton struct {
+	Text   string `bson:"text"`
+	Action string `bson:"action"`
+	URL    string `bson:"url"`
+}
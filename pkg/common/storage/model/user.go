@@ -26,6 +26,21 @@ type User struct {
 	AppMangerLevel   int32     `bson:"app_manger_level"`
 	GlobalRecvMsgOpt int32     `bson:"global_recv_msg_opt"`
 	CreateTime       time.Time `bson:"create_time"`
+	// 封禁状态，BanTime为零值表示未封禁，BanExpireTime为零值表示永久封禁
+	BanReason         string    `bson:"ban_reason"`
+	BanOperatorUserID string    `bson:"ban_operator_user_id"`
+	BanTime           time.Time `bson:"ban_time"`
+	BanExpireTime     time.Time `bson:"ban_expire_time"`
+	// Profile 从Ex中按资料字段定义提取的类型化取值，用于搜索，Ex仍是客户端读取的原始内容
+	Profile map[string]any `bson:"profile,omitempty"`
+}
+
+// IsBanned 用户在now时是否处于封禁中，到期后自动解除
+func (u *User) IsBanned(now time.Time) bool {
+	if u.BanTime.IsZero() {
+		return false
+	}
+	return u.BanExpireTime.IsZero() || now.Before(u.BanExpireTime)
 }
 
 func (u *User) GetNickname() string {
@@ -0,0 +1,102 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// 注销任务和步骤的状态
+const (
+	UserErasureStatusPending   int32 = 0 // 未执行
+	UserErasureStatusRunning   int32 = 1 // 执行中
+	UserErasureStatusSucceeded int32 = 2 // 已完成
+	UserErasureStatusFailed    int32 = 3 // 执行失败，等待重试
+)
+
+// 注销步骤，按顺序执行，每一步都可以重复执行
+const (
+	UserErasureStepRevokeTokens  = "revoke_tokens"  // 踢下线并使令牌失效
+	UserErasureStepGroups        = "groups"         // 退出群组，群主转让或解散群组
+	UserErasureStepGroupRequests = "group_requests" // 删除入群申请，匿名化作为审批人或邀请人的记录
+	UserErasureStepRelations     = "relations"      // 删除好友、黑名单、好友申请和好友分组
+	UserErasureStepFriendEvents  = "friend_events"  // 删除涉及用户的好友关系事件
+	UserErasureStepConversations = "conversations"  // 删除会话
+	UserErasureStepMsgs          = "msgs"           // 删除seq记录，可选删除发送的消息
+	UserErasureStepObjects       = "objects"        // 删除上传的文件
+	UserErasureStepUser          = "user"           // 删除用户资料和通讯录标识
+)
+
+// UserErasureSteps 注销步骤的执行顺序
+var UserErasureSteps = []string{
+	UserErasureStepRevokeTokens,
+	UserErasureStepGroups,
+	UserErasureStepGroupRequests,
+	UserErasureStepRelations,
+	UserErasureStepFriendEvents,
+	UserErasureStepConversations,
+	UserErasureStepMsgs,
+	UserErasureStepObjects,
+	UserErasureStepUser,
+}
+
+// UserErasureStep 注销步骤的执行结果
+type UserErasureStep struct {
+	Name       string    `bson:"name"`
+	Status     int32     `bson:"status"`
+	Error      string    `bson:"error"`
+	UpdateTime time.Time `bson:"update_time"`
+}
+
+// UserErasure 用户注销任务，每个用户同时只有一个未完成的任务
+// 任务中断或失败后从第一个未完成的步骤继续执行；已完成的任务保留，用户重新注册后可以再次注销
+type UserErasure struct {
+	UserID         string             `bson:"user_id"`
+	DeleteMsgs     bool               `bson:"delete_msgs"`
+	Status         int32              `bson:"status"`
+	Finished       bool               `bson:"finished"` // 任务已完成，未完成的任务按用户唯一
+	Steps          []*UserErasureStep `bson:"steps"`
+	OperatorUserID string             `bson:"operator_user_id"`
+	CreateTime     time.Time          `bson:"create_time"`
+	UpdateTime     time.Time          `bson:"update_time"`
+	FinishTime     time.Time          `bson:"finish_time"`
+}
+
+// NewUserErasure 创建注销任务，全部步骤为未执行状态
+func NewUserErasure(userID string, deleteMsgs bool, operatorUserID string, now time.Time) *UserErasure {
+	steps := make([]*UserErasureStep, 0, len(UserErasureSteps))
+	for _, name := range UserErasureSteps {
+		steps = append(steps, &UserErasureStep{Name: name, Status: UserErasureStatusPending, UpdateTime: now})
+	}
+	return &UserErasure{
+		UserID:         userID,
+		DeleteMsgs:     deleteMsgs,
+		Status:         UserErasureStatusRunning,
+		Steps:          steps,
+		OperatorUserID: operatorUserID,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+}
+
+// Step 返回指定名称的步骤，不存在时返回nil
+func (e *UserErasure) Step(name string) *UserErasureStep {
+	for _, step := range e.Steps {
+		if step.Name == name {
+			return step
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// 用户标识类型
+const (
+	UserIdentifierPhone int32 = 1 // 手机号
+	UserIdentifierEmail int32 = 2 // 邮箱
+)
+
+// UserIdentifier 用户标识索引，用于通讯录匹配
+// 只保存规范化后标识的加盐哈希，不保存明文；每个用户每种类型最多一条
+type UserIdentifier struct {
+	UserID     string    `bson:"user_id"`
+	Type       int32     `bson:"type"`
+	Hash       string    `bson:"hash"`
+	CreateTime time.Time `bson:"create_time"`
+}
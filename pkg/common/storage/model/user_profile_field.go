@@ -0,0 +1,46 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// 自定义资料字段的值类型
+const (
+	UserProfileFieldString int32 = 1 // 字符串
+	UserProfileFieldNumber int32 = 2 // 数字
+	UserProfileFieldBool   int32 = 3 // 布尔
+)
+
+// 自定义资料字段的可见范围
+const (
+	UserProfileVisibilityPublic  int32 = 1 // 所有人可见
+	UserProfileVisibilityFriends int32 = 2 // 仅好友可见
+	UserProfileVisibilityPrivate int32 = 3 // 仅本人和管理员可见
+)
+
+// UserProfileField 管理员定义的自定义资料字段，取值保存在用户Ex的同名键中
+// Searchable为true时在用户集合上为该字段建立索引
+type UserProfileField struct {
+	Name       string `bson:"name"`
+	Type       int32  `bson:"type"`
+	Visibility int32  `bson:"visibility"`
+	Searchable bool   `bson:"searchable"`
+	// Backfilled 存量用户Ex中的取值是否已按当前类型回填到profile，新增字段或修改类型后为false
+	Backfilled bool      `bson:"backfilled"`
+	CreateTime time.Time `bson:"create_time"`
+	UpdateTime time.Time `bson:"update_time"`
+}
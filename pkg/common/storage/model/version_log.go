@@ -17,6 +17,8 @@ const (
 const (
 	VersionGroupChangeID = ""
 	VersionSortChangeID  = "____S_O_R_T_I_D____"
+	// VersionFriendCategoryChangeID 好友版本日志中表示好友分组（创建、重命名、排序、删除）变更的记录ID
+	VersionFriendCategoryChangeID = "____F_R_I_E_N_D_C_A_T_E_G_O_R_Y____"
 )
 
 type VersionLogElem struct {
@@ -24,6 +24,23 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// GroupChannelSeparator 频道群组ID中父群ID与频道ID的分隔符
+// 频道消息以"父群ID#频道ID"作为MsgData.GroupID发送，会话ID为"sg_父群ID#频道ID"，拥有独立的seq和已读状态
+const GroupChannelSeparator = "#"
+
+// GetGroupChannelID 生成频道消息使用的群组ID
+func GetGroupChannelID(groupID, channelID string) string {
+	return groupID + GroupChannelSeparator + channelID
+}
+
+// ParseGroupChannelID 解析消息中的群组ID，返回父群ID和频道ID，普通群消息的channelID为空
+func ParseGroupChannelID(id string) (groupID, channelID string) {
+	if i := strings.Index(id, GroupChannelSeparator); i >= 0 {
+		return id[:i], id[i+len(GroupChannelSeparator):]
+	}
+	return id, ""
+}
+
 func IsGroupConversationID(conversationID string) bool {
 	return strings.HasPrefix(conversationID, "g_") || strings.HasPrefix(conversationID, "sg_")
 }
@@ -0,0 +1,53 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgprocessor
+
+import (
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/utils/datautil"
+)
+
+// ReceiveMentionOnlyMessage 会话消息接收选项：仅被@时通知
+// 与constant.ReceiveMessage等取值共用会话的recv_msg_opt字段，
+// 消息照常接收，但离线推送和未读总数只在被@（含@所有人）时计入
+const ReceiveMentionOnlyMessage = 3
+
+// IsAtAllMsg 判断消息是否@了所有人
+func IsAtAllMsg(msg *sdkws.MsgData) bool {
+	return msg.ContentType == constant.AtText && datautil.Contain(constant.AtAllString, msg.AtUserIDList...)
+}
+
+// GetAtUserIDs 获取消息中@的具体用户（去重，不含@所有人）
+func GetAtUserIDs(msg *sdkws.MsgData) []string {
+	if msg.ContentType != constant.AtText {
+		return nil
+	}
+	userIDs := make([]string, 0, len(msg.AtUserIDList))
+	for _, userID := range msg.AtUserIDList {
+		if userID != constant.AtAllString {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return datautil.Distinct(userIDs)
+}
+
+// IsMentioned 判断用户是否被消息@到（直接@或@所有人）
+func IsMentioned(msg *sdkws.MsgData, userID string) bool {
+	if msg.ContentType != constant.AtText {
+		return false
+	}
+	return datautil.Contain(userID, msg.AtUserIDList...) || datautil.Contain(constant.AtAllString, msg.AtUserIDList...)
+}
@@ -25,6 +25,14 @@ package msgprocessor
 
 import "github.com/openimsdk/protocol/constant"
 
+// SendMsgDedup 标识消息在msg服务登记了发送幂等记录，msgtransfer分配序列号后据此回写seq
+// 该选项为服务端扩展选项，未设置时视为false（与constant中缺省为true的选项不同）
+const SendMsgDedup = "sendMsgDedup"
+
+// HighPriority 标识消息走高优先级通道（独立的Kafka主题和消费者组）
+// 该选项由msg服务根据消息所属的会话设置，只在通知会话的消息上写入，客户端传入的值会被覆盖，未设置时视为false
+const HighPriority = "highPriority"
+
 // Options 消息选项类型，使用map存储各种开关配置
 // 键为选项名称（字符串常量），值为布尔开关
 // 这种设计提供了最大的灵活性，可以动态组合各种选项
@@ -447,6 +455,20 @@ func WithReactionFromCache() OptionsOpt {
 	}
 }
 
+// WithSendMsgDedup 标记消息已登记发送幂等记录
+func WithSendMsgDedup() OptionsOpt {
+	return func(options Options) {
+		options[SendMsgDedup] = true
+	}
+}
+
+// WithHighPriority 设置消息是否走高优先级通道
+func WithHighPriority(b bool) OptionsOpt {
+	return func(options Options) {
+		options[HighPriority] = b
+	}
+}
+
 // ========== 选项查询方法 ==========
 // 以下方法用于查询选项配置状态
 
@@ -538,3 +560,13 @@ func (o Options) IsSenderConversationUpdate() bool {
 func (o Options) IsReactionFromCache() bool {
 	return o.Is(constant.IsReactionFromCache)
 }
+
+// IsSendMsgDedup 是否登记了发送幂等记录，缺省为false
+func (o Options) IsSendMsgDedup() bool {
+	return o[SendMsgDedup]
+}
+
+// IsHighPriority 是否走高优先级通道，缺省为false
+func (o Options) IsHighPriority() bool {
+	return o[HighPriority]
+}
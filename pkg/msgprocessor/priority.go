@@ -0,0 +1,59 @@
+// Copyright © 2023 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgprocessor
+
+import (
+	"github.com/openimsdk/protocol/sdkws"
+)
+
+const (
+	// PriorityLaneNormal 普通消息通道
+	PriorityLaneNormal = "normal"
+	// PriorityLaneHigh 高优先级消息通道
+	PriorityLaneHigh = "high"
+)
+
+// IsHighPriorityConversation 判断会话是否走高优先级通道
+// 通道按会话划分，同一会话的消息始终进入同一通道，保证会话内按顺序处理：
+// 通知会话（n_前缀）中的系统通知对延迟敏感，走高优先级通道；聊天会话中的全部消息（包括信令、输入状态）走普通通道
+func IsHighPriorityConversation(conversationID string) bool {
+	return IsNotification(conversationID)
+}
+
+// SetMsgPriority 根据消息所属的会话设置优先级选项，覆盖客户端传入的值
+// 只在走高优先级通道时写入选项，普通消息不携带该选项
+func SetMsgPriority(msg *sdkws.MsgData) {
+	if !IsNotificationByMsg(msg) {
+		delete(msg.Options, HighPriority)
+		return
+	}
+	if msg.Options == nil {
+		msg.Options = make(map[string]bool)
+	}
+	WithOptions(msg.Options, WithHighPriority(true))
+}
+
+// IsHighPriorityMsg 消息是否走高优先级通道
+func IsHighPriorityMsg(msg *sdkws.MsgData) bool {
+	return Options(msg.GetOptions()).IsHighPriority()
+}
+
+// GetPriorityLane 获取消息所属的优先级通道名称，用于监控指标标签
+func GetPriorityLane(msg *sdkws.MsgData) string {
+	if IsHighPriorityMsg(msg) {
+		return PriorityLaneHigh
+	}
+	return PriorityLaneNormal
+}
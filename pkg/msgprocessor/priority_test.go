@@ -0,0 +1,38 @@
+package msgprocessor
+
+import (
+	"testing"
+
+	"github.com/openimsdk/protocol/constant"
+	"github.com/openimsdk/protocol/sdkws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHighPriorityConversation(t *testing.T) {
+	assert.True(t, IsHighPriorityConversation("n_g1"))
+	assert.False(t, IsHighPriorityConversation("g_g1"))
+	assert.False(t, IsHighPriorityConversation("si_u1_u2"))
+}
+
+func TestSetMsgPriority(t *testing.T) {
+	// 聊天会话中的消息走普通通道，客户端传入的值被移除
+	msg := &sdkws.MsgData{SessionType: constant.SingleChatType, ContentType: constant.Text, Options: map[string]bool{HighPriority: true}}
+	SetMsgPriority(msg)
+	assert.False(t, IsHighPriorityMsg(msg))
+	assert.NotContains(t, msg.Options, HighPriority)
+	assert.Equal(t, PriorityLaneNormal, GetPriorityLane(msg))
+
+	// 同一聊天会话中的信令和输入状态与普通消息在同一通道，不会越过之前的消息
+	msg = &sdkws.MsgData{SessionType: constant.SingleChatType, ContentType: constant.Typing}
+	SetMsgPriority(msg)
+	assert.False(t, IsHighPriorityMsg(msg))
+	assert.NotContains(t, msg.Options, HighPriority)
+
+	// 通知会话中的消息走高优先级通道
+	msg = &sdkws.MsgData{SessionType: constant.SingleChatType, SendID: "u1", RecvID: "u2", ContentType: constant.FriendAddedNotification,
+		Options: map[string]bool{constant.IsNotNotification: false}}
+	SetMsgPriority(msg)
+	assert.True(t, IsHighPriorityMsg(msg))
+	assert.Equal(t, PriorityLaneHigh, GetPriorityLane(msg))
+	assert.True(t, IsHighPriorityConversation(GetConversationIDByMsg(msg)))
+}
@@ -0,0 +1,45 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgprocessor
+
+// TemplateMsg 引用服务端消息模板的消息内容类型
+// Content为TemplateElem，由msg服务在发送时渲染：文本模板转为Text消息，卡片、按钮模板转为Custom消息
+const TemplateMsg = 130
+
+// TemplateElem 消息模板引用
+// 通知账号消息（OANotification）和业务通知（BusinessNotification）在通知详情的template字段携带，
+// 渲染结果分别写入通知的text/ex和data
+type TemplateElem struct {
+	TemplateID string            `mapstructure:"templateID" json:"templateID"       validate:"required"`
+	Locale     string            `mapstructure:"locale"     json:"locale,omitempty"`
+	Params     map[string]string `mapstructure:"params"     json:"params,omitempty"`
+}
+
+// TemplateCardElem 卡片、按钮模板渲染后的卡片数据，作为Custom消息的data或通知的ex、data
+type TemplateCardElem struct {
+	TemplateID  string            `json:"templateID"`
+	PayloadType string            `json:"payloadType"`
+	Title       string            `json:"title,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	ImageURL    string            `json:"imageURL,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Buttons     []*TemplateButton `json:"buttons,omitempty"`
+}
+
+type TemplateButton struct {
+	Text   string `json:"text"`
+	Action string `json:"action,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
@@ -15,6 +15,8 @@
 package rpccache
 
 import (
+	"encoding/json"
+
 	"github.com/openimsdk/tools/errs"
 	"google.golang.org/protobuf/proto"
 )
@@ -155,3 +157,27 @@ func (cacheProto[V]) Unmarshal(resp []byte, err error) (*V, error) {
 	}
 	return &val, nil
 }
+
+// cacheJson 缓存JSON处理器
+// 用于扩展RPC（rpcext）返回的普通Go结构体，用法与cacheProto一致
+type cacheJson[V any] struct{}
+
+// Marshal 序列化方法
+func (cacheJson[V]) Marshal(resp *V, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resp)
+}
+
+// Unmarshal 反序列化方法
+func (cacheJson[V]) Unmarshal(resp []byte, err error) (*V, error) {
+	if err != nil {
+		return nil, err
+	}
+	var val V
+	if err := json.Unmarshal(resp, &val); err != nil {
+		return nil, errs.WrapMsg(err, "local cache json.Unmarshal error")
+	}
+	return &val, nil
+}
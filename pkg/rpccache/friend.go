@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 	"github.com/openimsdk/protocol/relation"
 
@@ -187,3 +188,41 @@ func (f *FriendLocalCache) isBlack(ctx context.Context, possibleBlackUserID, use
 		return cache.Marshal(f.client.FriendClient.IsBlack(ctx, &relation.IsBlackReq{UserID1: possibleBlackUserID, UserID2: userID}))
 	}, cachekey.GetBlackIDsKey(userID))) // 关联到黑名单ID列表缓存键
 }
+
+// GetBlackScope 获取blockUserID在ownerUserID黑名单中的生效范围
+// 参数:
+//   - ctx: 上下文
+//   - ownerUserID: 黑名单拥有者
+//   - blockUserID: 可能被拉黑的用户ID
+//
+// 返回:
+//   - int32: 黑名单范围位，不在黑名单中时为0
+//   - error: 错误信息
+//
+// 功能:
+//   - 缓存键关联到黑名单ID列表缓存键，加入、移出黑名单或修改范围时一同失效
+func (f *FriendLocalCache) GetBlackScope(ctx context.Context, ownerUserID, blockUserID string) (val int32, err error) {
+	log.ZDebug(ctx, "FriendLocalCache GetBlackScope req", "ownerUserID", ownerUserID, "blockUserID", blockUserID)
+	defer func() {
+		if err == nil {
+			log.ZDebug(ctx, "FriendLocalCache GetBlackScope return", "ownerUserID", ownerUserID, "blockUserID", blockUserID, "value", val)
+		} else {
+			log.ZError(ctx, "FriendLocalCache GetBlackScope return", err, "ownerUserID", ownerUserID, "blockUserID", blockUserID)
+		}
+	}()
+	var cache cacheJson[relationext.CheckBlackScopesResp]
+	res, err := cache.Unmarshal(f.local.GetLink(ctx, cachekey.GetBlackScopeKey(ownerUserID, blockUserID), func(ctx context.Context) ([]byte, error) {
+		log.ZDebug(ctx, "FriendLocalCache GetBlackScope rpc", "ownerUserID", ownerUserID, "blockUserID", blockUserID)
+		return cache.Marshal(f.client.RelationExtClient.CheckBlackScopes(ctx, &relationext.CheckBlackScopesReq{
+			OwnerUserIDs: []string{ownerUserID},
+			BlockUserIDs: []string{blockUserID},
+		}))
+	}, cachekey.GetBlackIDsKey(ownerUserID)))
+	if err != nil {
+		return 0, err
+	}
+	if len(res.Scopes) == 0 {
+		return 0, nil
+	}
+	return res.Scopes[0].Scope, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
 	"github.com/openimsdk/open-im-server/v3/pkg/rpcli"
 	"github.com/openimsdk/protocol/group"
 	"github.com/openimsdk/tools/utils/datautil"
@@ -162,6 +163,107 @@ func (g *GroupLocalCache) GetGroupInfo(ctx context.Context, groupID string) (val
 	}))
 }
 
+// GetGroupSettings 获取群组扩展设置（@提及策略等）
+// 参数:
+//   - ctx: 上下文
+//   - groupID: 群组ID
+//
+// 返回:
+//   - *groupext.GroupSettings: 群组扩展设置
+//   - error: 错误信息
+//
+// 功能:
+//   - 扩展设置保存在群组文档中，缓存关联到群组信息缓存键，群组信息变更时一同失效
+func (g *GroupLocalCache) GetGroupSettings(ctx context.Context, groupID string) (val *groupext.GroupSettings, err error) {
+	log.ZDebug(ctx, "GroupLocalCache GetGroupSettings req", "groupID", groupID)
+	defer func() {
+		if err == nil {
+			log.ZDebug(ctx, "GroupLocalCache GetGroupSettings return", "groupID", groupID, "value", val)
+		} else {
+			log.ZError(ctx, "GroupLocalCache GetGroupSettings return", err, "groupID", groupID)
+		}
+	}()
+	var cache cacheJson[groupext.GroupSettings]
+	return cache.Unmarshal(g.local.GetLink(ctx, cachekey.GetGroupSettingsKey(groupID), func(ctx context.Context) ([]byte, error) {
+		log.ZDebug(ctx, "GroupLocalCache GetGroupSettings rpc", "groupID", groupID)
+		return cache.Marshal(g.client.GetGroupSettings(ctx, groupID))
+	}, cachekey.GetGroupInfoKey(groupID)))
+}
+
+// GetGroupMemberPermissions 获取群成员的有效权限
+// 参数:
+//   - ctx: 上下文
+//   - groupID: 群组ID
+//   - userID: 用户ID
+//
+// 返回:
+//   - *groupext.GetGroupMemberPermissionsResp: 成员角色和有效权限位
+//   - error: 错误信息
+//
+// 功能:
+//   - 有效权限由群组服务根据RoleLevel、自定义角色和群组设置计算
+//   - 缓存同时关联群组信息和群成员信息缓存键，角色定义或成员角色变更时失效
+func (g *GroupLocalCache) GetGroupMemberPermissions(ctx context.Context, groupID, userID string) (val *groupext.GetGroupMemberPermissionsResp, err error) {
+	log.ZDebug(ctx, "GroupLocalCache GetGroupMemberPermissions req", "groupID", groupID, "userID", userID)
+	defer func() {
+		if err == nil {
+			log.ZDebug(ctx, "GroupLocalCache GetGroupMemberPermissions return", "groupID", groupID, "userID", userID, "value", val)
+		} else {
+			log.ZError(ctx, "GroupLocalCache GetGroupMemberPermissions return", err, "groupID", groupID, "userID", userID)
+		}
+	}()
+	var cache cacheJson[groupext.GetGroupMemberPermissionsResp]
+	return cache.Unmarshal(g.local.GetLink(ctx, cachekey.GetGroupMemberPermissionsKey(groupID, userID), func(ctx context.Context) ([]byte, error) {
+		log.ZDebug(ctx, "GroupLocalCache GetGroupMemberPermissions rpc", "groupID", groupID, "userID", userID)
+		return cache.Marshal(g.client.GetGroupMemberPermissions(ctx, groupID, userID))
+	}, cachekey.GetGroupInfoKey(groupID), cachekey.GetGroupMemberInfoKey(groupID, userID)))
+}
+
+// GetGroupChannels 获取群组的全部频道
+// 参数:
+//   - ctx: 上下文
+//   - groupID: 父群ID
+//
+// 返回:
+//   - []*groupext.GroupChannel: 频道列表
+//   - error: 错误信息
+//
+// 功能:
+//   - 缓存关联到群组信息缓存键，频道变更时随群组信息一同失效
+func (g *GroupLocalCache) GetGroupChannels(ctx context.Context, groupID string) (val []*groupext.GroupChannel, err error) {
+	log.ZDebug(ctx, "GroupLocalCache GetGroupChannels req", "groupID", groupID)
+	defer func() {
+		if err == nil {
+			log.ZDebug(ctx, "GroupLocalCache GetGroupChannels return", "groupID", groupID, "value", val)
+		} else {
+			log.ZError(ctx, "GroupLocalCache GetGroupChannels return", err, "groupID", groupID)
+		}
+	}()
+	var cache cacheJson[groupext.GetGroupChannelsResp]
+	resp, err := cache.Unmarshal(g.local.GetLink(ctx, cachekey.GetGroupChannelsKey(groupID), func(ctx context.Context) ([]byte, error) {
+		log.ZDebug(ctx, "GroupLocalCache GetGroupChannels rpc", "groupID", groupID)
+		return cache.Marshal(g.client.GetGroupChannels(ctx, groupID))
+	}, cachekey.GetGroupInfoKey(groupID)))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Channels, nil
+}
+
+// GetGroupChannel 获取群组的单个频道，频道不存在时返回ErrRecordNotFound
+func (g *GroupLocalCache) GetGroupChannel(ctx context.Context, groupID, channelID string) (*groupext.GroupChannel, error) {
+	channels, err := g.GetGroupChannels(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if channel.ChannelID == channelID {
+			return channel, nil
+		}
+	}
+	return nil, errs.ErrRecordNotFound.WrapMsg("group channel not found", "groupID", groupID, "channelID", channelID)
+}
+
 // GetGroupMemberIDs 获取群组成员ID列表
 // 参数:
 //   - ctx: 上下文
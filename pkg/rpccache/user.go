@@ -22,6 +22,7 @@ import (
 	"github.com/openimsdk/open-im-server/v3/pkg/common/config"
 	"github.com/openimsdk/open-im-server/v3/pkg/common/storage/cache/cachekey"
 	"github.com/openimsdk/open-im-server/v3/pkg/localcache"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
 	"github.com/openimsdk/protocol/sdkws"
 	"github.com/openimsdk/protocol/user"
 	"github.com/openimsdk/tools/errs"
@@ -109,6 +110,37 @@ func (u *UserLocalCache) GetUserInfo(ctx context.Context, userID string) (val *s
 	}))
 }
 
+// GetUserBan 获取用户封禁记录
+// 参数:
+//   - ctx: 上下文
+//   - userID: 用户ID
+//
+// 返回:
+//   - *userext.UserBan: 封禁记录，未被封禁时为nil；是否生效需调用方按当前时间通过IsActive判断
+//   - error: 错误信息
+//
+// 功能:
+//   - 封禁字段保存在用户文档中，缓存关联到用户信息缓存键，封禁/解封时一同失效
+func (u *UserLocalCache) GetUserBan(ctx context.Context, userID string) (val *userext.UserBan, err error) {
+	log.ZDebug(ctx, "UserLocalCache GetUserBan req", "userID", userID)
+	defer func() {
+		if err == nil {
+			log.ZDebug(ctx, "UserLocalCache GetUserBan return", "userID", userID, "value", val)
+		} else {
+			log.ZError(ctx, "UserLocalCache GetUserBan return", err, "userID", userID)
+		}
+	}()
+	var cache cacheJson[userext.GetUserBanResp]
+	resp, err := cache.Unmarshal(u.local.GetLink(ctx, cachekey.GetUserBanKey(userID), func(ctx context.Context) ([]byte, error) {
+		log.ZDebug(ctx, "UserLocalCache GetUserBan rpc", "userID", userID)
+		return cache.Marshal(u.client.UserExtClient.GetUserBan(ctx, &userext.GetUserBanReq{UserID: userID}))
+	}, cachekey.GetUserInfoKey(userID)))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ban, nil
+}
+
 // GetUserGlobalMsgRecvOpt 获取用户全局消息接收选项
 // 参数:
 //   - ctx: 上下文
@@ -0,0 +1,86 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conversationext 会话服务扩展RPC定义
+package conversationext
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "openim.conversationext.conversationExt"
+
+// EraseUserConversationsReq 由用户服务在注销用户时调用，删除用户的全部会话
+// 其他用户与该用户的会话不受影响
+type EraseUserConversationsReq struct {
+	UserID string `json:"userID"`
+}
+
+type EraseUserConversationsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// DeleteGroupChannelConversationsReq 由群组服务在删除频道时调用，删除全部成员的频道会话
+type DeleteGroupChannelConversationsReq struct {
+	GroupID    string   `json:"groupID"`
+	ChannelIDs []string `json:"channelIDs"`
+}
+
+type DeleteGroupChannelConversationsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// ConversationExtServer 会话扩展RPC服务端接口
+type ConversationExtServer interface {
+	EraseUserConversations(context.Context, *EraseUserConversationsReq) (*EraseUserConversationsResp, error)
+	DeleteGroupChannelConversations(context.Context, *DeleteGroupChannelConversationsReq) (*DeleteGroupChannelConversationsResp, error)
+}
+
+// ConversationExtClient 会话扩展RPC客户端接口
+type ConversationExtClient interface {
+	EraseUserConversations(ctx context.Context, in *EraseUserConversationsReq, opts ...grpc.CallOption) (*EraseUserConversationsResp, error)
+	DeleteGroupChannelConversations(ctx context.Context, in *DeleteGroupChannelConversationsReq, opts ...grpc.CallOption) (*DeleteGroupChannelConversationsResp, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*ConversationExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		rpcext.Method(serviceName, "EraseUserConversations", ConversationExtServer.EraseUserConversations),
+		rpcext.Method(serviceName, "DeleteGroupChannelConversations", ConversationExtServer.DeleteGroupChannelConversations),
+	},
+}
+
+func RegisterConversationExtServer(s grpc.ServiceRegistrar, srv ConversationExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func NewConversationExtClient(cc grpc.ClientConnInterface) ConversationExtClient {
+	return &conversationExtClient{cc: cc}
+}
+
+type conversationExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *conversationExtClient) EraseUserConversations(ctx context.Context, in *EraseUserConversationsReq, opts ...grpc.CallOption) (*EraseUserConversationsResp, error) {
+	return rpcext.Invoke[EraseUserConversationsReq, EraseUserConversationsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUserConversations"), in, opts...)
+}
+
+func (c *conversationExtClient) DeleteGroupChannelConversations(ctx context.Context, in *DeleteGroupChannelConversationsReq, opts ...grpc.CallOption) (*DeleteGroupChannelConversationsResp, error) {
+	return rpcext.Invoke[DeleteGroupChannelConversationsReq, DeleteGroupChannelConversationsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteGroupChannelConversations"), in, opts...)
+}
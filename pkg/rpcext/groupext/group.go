@@ -0,0 +1,946 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package groupext 群组服务扩展RPC定义
+package groupext
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext"
+	"github.com/openimsdk/protocol/sdkws"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "openim.groupext.groupExt"
+
+// GroupSettings 群组的服务端扩展设置
+type GroupSettings struct {
+	GroupID         string `json:"groupID"`
+	AtAllPolicy     int32  `json:"atAllPolicy"`     // 允许@所有人的成员范围：0所有成员，1群主和管理员，2仅群主
+	MaxAtUserCount  int32  `json:"maxAtUserCount"`  // 单条消息最多@的成员数，0表示使用全局配置
+	SlowModeSeconds int32  `json:"slowModeSeconds"` // 慢速模式：普通成员两次发言的最小间隔（秒），0表示关闭
+	// 定时禁言计划，为nil表示未设置
+	MuteSchedule *GroupMuteSchedule `json:"muteSchedule,omitempty"`
+	// 归档状态：已归档的群组只读，拒绝发送消息和成员、资料变动
+	Archived    bool  `json:"archived"`
+	ArchiveTime int64 `json:"archiveTime"` // 归档时间（毫秒时间戳），未归档时为0
+	// 入群审批策略
+	JoinApprovalMode         int32 `json:"joinApprovalMode"`         // 审批模式：0任一审批人同意即通过，1需joinApprovalCount人同意
+	JoinApprovalCount        int32 `json:"joinApprovalCount"`        // 多人审批模式下通过所需的同意人数
+	JoinRequestExpireSeconds int32 `json:"joinRequestExpireSeconds"` // 待处理申请的有效期（秒），0表示不过期
+}
+
+// GroupMuteSchedule 群组定时禁言计划
+type GroupMuteSchedule struct {
+	Timezone  string             `json:"timezone"`  // IANA时区名，如Asia/Shanghai，为空表示UTC
+	Windows   []*GroupMuteWindow `json:"windows"`   // 每日重复的禁言时段
+	MuteUntil int64              `json:"muteUntil"` // 一次性禁言的结束时间（毫秒时间戳），0表示未设置
+}
+
+// GroupMuteWindow 每日重复的禁言时段，endMinute小于startMinute表示跨越零点
+type GroupMuteWindow struct {
+	StartMinute int32   `json:"startMinute"` // 开始时间，当天零点起的分钟数（0-1439）
+	EndMinute   int32   `json:"endMinute"`   // 结束时间，当天零点起的分钟数（0-1439），不包含
+	Weekdays    []int32 `json:"weekdays"`    // 生效的星期（0为周日），跨零点时段按开始当天计算，为空表示每天
+}
+
+type GetGroupSettingsReq struct {
+	GroupID string `json:"groupID" binding:"required"`
+}
+
+type GetGroupSettingsResp struct {
+	Settings *GroupSettings `json:"settings"`
+}
+
+type SetGroupMentionPolicyReq struct {
+	GroupID        string `json:"groupID" binding:"required"`
+	AtAllPolicy    *int32 `json:"atAllPolicy"`
+	MaxAtUserCount *int32 `json:"maxAtUserCount"`
+}
+
+type SetGroupMentionPolicyResp struct{}
+
+type SetGroupSlowModeReq struct {
+	GroupID         string `json:"groupID" binding:"required"`
+	SlowModeSeconds int32  `json:"slowModeSeconds"`
+}
+
+type SetGroupSlowModeResp struct{}
+
+// SetGroupMuteScheduleReq 整体替换群组的定时禁言计划，windows为空且muteUntil为0时清除计划
+type SetGroupMuteScheduleReq struct {
+	GroupID   string             `json:"groupID" binding:"required"`
+	Timezone  string             `json:"timezone"`
+	Windows   []*GroupMuteWindow `json:"windows"`
+	MuteUntil int64              `json:"muteUntil"`
+}
+
+type SetGroupMuteScheduleResp struct {
+	Muted bool `json:"muted"` // 设置后当前是否处于定时禁言中
+}
+
+// ProcessGroupMuteSchedulesReq 由定时任务调用，检查定时禁言状态切换并发送禁言/解禁通知
+type ProcessGroupMuteSchedulesReq struct{}
+
+type ProcessGroupMuteSchedulesResp struct {
+	MutedGroupIDs   []string `json:"mutedGroupIDs"`   // 本次进入定时禁言的群组
+	UnmutedGroupIDs []string `json:"unmutedGroupIDs"` // 本次解除定时禁言的群组
+}
+
+// GroupInviteLink 群组邀请链接
+type GroupInviteLink struct {
+	Token            string `json:"token"`
+	GroupID          string `json:"groupID"`
+	CreatorUserID    string `json:"creatorUserID"`
+	ExpireTime       int64  `json:"expireTime"` // 毫秒时间戳，0表示永不过期
+	MaxUses          int32  `json:"maxUses"`    // 0表示不限次数
+	UsedCount        int32  `json:"usedCount"`
+	SkipVerification bool   `json:"skipVerification"`
+	Revoked          bool   `json:"revoked"`
+	Ex               string `json:"ex"`
+	CreateTime       int64  `json:"createTime"`
+}
+
+type CreateGroupInviteLinkReq struct {
+	GroupID          string `json:"groupID" binding:"required"`
+	ExpireTime       int64  `json:"expireTime"`
+	MaxUses          int32  `json:"maxUses"`
+	SkipVerification bool   `json:"skipVerification"`
+	Ex               string `json:"ex"`
+}
+
+type CreateGroupInviteLinkResp struct {
+	Link *GroupInviteLink `json:"link"`
+}
+
+type GetGroupInviteLinksReq struct {
+	GroupID string `json:"groupID" binding:"required"`
+}
+
+type GetGroupInviteLinksResp struct {
+	Links []*GroupInviteLink `json:"links"`
+}
+
+type RevokeGroupInviteLinksReq struct {
+	GroupID string   `json:"groupID" binding:"required"`
+	Tokens  []string `json:"tokens" binding:"required"`
+}
+
+type RevokeGroupInviteLinksResp struct{}
+
+type GetGroupInviteLinkInfoReq struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// GetGroupInviteLinkInfoResp 兑换前预览的群组信息
+type GetGroupInviteLinkInfoResp struct {
+	GroupID          string `json:"groupID"`
+	GroupName        string `json:"groupName"`
+	FaceURL          string `json:"faceURL"`
+	MemberCount      uint32 `json:"memberCount"`
+	CreatorUserID    string `json:"creatorUserID"`
+	ExpireTime       int64  `json:"expireTime"`
+	NeedVerification bool   `json:"needVerification"` // 兑换后是否需要管理员审批
+}
+
+type RedeemGroupInviteLinkReq struct {
+	Token      string `json:"token" binding:"required"`
+	JoinSource int32  `json:"joinSource"` // 2邀请链接，4扫描二维码，缺省为2
+	ReqMessage string `json:"reqMessage"`
+	Ex         string `json:"ex"`
+}
+
+type RedeemGroupInviteLinkResp struct {
+	GroupID string `json:"groupID"`
+	Joined  bool   `json:"joined"` // true已直接入群，false已提交加群申请等待审批
+}
+
+// GroupRole 群组自定义角色，Permissions为权限位的组合：
+// 1移除成员，2禁言成员，4修改群资料，8审批加群，16置顶消息，32撤回他人消息，64@所有人
+type GroupRole struct {
+	RoleID      string   `json:"roleID"`
+	Name        string   `json:"name"`
+	Permissions int64    `json:"permissions"`
+	UserIDs     []string `json:"userIDs"` // 拥有该角色的成员
+}
+
+type CreateGroupRoleReq struct {
+	GroupID     string `json:"groupID" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Permissions int64  `json:"permissions"`
+}
+
+type CreateGroupRoleResp struct {
+	Role *GroupRole `json:"role"`
+}
+
+type UpdateGroupRoleReq struct {
+	GroupID     string  `json:"groupID" binding:"required"`
+	RoleID      string  `json:"roleID" binding:"required"`
+	Name        *string `json:"name"`
+	Permissions *int64  `json:"permissions"`
+}
+
+type UpdateGroupRoleResp struct{}
+
+type DeleteGroupRolesReq struct {
+	GroupID string   `json:"groupID" binding:"required"`
+	RoleIDs []string `json:"roleIDs" binding:"required"`
+}
+
+type DeleteGroupRolesResp struct{}
+
+type GetGroupRolesReq struct {
+	GroupID string `json:"groupID" binding:"required"`
+}
+
+type GetGroupRolesResp struct {
+	Roles []*GroupRole `json:"roles"`
+}
+
+// SetGroupMemberRoleReq 为成员设置自定义角色，RoleID为空表示移除角色
+type SetGroupMemberRoleReq struct {
+	GroupID string   `json:"groupID" binding:"required"`
+	UserIDs []string `json:"userIDs" binding:"required"`
+	RoleID  string   `json:"roleID"`
+}
+
+type SetGroupMemberRoleResp struct{}
+
+type GetGroupMemberPermissionsReq struct {
+	GroupID string `json:"groupID" binding:"required"`
+	UserID  string `json:"userID" binding:"required"`
+}
+
+// GetGroupMemberPermissionsResp 成员在群内的有效权限：RoleLevel对应的内置权限叠加自定义角色权限
+type GetGroupMemberPermissionsResp struct {
+	GroupID     string `json:"groupID"`
+	UserID      string `json:"userID"`
+	RoleLevel   int32  `json:"roleLevel"`
+	RoleID      string `json:"roleID"`
+	Permissions int64  `json:"permissions"`
+}
+
+// GroupAnnouncement 群公告
+type GroupAnnouncement struct {
+	AnnouncementID string `json:"announcementID"`
+	GroupID        string `json:"groupID"`
+	Content        string `json:"content"`
+	CreatorUserID  string `json:"creatorUserID"`
+	Pinned         bool   `json:"pinned"`
+	RequireAck     bool   `json:"requireAck"` // 是否需要成员确认
+	Acked          bool   `json:"acked"`      // 当前用户是否已确认
+	Ex             string `json:"ex"`
+	CreateTime     int64  `json:"createTime"`
+}
+
+type CreateGroupAnnouncementReq struct {
+	GroupID    string `json:"groupID" binding:"required"`
+	Content    string `json:"content" binding:"required"`
+	Pinned     bool   `json:"pinned"`
+	RequireAck bool   `json:"requireAck"`
+	Ex         string `json:"ex"`
+}
+
+type CreateGroupAnnouncementResp struct {
+	Announcement *GroupAnnouncement `json:"announcement"`
+}
+
+type GetGroupAnnouncementsReq struct {
+	GroupID    string                   `json:"groupID" binding:"required"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type GetGroupAnnouncementsResp struct {
+	Total         int64                `json:"total"`
+	Announcements []*GroupAnnouncement `json:"announcements"`
+}
+
+type SetGroupAnnouncementPinnedReq struct {
+	GroupID        string `json:"groupID" binding:"required"`
+	AnnouncementID string `json:"announcementID" binding:"required"`
+	Pinned         bool   `json:"pinned"`
+}
+
+type SetGroupAnnouncementPinnedResp struct{}
+
+type DeleteGroupAnnouncementsReq struct {
+	GroupID         string   `json:"groupID" binding:"required"`
+	AnnouncementIDs []string `json:"announcementIDs" binding:"required"`
+}
+
+type DeleteGroupAnnouncementsResp struct{}
+
+type AckGroupAnnouncementReq struct {
+	GroupID        string `json:"groupID" binding:"required"`
+	AnnouncementID string `json:"announcementID" binding:"required"`
+}
+
+type AckGroupAnnouncementResp struct{}
+
+type GroupAnnouncementAck struct {
+	UserID  string `json:"userID"`
+	AckTime int64  `json:"ackTime"`
+}
+
+type GetGroupAnnouncementAcksReq struct {
+	GroupID        string `json:"groupID" binding:"required"`
+	AnnouncementID string `json:"announcementID" binding:"required"`
+}
+
+// GetGroupAnnouncementAcksResp 公告的确认情况，UnackedUserIDs为当前群成员中尚未确认的成员
+type GetGroupAnnouncementAcksResp struct {
+	Acks           []*GroupAnnouncementAck `json:"acks"`
+	UnackedUserIDs []string                `json:"unackedUserIDs"`
+}
+
+type RemindGroupAnnouncementReq struct {
+	GroupID        string `json:"groupID" binding:"required"`
+	AnnouncementID string `json:"announcementID" binding:"required"`
+}
+
+type RemindGroupAnnouncementResp struct {
+	RemindedUserIDs []string `json:"remindedUserIDs"`
+}
+
+// SucceedGroupOwnersReq 为群主账号已删除的群组执行群主继任
+type SucceedGroupOwnersReq struct {
+	GroupIDs []string `json:"groupIDs" binding:"required"`
+}
+
+// GroupOwnerSuccession 单个群组的继任结果，ErrMsg非空表示该群组未处理
+type GroupOwnerSuccession struct {
+	GroupID        string `json:"groupID"`
+	OldOwnerUserID string `json:"oldOwnerUserID"`
+	NewOwnerUserID string `json:"newOwnerUserID"`
+	Dismissed      bool   `json:"dismissed"` // 没有可继任的成员，群组已解散
+	ErrMsg         string `json:"errMsg"`
+}
+
+type SucceedGroupOwnersResp struct {
+	Results []*GroupOwnerSuccession `json:"results"`
+}
+
+// GroupChannel 群组频道，频道消息以ChannelGroupID作为MsgData.GroupID发送
+type GroupChannel struct {
+	GroupID           string `json:"groupID"`
+	ChannelID         string `json:"channelID"`
+	ChannelGroupID    string `json:"channelGroupID"` // 频道消息使用的群组ID，格式为"groupID#channelID"
+	ConversationID    string `json:"conversationID"` // 频道会话ID
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	FaceURL           string `json:"faceURL"`
+	Order             int32  `json:"order"`
+	ReadOnly          bool   `json:"readOnly"`          // 只读频道：仅群主、管理员和拥有管理频道权限的成员可发言
+	DeniedPermissions int64  `json:"deniedPermissions"` // 在父群权限基础上对普通成员收回的权限位
+	CreatorUserID     string `json:"creatorUserID"`
+	Ex                string `json:"ex"`
+	CreateTime        int64  `json:"createTime"`
+}
+
+type CreateGroupChannelReq struct {
+	GroupID           string `json:"groupID" binding:"required"`
+	ChannelID         string `json:"channelID"` // 为空时自动生成
+	Name              string `json:"name" binding:"required"`
+	Description       string `json:"description"`
+	FaceURL           string `json:"faceURL"`
+	Order             int32  `json:"order"`
+	ReadOnly          bool   `json:"readOnly"`
+	DeniedPermissions int64  `json:"deniedPermissions"`
+	Ex                string `json:"ex"`
+}
+
+type CreateGroupChannelResp struct {
+	Channel *GroupChannel `json:"channel"`
+}
+
+// UpdateGroupChannelReq 修改频道，未传的字段不修改
+type UpdateGroupChannelReq struct {
+	GroupID           string  `json:"groupID" binding:"required"`
+	ChannelID         string  `json:"channelID" binding:"required"`
+	Name              *string `json:"name"`
+	Description       *string `json:"description"`
+	FaceURL           *string `json:"faceURL"`
+	Order             *int32  `json:"order"`
+	ReadOnly          *bool   `json:"readOnly"`
+	DeniedPermissions *int64  `json:"deniedPermissions"`
+	Ex                *string `json:"ex"`
+}
+
+type UpdateGroupChannelResp struct{}
+
+type DeleteGroupChannelsReq struct {
+	GroupID    string   `json:"groupID" binding:"required"`
+	ChannelIDs []string `json:"channelIDs" binding:"required"`
+}
+
+type DeleteGroupChannelsResp struct{}
+
+// GetGroupChannelsReq 获取群组频道，channelIDs为空时返回全部频道
+type GetGroupChannelsReq struct {
+	GroupID    string   `json:"groupID" binding:"required"`
+	ChannelIDs []string `json:"channelIDs"`
+}
+
+type GetGroupChannelsResp struct {
+	Channels []*GroupChannel `json:"channels"`
+}
+
+type GetIncrementalGroupChannelsReq struct {
+	GroupID   string `json:"groupID" binding:"required"`
+	VersionID string `json:"versionID"`
+	Version   uint64 `json:"version"`
+}
+
+type GetIncrementalGroupChannelsResp struct {
+	VersionID string          `json:"versionID"`
+	Version   uint64          `json:"version"`
+	Full      bool            `json:"full"`
+	Delete    []string        `json:"delete"`
+	Insert    []*GroupChannel `json:"insert"`
+	Update    []*GroupChannel `json:"update"`
+}
+
+// ImportGroupMember 导入的群成员，userID需为已存在的用户
+type ImportGroupMember struct {
+	UserID        string `json:"userID"`
+	Nickname      string `json:"nickname"`
+	FaceURL       string `json:"faceURL"`
+	RoleLevel     int32  `json:"roleLevel"` // 群主、管理员或普通成员，为0时视为普通成员，每个群组有且只有一个群主
+	RoleID        string `json:"roleID"`    // 自定义角色ID，需在群组的roles中定义
+	JoinTime      int64  `json:"joinTime"`  // 入群时间（毫秒时间戳），为0时使用导入时间
+	InviterUserID string `json:"inviterUserID"`
+	MuteEndTime   int64  `json:"muteEndTime"` // 禁言结束时间（毫秒时间戳），0表示未禁言
+	Ex            string `json:"ex"`
+}
+
+// ImportGroup 导入的群组，externalID为幂等键，重复导入时更新已导入的群组
+type ImportGroup struct {
+	ExternalID        string               `json:"externalID"`
+	GroupID           string               `json:"groupID"` // 新建群组使用的群组ID，为空时自动生成，更新已导入的群组时忽略
+	GroupName         string               `json:"groupName"`
+	FaceURL           string               `json:"faceURL"`
+	Introduction      string               `json:"introduction"`
+	Notification      string               `json:"notification"`
+	Ex                string               `json:"ex"`
+	NeedVerification  int32                `json:"needVerification"`
+	LookMemberInfo    int32                `json:"lookMemberInfo"`
+	ApplyMemberFriend int32                `json:"applyMemberFriend"`
+	Muted             bool                 `json:"muted"`      // 是否全员禁言
+	CreateTime        int64                `json:"createTime"` // 创建时间（毫秒时间戳），为0时使用导入时间
+	Roles             []*GroupRole         `json:"roles"`      // 自定义角色，userIDs忽略，为nil时保留已有角色
+	Members           []*ImportGroupMember `json:"members"`
+}
+
+// ImportGroupResult 单个群组的导入结果，errCode为0表示成功
+type ImportGroupResult struct {
+	Index      int    `json:"index"`
+	ExternalID string `json:"externalID"`
+	GroupID    string `json:"groupID"`
+	Created    bool   `json:"created"` // true为新建，false为更新已导入的群组
+	ErrCode    int    `json:"errCode"`
+	ErrMsg     string `json:"errMsg"`
+}
+
+// ImportGroupsReq 批量导入群组（仅系统管理员），不发送任何通知
+type ImportGroupsReq struct {
+	Groups []*ImportGroup `json:"groups" binding:"required"`
+}
+
+type ImportGroupsResp struct {
+	Results []*ImportGroupResult `json:"results"`
+}
+
+// SearchGroupMembersReq 按条件搜索群成员，未传的条件不参与过滤，使用游标翻页
+type SearchGroupMembersReq struct {
+	GroupID        string   `json:"groupID" binding:"required"`
+	Keyword        string   `json:"keyword"` // 匹配昵称或用户ID，不区分大小写
+	RoleLevels     []int32  `json:"roleLevels"`
+	RoleIDs        []string `json:"roleIDs"`
+	InviterUserIDs []string `json:"inviterUserIDs"`
+	JoinSources    []int32  `json:"joinSources"`
+	JoinTimeBegin  int64    `json:"joinTimeBegin"` // 入群时间下限（毫秒时间戳），包含
+	JoinTimeEnd    int64    `json:"joinTimeEnd"`   // 入群时间上限（毫秒时间戳），不包含
+	Muted          *bool    `json:"muted"`         // true只返回禁言中的成员，false只返回未禁言的成员
+	Ex             string   `json:"ex"`            // 匹配Ex字段的子串
+	SortField      string   `json:"sortField"`     // joinTime、roleLevel、muteEndTime、nickname，默认joinTime
+	SortDesc       bool     `json:"sortDesc"`
+	Cursor         string   `json:"cursor"` // 上一页返回的nextCursor，首页为空
+	Count          int32    `json:"count"`  // 每页数量，默认100，最大500
+}
+
+type SearchGroupMembersResp struct {
+	Total      int64                        `json:"total"` // 符合条件的成员总数
+	Members    []*sdkws.GroupMemberFullInfo `json:"members"`
+	NextCursor string                       `json:"nextCursor"` // 为空表示没有更多数据
+}
+
+// ArchiveGroupReq 归档群组（群主或系统管理员）
+type ArchiveGroupReq struct {
+	GroupID string `json:"groupID" binding:"required"`
+}
+
+type ArchiveGroupResp struct{}
+
+// UnarchiveGroupReq 取消归档群组（群主或系统管理员）
+type UnarchiveGroupReq struct {
+	GroupID string `json:"groupID" binding:"required"`
+}
+
+type UnarchiveGroupResp struct{}
+
+// GetJoinedGroupsReq 分页获取用户加入的群组，与GetJoinedGroupList相同，但可以选择包含已归档的群组
+type GetJoinedGroupsReq struct {
+	FromUserID      string                   `json:"fromUserID" binding:"required"`
+	Pagination      *sdkws.RequestPagination `json:"pagination" binding:"required"`
+	IncludeArchived bool                     `json:"includeArchived"`
+}
+
+type GetJoinedGroupsResp struct {
+	Total  uint32             `json:"total"`
+	Groups []*sdkws.GroupInfo `json:"groups"`
+}
+
+type SetGroupJoinApprovalPolicyReq struct {
+	GroupID                  string `json:"groupID" binding:"required"`
+	JoinApprovalMode         *int32 `json:"joinApprovalMode"`
+	JoinApprovalCount        *int32 `json:"joinApprovalCount"`
+	JoinRequestExpireSeconds *int32 `json:"joinRequestExpireSeconds"`
+}
+
+type SetGroupJoinApprovalPolicyResp struct{}
+
+// GroupApplicationDecision 入群申请的一条审批记录
+type GroupApplicationDecision struct {
+	UserID string `json:"userID"` // 审批人，过期处理时为空
+	Result int32  `json:"result"` // 1同意，-1拒绝，2过期自动拒绝
+	Reason string `json:"reason"`
+	Time   int64  `json:"time"` // 毫秒时间戳
+}
+
+// GroupApplicationAudit 入群申请的审批进度和审批记录
+type GroupApplicationAudit struct {
+	GroupID           string                      `json:"groupID"`
+	UserID            string                      `json:"userID"`
+	HandleResult      int32                       `json:"handleResult"`
+	ReqTime           int64                       `json:"reqTime"`
+	RequiredApprovals int32                       `json:"requiredApprovals"` // 按当前审批策略计算的通过所需同意人数
+	Approvals         int32                       `json:"approvals"`         // 已获得的同意人数
+	Decisions         []*GroupApplicationDecision `json:"decisions"`
+	History           []*GroupApplicationHistory  `json:"history,omitempty"` // 重新申请前的历史申请
+}
+
+// GroupApplicationHistory 重新申请时归档的上一次申请
+type GroupApplicationHistory struct {
+	HandleResult int32                       `json:"handleResult"`
+	ReqMsg       string                      `json:"reqMsg"`
+	HandledMsg   string                      `json:"handledMsg"`
+	ReqTime      int64                       `json:"reqTime"`
+	HandleUserID string                      `json:"handleUserID"`
+	HandledTime  int64                       `json:"handledTime"`
+	Decisions    []*GroupApplicationDecision `json:"decisions"`
+}
+
+// GetGroupApplicationDecisionsReq 获取入群申请的审批进度和审批记录，通常配合get_recv_group_applicationList分页结果使用
+type GetGroupApplicationDecisionsReq struct {
+	GroupID     string   `json:"groupID" binding:"required"`
+	FromUserIDs []string `json:"fromUserIDs" binding:"required"`
+}
+
+type GetGroupApplicationDecisionsResp struct {
+	Applications []*GroupApplicationAudit `json:"applications"`
+}
+
+// GetGroupApplicationsReq 分页获取收到的入群申请，参数与GetGroupApplicationList相同，每条申请附带审批记录
+type GetGroupApplicationsReq struct {
+	Pagination    *sdkws.RequestPagination `json:"pagination" binding:"required"`
+	FromUserID    string                   `json:"fromUserID"`
+	GroupIDs      []string                 `json:"groupIDs"`
+	HandleResults []int32                  `json:"handleResults"`
+}
+
+// GroupApplication 入群申请及其审批进度和审批记录
+type GroupApplication struct {
+	*sdkws.GroupRequest
+	RequiredApprovals int32                       `json:"requiredApprovals"` // 按当前审批策略计算的通过所需同意人数
+	Approvals         int32                       `json:"approvals"`         // 已获得的同意人数
+	Decisions         []*GroupApplicationDecision `json:"decisions"`
+}
+
+type GetGroupApplicationsResp struct {
+	Total         uint32              `json:"total"`
+	GroupRequests []*GroupApplication `json:"groupRequests"`
+}
+
+// ExpireGroupApplicationsReq 由定时任务调用，自动拒绝超过有效期的入群申请
+type ExpireGroupApplicationsReq struct{}
+
+type ExpireGroupApplicationsResp struct {
+	ExpiredCount int64 `json:"expiredCount"`
+}
+
+// GetSharedGroupCountsReq 统计与用户有共同群组的成员及共同群组数，用于好友推荐
+type GetSharedGroupCountsReq struct {
+	UserID         string   `json:"userID" binding:"required"`
+	ExcludeUserIDs []string `json:"excludeUserIDs"` // 不参与统计的用户，如已有的好友
+	Limit          int32    `json:"limit"`          // 按共同群组数降序返回的最大人数
+}
+
+// SharedGroupCount 与用户的共同群组数
+type SharedGroupCount struct {
+	UserID string `json:"userID"`
+	Count  int64  `json:"count"`
+}
+
+type GetSharedGroupCountsResp struct {
+	Users []*SharedGroupCount `json:"users"`
+}
+
+// EraseUserGroupsReq 由用户服务在注销用户时调用，将用户移出其加入的全部群组
+// 用户是群主时按群主继任规则选出新群主，没有可继任的成员时解散群组；已归档的群组同样处理
+type EraseUserGroupsReq struct {
+	UserID string `json:"userID"`
+}
+
+type EraseUserGroupsResp struct {
+	QuitCount        int64 `json:"quitCount"`        // 作为普通成员或管理员退出的群组数
+	TransferredCount int64 `json:"transferredCount"` // 转让群主后退出的群组数
+	DismissedCount   int64 `json:"dismissedCount"`   // 没有可继任成员而解散的群组数
+}
+
+// EraseUserGroupRequestsReq 由用户服务在注销用户时调用，删除用户提交的入群申请，
+// 其他用户的申请中该用户作为处理人、邀请人和审批人的记录置空；可重复调用
+type EraseUserGroupRequestsReq struct {
+	UserID string `json:"userID"`
+}
+
+type EraseUserGroupRequestsResp struct {
+	DeletedCount    int64 `json:"deletedCount"`    // 删除的申请数
+	AnonymizedCount int64 `json:"anonymizedCount"` // 匿名化的申请数
+}
+
+// GroupExtServer 群组扩展RPC服务端接口
+type GroupExtServer interface {
+	GetGroupSettings(context.Context, *GetGroupSettingsReq) (*GetGroupSettingsResp, error)
+	SetGroupMentionPolicy(context.Context, *SetGroupMentionPolicyReq) (*SetGroupMentionPolicyResp, error)
+	SetGroupSlowMode(context.Context, *SetGroupSlowModeReq) (*SetGroupSlowModeResp, error)
+	SetGroupMuteSchedule(context.Context, *SetGroupMuteScheduleReq) (*SetGroupMuteScheduleResp, error)
+	ProcessGroupMuteSchedules(context.Context, *ProcessGroupMuteSchedulesReq) (*ProcessGroupMuteSchedulesResp, error)
+	CreateGroupInviteLink(context.Context, *CreateGroupInviteLinkReq) (*CreateGroupInviteLinkResp, error)
+	GetGroupInviteLinks(context.Context, *GetGroupInviteLinksReq) (*GetGroupInviteLinksResp, error)
+	RevokeGroupInviteLinks(context.Context, *RevokeGroupInviteLinksReq) (*RevokeGroupInviteLinksResp, error)
+	GetGroupInviteLinkInfo(context.Context, *GetGroupInviteLinkInfoReq) (*GetGroupInviteLinkInfoResp, error)
+	RedeemGroupInviteLink(context.Context, *RedeemGroupInviteLinkReq) (*RedeemGroupInviteLinkResp, error)
+	CreateGroupRole(context.Context, *CreateGroupRoleReq) (*CreateGroupRoleResp, error)
+	UpdateGroupRole(context.Context, *UpdateGroupRoleReq) (*UpdateGroupRoleResp, error)
+	DeleteGroupRoles(context.Context, *DeleteGroupRolesReq) (*DeleteGroupRolesResp, error)
+	GetGroupRoles(context.Context, *GetGroupRolesReq) (*GetGroupRolesResp, error)
+	SetGroupMemberRole(context.Context, *SetGroupMemberRoleReq) (*SetGroupMemberRoleResp, error)
+	GetGroupMemberPermissions(context.Context, *GetGroupMemberPermissionsReq) (*GetGroupMemberPermissionsResp, error)
+	CreateGroupAnnouncement(context.Context, *CreateGroupAnnouncementReq) (*CreateGroupAnnouncementResp, error)
+	GetGroupAnnouncements(context.Context, *GetGroupAnnouncementsReq) (*GetGroupAnnouncementsResp, error)
+	SetGroupAnnouncementPinned(context.Context, *SetGroupAnnouncementPinnedReq) (*SetGroupAnnouncementPinnedResp, error)
+	DeleteGroupAnnouncements(context.Context, *DeleteGroupAnnouncementsReq) (*DeleteGroupAnnouncementsResp, error)
+	AckGroupAnnouncement(context.Context, *AckGroupAnnouncementReq) (*AckGroupAnnouncementResp, error)
+	GetGroupAnnouncementAcks(context.Context, *GetGroupAnnouncementAcksReq) (*GetGroupAnnouncementAcksResp, error)
+	RemindGroupAnnouncement(context.Context, *RemindGroupAnnouncementReq) (*RemindGroupAnnouncementResp, error)
+	SucceedGroupOwners(context.Context, *SucceedGroupOwnersReq) (*SucceedGroupOwnersResp, error)
+	CreateGroupChannel(context.Context, *CreateGroupChannelReq) (*CreateGroupChannelResp, error)
+	UpdateGroupChannel(context.Context, *UpdateGroupChannelReq) (*UpdateGroupChannelResp, error)
+	DeleteGroupChannels(context.Context, *DeleteGroupChannelsReq) (*DeleteGroupChannelsResp, error)
+	GetGroupChannels(context.Context, *GetGroupChannelsReq) (*GetGroupChannelsResp, error)
+	GetIncrementalGroupChannels(context.Context, *GetIncrementalGroupChannelsReq) (*GetIncrementalGroupChannelsResp, error)
+	ImportGroups(context.Context, *ImportGroupsReq) (*ImportGroupsResp, error)
+	SearchGroupMembers(context.Context, *SearchGroupMembersReq) (*SearchGroupMembersResp, error)
+	ArchiveGroup(context.Context, *ArchiveGroupReq) (*ArchiveGroupResp, error)
+	UnarchiveGroup(context.Context, *UnarchiveGroupReq) (*UnarchiveGroupResp, error)
+	GetJoinedGroups(context.Context, *GetJoinedGroupsReq) (*GetJoinedGroupsResp, error)
+	SetGroupJoinApprovalPolicy(context.Context, *SetGroupJoinApprovalPolicyReq) (*SetGroupJoinApprovalPolicyResp, error)
+	GetGroupApplicationDecisions(context.Context, *GetGroupApplicationDecisionsReq) (*GetGroupApplicationDecisionsResp, error)
+	GetGroupApplications(context.Context, *GetGroupApplicationsReq) (*GetGroupApplicationsResp, error)
+	ExpireGroupApplications(context.Context, *ExpireGroupApplicationsReq) (*ExpireGroupApplicationsResp, error)
+	GetSharedGroupCounts(context.Context, *GetSharedGroupCountsReq) (*GetSharedGroupCountsResp, error)
+	EraseUserGroups(context.Context, *EraseUserGroupsReq) (*EraseUserGroupsResp, error)
+	EraseUserGroupRequests(context.Context, *EraseUserGroupRequestsReq) (*EraseUserGroupRequestsResp, error)
+}
+
+// GroupExtClient 群组扩展RPC客户端接口
+type GroupExtClient interface {
+	GetGroupSettings(ctx context.Context, in *GetGroupSettingsReq, opts ...grpc.CallOption) (*GetGroupSettingsResp, error)
+	SetGroupMentionPolicy(ctx context.Context, in *SetGroupMentionPolicyReq, opts ...grpc.CallOption) (*SetGroupMentionPolicyResp, error)
+	SetGroupSlowMode(ctx context.Context, in *SetGroupSlowModeReq, opts ...grpc.CallOption) (*SetGroupSlowModeResp, error)
+	SetGroupMuteSchedule(ctx context.Context, in *SetGroupMuteScheduleReq, opts ...grpc.CallOption) (*SetGroupMuteScheduleResp, error)
+	ProcessGroupMuteSchedules(ctx context.Context, in *ProcessGroupMuteSchedulesReq, opts ...grpc.CallOption) (*ProcessGroupMuteSchedulesResp, error)
+	CreateGroupInviteLink(ctx context.Context, in *CreateGroupInviteLinkReq, opts ...grpc.CallOption) (*CreateGroupInviteLinkResp, error)
+	GetGroupInviteLinks(ctx context.Context, in *GetGroupInviteLinksReq, opts ...grpc.CallOption) (*GetGroupInviteLinksResp, error)
+	RevokeGroupInviteLinks(ctx context.Context, in *RevokeGroupInviteLinksReq, opts ...grpc.CallOption) (*RevokeGroupInviteLinksResp, error)
+	GetGroupInviteLinkInfo(ctx context.Context, in *GetGroupInviteLinkInfoReq, opts ...grpc.CallOption) (*GetGroupInviteLinkInfoResp, error)
+	RedeemGroupInviteLink(ctx context.Context, in *RedeemGroupInviteLinkReq, opts ...grpc.CallOption) (*RedeemGroupInviteLinkResp, error)
+	CreateGroupRole(ctx context.Context, in *CreateGroupRoleReq, opts ...grpc.CallOption) (*CreateGroupRoleResp, error)
+	UpdateGroupRole(ctx context.Context, in *UpdateGroupRoleReq, opts ...grpc.CallOption) (*UpdateGroupRoleResp, error)
+	DeleteGroupRoles(ctx context.Context, in *DeleteGroupRolesReq, opts ...grpc.CallOption) (*DeleteGroupRolesResp, error)
+	GetGroupRoles(ctx context.Context, in *GetGroupRolesReq, opts ...grpc.CallOption) (*GetGroupRolesResp, error)
+	SetGroupMemberRole(ctx context.Context, in *SetGroupMemberRoleReq, opts ...grpc.CallOption) (*SetGroupMemberRoleResp, error)
+	GetGroupMemberPermissions(ctx context.Context, in *GetGroupMemberPermissionsReq, opts ...grpc.CallOption) (*GetGroupMemberPermissionsResp, error)
+	CreateGroupAnnouncement(ctx context.Context, in *CreateGroupAnnouncementReq, opts ...grpc.CallOption) (*CreateGroupAnnouncementResp, error)
+	GetGroupAnnouncements(ctx context.Context, in *GetGroupAnnouncementsReq, opts ...grpc.CallOption) (*GetGroupAnnouncementsResp, error)
+	SetGroupAnnouncementPinned(ctx context.Context, in *SetGroupAnnouncementPinnedReq, opts ...grpc.CallOption) (*SetGroupAnnouncementPinnedResp, error)
+	DeleteGroupAnnouncements(ctx context.Context, in *DeleteGroupAnnouncementsReq, opts ...grpc.CallOption) (*DeleteGroupAnnouncementsResp, error)
+	AckGroupAnnouncement(ctx context.Context, in *AckGroupAnnouncementReq, opts ...grpc.CallOption) (*AckGroupAnnouncementResp, error)
+	GetGroupAnnouncementAcks(ctx context.Context, in *GetGroupAnnouncementAcksReq, opts ...grpc.CallOption) (*GetGroupAnnouncementAcksResp, error)
+	RemindGroupAnnouncement(ctx context.Context, in *RemindGroupAnnouncementReq, opts ...grpc.CallOption) (*RemindGroupAnnouncementResp, error)
+	SucceedGroupOwners(ctx context.Context, in *SucceedGroupOwnersReq, opts ...grpc.CallOption) (*SucceedGroupOwnersResp, error)
+	CreateGroupChannel(ctx context.Context, in *CreateGroupChannelReq, opts ...grpc.CallOption) (*CreateGroupChannelResp, error)
+	UpdateGroupChannel(ctx context.Context, in *UpdateGroupChannelReq, opts ...grpc.CallOption) (*UpdateGroupChannelResp, error)
+	DeleteGroupChannels(ctx context.Context, in *DeleteGroupChannelsReq, opts ...grpc.CallOption) (*DeleteGroupChannelsResp, error)
+	GetGroupChannels(ctx context.Context, in *GetGroupChannelsReq, opts ...grpc.CallOption) (*GetGroupChannelsResp, error)
+	GetIncrementalGroupChannels(ctx context.Context, in *GetIncrementalGroupChannelsReq, opts ...grpc.CallOption) (*GetIncrementalGroupChannelsResp, error)
+	ImportGroups(ctx context.Context, in *ImportGroupsReq, opts ...grpc.CallOption) (*ImportGroupsResp, error)
+	SearchGroupMembers(ctx context.Context, in *SearchGroupMembersReq, opts ...grpc.CallOption) (*SearchGroupMembersResp, error)
+	ArchiveGroup(ctx context.Context, in *ArchiveGroupReq, opts ...grpc.CallOption) (*ArchiveGroupResp, error)
+	UnarchiveGroup(ctx context.Context, in *UnarchiveGroupReq, opts ...grpc.CallOption) (*UnarchiveGroupResp, error)
+	GetJoinedGroups(ctx context.Context, in *GetJoinedGroupsReq, opts ...grpc.CallOption) (*GetJoinedGroupsResp, error)
+	SetGroupJoinApprovalPolicy(ctx context.Context, in *SetGroupJoinApprovalPolicyReq, opts ...grpc.CallOption) (*SetGroupJoinApprovalPolicyResp, error)
+	GetGroupApplicationDecisions(ctx context.Context, in *GetGroupApplicationDecisionsReq, opts ...grpc.CallOption) (*GetGroupApplicationDecisionsResp, error)
+	GetGroupApplications(ctx context.Context, in *GetGroupApplicationsReq, opts ...grpc.CallOption) (*GetGroupApplicationsResp, error)
+	ExpireGroupApplications(ctx context.Context, in *ExpireGroupApplicationsReq, opts ...grpc.CallOption) (*ExpireGroupApplicationsResp, error)
+	GetSharedGroupCounts(ctx context.Context, in *GetSharedGroupCountsReq, opts ...grpc.CallOption) (*GetSharedGroupCountsResp, error)
+	EraseUserGroups(ctx context.Context, in *EraseUserGroupsReq, opts ...grpc.CallOption) (*EraseUserGroupsResp, error)
+	EraseUserGroupRequests(ctx context.Context, in *EraseUserGroupRequestsReq, opts ...grpc.CallOption) (*EraseUserGroupRequestsResp, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*GroupExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		rpcext.Method(serviceName, "GetGroupSettings", GroupExtServer.GetGroupSettings),
+		rpcext.Method(serviceName, "SetGroupMentionPolicy", GroupExtServer.SetGroupMentionPolicy),
+		rpcext.Method(serviceName, "SetGroupSlowMode", GroupExtServer.SetGroupSlowMode),
+		rpcext.Method(serviceName, "SetGroupMuteSchedule", GroupExtServer.SetGroupMuteSchedule),
+		rpcext.Method(serviceName, "ProcessGroupMuteSchedules", GroupExtServer.ProcessGroupMuteSchedules),
+		rpcext.Method(serviceName, "CreateGroupInviteLink", GroupExtServer.CreateGroupInviteLink),
+		rpcext.Method(serviceName, "GetGroupInviteLinks", GroupExtServer.GetGroupInviteLinks),
+		rpcext.Method(serviceName, "RevokeGroupInviteLinks", GroupExtServer.RevokeGroupInviteLinks),
+		rpcext.Method(serviceName, "GetGroupInviteLinkInfo", GroupExtServer.GetGroupInviteLinkInfo),
+		rpcext.Method(serviceName, "RedeemGroupInviteLink", GroupExtServer.RedeemGroupInviteLink),
+		rpcext.Method(serviceName, "CreateGroupRole", GroupExtServer.CreateGroupRole),
+		rpcext.Method(serviceName, "UpdateGroupRole", GroupExtServer.UpdateGroupRole),
+		rpcext.Method(serviceName, "DeleteGroupRoles", GroupExtServer.DeleteGroupRoles),
+		rpcext.Method(serviceName, "GetGroupRoles", GroupExtServer.GetGroupRoles),
+		rpcext.Method(serviceName, "SetGroupMemberRole", GroupExtServer.SetGroupMemberRole),
+		rpcext.Method(serviceName, "GetGroupMemberPermissions", GroupExtServer.GetGroupMemberPermissions),
+		rpcext.Method(serviceName, "CreateGroupAnnouncement", GroupExtServer.CreateGroupAnnouncement),
+		rpcext.Method(serviceName, "GetGroupAnnouncements", GroupExtServer.GetGroupAnnouncements),
+		rpcext.Method(serviceName, "SetGroupAnnouncementPinned", GroupExtServer.SetGroupAnnouncementPinned),
+		rpcext.Method(serviceName, "DeleteGroupAnnouncements", GroupExtServer.DeleteGroupAnnouncements),
+		rpcext.Method(serviceName, "AckGroupAnnouncement", GroupExtServer.AckGroupAnnouncement),
+		rpcext.Method(serviceName, "GetGroupAnnouncementAcks", GroupExtServer.GetGroupAnnouncementAcks),
+		rpcext.Method(serviceName, "RemindGroupAnnouncement", GroupExtServer.RemindGroupAnnouncement),
+		rpcext.Method(serviceName, "SucceedGroupOwners", GroupExtServer.SucceedGroupOwners),
+		rpcext.Method(serviceName, "CreateGroupChannel", GroupExtServer.CreateGroupChannel),
+		rpcext.Method(serviceName, "UpdateGroupChannel", GroupExtServer.UpdateGroupChannel),
+		rpcext.Method(serviceName, "DeleteGroupChannels", GroupExtServer.DeleteGroupChannels),
+		rpcext.Method(serviceName, "GetGroupChannels", GroupExtServer.GetGroupChannels),
+		rpcext.Method(serviceName, "GetIncrementalGroupChannels", GroupExtServer.GetIncrementalGroupChannels),
+		rpcext.Method(serviceName, "ImportGroups", GroupExtServer.ImportGroups),
+		rpcext.Method(serviceName, "SearchGroupMembers", GroupExtServer.SearchGroupMembers),
+		rpcext.Method(serviceName, "ArchiveGroup", GroupExtServer.ArchiveGroup),
+		rpcext.Method(serviceName, "UnarchiveGroup", GroupExtServer.UnarchiveGroup),
+		rpcext.Method(serviceName, "GetJoinedGroups", GroupExtServer.GetJoinedGroups),
+		rpcext.Method(serviceName, "SetGroupJoinApprovalPolicy", GroupExtServer.SetGroupJoinApprovalPolicy),
+		rpcext.Method(serviceName, "GetGroupApplicationDecisions", GroupExtServer.GetGroupApplicationDecisions),
+		rpcext.Method(serviceName, "GetGroupApplications", GroupExtServer.GetGroupApplications),
+		rpcext.Method(serviceName, "ExpireGroupApplications", GroupExtServer.ExpireGroupApplications),
+		rpcext.Method(serviceName, "GetSharedGroupCounts", GroupExtServer.GetSharedGroupCounts),
+		rpcext.Method(serviceName, "EraseUserGroups", GroupExtServer.EraseUserGroups),
+		rpcext.Method(serviceName, "EraseUserGroupRequests", GroupExtServer.EraseUserGroupRequests),
+	},
+}
+
+func RegisterGroupExtServer(s grpc.ServiceRegistrar, srv GroupExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func NewGroupExtClient(cc grpc.ClientConnInterface) GroupExtClient {
+	return &groupExtClient{cc: cc}
+}
+
+type groupExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *groupExtClient) GetGroupSettings(ctx context.Context, in *GetGroupSettingsReq, opts ...grpc.CallOption) (*GetGroupSettingsResp, error) {
+	return rpcext.Invoke[GetGroupSettingsReq, GetGroupSettingsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupSettings"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupMentionPolicy(ctx context.Context, in *SetGroupMentionPolicyReq, opts ...grpc.CallOption) (*SetGroupMentionPolicyResp, error) {
+	return rpcext.Invoke[SetGroupMentionPolicyReq, SetGroupMentionPolicyResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetGroupMentionPolicy"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupSlowMode(ctx context.Context, in *SetGroupSlowModeReq, opts ...grpc.CallOption) (*SetGroupSlowModeResp, error) {
+	return rpcext.Invoke[SetGroupSlowModeReq, SetGroupSlowModeResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetGroupSlowMode"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupMuteSchedule(ctx context.Context, in *SetGroupMuteScheduleReq, opts ...grpc.CallOption) (*SetGroupMuteScheduleResp, error) {
+	return rpcext.Invoke[SetGroupMuteScheduleReq, SetGroupMuteScheduleResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetGroupMuteSchedule"), in, opts...)
+}
+
+func (c *groupExtClient) ProcessGroupMuteSchedules(ctx context.Context, in *ProcessGroupMuteSchedulesReq, opts ...grpc.CallOption) (*ProcessGroupMuteSchedulesResp, error) {
+	return rpcext.Invoke[ProcessGroupMuteSchedulesReq, ProcessGroupMuteSchedulesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ProcessGroupMuteSchedules"), in, opts...)
+}
+
+func (c *groupExtClient) CreateGroupInviteLink(ctx context.Context, in *CreateGroupInviteLinkReq, opts ...grpc.CallOption) (*CreateGroupInviteLinkResp, error) {
+	return rpcext.Invoke[CreateGroupInviteLinkReq, CreateGroupInviteLinkResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CreateGroupInviteLink"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupInviteLinks(ctx context.Context, in *GetGroupInviteLinksReq, opts ...grpc.CallOption) (*GetGroupInviteLinksResp, error) {
+	return rpcext.Invoke[GetGroupInviteLinksReq, GetGroupInviteLinksResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupInviteLinks"), in, opts...)
+}
+
+func (c *groupExtClient) RevokeGroupInviteLinks(ctx context.Context, in *RevokeGroupInviteLinksReq, opts ...grpc.CallOption) (*RevokeGroupInviteLinksResp, error) {
+	return rpcext.Invoke[RevokeGroupInviteLinksReq, RevokeGroupInviteLinksResp](ctx, c.cc, rpcext.FullMethod(serviceName, "RevokeGroupInviteLinks"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupInviteLinkInfo(ctx context.Context, in *GetGroupInviteLinkInfoReq, opts ...grpc.CallOption) (*GetGroupInviteLinkInfoResp, error) {
+	return rpcext.Invoke[GetGroupInviteLinkInfoReq, GetGroupInviteLinkInfoResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupInviteLinkInfo"), in, opts...)
+}
+
+func (c *groupExtClient) RedeemGroupInviteLink(ctx context.Context, in *RedeemGroupInviteLinkReq, opts ...grpc.CallOption) (*RedeemGroupInviteLinkResp, error) {
+	return rpcext.Invoke[RedeemGroupInviteLinkReq, RedeemGroupInviteLinkResp](ctx, c.cc, rpcext.FullMethod(serviceName, "RedeemGroupInviteLink"), in, opts...)
+}
+
+func (c *groupExtClient) CreateGroupRole(ctx context.Context, in *CreateGroupRoleReq, opts ...grpc.CallOption) (*CreateGroupRoleResp, error) {
+	return rpcext.Invoke[CreateGroupRoleReq, CreateGroupRoleResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CreateGroupRole"), in, opts...)
+}
+
+func (c *groupExtClient) UpdateGroupRole(ctx context.Context, in *UpdateGroupRoleReq, opts ...grpc.CallOption) (*UpdateGroupRoleResp, error) {
+	return rpcext.Invoke[UpdateGroupRoleReq, UpdateGroupRoleResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UpdateGroupRole"), in, opts...)
+}
+
+func (c *groupExtClient) DeleteGroupRoles(ctx context.Context, in *DeleteGroupRolesReq, opts ...grpc.CallOption) (*DeleteGroupRolesResp, error) {
+	return rpcext.Invoke[DeleteGroupRolesReq, DeleteGroupRolesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteGroupRoles"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupRoles(ctx context.Context, in *GetGroupRolesReq, opts ...grpc.CallOption) (*GetGroupRolesResp, error) {
+	return rpcext.Invoke[GetGroupRolesReq, GetGroupRolesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupRoles"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupMemberRole(ctx context.Context, in *SetGroupMemberRoleReq, opts ...grpc.CallOption) (*SetGroupMemberRoleResp, error) {
+	return rpcext.Invoke[SetGroupMemberRoleReq, SetGroupMemberRoleResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetGroupMemberRole"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupMemberPermissions(ctx context.Context, in *GetGroupMemberPermissionsReq, opts ...grpc.CallOption) (*GetGroupMemberPermissionsResp, error) {
+	return rpcext.Invoke[GetGroupMemberPermissionsReq, GetGroupMemberPermissionsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupMemberPermissions"), in, opts...)
+}
+
+func (c *groupExtClient) CreateGroupAnnouncement(ctx context.Context, in *CreateGroupAnnouncementReq, opts ...grpc.CallOption) (*CreateGroupAnnouncementResp, error) {
+	return rpcext.Invoke[CreateGroupAnnouncementReq, CreateGroupAnnouncementResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CreateGroupAnnouncement"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupAnnouncements(ctx context.Context, in *GetGroupAnnouncementsReq, opts ...grpc.CallOption) (*GetGroupAnnouncementsResp, error) {
+	return rpcext.Invoke[GetGroupAnnouncementsReq, GetGroupAnnouncementsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupAnnouncements"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupAnnouncementPinned(ctx context.Context, in *SetGroupAnnouncementPinnedReq, opts ...grpc.CallOption) (*SetGroupAnnouncementPinnedResp, error) {
+	return rpcext.Invoke[SetGroupAnnouncementPinnedReq, SetGroupAnnouncementPinnedResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetGroupAnnouncementPinned"), in, opts...)
+}
+
+func (c *groupExtClient) DeleteGroupAnnouncements(ctx context.Context, in *DeleteGroupAnnouncementsReq, opts ...grpc.CallOption) (*DeleteGroupAnnouncementsResp, error) {
+	return rpcext.Invoke[DeleteGroupAnnouncementsReq, DeleteGroupAnnouncementsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteGroupAnnouncements"), in, opts...)
+}
+
+func (c *groupExtClient) AckGroupAnnouncement(ctx context.Context, in *AckGroupAnnouncementReq, opts ...grpc.CallOption) (*AckGroupAnnouncementResp, error) {
+	return rpcext.Invoke[AckGroupAnnouncementReq, AckGroupAnnouncementResp](ctx, c.cc, rpcext.FullMethod(serviceName, "AckGroupAnnouncement"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupAnnouncementAcks(ctx context.Context, in *GetGroupAnnouncementAcksReq, opts ...grpc.CallOption) (*GetGroupAnnouncementAcksResp, error) {
+	return rpcext.Invoke[GetGroupAnnouncementAcksReq, GetGroupAnnouncementAcksResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupAnnouncementAcks"), in, opts...)
+}
+
+func (c *groupExtClient) RemindGroupAnnouncement(ctx context.Context, in *RemindGroupAnnouncementReq, opts ...grpc.CallOption) (*RemindGroupAnnouncementResp, error) {
+	return rpcext.Invoke[RemindGroupAnnouncementReq, RemindGroupAnnouncementResp](ctx, c.cc, rpcext.FullMethod(serviceName, "RemindGroupAnnouncement"), in, opts...)
+}
+
+func (c *groupExtClient) SucceedGroupOwners(ctx context.Context, in *SucceedGroupOwnersReq, opts ...grpc.CallOption) (*SucceedGroupOwnersResp, error) {
+	return rpcext.Invoke[SucceedGroupOwnersReq, SucceedGroupOwnersResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SucceedGroupOwners"), in, opts...)
+}
+
+func (c *groupExtClient) CreateGroupChannel(ctx context.Context, in *CreateGroupChannelReq, opts ...grpc.CallOption) (*CreateGroupChannelResp, error) {
+	return rpcext.Invoke[CreateGroupChannelReq, CreateGroupChannelResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CreateGroupChannel"), in, opts...)
+}
+
+func (c *groupExtClient) UpdateGroupChannel(ctx context.Context, in *UpdateGroupChannelReq, opts ...grpc.CallOption) (*UpdateGroupChannelResp, error) {
+	return rpcext.Invoke[UpdateGroupChannelReq, UpdateGroupChannelResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UpdateGroupChannel"), in, opts...)
+}
+
+func (c *groupExtClient) DeleteGroupChannels(ctx context.Context, in *DeleteGroupChannelsReq, opts ...grpc.CallOption) (*DeleteGroupChannelsResp, error) {
+	return rpcext.Invoke[DeleteGroupChannelsReq, DeleteGroupChannelsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteGroupChannels"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupChannels(ctx context.Context, in *GetGroupChannelsReq, opts ...grpc.CallOption) (*GetGroupChannelsResp, error) {
+	return rpcext.Invoke[GetGroupChannelsReq, GetGroupChannelsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupChannels"), in, opts...)
+}
+
+func (c *groupExtClient) GetIncrementalGroupChannels(ctx context.Context, in *GetIncrementalGroupChannelsReq, opts ...grpc.CallOption) (*GetIncrementalGroupChannelsResp, error) {
+	return rpcext.Invoke[GetIncrementalGroupChannelsReq, GetIncrementalGroupChannelsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetIncrementalGroupChannels"), in, opts...)
+}
+
+func (c *groupExtClient) ImportGroups(ctx context.Context, in *ImportGroupsReq, opts ...grpc.CallOption) (*ImportGroupsResp, error) {
+	return rpcext.Invoke[ImportGroupsReq, ImportGroupsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ImportGroups"), in, opts...)
+}
+
+func (c *groupExtClient) SearchGroupMembers(ctx context.Context, in *SearchGroupMembersReq, opts ...grpc.CallOption) (*SearchGroupMembersResp, error) {
+	return rpcext.Invoke[SearchGroupMembersReq, SearchGroupMembersResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SearchGroupMembers"), in, opts...)
+}
+
+func (c *groupExtClient) ArchiveGroup(ctx context.Context, in *ArchiveGroupReq, opts ...grpc.CallOption) (*ArchiveGroupResp, error) {
+	return rpcext.Invoke[ArchiveGroupReq, ArchiveGroupResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ArchiveGroup"), in, opts...)
+}
+
+func (c *groupExtClient) UnarchiveGroup(ctx context.Context, in *UnarchiveGroupReq, opts ...grpc.CallOption) (*UnarchiveGroupResp, error) {
+	return rpcext.Invoke[UnarchiveGroupReq, UnarchiveGroupResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UnarchiveGroup"), in, opts...)
+}
+
+func (c *groupExtClient) GetJoinedGroups(ctx context.Context, in *GetJoinedGroupsReq, opts ...grpc.CallOption) (*GetJoinedGroupsResp, error) {
+	return rpcext.Invoke[GetJoinedGroupsReq, GetJoinedGroupsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetJoinedGroups"), in, opts...)
+}
+
+func (c *groupExtClient) SetGroupJoinApprovalPolicy(ctx context.Context, in *SetGroupJoinApprovalPolicyReq, opts ...grpc.CallOption) (*SetGroupJoinApprovalPolicyResp, error) {
+	return rpcext.Invoke[SetGroupJoinApprovalPolicyReq, SetGroupJoinApprovalPolicyResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetGroupJoinApprovalPolicy"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupApplicationDecisions(ctx context.Context, in *GetGroupApplicationDecisionsReq, opts ...grpc.CallOption) (*GetGroupApplicationDecisionsResp, error) {
+	return rpcext.Invoke[GetGroupApplicationDecisionsReq, GetGroupApplicationDecisionsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupApplicationDecisions"), in, opts...)
+}
+
+func (c *groupExtClient) GetGroupApplications(ctx context.Context, in *GetGroupApplicationsReq, opts ...grpc.CallOption) (*GetGroupApplicationsResp, error) {
+	return rpcext.Invoke[GetGroupApplicationsReq, GetGroupApplicationsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetGroupApplications"), in, opts...)
+}
+
+func (c *groupExtClient) ExpireGroupApplications(ctx context.Context, in *ExpireGroupApplicationsReq, opts ...grpc.CallOption) (*ExpireGroupApplicationsResp, error) {
+	return rpcext.Invoke[ExpireGroupApplicationsReq, ExpireGroupApplicationsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ExpireGroupApplications"), in, opts...)
+}
+
+func (c *groupExtClient) GetSharedGroupCounts(ctx context.Context, in *GetSharedGroupCountsReq, opts ...grpc.CallOption) (*GetSharedGroupCountsResp, error) {
+	return rpcext.Invoke[GetSharedGroupCountsReq, GetSharedGroupCountsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetSharedGroupCounts"), in, opts...)
+}
+
+func (c *groupExtClient) EraseUserGroups(ctx context.Context, in *EraseUserGroupsReq, opts ...grpc.CallOption) (*EraseUserGroupsResp, error) {
+	return rpcext.Invoke[EraseUserGroupsReq, EraseUserGroupsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUserGroups"), in, opts...)
+}
+
+func (c *groupExtClient) EraseUserGroupRequests(ctx context.Context, in *EraseUserGroupRequestsReq, opts ...grpc.CallOption) (*EraseUserGroupRequestsResp, error) {
+	return rpcext.Invoke[EraseUserGroupRequestsReq, EraseUserGroupRequestsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUserGroupRequests"), in, opts...)
+}
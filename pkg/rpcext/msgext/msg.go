@@ -0,0 +1,213 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package msgext 消息服务扩展RPC定义
+package msgext
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext"
+	"github.com/openimsdk/protocol/sdkws"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "openim.msgext.msgExt"
+
+// MsgTemplate 消息模板，内容中以{{name}}引用参数
+type MsgTemplate struct {
+	TemplateID    string                         `json:"templateID" binding:"required"`
+	Name          string                         `json:"name"`
+	PayloadType   string                         `json:"payloadType" binding:"required"` // text、card、button
+	Params        []*MsgTemplateParam            `json:"params"`
+	DefaultLocale string                         `json:"defaultLocale" binding:"required"`
+	Locales       map[string]*MsgTemplateContent `json:"locales" binding:"required"`
+	Ex            string                         `json:"ex"`
+	CreateTime    int64                          `json:"createTime"`
+	UpdateTime    int64                          `json:"updateTime"`
+}
+
+type MsgTemplateParam struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"` // string、number、url，缺省为string
+	Required bool   `json:"required"`
+}
+
+type MsgTemplateContent struct {
+	Title    string               `json:"title"`
+	Text     string               `json:"text"`
+	ImageURL string               `json:"imageURL"`
+	URL      string               `json:"url"`
+	Buttons  []*MsgTemplateButton `json:"buttons"`
+}
+
+type MsgTemplateButton struct {
+	Text   string `json:"text"`
+	Action string `json:"action"`
+	URL    string `json:"url"`
+}
+
+type CreateMsgTemplateReq struct {
+	Template *MsgTemplate `json:"template" binding:"required"`
+}
+
+type CreateMsgTemplateResp struct{}
+
+type UpdateMsgTemplateReq struct {
+	Template *MsgTemplate `json:"template" binding:"required"`
+}
+
+type UpdateMsgTemplateResp struct{}
+
+type DeleteMsgTemplatesReq struct {
+	TemplateIDs []string `json:"templateIDs" binding:"required"`
+}
+
+type DeleteMsgTemplatesResp struct{}
+
+type GetMsgTemplateReq struct {
+	TemplateID string `json:"templateID" binding:"required"`
+}
+
+type GetMsgTemplateResp struct {
+	Template *MsgTemplate `json:"template"`
+}
+
+type SearchMsgTemplatesReq struct {
+	Keyword    string                   `json:"keyword"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type SearchMsgTemplatesResp struct {
+	Total     int64          `json:"total"`
+	Templates []*MsgTemplate `json:"templates"`
+}
+
+type RenderMsgTemplateReq struct {
+	TemplateID string            `json:"templateID" binding:"required"`
+	Locale     string            `json:"locale"`
+	Params     map[string]string `json:"params"`
+}
+
+// RenderMsgTemplateResp 渲染结果，文本模板只返回Text，卡片和按钮模板同时返回Card
+type RenderMsgTemplateResp struct {
+	TemplateID  string              `json:"templateID"`
+	PayloadType string              `json:"payloadType"`
+	Locale      string              `json:"locale"`
+	Text        string              `json:"text"`
+	Card        *MsgTemplateContent `json:"card,omitempty"`
+}
+
+// EraseUserSeqsReq 由用户服务在注销用户时调用，删除用户在各会话中的seq记录
+type EraseUserSeqsReq struct {
+	UserID string `json:"userID"`
+}
+
+type EraseUserSeqsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// DeleteUserSentMsgsReq 物理删除用户发送的单聊和群聊消息，每次最多删除Limit条
+// 调用方在DeletedCount小于Limit之前需要重复调用
+type DeleteUserSentMsgsReq struct {
+	UserID string `json:"userID"`
+	Limit  int32  `json:"limit"`
+}
+
+type DeleteUserSentMsgsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// MsgExtServer 消息扩展RPC服务端接口
+type MsgExtServer interface {
+	CreateMsgTemplate(context.Context, *CreateMsgTemplateReq) (*CreateMsgTemplateResp, error)
+	UpdateMsgTemplate(context.Context, *UpdateMsgTemplateReq) (*UpdateMsgTemplateResp, error)
+	DeleteMsgTemplates(context.Context, *DeleteMsgTemplatesReq) (*DeleteMsgTemplatesResp, error)
+	GetMsgTemplate(context.Context, *GetMsgTemplateReq) (*GetMsgTemplateResp, error)
+	SearchMsgTemplates(context.Context, *SearchMsgTemplatesReq) (*SearchMsgTemplatesResp, error)
+	RenderMsgTemplate(context.Context, *RenderMsgTemplateReq) (*RenderMsgTemplateResp, error)
+	EraseUserSeqs(context.Context, *EraseUserSeqsReq) (*EraseUserSeqsResp, error)
+	DeleteUserSentMsgs(context.Context, *DeleteUserSentMsgsReq) (*DeleteUserSentMsgsResp, error)
+}
+
+// MsgExtClient 消息扩展RPC客户端接口
+type MsgExtClient interface {
+	CreateMsgTemplate(ctx context.Context, in *CreateMsgTemplateReq, opts ...grpc.CallOption) (*CreateMsgTemplateResp, error)
+	UpdateMsgTemplate(ctx context.Context, in *UpdateMsgTemplateReq, opts ...grpc.CallOption) (*UpdateMsgTemplateResp, error)
+	DeleteMsgTemplates(ctx context.Context, in *DeleteMsgTemplatesReq, opts ...grpc.CallOption) (*DeleteMsgTemplatesResp, error)
+	GetMsgTemplate(ctx context.Context, in *GetMsgTemplateReq, opts ...grpc.CallOption) (*GetMsgTemplateResp, error)
+	SearchMsgTemplates(ctx context.Context, in *SearchMsgTemplatesReq, opts ...grpc.CallOption) (*SearchMsgTemplatesResp, error)
+	RenderMsgTemplate(ctx context.Context, in *RenderMsgTemplateReq, opts ...grpc.CallOption) (*RenderMsgTemplateResp, error)
+	EraseUserSeqs(ctx context.Context, in *EraseUserSeqsReq, opts ...grpc.CallOption) (*EraseUserSeqsResp, error)
+	DeleteUserSentMsgs(ctx context.Context, in *DeleteUserSentMsgsReq, opts ...grpc.CallOption) (*DeleteUserSentMsgsResp, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*MsgExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		rpcext.Method(serviceName, "CreateMsgTemplate", MsgExtServer.CreateMsgTemplate),
+		rpcext.Method(serviceName, "UpdateMsgTemplate", MsgExtServer.UpdateMsgTemplate),
+		rpcext.Method(serviceName, "DeleteMsgTemplates", MsgExtServer.DeleteMsgTemplates),
+		rpcext.Method(serviceName, "GetMsgTemplate", MsgExtServer.GetMsgTemplate),
+		rpcext.Method(serviceName, "SearchMsgTemplates", MsgExtServer.SearchMsgTemplates),
+		rpcext.Method(serviceName, "RenderMsgTemplate", MsgExtServer.RenderMsgTemplate),
+		rpcext.Method(serviceName, "EraseUserSeqs", MsgExtServer.EraseUserSeqs),
+		rpcext.Method(serviceName, "DeleteUserSentMsgs", MsgExtServer.DeleteUserSentMsgs),
+	},
+}
+
+func RegisterMsgExtServer(s grpc.ServiceRegistrar, srv MsgExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func NewMsgExtClient(cc grpc.ClientConnInterface) MsgExtClient {
+	return &msgExtClient{cc: cc}
+}
+
+type msgExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *msgExtClient) CreateMsgTemplate(ctx context.Context, in *CreateMsgTemplateReq, opts ...grpc.CallOption) (*CreateMsgTemplateResp, error) {
+	return rpcext.Invoke[CreateMsgTemplateReq, CreateMsgTemplateResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CreateMsgTemplate"), in, opts...)
+}
+
+func (c *msgExtClient) UpdateMsgTemplate(ctx context.Context, in *UpdateMsgTemplateReq, opts ...grpc.CallOption) (*UpdateMsgTemplateResp, error) {
+	return rpcext.Invoke[UpdateMsgTemplateReq, UpdateMsgTemplateResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UpdateMsgTemplate"), in, opts...)
+}
+
+func (c *msgExtClient) DeleteMsgTemplates(ctx context.Context, in *DeleteMsgTemplatesReq, opts ...grpc.CallOption) (*DeleteMsgTemplatesResp, error) {
+	return rpcext.Invoke[DeleteMsgTemplatesReq, DeleteMsgTemplatesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteMsgTemplates"), in, opts...)
+}
+
+func (c *msgExtClient) GetMsgTemplate(ctx context.Context, in *GetMsgTemplateReq, opts ...grpc.CallOption) (*GetMsgTemplateResp, error) {
+	return rpcext.Invoke[GetMsgTemplateReq, GetMsgTemplateResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetMsgTemplate"), in, opts...)
+}
+
+func (c *msgExtClient) SearchMsgTemplates(ctx context.Context, in *SearchMsgTemplatesReq, opts ...grpc.CallOption) (*SearchMsgTemplatesResp, error) {
+	return rpcext.Invoke[SearchMsgTemplatesReq, SearchMsgTemplatesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SearchMsgTemplates"), in, opts...)
+}
+
+func (c *msgExtClient) RenderMsgTemplate(ctx context.Context, in *RenderMsgTemplateReq, opts ...grpc.CallOption) (*RenderMsgTemplateResp, error) {
+	return rpcext.Invoke[RenderMsgTemplateReq, RenderMsgTemplateResp](ctx, c.cc, rpcext.FullMethod(serviceName, "RenderMsgTemplate"), in, opts...)
+}
+
+func (c *msgExtClient) EraseUserSeqs(ctx context.Context, in *EraseUserSeqsReq, opts ...grpc.CallOption) (*EraseUserSeqsResp, error) {
+	return rpcext.Invoke[EraseUserSeqsReq, EraseUserSeqsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUserSeqs"), in, opts...)
+}
+
+func (c *msgExtClient) DeleteUserSentMsgs(ctx context.Context, in *DeleteUserSentMsgsReq, opts ...grpc.CallOption) (*DeleteUserSentMsgsResp, error) {
+	return rpcext.Invoke[DeleteUserSentMsgsReq, DeleteUserSentMsgsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteUserSentMsgs"), in, opts...)
+}
@@ -0,0 +1,394 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package relationext 好友关系服务扩展RPC定义
+package relationext
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext"
+	"github.com/openimsdk/protocol/sdkws"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "openim.relationext.relationExt"
+
+// FriendCategory 好友分组
+type FriendCategory struct {
+	CategoryID    string   `json:"categoryID"`
+	Name          string   `json:"name"`
+	Order         int32    `json:"order"` // 分组列表排序，升序
+	CreateTime    int64    `json:"createTime"`
+	FriendUserIDs []string `json:"friendUserIDs"` // 属于该分组的好友
+}
+
+type CreateFriendCategoryReq struct {
+	OwnerUserID string `json:"ownerUserID" binding:"required"`
+	CategoryID  string `json:"categoryID"` // 为空时由服务端生成
+	Name        string `json:"name" binding:"required"`
+	Order       int32  `json:"order"`
+}
+
+type CreateFriendCategoryResp struct {
+	Category *FriendCategory `json:"category"`
+}
+
+// UpdateFriendCategoryReq 修改好友分组，字段为nil表示不修改
+type UpdateFriendCategoryReq struct {
+	OwnerUserID string  `json:"ownerUserID" binding:"required"`
+	CategoryID  string  `json:"categoryID" binding:"required"`
+	Name        *string `json:"name"`
+	Order       *int32  `json:"order"`
+}
+
+type UpdateFriendCategoryResp struct{}
+
+// SortFriendCategoriesReq 按categoryIDs的顺序重新排列分组，需包含用户的全部分组
+type SortFriendCategoriesReq struct {
+	OwnerUserID string   `json:"ownerUserID" binding:"required"`
+	CategoryIDs []string `json:"categoryIDs" binding:"required"`
+}
+
+type SortFriendCategoriesResp struct{}
+
+type DeleteFriendCategoriesReq struct {
+	OwnerUserID string   `json:"ownerUserID" binding:"required"`
+	CategoryIDs []string `json:"categoryIDs" binding:"required"`
+}
+
+type DeleteFriendCategoriesResp struct{}
+
+// GetFriendCategoriesReq 获取用户的全部好友分组及分组内的好友
+type GetFriendCategoriesReq struct {
+	OwnerUserID string `json:"ownerUserID" binding:"required"`
+}
+
+type GetFriendCategoriesResp struct {
+	Categories []*FriendCategory `json:"categories"`
+	// 好友版本号，与get_incremental_friends的version/versionID对应，客户端据此判断分组是否需要刷新
+	Version   uint64 `json:"version"`
+	VersionID string `json:"versionID"`
+}
+
+// GetIncrementalFriendsWithCategoriesReq 增量同步好友列表，参数与GetIncrementalFriends相同
+type GetIncrementalFriendsWithCategoriesReq struct {
+	UserID    string `json:"userID" binding:"required"`
+	VersionID string `json:"versionID"`
+	Version   uint64 `json:"version"`
+}
+
+// IncrementalFriend 增量同步的好友信息及好友所属的分组
+type IncrementalFriend struct {
+	*sdkws.FriendInfo
+	CategoryIDs []string `json:"categoryIDs"`
+}
+
+// GetIncrementalFriendsWithCategoriesResp 在GetIncrementalFriends的基础上附带好友分组
+// 好友所属分组的变化随好友更新返回；分组本身有变化或全量同步时categoryChanged为true，categories为用户的全部分组
+type GetIncrementalFriendsWithCategoriesResp struct {
+	VersionID       string               `json:"versionID"`
+	Version         uint64               `json:"version"`
+	Full            bool                 `json:"full"`
+	Delete          []string             `json:"delete"`
+	Insert          []*IncrementalFriend `json:"insert"`
+	Update          []*IncrementalFriend `json:"update"`
+	SortVersion     uint64               `json:"sortVersion"`
+	CategoryChanged bool                 `json:"categoryChanged"`
+	Categories      []*FriendCategory    `json:"categories"`
+}
+
+// SetFriendsCategoriesReq 设置好友所属的分组，覆盖好友原有的分组，categoryIDs为空表示移出全部分组
+type SetFriendsCategoriesReq struct {
+	OwnerUserID   string   `json:"ownerUserID" binding:"required"`
+	FriendUserIDs []string `json:"friendUserIDs" binding:"required"`
+	CategoryIDs   []string `json:"categoryIDs"`
+}
+
+type SetFriendsCategoriesResp struct{}
+
+// UpdateFriendCategoryMembersReq 向单个分组加入或移出好友，不影响好友所属的其他分组
+type UpdateFriendCategoryMembersReq struct {
+	OwnerUserID         string   `json:"ownerUserID" binding:"required"`
+	CategoryID          string   `json:"categoryID" binding:"required"`
+	AddFriendUserIDs    []string `json:"addFriendUserIDs"`
+	RemoveFriendUserIDs []string `json:"removeFriendUserIDs"`
+}
+
+type UpdateFriendCategoryMembersResp struct{}
+
+// GetFriendSuggestionsReq 获取“可能认识的人”，按共同好友数和共同群组数排序
+type GetFriendSuggestionsReq struct {
+	UserID string `json:"userID" binding:"required"`
+	Count  int32  `json:"count"` // 返回的最大人数，默认20，最大100
+}
+
+// FriendSuggestion 好友推荐
+type FriendSuggestion struct {
+	UserID            string `json:"userID"`
+	Nickname          string `json:"nickname"`
+	FaceURL           string `json:"faceURL"`
+	MutualFriendCount int64  `json:"mutualFriendCount"` // 共同好友数
+	SharedGroupCount  int64  `json:"sharedGroupCount"`  // 共同群组数
+}
+
+type GetFriendSuggestionsResp struct {
+	Suggestions []*FriendSuggestion `json:"suggestions"`
+}
+
+// BlackScope 黑名单生效范围，Scope为范围位的组合：
+// 1禁止对方发送单聊消息，2对对方隐藏在线状态，4禁止对方发起好友申请，8对对方隐藏自定义资料
+type BlackScope struct {
+	OwnerUserID string `json:"ownerUserID"`
+	BlockUserID string `json:"blockUserID"`
+	Scope       int32  `json:"scope"`
+}
+
+// SetBlackScopeReq 修改黑名单的生效范围，对方需已在黑名单中
+type SetBlackScopeReq struct {
+	OwnerUserID string `json:"ownerUserID" binding:"required"`
+	BlackUserID string `json:"blackUserID" binding:"required"`
+	Scope       int32  `json:"scope"`
+}
+
+type SetBlackScopeResp struct{}
+
+// GetBlackScopesReq 获取用户黑名单的生效范围，blackUserIDs为空时返回全部黑名单
+type GetBlackScopesReq struct {
+	OwnerUserID  string   `json:"ownerUserID" binding:"required"`
+	BlackUserIDs []string `json:"blackUserIDs"`
+}
+
+type GetBlackScopesResp struct {
+	Scopes []*BlackScope `json:"scopes"`
+}
+
+// CheckBlackScopesReq 查询ownerUserIDs中的用户拉黑blockUserIDs中用户的范围
+// 由消息、用户和网关服务在校验黑名单时调用，不对外开放
+type CheckBlackScopesReq struct {
+	OwnerUserIDs []string `json:"ownerUserIDs"`
+	BlockUserIDs []string `json:"blockUserIDs"`
+}
+
+// CheckBlackScopesResp 只返回存在黑名单关系的用户对
+type CheckBlackScopesResp struct {
+	Scopes []*BlackScope `json:"scopes"`
+}
+
+// ExpireFriendRequestsReq 由定时任务调用，将超过有效期的待处理好友申请标记为已过期
+type ExpireFriendRequestsReq struct{}
+
+type ExpireFriendRequestsResp struct {
+	ExpiredCount int64 `json:"expiredCount"`
+}
+
+// FriendEvent 好友关系事件，Type为1成为好友，2删除好友，3加入黑名单，4移出黑名单
+type FriendEvent struct {
+	OwnerUserID    string `json:"ownerUserID"`
+	PeerUserID     string `json:"peerUserID"`
+	Type           int32  `json:"type"`
+	OperatorUserID string `json:"operatorUserID"`
+	Source         string `json:"source"`
+	CreateTime     int64  `json:"createTime"`
+}
+
+// GetFriendEventsReq 按时间倒序查询用户之间的好友关系事件，仅系统管理员可调用
+// peerUserID为空时查询userID参与的全部事件，startTime/endTime为毫秒时间戳，0表示不限制
+type GetFriendEventsReq struct {
+	UserID     string                   `json:"userID" binding:"required"`
+	PeerUserID string                   `json:"peerUserID"`
+	StartTime  int64                    `json:"startTime"`
+	EndTime    int64                    `json:"endTime"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type GetFriendEventsResp struct {
+	Total  int64          `json:"total"`
+	Events []*FriendEvent `json:"events"`
+}
+
+// ClearFriendEventsReq 由定时任务调用，删除发生时间早于beforeTime（毫秒时间戳）的好友关系事件
+type ClearFriendEventsReq struct {
+	BeforeTime int64 `json:"beforeTime"`
+}
+
+type ClearFriendEventsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// EraseUserRelationsReq 由用户服务在注销用户时调用，删除用户双向的好友关系、黑名单、好友申请和好友分组
+// 可重复调用，已删除的数据不会重复处理；删除不记录好友关系事件，已有的事件由EraseUserFriendEvents删除
+type EraseUserRelationsReq struct {
+	UserID string `json:"userID"`
+}
+
+type EraseUserRelationsResp struct {
+	DeletedFriends int64 `json:"deletedFriends"` // 删除的好友关系数，包含两个方向
+	DeletedBlacks  int64 `json:"deletedBlacks"`  // 删除的黑名单记录数，包含两个方向
+}
+
+// EraseUserFriendEventsReq 由用户服务在注销用户时调用，删除用户作为任一方或操作者的好友关系事件
+type EraseUserFriendEventsReq struct {
+	UserID string `json:"userID"`
+}
+
+type EraseUserFriendEventsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// RelationExtServer 好友关系扩展RPC服务端接口
+type RelationExtServer interface {
+	CreateFriendCategory(context.Context, *CreateFriendCategoryReq) (*CreateFriendCategoryResp, error)
+	UpdateFriendCategory(context.Context, *UpdateFriendCategoryReq) (*UpdateFriendCategoryResp, error)
+	SortFriendCategories(context.Context, *SortFriendCategoriesReq) (*SortFriendCategoriesResp, error)
+	DeleteFriendCategories(context.Context, *DeleteFriendCategoriesReq) (*DeleteFriendCategoriesResp, error)
+	GetFriendCategories(context.Context, *GetFriendCategoriesReq) (*GetFriendCategoriesResp, error)
+	GetIncrementalFriendsWithCategories(context.Context, *GetIncrementalFriendsWithCategoriesReq) (*GetIncrementalFriendsWithCategoriesResp, error)
+	SetFriendsCategories(context.Context, *SetFriendsCategoriesReq) (*SetFriendsCategoriesResp, error)
+	UpdateFriendCategoryMembers(context.Context, *UpdateFriendCategoryMembersReq) (*UpdateFriendCategoryMembersResp, error)
+	ExpireFriendRequests(context.Context, *ExpireFriendRequestsReq) (*ExpireFriendRequestsResp, error)
+	GetFriendSuggestions(context.Context, *GetFriendSuggestionsReq) (*GetFriendSuggestionsResp, error)
+	SetBlackScope(context.Context, *SetBlackScopeReq) (*SetBlackScopeResp, error)
+	GetBlackScopes(context.Context, *GetBlackScopesReq) (*GetBlackScopesResp, error)
+	CheckBlackScopes(context.Context, *CheckBlackScopesReq) (*CheckBlackScopesResp, error)
+	GetFriendEvents(context.Context, *GetFriendEventsReq) (*GetFriendEventsResp, error)
+	ClearFriendEvents(context.Context, *ClearFriendEventsReq) (*ClearFriendEventsResp, error)
+	EraseUserRelations(context.Context, *EraseUserRelationsReq) (*EraseUserRelationsResp, error)
+	EraseUserFriendEvents(context.Context, *EraseUserFriendEventsReq) (*EraseUserFriendEventsResp, error)
+}
+
+// RelationExtClient 好友关系扩展RPC客户端接口
+type RelationExtClient interface {
+	CreateFriendCategory(ctx context.Context, in *CreateFriendCategoryReq, opts ...grpc.CallOption) (*CreateFriendCategoryResp, error)
+	UpdateFriendCategory(ctx context.Context, in *UpdateFriendCategoryReq, opts ...grpc.CallOption) (*UpdateFriendCategoryResp, error)
+	SortFriendCategories(ctx context.Context, in *SortFriendCategoriesReq, opts ...grpc.CallOption) (*SortFriendCategoriesResp, error)
+	DeleteFriendCategories(ctx context.Context, in *DeleteFriendCategoriesReq, opts ...grpc.CallOption) (*DeleteFriendCategoriesResp, error)
+	GetFriendCategories(ctx context.Context, in *GetFriendCategoriesReq, opts ...grpc.CallOption) (*GetFriendCategoriesResp, error)
+	GetIncrementalFriendsWithCategories(ctx context.Context, in *GetIncrementalFriendsWithCategoriesReq, opts ...grpc.CallOption) (*GetIncrementalFriendsWithCategoriesResp, error)
+	SetFriendsCategories(ctx context.Context, in *SetFriendsCategoriesReq, opts ...grpc.CallOption) (*SetFriendsCategoriesResp, error)
+	UpdateFriendCategoryMembers(ctx context.Context, in *UpdateFriendCategoryMembersReq, opts ...grpc.CallOption) (*UpdateFriendCategoryMembersResp, error)
+	ExpireFriendRequests(ctx context.Context, in *ExpireFriendRequestsReq, opts ...grpc.CallOption) (*ExpireFriendRequestsResp, error)
+	GetFriendSuggestions(ctx context.Context, in *GetFriendSuggestionsReq, opts ...grpc.CallOption) (*GetFriendSuggestionsResp, error)
+	SetBlackScope(ctx context.Context, in *SetBlackScopeReq, opts ...grpc.CallOption) (*SetBlackScopeResp, error)
+	GetBlackScopes(ctx context.Context, in *GetBlackScopesReq, opts ...grpc.CallOption) (*GetBlackScopesResp, error)
+	CheckBlackScopes(ctx context.Context, in *CheckBlackScopesReq, opts ...grpc.CallOption) (*CheckBlackScopesResp, error)
+	GetFriendEvents(ctx context.Context, in *GetFriendEventsReq, opts ...grpc.CallOption) (*GetFriendEventsResp, error)
+	ClearFriendEvents(ctx context.Context, in *ClearFriendEventsReq, opts ...grpc.CallOption) (*ClearFriendEventsResp, error)
+	EraseUserRelations(ctx context.Context, in *EraseUserRelationsReq, opts ...grpc.CallOption) (*EraseUserRelationsResp, error)
+	EraseUserFriendEvents(ctx context.Context, in *EraseUserFriendEventsReq, opts ...grpc.CallOption) (*EraseUserFriendEventsResp, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*RelationExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		rpcext.Method(serviceName, "CreateFriendCategory", RelationExtServer.CreateFriendCategory),
+		rpcext.Method(serviceName, "UpdateFriendCategory", RelationExtServer.UpdateFriendCategory),
+		rpcext.Method(serviceName, "SortFriendCategories", RelationExtServer.SortFriendCategories),
+		rpcext.Method(serviceName, "DeleteFriendCategories", RelationExtServer.DeleteFriendCategories),
+		rpcext.Method(serviceName, "GetFriendCategories", RelationExtServer.GetFriendCategories),
+		rpcext.Method(serviceName, "GetIncrementalFriendsWithCategories", RelationExtServer.GetIncrementalFriendsWithCategories),
+		rpcext.Method(serviceName, "SetFriendsCategories", RelationExtServer.SetFriendsCategories),
+		rpcext.Method(serviceName, "UpdateFriendCategoryMembers", RelationExtServer.UpdateFriendCategoryMembers),
+		rpcext.Method(serviceName, "ExpireFriendRequests", RelationExtServer.ExpireFriendRequests),
+		rpcext.Method(serviceName, "GetFriendSuggestions", RelationExtServer.GetFriendSuggestions),
+		rpcext.Method(serviceName, "SetBlackScope", RelationExtServer.SetBlackScope),
+		rpcext.Method(serviceName, "GetBlackScopes", RelationExtServer.GetBlackScopes),
+		rpcext.Method(serviceName, "CheckBlackScopes", RelationExtServer.CheckBlackScopes),
+		rpcext.Method(serviceName, "GetFriendEvents", RelationExtServer.GetFriendEvents),
+		rpcext.Method(serviceName, "ClearFriendEvents", RelationExtServer.ClearFriendEvents),
+		rpcext.Method(serviceName, "EraseUserRelations", RelationExtServer.EraseUserRelations),
+		rpcext.Method(serviceName, "EraseUserFriendEvents", RelationExtServer.EraseUserFriendEvents),
+	},
+}
+
+func RegisterRelationExtServer(s grpc.ServiceRegistrar, srv RelationExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func NewRelationExtClient(cc grpc.ClientConnInterface) RelationExtClient {
+	return &relationExtClient{cc: cc}
+}
+
+type relationExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *relationExtClient) CreateFriendCategory(ctx context.Context, in *CreateFriendCategoryReq, opts ...grpc.CallOption) (*CreateFriendCategoryResp, error) {
+	return rpcext.Invoke[CreateFriendCategoryReq, CreateFriendCategoryResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CreateFriendCategory"), in, opts...)
+}
+
+func (c *relationExtClient) UpdateFriendCategory(ctx context.Context, in *UpdateFriendCategoryReq, opts ...grpc.CallOption) (*UpdateFriendCategoryResp, error) {
+	return rpcext.Invoke[UpdateFriendCategoryReq, UpdateFriendCategoryResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UpdateFriendCategory"), in, opts...)
+}
+
+func (c *relationExtClient) SortFriendCategories(ctx context.Context, in *SortFriendCategoriesReq, opts ...grpc.CallOption) (*SortFriendCategoriesResp, error) {
+	return rpcext.Invoke[SortFriendCategoriesReq, SortFriendCategoriesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SortFriendCategories"), in, opts...)
+}
+
+func (c *relationExtClient) DeleteFriendCategories(ctx context.Context, in *DeleteFriendCategoriesReq, opts ...grpc.CallOption) (*DeleteFriendCategoriesResp, error) {
+	return rpcext.Invoke[DeleteFriendCategoriesReq, DeleteFriendCategoriesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteFriendCategories"), in, opts...)
+}
+
+func (c *relationExtClient) GetFriendCategories(ctx context.Context, in *GetFriendCategoriesReq, opts ...grpc.CallOption) (*GetFriendCategoriesResp, error) {
+	return rpcext.Invoke[GetFriendCategoriesReq, GetFriendCategoriesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetFriendCategories"), in, opts...)
+}
+
+func (c *relationExtClient) GetIncrementalFriendsWithCategories(ctx context.Context, in *GetIncrementalFriendsWithCategoriesReq, opts ...grpc.CallOption) (*GetIncrementalFriendsWithCategoriesResp, error) {
+	return rpcext.Invoke[GetIncrementalFriendsWithCategoriesReq, GetIncrementalFriendsWithCategoriesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetIncrementalFriendsWithCategories"), in, opts...)
+}
+
+func (c *relationExtClient) SetFriendsCategories(ctx context.Context, in *SetFriendsCategoriesReq, opts ...grpc.CallOption) (*SetFriendsCategoriesResp, error) {
+	return rpcext.Invoke[SetFriendsCategoriesReq, SetFriendsCategoriesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetFriendsCategories"), in, opts...)
+}
+
+func (c *relationExtClient) UpdateFriendCategoryMembers(ctx context.Context, in *UpdateFriendCategoryMembersReq, opts ...grpc.CallOption) (*UpdateFriendCategoryMembersResp, error) {
+	return rpcext.Invoke[UpdateFriendCategoryMembersReq, UpdateFriendCategoryMembersResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UpdateFriendCategoryMembers"), in, opts...)
+}
+
+func (c *relationExtClient) ExpireFriendRequests(ctx context.Context, in *ExpireFriendRequestsReq, opts ...grpc.CallOption) (*ExpireFriendRequestsResp, error) {
+	return rpcext.Invoke[ExpireFriendRequestsReq, ExpireFriendRequestsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ExpireFriendRequests"), in, opts...)
+}
+
+func (c *relationExtClient) GetFriendSuggestions(ctx context.Context, in *GetFriendSuggestionsReq, opts ...grpc.CallOption) (*GetFriendSuggestionsResp, error) {
+	return rpcext.Invoke[GetFriendSuggestionsReq, GetFriendSuggestionsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetFriendSuggestions"), in, opts...)
+}
+
+func (c *relationExtClient) SetBlackScope(ctx context.Context, in *SetBlackScopeReq, opts ...grpc.CallOption) (*SetBlackScopeResp, error) {
+	return rpcext.Invoke[SetBlackScopeReq, SetBlackScopeResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetBlackScope"), in, opts...)
+}
+
+func (c *relationExtClient) GetBlackScopes(ctx context.Context, in *GetBlackScopesReq, opts ...grpc.CallOption) (*GetBlackScopesResp, error) {
+	return rpcext.Invoke[GetBlackScopesReq, GetBlackScopesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetBlackScopes"), in, opts...)
+}
+
+func (c *relationExtClient) CheckBlackScopes(ctx context.Context, in *CheckBlackScopesReq, opts ...grpc.CallOption) (*CheckBlackScopesResp, error) {
+	return rpcext.Invoke[CheckBlackScopesReq, CheckBlackScopesResp](ctx, c.cc, rpcext.FullMethod(serviceName, "CheckBlackScopes"), in, opts...)
+}
+
+func (c *relationExtClient) GetFriendEvents(ctx context.Context, in *GetFriendEventsReq, opts ...grpc.CallOption) (*GetFriendEventsResp, error) {
+	return rpcext.Invoke[GetFriendEventsReq, GetFriendEventsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetFriendEvents"), in, opts...)
+}
+
+func (c *relationExtClient) ClearFriendEvents(ctx context.Context, in *ClearFriendEventsReq, opts ...grpc.CallOption) (*ClearFriendEventsResp, error) {
+	return rpcext.Invoke[ClearFriendEventsReq, ClearFriendEventsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ClearFriendEvents"), in, opts...)
+}
+
+func (c *relationExtClient) EraseUserRelations(ctx context.Context, in *EraseUserRelationsReq, opts ...grpc.CallOption) (*EraseUserRelationsResp, error) {
+	return rpcext.Invoke[EraseUserRelationsReq, EraseUserRelationsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUserRelations"), in, opts...)
+}
+
+func (c *relationExtClient) EraseUserFriendEvents(ctx context.Context, in *EraseUserFriendEventsReq, opts ...grpc.CallOption) (*EraseUserFriendEventsResp, error) {
+	return rpcext.Invoke[EraseUserFriendEventsReq, EraseUserFriendEventsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUserFriendEvents"), in, opts...)
+}
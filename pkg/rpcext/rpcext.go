@@ -0,0 +1,94 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpcext 服务端扩展RPC
+//
+// protocol仓库中的proto定义由上游统一维护，服务端新增的接口不便直接修改proto。
+// 扩展RPC使用普通Go结构体作为请求/响应，通过JSON编解码在gRPC上传输，
+// 与proto服务注册在同一个gRPC Server上，复用服务发现、拦截器（mw）和a2r网关调用。
+//
+// 各服务的扩展接口定义在子包中（如groupext），服务描述的写法与protoc生成代码保持一致。
+package rpcext
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name 扩展RPC使用的gRPC content-subtype，请求头为application/grpc+json
+const Name = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return Name
+}
+
+// Invoke 以JSON编码调用扩展RPC
+func Invoke[Req, Resp any](ctx context.Context, cc grpc.ClientConnInterface, method string, req *Req, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(Name)}, opts...)
+	if err := cc.Invoke(ctx, method, req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Method 构造扩展RPC的方法描述，等价于protoc生成的_Xxx_Handler
+//   - service: 完整服务名，如openim.groupext.groupExt
+//   - name: 方法名
+//   - call: 服务端实现，通常为接口方法表达式，如GroupExtServer.GetGroupSettings
+func Method[S, Req, Resp any](service string, name string, call func(S, context.Context, *Req) (*Resp, error)) grpc.MethodDesc {
+	fullMethod := FullMethod(service, name)
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(Req)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return call(srv.(S), ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: fullMethod,
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return call(srv.(S), ctx, req.(*Req))
+			}
+			return interceptor(ctx, in, info, handler)
+		},
+	}
+}
+
+// FullMethod 拼接扩展RPC的完整方法名
+func FullMethod(service string, name string) string {
+	return "/" + service + "/" + name
+}
@@ -0,0 +1,59 @@
+package rpcext
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type echoReq struct {
+	Text string `json:"text"`
+}
+
+type echoResp struct {
+	Text string `json:"text"`
+}
+
+type echoServer interface {
+	Echo(context.Context, *echoReq) (*echoResp, error)
+}
+
+type echo struct{}
+
+func (echo) Echo(_ context.Context, req *echoReq) (*echoResp, error) {
+	return &echoResp{Text: req.Text}, nil
+}
+
+func TestInvoke(t *testing.T) {
+	const service = "openim.test.echo"
+	lis := bufconn.Listen(1024 * 1024)
+	var intercepted string
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		intercepted = info.FullMethod
+		return handler(ctx, req)
+	}))
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: service,
+		HandlerType: (*echoServer)(nil),
+		Methods:     []grpc.MethodDesc{Method(service, "Echo", echoServer.Echo)},
+	}, echo{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer cc.Close()
+
+	resp, err := Invoke[echoReq, echoResp](context.Background(), cc, FullMethod(service, "Echo"), &echoReq{Text: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", resp.Text)
+	assert.Equal(t, "/openim.test.echo/Echo", intercepted)
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package thirdext 第三方服务扩展RPC定义
+package thirdext
+
+import (
+	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext"
+	"github.com/openimsdk/protocol/sdkws"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "openim.thirdext.thirdExt"
+
+// DeleteUserObjectsReq 删除用户上传的对象，每次最多删除Limit个
+// 调用方在DeletedCount小于Limit之前需要重复调用
+type DeleteUserObjectsReq struct {
+	UserID string `json:"userID"`
+	Limit  int32  `json:"limit"`
+}
+
+type DeleteUserObjectsResp struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// ApplicationVersion 应用版本
+// Version为语义化版本号（如1.2.0、v2.0.0-beta.1），Latest标记平台当前发布的版本
+type ApplicationVersion struct {
+	ID         string `json:"id"`
+	Platform   string `json:"platform"`
+	Hot        bool   `json:"hot"`
+	Version    string `json:"version"`
+	Url        string `json:"url"`
+	Text       string `json:"text"`
+	Force      bool   `json:"force"`
+	Latest     bool   `json:"latest"`
+	CreateTime int64  `json:"createTime"`
+}
+
+// AddApplicationVersionReq 新增应用版本
+type AddApplicationVersionReq struct {
+	Platform string `json:"platform" binding:"required"`
+	Hot      bool   `json:"hot"`
+	Version  string `json:"version" binding:"required"`
+	Url      string `json:"url" binding:"required"`
+	Text     string `json:"text"`
+	Force    bool   `json:"force"`
+	Latest   bool   `json:"latest"`
+}
+
+type AddApplicationVersionResp struct {
+	Version *ApplicationVersion `json:"version"`
+}
+
+// UpdateApplicationVersionReq 更新应用版本，未设置的字段保持不变
+type UpdateApplicationVersionReq struct {
+	ID       string  `json:"id" binding:"required"`
+	Platform *string `json:"platform"`
+	Hot      *bool   `json:"hot"`
+	Version  *string `json:"version"`
+	Url      *string `json:"url"`
+	Text     *string `json:"text"`
+	Force    *bool   `json:"force"`
+	Latest   *bool   `json:"latest"`
+}
+
+type UpdateApplicationVersionResp struct {
+	Version *ApplicationVersion `json:"version"`
+}
+
+type DeleteApplicationVersionsReq struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+type DeleteApplicationVersionsResp struct{}
+
+// SearchApplicationVersionsReq 按版本号或更新说明搜索，Platforms为空时不限制平台
+type SearchApplicationVersionsReq struct {
+	Keyword    string                   `json:"keyword"`
+	Platforms  []string                 `json:"platforms"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type SearchApplicationVersionsResp struct {
+	Total    int64                 `json:"total"`
+	Versions []*ApplicationVersion `json:"versions"`
+}
+
+// GetLatestApplicationVersionReq 获取平台当前发布的版本
+// Version为客户端当前版本，用于判断是否需要更新和强制更新
+type GetLatestApplicationVersionReq struct {
+	Platform string `json:"platform" binding:"required"`
+	Version  string `json:"version"`
+}
+
+// GetLatestApplicationVersionResp 平台没有发布版本时Version为nil
+// Force在客户端版本与最新版本之间（含最新版本）存在强制更新的版本时为true
+type GetLatestApplicationVersionResp struct {
+	Version   *ApplicationVersion `json:"version"`
+	HasUpdate bool                `json:"hasUpdate"`
+	Force     bool                `json:"force"`
+}
+
+// ThirdExtServer 第三方扩展RPC服务端接口
+type ThirdExtServer interface {
+	DeleteUserObjects(context.Context, *DeleteUserObjectsReq) (*DeleteUserObjectsResp, error)
+	AddApplicationVersion(context.Context, *AddApplicationVersionReq) (*AddApplicationVersionResp, error)
+	UpdateApplicationVersion(context.Context, *UpdateApplicationVersionReq) (*UpdateApplicationVersionResp, error)
+	DeleteApplicationVersions(context.Context, *DeleteApplicationVersionsReq) (*DeleteApplicationVersionsResp, error)
+	SearchApplicationVersions(context.Context, *SearchApplicationVersionsReq) (*SearchApplicationVersionsResp, error)
+	GetLatestApplicationVersion(context.Context, *GetLatestApplicationVersionReq) (*GetLatestApplicationVersionResp, error)
+}
+
+// ThirdExtClient 第三方扩展RPC客户端接口
+type ThirdExtClient interface {
+	DeleteUserObjects(ctx context.Context, in *DeleteUserObjectsReq, opts ...grpc.CallOption) (*DeleteUserObjectsResp, error)
+	AddApplicationVersion(ctx context.Context, in *AddApplicationVersionReq, opts ...grpc.CallOption) (*AddApplicationVersionResp, error)
+	UpdateApplicationVersion(ctx context.Context, in *UpdateApplicationVersionReq, opts ...grpc.CallOption) (*UpdateApplicationVersionResp, error)
+	DeleteApplicationVersions(ctx context.Context, in *DeleteApplicationVersionsReq, opts ...grpc.CallOption) (*DeleteApplicationVersionsResp, error)
+	SearchApplicationVersions(ctx context.Context, in *SearchApplicationVersionsReq, opts ...grpc.CallOption) (*SearchApplicationVersionsResp, error)
+	GetLatestApplicationVersion(ctx context.Context, in *GetLatestApplicationVersionReq, opts ...grpc.CallOption) (*GetLatestApplicationVersionResp, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*ThirdExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		rpcext.Method(serviceName, "DeleteUserObjects", ThirdExtServer.DeleteUserObjects),
+		rpcext.Method(serviceName, "AddApplicationVersion", ThirdExtServer.AddApplicationVersion),
+		rpcext.Method(serviceName, "UpdateApplicationVersion", ThirdExtServer.UpdateApplicationVersion),
+		rpcext.Method(serviceName, "DeleteApplicationVersions", ThirdExtServer.DeleteApplicationVersions),
+		rpcext.Method(serviceName, "SearchApplicationVersions", ThirdExtServer.SearchApplicationVersions),
+		rpcext.Method(serviceName, "GetLatestApplicationVersion", ThirdExtServer.GetLatestApplicationVersion),
+	},
+}
+
+func RegisterThirdExtServer(s grpc.ServiceRegistrar, srv ThirdExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func NewThirdExtClient(cc grpc.ClientConnInterface) ThirdExtClient {
+	return &thirdExtClient{cc: cc}
+}
+
+type thirdExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *thirdExtClient) DeleteUserObjects(ctx context.Context, in *DeleteUserObjectsReq, opts ...grpc.CallOption) (*DeleteUserObjectsResp, error) {
+	return rpcext.Invoke[DeleteUserObjectsReq, DeleteUserObjectsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteUserObjects"), in, opts...)
+}
+
+func (c *thirdExtClient) AddApplicationVersion(ctx context.Context, in *AddApplicationVersionReq, opts ...grpc.CallOption) (*AddApplicationVersionResp, error) {
+	return rpcext.Invoke[AddApplicationVersionReq, AddApplicationVersionResp](ctx, c.cc, rpcext.FullMethod(serviceName, "AddApplicationVersion"), in, opts...)
+}
+
+func (c *thirdExtClient) UpdateApplicationVersion(ctx context.Context, in *UpdateApplicationVersionReq, opts ...grpc.CallOption) (*UpdateApplicationVersionResp, error) {
+	return rpcext.Invoke[UpdateApplicationVersionReq, UpdateApplicationVersionResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UpdateApplicationVersion"), in, opts...)
+}
+
+func (c *thirdExtClient) DeleteApplicationVersions(ctx context.Context, in *DeleteApplicationVersionsReq, opts ...grpc.CallOption) (*DeleteApplicationVersionsResp, error) {
+	return rpcext.Invoke[DeleteApplicationVersionsReq, DeleteApplicationVersionsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteApplicationVersions"), in, opts...)
+}
+
+func (c *thirdExtClient) SearchApplicationVersions(ctx context.Context, in *SearchApplicationVersionsReq, opts ...grpc.CallOption) (*SearchApplicationVersionsResp, error) {
+	return rpcext.Invoke[SearchApplicationVersionsReq, SearchApplicationVersionsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SearchApplicationVersions"), in, opts...)
+}
+
+func (c *thirdExtClient) GetLatestApplicationVersion(ctx context.Context, in *GetLatestApplicationVersionReq, opts ...grpc.CallOption) (*GetLatestApplicationVersionResp, error) {
+	return rpcext.Invoke[GetLatestApplicationVersionReq, GetLatestApplicationVersionResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetLatestApplicationVersion"), in, opts...)
+}
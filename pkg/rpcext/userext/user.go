@@ -0,0 +1,370 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package userext 用户服务扩展RPC定义
+package userext
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext"
+	"github.com/openimsdk/protocol/sdkws"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "openim.userext.userExt"
+
+// GetContactImportConfigReq 获取通讯录匹配的哈希参数
+type GetContactImportConfigReq struct{}
+
+// GetContactImportConfigResp 客户端按hex(sha256(salt + 规范化标识))计算哈希：
+// 手机号只保留数字和开头的+，邮箱去除首尾空白并转为小写
+type GetContactImportConfigResp struct {
+	Enabled bool   `json:"enabled"`
+	Salt    string `json:"salt"`
+}
+
+// MatchContactsReq 提交通讯录中手机号、邮箱的加盐哈希，返回已注册的用户，提交的哈希不保存
+type MatchContactsReq struct {
+	UserID string   `json:"userID" binding:"required"`
+	Hashes []string `json:"hashes" binding:"required"`
+}
+
+// ContactMatch 匹配到的用户，Hash为请求中对应的哈希
+type ContactMatch struct {
+	Hash   string `json:"hash"`
+	UserID string `json:"userID"`
+}
+
+type MatchContactsResp struct {
+	Matches []*ContactMatch `json:"matches"`
+}
+
+// String 日志中只记录哈希的数量，mw拦截器按此格式记录请求，避免通讯录哈希写入日志
+func (x *MatchContactsReq) String() string {
+	return fmt.Sprintf("{userID:%s hashes:%d}", x.UserID, len(x.Hashes))
+}
+
+// String 日志中只记录匹配到的用户，不记录对应的哈希
+func (x *MatchContactsResp) String() string {
+	userIDs := make([]string, 0, len(x.Matches))
+	for _, match := range x.Matches {
+		userIDs = append(userIDs, match.UserID)
+	}
+	return fmt.Sprintf("{matches:%v}", userIDs)
+}
+
+// UserErasureStep 注销步骤，Status取值与任务状态相同，0表示未执行
+type UserErasureStep struct {
+	Name       string `json:"name"`
+	Status     int32  `json:"status"`
+	Error      string `json:"error"`
+	UpdateTime int64  `json:"updateTime"`
+}
+
+// UserErasure 用户注销任务，Status：1执行中、2已完成、3执行失败
+type UserErasure struct {
+	UserID         string             `json:"userID"`
+	DeleteMsgs     bool               `json:"deleteMsgs"`
+	Status         int32              `json:"status"`
+	Steps          []*UserErasureStep `json:"steps"`
+	OperatorUserID string             `json:"operatorUserID"`
+	CreateTime     int64              `json:"createTime"`
+	UpdateTime     int64              `json:"updateTime"`
+	FinishTime     int64              `json:"finishTime"`
+}
+
+// EraseUserReq 创建注销任务，任务在后台执行，DeleteMsgs为true时同时删除用户发送的消息
+type EraseUserReq struct {
+	UserID     string `json:"userID" binding:"required"`
+	DeleteMsgs bool   `json:"deleteMsgs"`
+}
+
+type EraseUserResp struct {
+	Erasure *UserErasure `json:"erasure"`
+}
+
+// ResumeUserErasureReq 从第一个未完成的步骤继续执行失败或中断的注销任务
+type ResumeUserErasureReq struct {
+	UserID string `json:"userID" binding:"required"`
+}
+
+type ResumeUserErasureResp struct {
+	Erasure *UserErasure `json:"erasure"`
+}
+
+// GetUserErasuresReq 查询注销任务，UserIDs为空时不限制用户，Status为0时不限制状态
+type GetUserErasuresReq struct {
+	UserIDs    []string                 `json:"userIDs"`
+	Status     int32                    `json:"status"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type GetUserErasuresResp struct {
+	Total    int64          `json:"total"`
+	Erasures []*UserErasure `json:"erasures"`
+}
+
+// ResumeStaleUserErasuresReq 由定时任务调用，接管长时间没有进展的执行中任务
+type ResumeStaleUserErasuresReq struct{}
+
+type ResumeStaleUserErasuresResp struct {
+	ResumedCount int64 `json:"resumedCount"`
+}
+
+// UserBan 用户封禁状态，ExpireTime为0表示永久封禁
+type UserBan struct {
+	UserID         string `json:"userID"`
+	Reason         string `json:"reason"`
+	OperatorUserID string `json:"operatorUserID"`
+	BanTime        int64  `json:"banTime"`
+	ExpireTime     int64  `json:"expireTime"`
+}
+
+// IsActive 封禁在now时是否仍然有效，调用方缓存封禁状态时据此判断到期
+func (b *UserBan) IsActive(now time.Time) bool {
+	if b == nil || b.BanTime == 0 {
+		return false
+	}
+	return b.ExpireTime == 0 || now.UnixMilli() < b.ExpireTime
+}
+
+// BanUserReq 封禁用户，ExpireTime为到期时间（毫秒时间戳），0表示永久封禁
+// 重复封禁会覆盖原因和到期时间
+type BanUserReq struct {
+	UserID     string `json:"userID" binding:"required"`
+	Reason     string `json:"reason"`
+	ExpireTime int64  `json:"expireTime"`
+}
+
+type BanUserResp struct {
+	Ban *UserBan `json:"ban"`
+}
+
+type UnbanUserReq struct {
+	UserID string `json:"userID" binding:"required"`
+}
+
+type UnbanUserResp struct{}
+
+// GetUserBanReq 查询用户当前的封禁，未封禁或已到期时Ban为空
+type GetUserBanReq struct {
+	UserID string `json:"userID" binding:"required"`
+}
+
+type GetUserBanResp struct {
+	Ban *UserBan `json:"ban"`
+}
+
+// SearchBannedUsersReq 分页查询封禁中的用户，Keyword匹配用户ID或昵称
+type SearchBannedUsersReq struct {
+	Keyword    string                   `json:"keyword"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type BannedUser struct {
+	UserID   string   `json:"userID"`
+	Nickname string   `json:"nickname"`
+	FaceURL  string   `json:"faceURL"`
+	Ban      *UserBan `json:"ban"`
+}
+
+type SearchBannedUsersResp struct {
+	Total int64         `json:"total"`
+	Users []*BannedUser `json:"users"`
+}
+
+// UserProfileField 自定义资料字段定义，取值保存在用户Ex（JSON对象）的同名键中
+// Type: 1-字符串，2-数字，3-布尔；Visibility: 1-所有人，2-仅好友，3-仅本人和管理员
+type UserProfileField struct {
+	Name       string `json:"name"`
+	Type       int32  `json:"type"`
+	Visibility int32  `json:"visibility"`
+	Searchable bool   `json:"searchable"`
+	Backfilled bool   `json:"backfilled"` // 存量用户的取值是否已按当前类型回填，只读
+	CreateTime int64  `json:"createTime"`
+	UpdateTime int64  `json:"updateTime"`
+}
+
+// SetUserProfileFieldReq 新增或覆盖自定义资料字段定义
+type SetUserProfileFieldReq struct {
+	Field *UserProfileField `json:"field" binding:"required"`
+}
+
+type SetUserProfileFieldResp struct{}
+
+type DeleteUserProfileFieldsReq struct {
+	Names []string `json:"names" binding:"required"`
+}
+
+type DeleteUserProfileFieldsResp struct{}
+
+type GetUserProfileFieldsReq struct{}
+
+type GetUserProfileFieldsResp struct {
+	Fields []*UserProfileField `json:"fields"`
+}
+
+// SearchUsersByProfileReq 按自定义资料字段查询用户，Profile中的字段必须可搜索，多个字段之间为且关系
+type SearchUsersByProfileReq struct {
+	Keyword    string                   `json:"keyword"`
+	Profile    map[string]any           `json:"profile"`
+	Pagination *sdkws.RequestPagination `json:"pagination" binding:"required"`
+}
+
+type SearchUsersByProfileResp struct {
+	Total int64             `json:"total"`
+	Users []*sdkws.UserInfo `json:"users"`
+}
+
+// BackfillUserProfileFieldsReq 由定时任务调用，继续未完成的自定义资料字段回填
+type BackfillUserProfileFieldsReq struct{}
+
+type BackfillUserProfileFieldsResp struct {
+	StartedCount int64 `json:"startedCount"`
+}
+
+// UserExtServer 用户扩展RPC服务端接口
+type UserExtServer interface {
+	GetContactImportConfig(context.Context, *GetContactImportConfigReq) (*GetContactImportConfigResp, error)
+	MatchContacts(context.Context, *MatchContactsReq) (*MatchContactsResp, error)
+	EraseUser(context.Context, *EraseUserReq) (*EraseUserResp, error)
+	ResumeUserErasure(context.Context, *ResumeUserErasureReq) (*ResumeUserErasureResp, error)
+	GetUserErasures(context.Context, *GetUserErasuresReq) (*GetUserErasuresResp, error)
+	ResumeStaleUserErasures(context.Context, *ResumeStaleUserErasuresReq) (*ResumeStaleUserErasuresResp, error)
+	BanUser(context.Context, *BanUserReq) (*BanUserResp, error)
+	UnbanUser(context.Context, *UnbanUserReq) (*UnbanUserResp, error)
+	GetUserBan(context.Context, *GetUserBanReq) (*GetUserBanResp, error)
+	SearchBannedUsers(context.Context, *SearchBannedUsersReq) (*SearchBannedUsersResp, error)
+	SetUserProfileField(context.Context, *SetUserProfileFieldReq) (*SetUserProfileFieldResp, error)
+	DeleteUserProfileFields(context.Context, *DeleteUserProfileFieldsReq) (*DeleteUserProfileFieldsResp, error)
+	GetUserProfileFields(context.Context, *GetUserProfileFieldsReq) (*GetUserProfileFieldsResp, error)
+	SearchUsersByProfile(context.Context, *SearchUsersByProfileReq) (*SearchUsersByProfileResp, error)
+	BackfillUserProfileFields(context.Context, *BackfillUserProfileFieldsReq) (*BackfillUserProfileFieldsResp, error)
+}
+
+// UserExtClient 用户扩展RPC客户端接口
+type UserExtClient interface {
+	GetContactImportConfig(ctx context.Context, in *GetContactImportConfigReq, opts ...grpc.CallOption) (*GetContactImportConfigResp, error)
+	MatchContacts(ctx context.Context, in *MatchContactsReq, opts ...grpc.CallOption) (*MatchContactsResp, error)
+	EraseUser(ctx context.Context, in *EraseUserReq, opts ...grpc.CallOption) (*EraseUserResp, error)
+	ResumeUserErasure(ctx context.Context, in *ResumeUserErasureReq, opts ...grpc.CallOption) (*ResumeUserErasureResp, error)
+	GetUserErasures(ctx context.Context, in *GetUserErasuresReq, opts ...grpc.CallOption) (*GetUserErasuresResp, error)
+	ResumeStaleUserErasures(ctx context.Context, in *ResumeStaleUserErasuresReq, opts ...grpc.CallOption) (*ResumeStaleUserErasuresResp, error)
+	BanUser(ctx context.Context, in *BanUserReq, opts ...grpc.CallOption) (*BanUserResp, error)
+	UnbanUser(ctx context.Context, in *UnbanUserReq, opts ...grpc.CallOption) (*UnbanUserResp, error)
+	GetUserBan(ctx context.Context, in *GetUserBanReq, opts ...grpc.CallOption) (*GetUserBanResp, error)
+	SearchBannedUsers(ctx context.Context, in *SearchBannedUsersReq, opts ...grpc.CallOption) (*SearchBannedUsersResp, error)
+	SetUserProfileField(ctx context.Context, in *SetUserProfileFieldReq, opts ...grpc.CallOption) (*SetUserProfileFieldResp, error)
+	DeleteUserProfileFields(ctx context.Context, in *DeleteUserProfileFieldsReq, opts ...grpc.CallOption) (*DeleteUserProfileFieldsResp, error)
+	GetUserProfileFields(ctx context.Context, in *GetUserProfileFieldsReq, opts ...grpc.CallOption) (*GetUserProfileFieldsResp, error)
+	SearchUsersByProfile(ctx context.Context, in *SearchUsersByProfileReq, opts ...grpc.CallOption) (*SearchUsersByProfileResp, error)
+	BackfillUserProfileFields(ctx context.Context, in *BackfillUserProfileFieldsReq, opts ...grpc.CallOption) (*BackfillUserProfileFieldsResp, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*UserExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		rpcext.Method(serviceName, "GetContactImportConfig", UserExtServer.GetContactImportConfig),
+		rpcext.Method(serviceName, "MatchContacts", UserExtServer.MatchContacts),
+		rpcext.Method(serviceName, "EraseUser", UserExtServer.EraseUser),
+		rpcext.Method(serviceName, "ResumeUserErasure", UserExtServer.ResumeUserErasure),
+		rpcext.Method(serviceName, "GetUserErasures", UserExtServer.GetUserErasures),
+		rpcext.Method(serviceName, "ResumeStaleUserErasures", UserExtServer.ResumeStaleUserErasures),
+		rpcext.Method(serviceName, "BanUser", UserExtServer.BanUser),
+		rpcext.Method(serviceName, "UnbanUser", UserExtServer.UnbanUser),
+		rpcext.Method(serviceName, "GetUserBan", UserExtServer.GetUserBan),
+		rpcext.Method(serviceName, "SearchBannedUsers", UserExtServer.SearchBannedUsers),
+		rpcext.Method(serviceName, "SetUserProfileField", UserExtServer.SetUserProfileField),
+		rpcext.Method(serviceName, "DeleteUserProfileFields", UserExtServer.DeleteUserProfileFields),
+		rpcext.Method(serviceName, "GetUserProfileFields", UserExtServer.GetUserProfileFields),
+		rpcext.Method(serviceName, "SearchUsersByProfile", UserExtServer.SearchUsersByProfile),
+		rpcext.Method(serviceName, "BackfillUserProfileFields", UserExtServer.BackfillUserProfileFields),
+	},
+}
+
+func RegisterUserExtServer(s grpc.ServiceRegistrar, srv UserExtServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func NewUserExtClient(cc grpc.ClientConnInterface) UserExtClient {
+	return &userExtClient{cc: cc}
+}
+
+type userExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *userExtClient) GetContactImportConfig(ctx context.Context, in *GetContactImportConfigReq, opts ...grpc.CallOption) (*GetContactImportConfigResp, error) {
+	return rpcext.Invoke[GetContactImportConfigReq, GetContactImportConfigResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetContactImportConfig"), in, opts...)
+}
+
+func (c *userExtClient) MatchContacts(ctx context.Context, in *MatchContactsReq, opts ...grpc.CallOption) (*MatchContactsResp, error) {
+	return rpcext.Invoke[MatchContactsReq, MatchContactsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "MatchContacts"), in, opts...)
+}
+
+func (c *userExtClient) EraseUser(ctx context.Context, in *EraseUserReq, opts ...grpc.CallOption) (*EraseUserResp, error) {
+	return rpcext.Invoke[EraseUserReq, EraseUserResp](ctx, c.cc, rpcext.FullMethod(serviceName, "EraseUser"), in, opts...)
+}
+
+func (c *userExtClient) ResumeUserErasure(ctx context.Context, in *ResumeUserErasureReq, opts ...grpc.CallOption) (*ResumeUserErasureResp, error) {
+	return rpcext.Invoke[ResumeUserErasureReq, ResumeUserErasureResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ResumeUserErasure"), in, opts...)
+}
+
+func (c *userExtClient) GetUserErasures(ctx context.Context, in *GetUserErasuresReq, opts ...grpc.CallOption) (*GetUserErasuresResp, error) {
+	return rpcext.Invoke[GetUserErasuresReq, GetUserErasuresResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetUserErasures"), in, opts...)
+}
+
+func (c *userExtClient) ResumeStaleUserErasures(ctx context.Context, in *ResumeStaleUserErasuresReq, opts ...grpc.CallOption) (*ResumeStaleUserErasuresResp, error) {
+	return rpcext.Invoke[ResumeStaleUserErasuresReq, ResumeStaleUserErasuresResp](ctx, c.cc, rpcext.FullMethod(serviceName, "ResumeStaleUserErasures"), in, opts...)
+}
+
+func (c *userExtClient) BanUser(ctx context.Context, in *BanUserReq, opts ...grpc.CallOption) (*BanUserResp, error) {
+	return rpcext.Invoke[BanUserReq, BanUserResp](ctx, c.cc, rpcext.FullMethod(serviceName, "BanUser"), in, opts...)
+}
+
+func (c *userExtClient) UnbanUser(ctx context.Context, in *UnbanUserReq, opts ...grpc.CallOption) (*UnbanUserResp, error) {
+	return rpcext.Invoke[UnbanUserReq, UnbanUserResp](ctx, c.cc, rpcext.FullMethod(serviceName, "UnbanUser"), in, opts...)
+}
+
+func (c *userExtClient) GetUserBan(ctx context.Context, in *GetUserBanReq, opts ...grpc.CallOption) (*GetUserBanResp, error) {
+	return rpcext.Invoke[GetUserBanReq, GetUserBanResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetUserBan"), in, opts...)
+}
+
+func (c *userExtClient) SearchBannedUsers(ctx context.Context, in *SearchBannedUsersReq, opts ...grpc.CallOption) (*SearchBannedUsersResp, error) {
+	return rpcext.Invoke[SearchBannedUsersReq, SearchBannedUsersResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SearchBannedUsers"), in, opts...)
+}
+
+func (c *userExtClient) SetUserProfileField(ctx context.Context, in *SetUserProfileFieldReq, opts ...grpc.CallOption) (*SetUserProfileFieldResp, error) {
+	return rpcext.Invoke[SetUserProfileFieldReq, SetUserProfileFieldResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SetUserProfileField"), in, opts...)
+}
+
+func (c *userExtClient) DeleteUserProfileFields(ctx context.Context, in *DeleteUserProfileFieldsReq, opts ...grpc.CallOption) (*DeleteUserProfileFieldsResp, error) {
+	return rpcext.Invoke[DeleteUserProfileFieldsReq, DeleteUserProfileFieldsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "DeleteUserProfileFields"), in, opts...)
+}
+
+func (c *userExtClient) GetUserProfileFields(ctx context.Context, in *GetUserProfileFieldsReq, opts ...grpc.CallOption) (*GetUserProfileFieldsResp, error) {
+	return rpcext.Invoke[GetUserProfileFieldsReq, GetUserProfileFieldsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "GetUserProfileFields"), in, opts...)
+}
+
+func (c *userExtClient) SearchUsersByProfile(ctx context.Context, in *SearchUsersByProfileReq, opts ...grpc.CallOption) (*SearchUsersByProfileResp, error) {
+	return rpcext.Invoke[SearchUsersByProfileReq, SearchUsersByProfileResp](ctx, c.cc, rpcext.FullMethod(serviceName, "SearchUsersByProfile"), in, opts...)
+}
+
+func (c *userExtClient) BackfillUserProfileFields(ctx context.Context, in *BackfillUserProfileFieldsReq, opts ...grpc.CallOption) (*BackfillUserProfileFieldsResp, error) {
+	return rpcext.Invoke[BackfillUserProfileFieldsReq, BackfillUserProfileFieldsResp](ctx, c.cc, rpcext.FullMethod(serviceName, "BackfillUserProfileFields"), in, opts...)
+}
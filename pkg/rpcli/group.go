@@ -2,17 +2,40 @@ package rpcli
 
 import (
 	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/groupext"
 	"github.com/openimsdk/protocol/group"
 	"github.com/openimsdk/protocol/sdkws"
+	"github.com/openimsdk/tools/mcontext"
 	"google.golang.org/grpc"
 )
 
 func NewGroupClient(cc grpc.ClientConnInterface) *GroupClient {
-	return &GroupClient{group.NewGroupClient(cc)}
+	return &GroupClient{GroupClient: group.NewGroupClient(cc), GroupExtClient: groupext.NewGroupExtClient(cc)}
 }
 
 type GroupClient struct {
 	group.GroupClient
+	groupext.GroupExtClient
+}
+
+// GetGroupSettings 服务间获取群组扩展设置，不带操作者，不校验成员身份
+func (x *GroupClient) GetGroupSettings(ctx context.Context, groupID string) (*groupext.GroupSettings, error) {
+	resp, err := x.GroupExtClient.GetGroupSettings(mcontext.WithOpUserIDContext(ctx, ""), &groupext.GetGroupSettingsReq{GroupID: groupID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Settings, nil
+}
+
+func (x *GroupClient) GetGroupMemberPermissions(ctx context.Context, groupID string, userID string) (*groupext.GetGroupMemberPermissionsResp, error) {
+	return x.GroupExtClient.GetGroupMemberPermissions(ctx, &groupext.GetGroupMemberPermissionsReq{GroupID: groupID, UserID: userID})
+}
+
+// GetGroupChannels 服务间获取群组频道，不带操作者，不校验成员身份
+// 结果被本地缓存共享，并且成员退出后仍需读取频道列表
+func (x *GroupClient) GetGroupChannels(ctx context.Context, groupID string) (*groupext.GetGroupChannelsResp, error) {
+	return x.GroupExtClient.GetGroupChannels(mcontext.WithOpUserIDContext(ctx, ""), &groupext.GetGroupChannelsReq{GroupID: groupID})
 }
 
 func (x *GroupClient) GetGroupsInfo(ctx context.Context, groupIDs []string) ([]*sdkws.GroupInfo, error) {
@@ -2,16 +2,19 @@ package rpcli
 
 import (
 	"context"
+
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/relationext"
 	"github.com/openimsdk/protocol/relation"
 	"google.golang.org/grpc"
 )
 
 func NewRelationClient(cc grpc.ClientConnInterface) *RelationClient {
-	return &RelationClient{relation.NewFriendClient(cc)}
+	return &RelationClient{FriendClient: relation.NewFriendClient(cc), RelationExtClient: relationext.NewRelationExtClient(cc)}
 }
 
 type RelationClient struct {
 	relation.FriendClient
+	relationext.RelationExtClient
 }
 
 func (x *RelationClient) GetFriendsInfo(ctx context.Context, ownerUserID string, friendUserIDs []string) ([]*relation.FriendInfoOnly, error) {
@@ -21,3 +24,44 @@ func (x *RelationClient) GetFriendsInfo(ctx context.Context, ownerUserID string,
 	req := &relation.GetFriendInfoReq{OwnerUserID: ownerUserID, FriendUserIDs: friendUserIDs}
 	return extractField(ctx, x.FriendClient.GetFriendInfo, req, (*relation.GetFriendInfoResp).GetFriendInfos)
 }
+
+func (x *RelationClient) GetFriendIDs(ctx context.Context, ownerUserID string) ([]string, error) {
+	req := &relation.GetFriendIDsReq{UserID: ownerUserID}
+	return extractField(ctx, x.FriendClient.GetFriendIDs, req, (*relation.GetFriendIDsResp).GetFriendIDs)
+}
+
+// maxCheckBlackScopePairs 与好友服务CheckBlackScopes单次查询的用户对数上限一致
+const maxCheckBlackScopePairs = 1000
+
+// FindBlackScopes 查询ownerUserIDs中的用户拉黑blockUserIDs中用户的范围，用户对较多时分批查询
+func (x *RelationClient) FindBlackScopes(ctx context.Context, ownerUserIDs []string, blockUserIDs []string) ([]*relationext.BlackScope, error) {
+	if len(ownerUserIDs) == 0 || len(blockUserIDs) == 0 {
+		return nil, nil
+	}
+	batch := max(maxCheckBlackScopePairs/len(blockUserIDs), 1)
+	var scopes []*relationext.BlackScope
+	for i := 0; i < len(ownerUserIDs); i += batch {
+		owners := ownerUserIDs[i:min(i+batch, len(ownerUserIDs))]
+		for j := 0; j < len(blockUserIDs); j += maxCheckBlackScopePairs / len(owners) {
+			blocks := blockUserIDs[j:min(j+maxCheckBlackScopePairs/len(owners), len(blockUserIDs))]
+			resp, err := x.RelationExtClient.CheckBlackScopes(ctx, &relationext.CheckBlackScopesReq{OwnerUserIDs: owners, BlockUserIDs: blocks})
+			if err != nil {
+				return nil, err
+			}
+			scopes = append(scopes, resp.Scopes...)
+		}
+	}
+	return scopes, nil
+}
+
+// GetBlackScope 获取blockUserID在ownerUserID黑名单中的范围，不在黑名单中时返回0
+func (x *RelationClient) GetBlackScope(ctx context.Context, ownerUserID, blockUserID string) (int32, error) {
+	scopes, err := x.FindBlackScopes(ctx, []string{ownerUserID}, []string{blockUserID})
+	if err != nil {
+		return 0, err
+	}
+	if len(scopes) == 0 {
+		return 0, nil
+	}
+	return scopes[0].Scope, nil
+}
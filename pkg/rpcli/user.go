@@ -2,22 +2,38 @@ package rpcli
 
 import (
 	"context"
+	"github.com/openimsdk/open-im-server/v3/pkg/rpcext/userext"
 	"github.com/openimsdk/protocol/sdkws"
 	"github.com/openimsdk/protocol/user"
 	"github.com/openimsdk/tools/errs"
+	"github.com/openimsdk/tools/mcontext"
 	"github.com/openimsdk/tools/utils/datautil"
 	"google.golang.org/grpc"
+	"time"
 )
 
 func NewUserClient(cc grpc.ClientConnInterface) *UserClient {
-	return &UserClient{user.NewUserClient(cc)}
+	return &UserClient{UserClient: user.NewUserClient(cc), UserExtClient: userext.NewUserExtClient(cc)}
 }
 
 type UserClient struct {
 	user.UserClient
+	userext.UserExtClient
 }
 
+// GetUsersInfo 服务间获取用户资料，不指定查看者，返回完整的自定义资料字段
+// 结果可能被本地缓存共享，因此不能带上当前请求的操作者
 func (x *UserClient) GetUsersInfo(ctx context.Context, userIDs []string) ([]*sdkws.UserInfo, error) {
+	return x.getDesignateUsers(mcontext.WithOpUserIDContext(ctx, ""), userIDs)
+}
+
+// GetVisibleUsersInfo 以当前请求的操作者为查看者获取用户资料，隐藏其无权查看的自定义资料字段
+// 用于直接返回给客户端的场景
+func (x *UserClient) GetVisibleUsersInfo(ctx context.Context, userIDs []string) ([]*sdkws.UserInfo, error) {
+	return x.getDesignateUsers(ctx, userIDs)
+}
+
+func (x *UserClient) getDesignateUsers(ctx context.Context, userIDs []string) ([]*sdkws.UserInfo, error) {
 	if len(userIDs) == 0 {
 		return nil, nil
 	}
@@ -29,6 +45,18 @@ func (x *UserClient) GetUserInfo(ctx context.Context, userID string) (*sdkws.Use
 	return firstValue(x.GetUsersInfo(ctx, []string{userID}))
 }
 
+// GetActiveUserBan 获取用户当前有效的封禁，未封禁或已到期时返回nil
+func (x *UserClient) GetActiveUserBan(ctx context.Context, userID string) (*userext.UserBan, error) {
+	resp, err := x.UserExtClient.GetUserBan(ctx, &userext.GetUserBanReq{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Ban.IsActive(time.Now()) {
+		return nil, nil
+	}
+	return resp.Ban, nil
+}
+
 func (x *UserClient) CheckUser(ctx context.Context, userIDs []string) error {
 	if len(userIDs) == 0 {
 		return nil
@@ -53,6 +81,17 @@ func (x *UserClient) GetUsersInfoMap(ctx context.Context, userIDs []string) (map
 	}), nil
 }
 
+// GetVisibleUsersInfoMap 以当前请求的操作者为查看者获取用户资料，以用户ID为键，用于直接返回给客户端的场景
+func (x *UserClient) GetVisibleUsersInfoMap(ctx context.Context, userIDs []string) (map[string]*sdkws.UserInfo, error) {
+	users, err := x.GetVisibleUsersInfo(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return datautil.SliceToMap(users, func(e *sdkws.UserInfo) string {
+		return e.UserID
+	}), nil
+}
+
 func (x *UserClient) GetAllOnlineUsers(ctx context.Context, cursor uint64) (*user.GetAllOnlineUsersResp, error) {
 	req := &user.GetAllOnlineUsersReq{Cursor: cursor}
 	return x.UserClient.GetAllOnlineUsers(ctx, req)